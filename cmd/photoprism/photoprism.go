@@ -58,13 +58,18 @@ func main() {
 		commands.MomentsCommand,
 		commands.OptimizeCommand,
 		commands.PurgeCommand,
+		commands.SyncCommand,
 		commands.CopyCommand,
 		commands.ConvertCommand,
 		commands.ResampleCommand,
+		commands.ThumbsCommand,
+		commands.ExportMetaCommand,
+		commands.DuplicatesCommand,
 		commands.MigrateCommand,
 		commands.ResetCommand,
 		commands.ConfigCommand,
 		commands.PasswdCommand,
+		commands.UsersCommand,
 		commands.VersionCommand,
 		commands.StatusCommand,
 	}
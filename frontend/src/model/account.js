@@ -41,6 +41,7 @@ export class Account extends RestModel {
             AccURL: "",
             AccType: "",
             AccKey: "",
+            AccHostKey: "",
             AccUser: "",
             AccPass: "",
             AccError: "",
@@ -59,6 +60,7 @@ export class Account extends RestModel {
             SyncUpload: false,
             SyncDownload: true,
             SyncRaw: true,
+            SyncDelete: false,
             CreatedAt: "",
             UpdatedAt: "",
             DeletedAt: null,
@@ -46,6 +46,8 @@ export default class Link extends Model {
             HasPassword: false,
             CanComment: false,
             CanEdit: false,
+            CanDownload: false,
+            CanUpload: false,
             CreatedAt: "",
             ModifiedAt: "",
         };
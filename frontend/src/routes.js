@@ -96,7 +96,7 @@ export default [
         path: "/moments",
         component: Albums,
         meta: {title: $gettext("Moments"), auth: true},
-        props: {view: "moment", staticFilter: {type: "moment"}},
+        props: {view: "moment", staticFilter: {type: "moment,event"}},
     },
     {
         name: "moment",
@@ -109,7 +109,7 @@ export default [
         path: "/albums",
         component: Albums,
         meta: {title: $gettext("Albums"), auth: true},
-        props: {view: "album", staticFilter: {type: "album"}},
+        props: {view: "album", staticFilter: {type: "album,smart"}},
     },
     {
         name: "album",
@@ -5,15 +5,53 @@ var Permissions = ACL{
 		RoleAdmin: Actions{ActionDefault: true},
 	},
 	ResourceConfig: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionRead: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
+		RoleGuest:  Actions{ActionRead: true},
+	},
+	ResourceSettings: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
 	},
 	ResourceAlbums: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionShare: true, ActionDownload: true, ActionLike: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true, ActionLike: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true},
 	},
 	ResourcePhotos: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true, ActionPrivate: true, ActionUpload: true, ActionDownload: true, ActionShare: true, ActionLike: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionUpload: true, ActionDownload: true, ActionLike: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionLike: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+	},
+	ResourceGeo: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true},
+		RoleFriend: Actions{ActionSearch: true},
+		RoleChild:  Actions{ActionSearch: true},
+	},
+	ResourceLabels: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourcePeople: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceUsers: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionUpdateSelf: true},
+		RoleFriend: Actions{ActionUpdateSelf: true},
+		RoleChild:  Actions{ActionUpdateSelf: true},
 	},
 }
@@ -50,3 +50,33 @@ func TestACL_Deny(t *testing.T) {
 		assert.True(t, Permissions.Deny(ResourceAlbums, RoleGuest, ActionDefault))
 	})
 }
+
+func TestACL_Roles(t *testing.T) {
+	t.Run("photos/family/private", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleFamily, ActionPrivate))
+	})
+	t.Run("photos/friend/private", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleFriend, ActionPrivate))
+	})
+	t.Run("photos/child/download", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleChild, ActionDownload))
+	})
+	t.Run("albums/family/create", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceAlbums, RoleFamily, ActionCreate))
+	})
+	t.Run("albums/friend/create", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceAlbums, RoleFriend, ActionCreate))
+	})
+	t.Run("users/family/update-self", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceUsers, RoleFamily, ActionUpdateSelf))
+	})
+	t.Run("users/family/create", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceUsers, RoleFamily, ActionCreate))
+	})
+	t.Run("users/guest/update-self", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceUsers, RoleGuest, ActionUpdateSelf))
+	})
+	t.Run("logs/family", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceLogs, RoleFamily, ActionRead))
+	})
+}
@@ -20,7 +20,9 @@ const (
 	ResourceGeo        Resource = "geo"
 	ResourcePasswords  Resource = "passwords"
 	ResourcePeople     Resource = "people"
+	ResourceUsers      Resource = "users"
 	ResourcePhotos     Resource = "photos"
 	ResourcePlaces     Resource = "places"
 	ResourceFeedback   Resource = "feedback"
+	ResourceWebhooks   Resource = "webhooks"
 )
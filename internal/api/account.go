@@ -132,6 +132,48 @@ func GetAccountFolders(router *gin.RouterGroup) {
 	})
 }
 
+// GET /api/v1/accounts/:id/sync
+//
+// Returns the changes the next sync would perform without changing any files (dry run).
+//
+// Parameters:
+//   id: string Account ID as returned by the API
+func GetAccountSyncPlan(router *gin.RouterGroup) {
+	router.GET("/accounts/:id/sync", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		id := ParseUint(c.Param("id"))
+
+		m, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		plan, err := workers.NewSync(service.Config()).Plan(m)
+
+		if err != nil {
+			log.Errorf("account-sync: %s", err.Error())
+			Abort(c, http.StatusBadRequest, i18n.ErrConnectionFailed)
+			return
+		}
+
+		if plan == nil {
+			plan = workers.SyncPlan{}
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(plan)))
+
+		c.JSON(http.StatusOK, plan)
+	})
+}
+
 // GET /api/v1/accounts/:id/share
 //
 // Parameters:
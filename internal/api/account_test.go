@@ -69,6 +69,17 @@ func TestGetAccountFolders(t *testing.T) {
 	})
 }
 
+func TestGetAccountSyncPlan(t *testing.T) {
+	t.Run("account not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAccountSyncPlan(router)
+		r := PerformRequest(app, "GET", "/api/v1/accounts/999000/sync")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestShareWithAccount(t *testing.T) {
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
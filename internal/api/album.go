@@ -62,6 +62,8 @@ func GetAlbums(router *gin.RouterGroup) {
 			f.ID = s.Shares.String()
 		}
 
+		f.UserUID = LibraryUID(s)
+
 		result, err := query.AlbumSearch(f)
 
 		if err != nil {
@@ -95,6 +97,14 @@ func GetAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only see their own albums and albums shared with them.
+		if uid := LibraryUID(s); uid != "" {
+			if result, err := query.AlbumSearch(form.AlbumSearch{ID: id, UserUID: uid}); err != nil || len(result) == 0 {
+				Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+				return
+			}
+		}
+
 		c.JSON(http.StatusOK, m)
 	})
 }
@@ -116,8 +126,22 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		m := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		var m *entity.Album
+
+		// Smart albums require a valid filter.
+		if f.AlbumType == entity.AlbumSmart {
+			if err := query.ValidateAlbumFilter(f.AlbumFilter); err != nil {
+				AbortInvalidQuery(c, err)
+				return
+			}
+
+			m = entity.NewSmartAlbum(f.AlbumTitle, f.AlbumFilter)
+		} else {
+			m = entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		}
+
 		m.AlbumFavorite = f.AlbumFavorite
+		m.CreatedBy = s.User.UserUID
 
 		log.Debugf("album: creating %+v %+v", f, m)
 
@@ -146,6 +170,12 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		uid := c.Param("uid")
 		m, err := query.AlbumByUID(uid)
 
@@ -168,12 +198,25 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		if f.AlbumType == entity.AlbumSmart {
+			if err := query.ValidateAlbumFilter(f.AlbumFilter); err != nil {
+				AbortInvalidQuery(c, err)
+				return
+			}
+		}
+
+		title, caption := m.AlbumTitle, m.AlbumCaption
+
 		if err := m.SaveForm(f); err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
 			return
 		}
 
+		if m.AlbumTitle != title || m.AlbumCaption != caption {
+			m.IndexPhotoTerms()
+		}
+
 		UpdateClientConfig()
 
 		event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -194,6 +237,12 @@ func DeleteAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		conf := service.Config()
 		id := c.Param("uid")
 
@@ -229,6 +278,12 @@ func LikeAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		id := c.Param("uid")
 		album, err := query.AlbumByUID(id)
 
@@ -262,6 +317,12 @@ func DislikeAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		id := c.Param("uid")
 		album, err := query.AlbumByUID(id)
 
@@ -282,6 +343,62 @@ func DislikeAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// POST /api/v1/albums/:uid/freeze
+//
+// Converts a smart album into a regular album containing the photos currently found by its filter.
+func FreezeAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/freeze", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		if !a.IsSmart() {
+			AbortBadRequest(c)
+			return
+		}
+
+		photoUIDs, err := query.AlbumPhotoUIDs(a, LibraryUID(s))
+
+		if err != nil {
+			log.Errorf("album: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		if _, err := a.Freeze(photoUIDs); err != nil {
+			log.Errorf("album: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		ClearAlbumThumbCache(a.AlbumUID)
+
+		UpdateClientConfig()
+
+		event.SuccessMsg(i18n.MsgAlbumSaved)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
 // POST /api/v1/albums/:uid/clone
 func CloneAlbums(router *gin.RouterGroup) {
 	router.POST("/albums/:uid/clone", func(c *gin.Context) {
@@ -292,6 +409,12 @@ func CloneAlbums(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		a, err := query.AlbumByUID(c.Param("uid"))
 
 		if err != nil {
@@ -306,6 +429,12 @@ func CloneAlbums(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only copy photos from their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, f.Albums...) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		var added []entity.PhotoAlbum
 
 		for _, uid := range f.Albums {
@@ -316,14 +445,14 @@ func CloneAlbums(router *gin.RouterGroup) {
 				continue
 			}
 
-			photos, err := query.AlbumPhotos(cloneAlbum, 10000)
+			photoUIDs, err := query.AlbumPhotoUIDs(cloneAlbum, LibraryUID(s))
 
 			if err != nil {
 				log.Errorf("album: %s", err)
 				continue
 			}
 
-			added = append(added, a.AddPhotos(photos.UIDs())...)
+			added = append(added, a.AddPhotos(photoUIDs)...)
 		}
 
 		if len(added) > 0 {
@@ -346,6 +475,12 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -369,6 +504,12 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only add photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, photos.UIDs()...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		added := a.AddPhotos(photos.UIDs())
 
 		if len(added) > 0 {
@@ -396,6 +537,12 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, c.Param("uid")) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -432,23 +579,56 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// albumDownloads returns the album photos that may be downloaded by guests if shared is true,
+// or by the registered user with the given UID, which may be empty for full access.
+func albumDownloads(a entity.Album, shared bool, userUID string) (results query.PhotoResults, err error) {
+	if shared {
+		// Guests may only download public content.
+		f := form.PhotoSearch{Count: 10000}
+
+		if err = query.ShareSearch(&f, a.AlbumUID); err == nil {
+			results, _, err = query.PhotoSearch(f)
+		}
+
+		return results, err
+	}
+
+	// Users may only download photos from their own library.
+	results, _, err = query.PhotoSearch(form.PhotoSearch{Album: a.AlbumUID, Filter: a.AlbumFilter, Count: 10000, UserUID: userUID})
+
+	return results, err
+}
+
 // GET /api/v1/albums/:uid/dl
 func DownloadAlbum(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/dl", func(c *gin.Context) {
+		uid := c.Param("uid")
+		shared := false
+		userUID := ""
+
 		if InvalidDownloadToken(c) {
-			AbortUnauthorized(c)
-			return
+			for _, link := range DownloadLinks(c) {
+				shared = shared || link.ShareUID == uid
+			}
+
+			userUID = service.Config().DownloadTokenUser(c.Query("t"))
+
+			// Users may only download their own albums and albums shared with them.
+			if !shared && (userUID == "" || !query.UserHasAlbums(userUID, []string{uid})) {
+				AbortUnauthorized(c)
+				return
+			}
 		}
 
 		start := time.Now()
-		a, err := query.AlbumByUID(c.Param("uid"))
+		a, err := query.AlbumByUID(uid)
 
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
 
-		p, err := query.AlbumPhotos(a, 10000)
+		p, err := albumDownloads(a, shared, userUID)
 
 		if err != nil {
 			AbortEntityNotFound(c)
@@ -468,7 +648,7 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			fileAlias := f.ShareFileName()
 
 			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, fileAlias); err != nil {
+				if err := addFileToZip(zipWriter, editedFileName(fileName, f.FileHash), fileAlias); err != nil {
 					log.Error(err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
 					return
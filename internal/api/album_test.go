@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
@@ -63,6 +65,21 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("smart album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart album", "Type": "smart", "Filter": "year:2014 | year:2015"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "smart", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "year:2014 | year:2015", gjson.Get(r.Body.String(), "Filter").String())
+	})
+	t.Run("smart album with invalid filter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart album", "Type": "smart", "Filter": "year:2014 |"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, int64(11), gjson.Get(r.Body.String(), "query.position").Int())
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
@@ -265,6 +282,133 @@ func TestDownloadAlbum(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+conf.DownloadToken())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("share token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		DownloadAlbum(router)
+
+		link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+		link.CanDownload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/dl?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9/dl?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestFreezeAlbum(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateAlbum(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Frozen", "Type": "smart", "Filter": "year:2014"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		FreezeAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/"+uid+"/freeze")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "album", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Filter").String())
+	})
+
+	t.Run("not smart", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		FreezeAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/"+uid+"/freeze")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		FreezeAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/123/freeze")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAlbumDownloads(t *testing.T) {
+	alice := entity.UserFixtures.Get("alice")
+
+	album := entity.NewSmartAlbum("Downloads", "type:image")
+	album.CreatedBy = alice.UserUID
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := albumDownloads(*album, false, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	library, err := albumDownloads(*album, false, alice.UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, len(all), len(library))
+	assert.True(t, query.UserHasPhotos(alice.UserUID, library.UIDs()))
+}
+
+func TestFreezeAlbum_RestrictedUser(t *testing.T) {
+	app, router, conf := NewApiTest()
+	FreezeAlbum(router)
+
+	alice := entity.UserFixtures.Get("alice")
+
+	album := entity.NewSmartAlbum("All Images", "type:image")
+	album.CreatedBy = alice.UserUID
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := query.AlbumPhotoUIDs(*album, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	library, err := query.AlbumPhotoUIDs(*album, alice.UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, len(all), len(library))
+
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	r := PerformUserRequest(app, "POST", "/api/v1/albums/"+album.AlbumUID+"/freeze", "", alice)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	frozen, err := query.AlbumByUID(album.AlbumUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, frozen.IsSmart())
+
+	photos, err := query.AlbumPhotoUIDs(frozen, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ElementsMatch(t, library, photos)
 }
 
 func TestCloneAlbums(t *testing.T) {
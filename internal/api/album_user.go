@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GET /api/v1/albums/:uid/users
+//
+// Returns the registered users an album is shared with.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumUsers(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/users", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		// Users may only share their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, uid) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		result, err := query.AlbumUsers(uid)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/albums/:uid/users/:user
+//
+// Shares an album with a registered user.
+//
+// Parameters:
+//   uid: string Album UID
+//   user: string User UID
+func ShareAlbumWithUser(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/users/:user", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		// Users may only share their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, uid) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		user, err := query.UserByUID(c.Param("user"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if entity.FirstOrCreateUserShare(entity.NewUserShare(user.UserUID, a.AlbumUID)) == nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		result, err := query.AlbumUsers(a.AlbumUID)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// DELETE /api/v1/albums/:uid/users/:user
+//
+// Stops sharing an album with a registered user.
+//
+// Parameters:
+//   uid: string Album UID
+//   user: string User UID
+func UnshareAlbumWithUser(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/users/:user", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		// Users may only change their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, uid) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		if err := entity.NewUserShare(c.Param("user"), uid).Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		PublishAlbumEvent(EntityUpdated, uid, c)
+
+		result, err := query.AlbumUsers(uid)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
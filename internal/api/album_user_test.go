@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestShareAlbumWithUser(t *testing.T) {
+	t.Run("share and unshare", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumUsers(router)
+		ShareAlbumWithUser(router)
+		UnshareAlbumWithUser(router)
+
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba9/users/ut9lxuqxkkvd1000")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "alice", gjson.Get(r.Body.String(), "0.UserName").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba9/users")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba9/users/ut9lxuqxkkvd1000")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("user not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareAlbumWithUser(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba9/users/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareAlbumWithUser(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/xxx/users/ut9lxuqxkkvd1000")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
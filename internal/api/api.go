@@ -32,10 +32,12 @@ https://docs.photoprism.org/developer-guide/
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -105,3 +107,23 @@ func AbortAlreadyExists(c *gin.Context, s string) {
 func AbortFeatureDisabled(c *gin.Context) {
 	Abort(c, http.StatusForbidden, i18n.ErrFeatureDisabled)
 }
+
+// AbortInvalidQuery aborts with the position of the syntax error if the search query is invalid.
+func AbortInvalidQuery(c *gin.Context, err error) {
+	var queryErr *form.QueryError
+
+	if !errors.As(err, &queryErr) {
+		AbortBadRequest(c)
+		return
+	}
+
+	resp := i18n.NewResponse(http.StatusBadRequest, i18n.ErrInvalidQuery)
+	resp.Details = queryErr.Error()
+
+	log.Debugf("api: abort %s with code %d (%s)", c.FullPath(), resp.Code, resp.Details)
+
+	c.AbortWithStatusJSON(resp.Code, struct {
+		i18n.Response
+		Query *form.QueryError `json:"query"`
+	}{resp, queryErr})
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/sirupsen/logrus"
 )
 
@@ -39,6 +41,20 @@ func PerformRequestWithBody(r http.Handler, method, path, body string) *httptest
 	return w
 }
 
+// Performs API request with the session of a registered user, requires public mode to be disabled.
+func PerformUserRequest(r http.Handler, method, path, body string, user entity.User) *httptest.ResponseRecorder {
+	return PerformSessionRequest(r, method, path, body, session.Data{User: user})
+}
+
+func PerformSessionRequest(r http.Handler, method, path, body string, sess session.Data) *httptest.ResponseRecorder {
+	reader := strings.NewReader(body)
+	req, _ := http.NewRequest(method, path, reader)
+	req.Header.Set("X-Session-ID", service.Session().Create(sess))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func TestMain(m *testing.M) {
 	log = logrus.StandardLogger()
 	log.SetLevel(logrus.DebugLevel)
@@ -35,6 +35,12 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("archive: adding %s", f.String())
 
 		// Soft delete by setting deleted_at to current date.
@@ -82,6 +88,12 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("photos: approving %s", f.String())
 
 		photos, err := query.PhotoSelection(f)
@@ -132,6 +144,12 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("archive: restoring %s", f.String())
 
 		err := entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", f.Photos).
@@ -176,6 +194,12 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only delete their own albums and albums shared with them.
+		if !AlbumsInLibrary(s, f.Albums...) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		log.Infof("albums: deleting %s", f.String())
 
 		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
@@ -211,6 +235,12 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, f.Photos...) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("photos: mark %s as private", f.String())
 
 		err := entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
@@ -21,9 +21,9 @@ func GetConfig(router *gin.RouterGroup) {
 		conf := service.Config()
 
 		if s.User.Guest() {
-			c.JSON(http.StatusOK, conf.GuestConfig())
+			c.JSON(http.StatusOK, GuestConfig(s))
 		} else if s.User.Registered() {
-			c.JSON(http.StatusOK, conf.UserConfig())
+			c.JSON(http.StatusOK, UserConfig(s))
 		} else {
 			c.JSON(http.StatusOK, conf.PublicConfig())
 		}
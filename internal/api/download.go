@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -22,17 +23,23 @@ import (
 //   hash: string The file hash as returned by the search API
 func GetDownload(router *gin.RouterGroup) {
 	router.GET("/dl/:hash", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
-			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
-			return
-		}
-
 		fileHash := c.Param("hash")
 
 		f, err := query.FileByHash(fileHash)
 
 		if err != nil {
-			c.AbortWithStatusJSON(404, gin.H{"error": err.Error()})
+			if InvalidDownloadToken(c) {
+				c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			} else {
+				c.AbortWithStatusJSON(404, gin.H{"error": err.Error()})
+			}
+
+			return
+		}
+
+		// Guests may only download files shared with them, users files in their library.
+		if InvalidDownloadToken(c) && !SharedDownload(c, f.PhotoUID) && !LibraryDownload(c, f.PhotoUID) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
 
@@ -52,6 +59,17 @@ func GetDownload(router *gin.RouterGroup) {
 
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", downloadFileName))
 
-		c.File(fileName)
+		c.File(editedFileName(fileName, f.FileHash))
 	})
 }
+
+// editedFileName returns the name of the file to download, which is a rendering of the edit if the photo was edited.
+func editedFileName(fileName, fileHash string) string {
+	result, err := photoprism.EditedFileName(fileName, fileHash)
+
+	if err != nil {
+		log.Errorf("download: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	return result
+}
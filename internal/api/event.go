@@ -53,3 +53,16 @@ func PublishLabelEvent(e EntityEvent, uid string, c *gin.Context) {
 
 	event.PublishEntities("labels", string(e), result)
 }
+
+func PublishPersonEvent(e EntityEvent, uid string, c *gin.Context) {
+	f := form.PersonSearch{ID: uid}
+	result, err := query.People(f)
+
+	if err != nil {
+		log.Error(err)
+		AbortUnexpected(c)
+		return
+	}
+
+	event.PublishEntities("people", string(e), result)
+}
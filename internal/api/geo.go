@@ -6,6 +6,7 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,22 @@ import (
 	geojson "github.com/paulmach/go.geojson"
 )
 
+// geoSearchForm binds the geo search form and restricts it to the photos the session may see.
+func geoSearchForm(c *gin.Context, s session.Data) (f form.GeoSearch, err error) {
+	if err = c.MustBindWith(&f, binding.Form); err != nil {
+		return f, err
+	}
+
+	if acl.Permissions.Deny(acl.ResourcePhotos, s.User.Role(), acl.ActionPrivate) {
+		f.Public = true
+		f.Private = false
+	}
+
+	f.UserUID = LibraryUID(s)
+
+	return f, nil
+}
+
 // GET /api/v1/geo
 func GetGeo(router *gin.RouterGroup) {
 	router.GET("/geo", func(c *gin.Context) {
@@ -25,9 +42,7 @@ func GetGeo(router *gin.RouterGroup) {
 			return
 		}
 
-		var f form.GeoSearch
-
-		err := c.MustBindWith(&f, binding.Form)
+		f, err := geoSearchForm(c, s)
 
 		if err != nil {
 			AbortBadRequest(c)
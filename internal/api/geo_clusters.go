@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/mvt"
+	"github.com/photoprism/photoprism/pkg/txt"
+
+	geojson "github.com/paulmach/go.geojson"
+)
+
+// GeoTileLayer is the name of the vector tile layer containing photo clusters.
+const GeoTileLayer = "photos"
+
+// GET /api/v1/geo/clusters
+//
+// Parameters:
+//   bbox: string Bounding box as "minLng,minLat,maxLng,maxLat" (optional)
+//   zoom: int    Web map zoom level, determines the cluster size
+func GetGeoClusters(router *gin.RouterGroup) {
+	router.GET("/geo/clusters", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		f, err := geoSearchForm(c, s)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		clusters, err := query.GeoClusterSearch(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		fc := geojson.NewFeatureCollection()
+
+		for _, cl := range clusters {
+			props := gin.H{
+				"Token":   cl.Token,
+				"Count":   cl.Count,
+				"UID":     cl.PhotoUID,
+				"Hash":    cl.FileHash,
+				"Width":   cl.FileWidth,
+				"Height":  cl.FileHeight,
+				"TakenAt": cl.TakenAt,
+				"Title":   cl.PhotoTitle,
+			}
+
+			if cl.PhotoType != "" {
+				props["Type"] = cl.PhotoType
+			}
+
+			feat := geojson.NewPointFeature([]float64{cl.Lng, cl.Lat})
+			feat.ID = cl.Token
+			feat.Properties = props
+			fc.AddFeature(feat)
+		}
+
+		resp, err := fc.MarshalJSON()
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json", resp)
+	})
+}
+
+// GET /api/v1/geo/tiles/:z/:x/:y
+//
+// Parameters:
+//   z: int Zoom level
+//   x: int Tile column
+//   y: int Tile row, optionally with .mvt or .pbf extension
+func GetGeoTile(router *gin.RouterGroup) {
+	router.GET("/geo/tiles/:z/:x/:y", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		z, errZ := strconv.Atoi(c.Param("z"))
+		x, errX := strconv.Atoi(c.Param("x"))
+		y, errY := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(c.Param("y"), ".mvt"), ".pbf"))
+
+		if errZ != nil || errX != nil || errY != nil || !mvt.ValidTile(z, x, y) {
+			AbortBadRequest(c)
+			return
+		}
+
+		f, err := geoSearchForm(c, s)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		minLng, minLat, maxLng, maxLat := mvt.TileBounds(z, x, y)
+
+		f.BBox = strings.Join([]string{
+			strconv.FormatFloat(minLng, 'f', -1, 64),
+			strconv.FormatFloat(minLat, 'f', -1, 64),
+			strconv.FormatFloat(maxLng, 'f', -1, 64),
+			strconv.FormatFloat(maxLat, 'f', -1, 64),
+		}, ",")
+		f.Zoom = z
+
+		clusters, err := query.GeoClusterSearch(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		layer := mvt.Layer{Name: GeoTileLayer, Extent: mvt.DefaultExtent}
+
+		for i, cl := range clusters {
+			px, py := mvt.Project(cl.Lat, cl.Lng, z, x, y, layer.Extent)
+
+			layer.Features = append(layer.Features, mvt.Feature{
+				ID: uint64(i + 1),
+				X:  px,
+				Y:  py,
+				Properties: map[string]interface{}{
+					"token": cl.Token,
+					"count": cl.Count,
+					"uid":   cl.PhotoUID,
+					"hash":  cl.FileHash,
+				},
+			})
+		}
+
+		tile, err := mvt.Encode(layer)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, mvt.MimeType, tile)
+	})
+}
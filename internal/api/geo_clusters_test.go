@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/mvt"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetGeoClusters(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoClusters(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?zoom=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "FeatureCollection", gjson.Get(r.Body.String(), "type").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "features.#").Int())
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "features.0.properties.Count").Int())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "features.0.properties.Hash").String())
+	})
+	t.Run("bbox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoClusters(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?zoom=12&bbox=50,-25,60,-15")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		for _, lat := range gjson.Get(r.Body.String(), "features.#.geometry.coordinates.1").Array() {
+			assert.InDelta(t, -21.34, lat.Float(), 0.01)
+		}
+	})
+	t.Run("invalid bbox", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoClusters(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/clusters?bbox=1,2,3")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetGeoTile(t *testing.T) {
+	t.Run("world", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/0/0/0.mvt")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, mvt.MimeType, r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Body.String(), GeoTileLayer)
+	})
+	t.Run("invalid tile", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetGeoTile(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tiles/1/2/0.mvt")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -34,6 +34,9 @@ func GetLabels(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only see labels of photos in their library.
+		f.UserUID = LibraryUID(s)
+
 		result, err := query.Labels(f)
 
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -23,18 +24,33 @@ func UpdateLink(c *gin.Context) {
 		return
 	}
 
-	var f form.Link
+	link := entity.FindLink(c.Param("link"))
+
+	if link == nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	// Permissions remain unchanged if they are not sent with the request.
+	f := form.Link{
+		CanComment:  link.CanComment,
+		CanEdit:     link.CanEdit,
+		CanDownload: link.CanDownload,
+		CanUpload:   link.CanUpload,
+	}
 
 	if err := c.BindJSON(&f); err != nil {
 		AbortBadRequest(c)
 		return
 	}
 
-	link := entity.FindLink(c.Param("link"))
-
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.CanComment = f.CanComment
+	link.CanEdit = f.CanEdit
+	link.CanDownload = f.CanDownload
+	link.CanUpload = f.CanUpload && rnd.IsPPID(link.ShareUID, 'a')
 
 	if f.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(f.LinkToken))
@@ -68,6 +84,11 @@ func DeleteLink(c *gin.Context) {
 
 	link := entity.FindLink(c.Param("link"))
 
+	if link == nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
 	if err := link.Delete(); err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
@@ -99,6 +120,10 @@ func CreateLink(c *gin.Context) {
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.CanDownload = f.CanDownload
+
+	// Guests can only upload to albums.
+	link.CanUpload = f.CanUpload && rnd.IsPPID(link.ShareUID, 'a')
 
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
@@ -146,6 +171,13 @@ func DeleteAlbumLink(router *gin.RouterGroup) {
 // GET /api/v1/albums/:uid/links
 func GetAlbumLinks(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.AlbumByUID(c.Param("uid"))
 
 		if err != nil {
@@ -186,6 +218,13 @@ func DeletePhotoLink(router *gin.RouterGroup) {
 // GET /api/v1/photos/:uid/links
 func GetPhotoLinks(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(c.Param("uid"))
 
 		if err != nil {
@@ -226,6 +265,13 @@ func DeleteLabelLink(router *gin.RouterGroup) {
 // GET /api/v1/labels/:uid/links
 func GetLabelLinks(router *gin.RouterGroup) {
 	router.GET("/labels/:uid/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m, err := query.LabelByUID(c.Param("uid"))
 
 		if err != nil {
@@ -236,3 +282,102 @@ func GetLabelLinks(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, m.Links())
 	})
 }
+
+// POST /api/v1/links
+//
+// Creates a link for sharing an originals folder or a selection of photos.
+func CreateShareLink(router *gin.RouterGroup) {
+	router.POST("/links", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.ShareLink
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var photoUIDs []string
+
+		link := entity.NewLink("", f.CanComment, f.CanEdit)
+
+		if f.Folder != "" {
+			folder, err := query.FolderByUID(f.Folder)
+
+			if err != nil || folder.Root != entity.RootOriginals {
+				AbortEntityNotFound(c)
+				return
+			}
+
+			link.ShareUID = folder.FolderUID
+		} else if f.Selection.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else {
+			photos, err := query.PhotoSelection(f.Selection)
+
+			if err != nil {
+				AbortEntityNotFound(c)
+				return
+			} else if len(photos) == 0 {
+				Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+				return
+			}
+
+			for _, p := range photos {
+				photoUIDs = append(photoUIDs, p.PhotoUID)
+			}
+
+			// Selections are shared by the link itself.
+			link.ShareUID = link.LinkUID
+		}
+
+		link.SetSlug(f.ShareSlug)
+		link.MaxViews = f.MaxViews
+		link.LinkExpires = f.LinkExpires
+		link.CanDownload = f.CanDownload
+
+		if f.Password != "" {
+			if err := link.SetPassword(f.Password); err != nil {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		if err := link.Save(); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if link.Selection() {
+			if err := link.SetPhotos(photoUIDs); err != nil {
+				log.Error(err)
+				AbortSaveFailed(c)
+				return
+			}
+		}
+
+		event.Success("added share link")
+
+		c.JSON(http.StatusOK, link)
+	})
+}
+
+// PUT /api/v1/links/:link
+func UpdateShareLink(router *gin.RouterGroup) {
+	router.PUT("/links/:link", func(c *gin.Context) {
+		UpdateLink(c)
+	})
+}
+
+// DELETE /api/v1/links/:link
+func DeleteShareLink(router *gin.RouterGroup) {
+	router.DELETE("/links/:link", func(c *gin.Context) {
+		DeleteLink(c)
+	})
+}
@@ -403,3 +403,57 @@ func TestGetLabelLinks(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestCreateShareLink(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateShareLink(router)
+	UpdateShareLink(router)
+	DeleteShareLink(router)
+
+	t.Run("selection", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"Selection": {"photos": ["pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"]}, "CanDownload": true, "CanUpload": true}`)
+
+		if r.Code != http.StatusOK {
+			t.Fatal(r.Body.String())
+		}
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		assert.Equal(t, uid, gjson.Get(r.Body.String(), "Share").String())
+		assert.True(t, gjson.Get(r.Body.String(), "CanDownload").Bool())
+		assert.False(t, gjson.Get(r.Body.String(), "CanUpload").Bool())
+
+		link := entity.FindLink(uid)
+
+		if link == nil {
+			t.Fatal("link not found")
+		}
+
+		assert.ElementsMatch(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, link.PhotoUIDs())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/links/"+uid, `{"Expires": 3600}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "3600", gjson.Get(r.Body.String(), "Expires").String())
+		assert.True(t, gjson.Get(r.Body.String(), "CanDownload").Bool())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/links/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindLink(uid))
+		assert.Empty(t, link.PhotoUIDs())
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"Selection": {}}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("folder not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/links", `{"Folder": "dt9lxuqxpogaaxxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("link not found", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/links/sxxx", `{}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/links/sxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/people
+func GetPeople(router *gin.RouterGroup) {
+	router.GET("/people", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.PersonSearch
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Users may only see people in their library and albums shared with them.
+		f.UserUID = LibraryUID(s)
+
+		result, err := query.People(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/people/:uid
+func GetPerson(router *gin.RouterGroup) {
+	router.GET("/people/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.PersonByUID(c.Param("uid"))
+
+		if err != nil || !PeopleInLibrary(s, m.PersonUID) {
+			Abort(c, http.StatusNotFound, i18n.ErrPersonNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// PUT /api/v1/people/:uid
+func UpdatePerson(router *gin.RouterGroup) {
+	router.PUT("/people/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Person
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		id := c.Param("uid")
+		m, err := query.PersonByUID(id)
+
+		// Users may only change people in their library and albums shared with them.
+		if err != nil || !PeopleInLibrary(s, m.PersonUID) {
+			Abort(c, http.StatusNotFound, i18n.ErrPersonNotFound)
+			return
+		}
+
+		m.SetName(f.PersonName)
+
+		// People with the same name are merged, so that all their faces are found by name.
+		// Users can't merge with people outside their library, as this would change them as well.
+		if other := entity.FindPerson(m.PersonSlug); m.HasName() && other != nil && other.PersonUID != m.PersonUID && PeopleInLibrary(s, other.PersonUID) {
+			if err := other.Merge(&m); err != nil {
+				log.Errorf("person: %s", err)
+				AbortSaveFailed(c)
+				return
+			}
+
+			event.SuccessMsg(i18n.MsgPersonSaved)
+			event.EntitiesDeleted("people", []string{m.PersonUID})
+
+			PublishPersonEvent(EntityUpdated, other.PersonUID, c)
+
+			c.JSON(http.StatusOK, other)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("person: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgPersonSaved)
+
+		PublishPersonEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPeople(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPeople(router)
+		r := PerformRequest(app, "GET", "/api/v1/people?count=10")
+		count := gjson.Get(r.Body.String(), "#")
+		assert.LessOrEqual(t, int64(2), count.Int())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPeople(router)
+		r := PerformRequest(app, "GET", "/api/v1/people?count=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetPerson(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPerson(router)
+		r := PerformRequest(app, "GET", "/api/v1/people/rt9lxuqxkkvd1000")
+		assert.Equal(t, "Jane Doe", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPerson(router)
+		r := PerformRequest(app, "GET", "/api/v1/people/xxx")
+		assert.Equal(t, "Person not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdatePerson(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePerson(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/rt9lxuqxkkvd1001", `{"Name": "John Doe"}`)
+		assert.Equal(t, "John Doe", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "john-doe", gjson.Get(r.Body.String(), "Slug").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("merge", func(t *testing.T) {
+		m := entity.NewPerson(face.Embedding{1, 0, 0})
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		UpdatePerson(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/"+m.PersonUID, `{"Name": "John Doe"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "rt9lxuqxkkvd1001", gjson.Get(r.Body.String(), "UID").String())
+		assert.Nil(t, entity.FindPerson(m.PersonUID))
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePerson(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/rt9lxuqxkkvd1001", `{"Name": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePerson(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/xxx", `{"Name": "John Doe"}`)
+		assert.Equal(t, "Person not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestPeople_RestrictedUser(t *testing.T) {
+	conf := service.Config()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	alice := entity.UserFixtures.Get("alice")
+
+	app, router, _ := NewApiTest()
+	GetPeople(router)
+	GetPerson(router)
+	UpdatePerson(router)
+
+	t.Run("search", func(t *testing.T) {
+		r := PerformUserRequest(app, "GET", "/api/v1/people?count=10", "", alice)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("get", func(t *testing.T) {
+		r := PerformUserRequest(app, "GET", "/api/v1/people/rt9lxuqxkkvd1000", "", alice)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("update", func(t *testing.T) {
+		r := PerformUserRequest(app, "PUT", "/api/v1/people/rt9lxuqxkkvd1000", `{"Name": "Mallory"}`, alice)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		if m := entity.FindPerson("rt9lxuqxkkvd1000"); m == nil {
+			t.Fatal("person should not be nil")
+		} else {
+			assert.NotEqual(t, "Mallory", m.PersonName)
+		}
+	})
+}
@@ -34,6 +34,19 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// SavePhotoMeta writes photo metadata to XMP sidecar files and originals, if enabled.
+func SavePhotoMeta(p entity.Photo) {
+	opt := photoprism.ExportMetaOptionsConfig(service.Config())
+
+	if !opt.Xmp && !opt.Embed {
+		return
+	}
+
+	if err := service.ExportMeta().Photo(p, opt); err != nil {
+		log.Errorf("photo: %s (update metadata)", err)
+	}
+}
+
 // GET /api/v1/photos/:uid
 //
 // Parameters:
@@ -47,13 +60,33 @@ func GetPhoto(router *gin.RouterGroup) {
 			return
 		}
 
-		p, err := query.PhotoPreloadByUID(c.Param("uid"))
+		id := c.Param("uid")
+
+		// Users may only see photos in their library and albums shared with them, guests only shared photos.
+		if s.Guest() {
+			if !SharedPhoto(s, id) {
+				AbortEntityNotFound(c)
+				return
+			}
+		} else if uid := LibraryUID(s); uid != "" {
+			if _, count, err := query.PhotoSearch(form.PhotoSearch{ID: id, UserUID: uid}); err != nil || count == 0 {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		p, err := query.PhotoPreloadByUID(id)
 
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
 		}
 
+		// Face markers reveal the people in a photo, so they are omitted if the session may not see people.
+		if acl.Permissions.Deny(acl.ResourcePeople, s.User.Role(), acl.ActionRead) {
+			p.Faces = nil
+		}
+
 		c.IndentedJSON(http.StatusOK, p)
 	})
 }
@@ -68,6 +101,12 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		uid := c.Param("uid")
 		m, err := query.PhotoByUID(uid)
 
@@ -109,6 +148,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(p)
+		SavePhotoMeta(p)
 
 		c.JSON(http.StatusOK, p)
 	})
@@ -120,7 +160,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 //   uid: string PhotoUID as returned by the API
 func GetPhotoDownload(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/dl", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
+		// Guests may only download photos shared with them, users photos in their library.
+		if InvalidDownloadToken(c) && !SharedDownload(c, c.Param("uid")) && !LibraryDownload(c, c.Param("uid")) {
 			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
@@ -148,7 +189,7 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", downloadFileName))
 
-		c.File(fileName)
+		c.File(editedFileName(fileName, f.FileHash))
 	})
 }
 
@@ -200,6 +241,12 @@ func ApprovePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
@@ -235,6 +282,12 @@ func LikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
@@ -270,6 +323,12 @@ func DislikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
@@ -306,6 +365,12 @@ func PhotoPrimary(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		uid := c.Param("uid")
 		fileUID := c.Param("file_uid")
 		err := query.SetPhotoPrimary(uid, fileUID)
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/workers"
+)
+
+// GET /api/v1/photos/:uid/edit
+//
+// Returns the non-destructive adjustments of a photo, or the defaults if it wasn't edited.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func GetPhotoEdit(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Users may only view photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		m, err := query.PhotoByUID(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if edit := entity.FindPhotoEdit(m.ID); edit != nil {
+			c.JSON(http.StatusOK, edit)
+		} else {
+			c.JSON(http.StatusOK, entity.PhotoEdit{PhotoID: m.ID, CropW: 1, CropH: 1})
+		}
+	})
+}
+
+// PUT /api/v1/photos/:uid/edit
+//
+// Saves non-destructive adjustments like crop, rotation and exposure, the original files stay untouched.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func UpdatePhotoEdit(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		m, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var f form.PhotoEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := f.Validate(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		edit, err := entity.SavePhotoEdit(&m, f)
+
+		if err != nil {
+			log.Errorf("photo: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Cached thumbnail names stay valid, the files are rendered again by the edits worker.
+		for _, file := range m.AllFiles() {
+			flushThumbCache(file.FileHash)
+		}
+
+		event.Publish(workers.EditTopic, event.Data{"uid": m.PhotoUID})
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, edit)
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoEdit(t *testing.T) {
+	t.Run("not edited", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoEdit(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "CropW").Int())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "Rotate").Int())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoEdit(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/edit")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdatePhotoEdit(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13/edit", `{"CropX": 0.1, "CropY": 0.1, "CropW": 0.8, "CropH": 0.8, "Rotate": -90, "Exposure": 0.5}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(270), gjson.Get(r.Body.String(), "Rotate").Int())
+		assert.Equal(t, 0.5, gjson.Get(r.Body.String(), "Exposure").Float())
+
+		GetPhotoEdit(router)
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y13/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(270), gjson.Get(r.Body.String(), "Rotate").Int())
+	})
+	t.Run("reset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13/edit", `{}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13/edit", `{"Angle": 90}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "angle must be between -45 and 45 degrees", gjson.Get(r.Body.String(), "details").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y13/edit", `{"Rotate": "left"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/xxx/edit", `{"Rotate": 90}`)
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
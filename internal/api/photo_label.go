@@ -27,6 +27,12 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(c.Param("uid"))
 
 		if err != nil {
@@ -102,6 +108,12 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		m, err := query.PhotoByUID(c.Param("uid"))
 
 		if err != nil {
@@ -166,6 +178,12 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		// TODO: Code clean-up, simplify
 
 		m, err := query.PhotoByUID(c.Param("uid"))
@@ -14,7 +14,7 @@ import (
 // GET /api/v1/photos
 //
 // Query:
-//   q:         string Query string
+//   q:         string Query string, supports OR, -negation, (groups) and ranges like iso:>1600
 //   label:     string Label
 //   cat:       string Category
 //   country:   string Country code
@@ -43,25 +43,30 @@ func GetPhotos(router *gin.RouterGroup) {
 			return
 		}
 
-		// Guests may only see public content in shared albums.
+		// Guests may only see public content in shared albums, labels, folders and selections.
 		if s.Guest() {
 			if f.Album == "" || !s.HasShare(f.Album) {
 				AbortUnauthorized(c)
 				return
 			}
 
+			if err := query.ShareSearch(&f, f.Album); err != nil {
+				log.Warnf("search: %s", err)
+				AbortEntityNotFound(c)
+				return
+			}
+		} else if acl.Permissions.Deny(acl.ResourcePhotos, s.User.Role(), acl.ActionPrivate) {
 			f.Public = true
 			f.Private = false
-			f.Hidden = false
-			f.Archived = false
-			f.Review = false
 		}
 
+		f.UserUID = LibraryUID(s)
+
 		result, count, err := query.PhotoSearch(f)
 
 		if err != nil {
 			log.Error(err)
-			AbortBadRequest(c)
+			AbortInvalidQuery(c, err)
 			return
 		}
 
@@ -26,4 +26,20 @@ func TestGetPhotos(t *testing.T) {
 		result := PerformRequest(app, "GET", "/api/v1/photos?xxx=10")
 		assert.Equal(t, http.StatusBadRequest, result.Code)
 	})
+	t.Run("boolean query", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=year:2014+OR+-country:mx")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=(year:2014+OR+label:cat")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Invalid search query", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "query.position").Int())
+		assert.Equal(t, "missing closing parenthesis", gjson.Get(r.Body.String(), "query.message").String())
+	})
 }
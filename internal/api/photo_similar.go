@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GET /api/v1/photos/:uid/similar
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//
+// Query:
+//   distance: int Maximum number of different perceptual hash bits (default 6)
+func GetSimilarPhotos(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/similar", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() || s.Guest() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		distance := query.SimilarDistance
+
+		if param := c.Query("distance"); param != "" {
+			if d, err := strconv.Atoi(param); err != nil || d < 0 || d > 64 {
+				AbortBadRequest(c)
+				return
+			} else {
+				distance = d
+			}
+		}
+
+		uid := c.Param("uid")
+		libraryUID := LibraryUID(s)
+
+		// Users may only compare photos in their library and albums shared with them.
+		if _, count, err := query.PhotoSearch(form.PhotoSearch{ID: uid, UserUID: libraryUID}); err != nil || count == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		similar, err := query.SimilarPhotos(uid, distance)
+
+		if err != nil {
+			log.Errorf("photo: %s (find similar)", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results := query.PhotoResults{}
+
+		if len(similar) > 0 {
+			found, _, err := query.PhotoSearch(form.PhotoSearch{ID: strings.Join(similar.UIDs(), ","), Primary: true, UserUID: libraryUID})
+
+			if err != nil {
+				log.Error(err)
+				AbortBadRequest(c)
+				return
+			}
+
+			private := acl.Permissions.Deny(acl.ResourcePhotos, s.User.Role(), acl.ActionPrivate)
+
+			// Keep the order of similar photos, closest matches first.
+			for _, uid := range similar.UIDs() {
+				for _, r := range found {
+					if r.PhotoUID == uid && (!private || !r.PhotoPrivate) {
+						results = append(results, r)
+						break
+					}
+				}
+			}
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(results)))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
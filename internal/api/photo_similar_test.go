@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetSimilarPhotos(t *testing.T) {
+	t.Run("bridge", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/similar")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "pt9jtdre2lvl0yh0", gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("distance zero", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/similar?distance=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "[]", r.Body.String())
+	})
+	t.Run("invalid distance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/similar?distance=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/similar")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -25,6 +28,41 @@ func TestGetPhoto(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/photos/xxx")
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
+
+	t.Run("markers", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "rt9lxuqxkkvd1000", gjson.Get(r.Body.String(), "Markers.0.PersonUID").String())
+	})
+
+	t.Run("guest", func(t *testing.T) {
+		conf := service.Config()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+
+		link := entity.NewLink("pt9jtdre2lvl0y11", false, false)
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+
+		guest := session.Data{User: entity.Guest, Tokens: []string{link.LinkToken}, Shares: session.UIDs{link.ShareUID}}
+
+		r := PerformSessionRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11", "", guest)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y11", gjson.Get(r.Body.String(), "UID").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Markers").Exists())
+
+		r = PerformSessionRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7", "", guest)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
 }
 
 func TestUpdatePhoto(t *testing.T) {
@@ -77,6 +115,51 @@ func TestGetPhotoDownload(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t=xxx")
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
+
+	t.Run("user token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPhotoDownload(router)
+
+		token := conf.UserDownloadToken(entity.UserFixtures.Get("alice").UserUID)
+
+		// In the library of alice, but the original is missing.
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t="+token)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh9/dl?t="+token)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+
+	t.Run("share token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownload(router)
+
+		link := entity.NewLink("pt9jtdre2lvl0y14", false, false)
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		// Downloads must be allowed by the link.
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y14/dl?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		link.CanDownload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		// The original is missing, but the token is accepted.
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y14/dl?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		// Photos that are not shared can't be downloaded.
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y15/dl?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
 }
 
 func TestLikePhoto(t *testing.T) {
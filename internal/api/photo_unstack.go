@@ -29,6 +29,12 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users may only change photos in their library and albums shared with them.
+		if !PhotosInLibrary(s, c.Param("uid")) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		conf := service.Config()
 
 		photoUID := c.Param("uid")
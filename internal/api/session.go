@@ -5,9 +5,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/session"
 )
@@ -33,22 +35,34 @@ func CreateSession(router *gin.RouterGroup) {
 			id = ""
 		}
 
-		conf := service.Config()
-
 		if f.HasToken() {
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidLink)})
+				return
 			}
 
-			data.Tokens = []string{f.Token}
+			var shares session.UIDs
 
 			for _, link := range links {
-				data.Shares = append(data.Shares, link.ShareUID)
+				// Skip password protected shares if the password is wrong.
+				if link.InvalidPassword(f.Password) {
+					continue
+				}
+
+				shares = append(shares, link.ShareUID)
 				link.Redeem()
 			}
 
+			if len(shares) == 0 {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
+				return
+			}
+
+			data.Tokens = []string{f.Token}
+			data.Shares = append(data.Shares, shares...)
+
 			// Upgrade from anonymous to guest. Don't downgrade.
 			if data.User.Anonymous() {
 				data.User = entity.Guest
@@ -78,10 +92,10 @@ func CreateSession(router *gin.RouterGroup) {
 
 		c.Header("X-Session-ID", id)
 
-		if data.User.Anonymous() {
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.GuestConfig()})
+		if data.User.Anonymous() || data.Guest() {
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": GuestConfig(data)})
 		} else {
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfig()})
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": UserConfig(data)})
 		}
 	})
 }
@@ -113,6 +127,57 @@ func Session(id string) session.Data {
 	return service.Session().Get(id)
 }
 
+// LibraryUID returns the UID of the registered user whose library the results must be restricted to,
+// or an empty string if the session user may see all content.
+func LibraryUID(s session.Data) string {
+	if s.Guest() || s.User.Admin() {
+		return ""
+	}
+
+	return s.User.UserUID
+}
+
+// PhotosInLibrary returns true if the session user may access all photos with the given UIDs.
+func PhotosInLibrary(s session.Data, photoUIDs ...string) bool {
+	return query.UserHasPhotos(LibraryUID(s), photoUIDs)
+}
+
+// AlbumsInLibrary returns true if the session user may access all albums with the given UIDs.
+func AlbumsInLibrary(s session.Data, albumUIDs ...string) bool {
+	return query.UserHasAlbums(LibraryUID(s), albumUIDs)
+}
+
+// PeopleInLibrary returns true if the session user may access all people with the given UIDs.
+func PeopleInLibrary(s session.Data, personUIDs ...string) bool {
+	return query.UserHasPeople(LibraryUID(s), personUIDs)
+}
+
+// SharedPhoto returns true if the photo is shared with the guest session.
+func SharedPhoto(s session.Data, photoUID string) bool {
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindValidLinks(token, "") {
+			if s.HasShare(link.ShareUID) && query.SharedPhoto(link, photoUID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// UserConfig returns the client config for a registered user. Users whose library is restricted
+// get a download token that only works for their own photos.
+func UserConfig(s session.Data) config.ClientConfig {
+	conf := service.Config()
+	result := conf.UserConfig()
+
+	if uid := LibraryUID(s); uid != "" {
+		result.DownloadToken = conf.UserDownloadToken(uid)
+	}
+
+	return result
+}
+
 // Auth returns the session if user is authorized for the current action.
 func Auth(id string, resource acl.Resource, action acl.Action) session.Data {
 	sess := Session(id)
@@ -139,3 +204,39 @@ func InvalidPreviewToken(c *gin.Context) bool {
 func InvalidDownloadToken(c *gin.Context) bool {
 	return service.Config().InvalidDownloadToken(c.Query("t"))
 }
+
+// LibraryDownload returns true if the token passed in the request belongs to a user whose library contains the photo.
+func LibraryDownload(c *gin.Context, photoUID string) bool {
+	userUID := service.Config().DownloadTokenUser(c.Query("t"))
+
+	return userUID != "" && query.UserHasPhotos(userUID, []string{photoUID})
+}
+
+// DownloadLinks returns the share links that allow downloads with the token passed in the request.
+func DownloadLinks(c *gin.Context) entity.Links {
+	return entity.FindDownloadLinks(c.Query("t"))
+}
+
+// SharedDownload returns true if the token passed in the request allows downloading the photo.
+func SharedDownload(c *gin.Context, photoUID string) bool {
+	for _, link := range DownloadLinks(c) {
+		if query.SharedPhoto(link, photoUID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SessionDownloadLinks returns the share links of a guest session that allow downloads.
+func SessionDownloadLinks(s session.Data) (result entity.Links) {
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindValidLinks(token, "") {
+			if link.CanDownload && s.HasShare(link.ShareUID) {
+				result = append(result, link)
+			}
+		}
+	}
+
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
@@ -36,6 +37,29 @@ func TestCreateSession(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism", "token": "1jxf3jfn2k"}`)
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("share password", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+
+		if err := link.SetPassword("foobar"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "`+link.LinkToken+`", "password": "xxx"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrInvalidPassword), gjson.Get(r.Body.String(), "error").String())
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "`+link.LinkToken+`", "password": "foobar"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
 	t.Run("invalid password", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		CreateSession(router)
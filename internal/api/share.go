@@ -5,16 +5,62 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
+// GuestConfig returns the client config for a guest session. Downloads and uploads are only enabled
+// if one of the shares allows them, in which case the download token of the share link is used.
+func GuestConfig(s session.Data) config.ClientConfig {
+	conf := service.Config()
+	result := conf.GuestConfig()
+
+	canDownload, canUpload := false, false
+
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindValidLinks(token, "") {
+			if !s.HasShare(link.ShareUID) {
+				continue
+			}
+
+			if link.CanDownload && !canDownload {
+				canDownload = true
+				result.DownloadToken = link.DownloadToken()
+			}
+
+			canUpload = canUpload || link.CanUpload
+		}
+	}
+
+	result.Settings.Features.Download = result.Settings.Features.Download && canDownload
+	result.Settings.Features.Upload = result.Settings.Features.Upload && canUpload && !conf.ReadOnly()
+
+	return result
+}
+
+// shareSession returns the session data of a guest visiting a share link,
+// password protected shares are excluded until the guest has been logged in.
+func shareSession(links entity.Links) (s session.Data) {
+	for _, link := range links {
+		if !link.HasPassword {
+			s.Shares = append(s.Shares, link.ShareUID)
+		}
+
+		if len(s.Tokens) == 0 {
+			s.Tokens = []string{link.LinkToken}
+		}
+	}
+
+	return s
+}
+
 // GET /s/:token/...
 func Shares(router *gin.RouterGroup) {
 	router.GET("/:token", func(c *gin.Context) {
-		conf := service.Config()
-
 		token := c.Param("token")
 
 		links := entity.FindValidLinks(token, "")
@@ -25,15 +71,13 @@ func Shares(router *gin.RouterGroup) {
 			return
 		}
 
-		clientConfig := conf.GuestConfig()
+		clientConfig := GuestConfig(shareSession(links))
 		clientConfig.SiteUrl = fmt.Sprintf("%ss/%s", clientConfig.SiteUrl, token)
 
 		c.HTML(http.StatusOK, "share.tmpl", gin.H{"config": clientConfig})
 	})
 
 	router.GET("/:token/:share", func(c *gin.Context) {
-		conf := service.Config()
-
 		token := c.Param("token")
 		share := c.Param("share")
 
@@ -47,13 +91,27 @@ func Shares(router *gin.RouterGroup) {
 
 		uid := links[0].ShareUID
 
+		if err := query.ShareSearch(&form.PhotoSearch{}, uid); err != nil {
+			log.Warnf("share: %s", err)
+			c.Redirect(http.StatusTemporaryRedirect, "/")
+			return
+		}
+
 		if uid != share {
 			c.Redirect(http.StatusPermanentRedirect, fmt.Sprintf("/s/%s/%s", token, uid))
 			return
 		}
 
-		clientConfig := conf.GuestConfig()
+		clientConfig := GuestConfig(shareSession(links))
 		clientConfig.SiteUrl = fmt.Sprintf("%ss/%s/%s", clientConfig.SiteUrl, token, uid)
+
+		// Don't reveal anything about password protected shares.
+		if links[0].HasPassword {
+			clientConfig.Flags += " password"
+			c.HTML(http.StatusOK, "share.tmpl", gin.H{"config": clientConfig})
+			return
+		}
+
 		clientConfig.SitePreview = fmt.Sprintf("%s/preview", clientConfig.SiteUrl)
 
 		if a, err := query.AlbumByUID(uid); err == nil {
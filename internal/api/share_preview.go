@@ -29,12 +29,15 @@ func SharePreview(router *gin.RouterGroup) {
 
 		token := c.Param("token")
 		share := c.Param("share")
-		links := entity.FindLinks(token, share)
+		links := entity.FindValidLinks(token, share)
 
 		if len(links) != 1 {
 			log.Warn("share: invalid token (preview)")
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
+		} else if links[0].HasPassword {
+			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
+			return
 		}
 
 		thumbPath := path.Join(conf.ThumbPath(), "share")
@@ -62,13 +65,13 @@ func SharePreview(router *gin.RouterGroup) {
 
 		var f form.PhotoSearch
 
-		// Previews may only contain public content in shared albums.
-		f.Album = share
-		f.Public = true
-		f.Private = false
-		f.Hidden = false
-		f.Archived = false
-		f.Review = false
+		// Previews may only contain public content.
+		if err := query.ShareSearch(&f, links[0].ShareUID); err != nil {
+			log.Warnf("share: %s (preview)", err)
+			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
+			return
+		}
+
 		f.Primary = true
 
 		// Get first 12 album entries.
@@ -83,6 +86,11 @@ func SharePreview(router *gin.RouterGroup) {
 			return
 		}
 
+		// Searches for selected photos ignore the result count.
+		if len(p) > f.Count {
+			p = p[:f.Count]
+		}
+
 		if count == 0 {
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
@@ -98,7 +106,7 @@ func SharePreview(router *gin.RouterGroup) {
 				return
 			}
 
-			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err := thumb.FromEditedFile(fileName, f.FileHash, conf.ThumbPath(), photoprism.FileEdit(f.FileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
 
 			if err != nil {
 				log.Error(err)
@@ -128,7 +136,7 @@ func SharePreview(router *gin.RouterGroup) {
 				return
 			}
 
-			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err := thumb.FromEditedFile(fileName, f.FileHash, conf.ThumbPath(), photoprism.FileEdit(f.FileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
 
 			if err != nil {
 				log.Error(err)
@@ -4,9 +4,38 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGuestConfig(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+	link.CanDownload = true
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("download", func(t *testing.T) {
+		result := GuestConfig(session.Data{Tokens: []string{link.LinkToken}, Shares: session.UIDs{link.ShareUID}})
+		assert.Equal(t, link.LinkToken, result.DownloadToken)
+		assert.False(t, result.Settings.Features.Upload)
+	})
+	t.Run("not shared", func(t *testing.T) {
+		result := GuestConfig(session.Data{Tokens: []string{link.LinkToken}})
+		assert.Equal(t, "", result.DownloadToken)
+		assert.False(t, result.Settings.Features.Download)
+	})
+	t.Run("no download", func(t *testing.T) {
+		result := GuestConfig(session.Data{Tokens: []string{"4jxf3jfn2k"}, Shares: session.UIDs{"at9lxuqxpogaaba7"}})
+		assert.Equal(t, "", result.DownloadToken)
+		assert.False(t, result.Settings.Features.Download)
+	})
+}
+
 func TestGetShares(t *testing.T) {
 	t.Run("invalid token or share", func(t *testing.T) {
 		app, router, _ := NewApiTest()
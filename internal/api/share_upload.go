@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Guests can upload up to 100 files with a total size of 1 GB at once.
+const (
+	ShareUploadMaxFiles = 100
+	ShareUploadMaxSize  = 1024 * 1024 * 1024
+)
+
+// POST /api/v1/shares/:token/:uid/upload
+//
+// Uploads files to a shared album if the link allows it, files are imported right away.
+//
+// Parameters:
+//   token: string Secret share token
+//   uid: string Album UID
+func ShareUpload(router *gin.RouterGroup) {
+	router.POST("/shares/:token/:uid/upload", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		uid := c.Param("uid")
+		links := entity.FindValidLinks(c.Param("token"), uid)
+
+		if len(links) != 1 || links[0].ShareUID != uid || !links[0].CanUpload {
+			AbortUnauthorized(c)
+			return
+		} else if links[0].HasPassword && !Session(SessionID(c)).HasShare(uid) {
+			// Password protected shares must be unlocked first.
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Uploads are imported right away and can't be queued.
+		if mutex.MainWorker.Busy() {
+			Abort(c, http.StatusConflict, i18n.ErrBusy)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		} else if a.IsSmart() {
+			AbortBadRequest(c)
+			return
+		}
+
+		start := time.Now()
+
+		if c.Request.ContentLength > ShareUploadMaxSize {
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrUploadLimit)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ShareUploadMaxSize)
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if len(files) > ShareUploadMaxFiles {
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrUploadLimit)
+			return
+		}
+
+		event.Publish("upload.start", event.Data{"time": start})
+
+		// Use a separate folder, so that only these files are imported.
+		p := path.Join(conf.ImportPath(), "upload", fmt.Sprintf("share-%s-%s", a.AlbumUID, rnd.Token(8)))
+
+		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var uploads []string
+
+		for _, file := range files {
+			fileName := path.Join(p, filepath.Base(file.Filename))
+
+			log.Debugf("share: saving upload %s", txt.Quote(file.Filename))
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				logError("share", os.RemoveAll(p))
+				AbortBadRequest(c)
+				return
+			}
+
+			uploads = append(uploads, fileName)
+		}
+
+		if !conf.UploadNSFW() && containsNSFW(uploads) {
+			logError("share", os.RemoveAll(p))
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		opt := photoprism.ImportOptionsMove(p)
+		opt.Albums = []string{a.AlbumUID}
+
+		done := service.Import().Start(opt)
+
+		// Remaining files must not be imported later without adding them to the album.
+		if !fs.IsEmpty(p) && len(done) > 0 {
+			log.Warnf("share: some files uploaded to %s could not be imported", txt.Quote(a.Title()))
+		}
+
+		logError("share", os.RemoveAll(p))
+
+		// Nothing was imported if another worker started in the meantime.
+		if len(done) == 0 {
+			Abort(c, http.StatusConflict, i18n.ErrBusy)
+			return
+		}
+
+		elapsed := int(time.Since(start).Seconds())
+
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, len(uploads), elapsed)
+
+		log.Infof("share: %s to %s", msg, txt.Quote(a.Title()))
+
+		event.Publish("import.completed", event.Data{"path": p, "seconds": elapsed})
+		event.Publish("index.completed", event.Data{"path": p, "seconds": elapsed})
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		UpdateClientConfig()
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareUpload(t *testing.T) {
+	app, router, _ := NewApiTest()
+	ShareUpload(router)
+
+	t.Run("invalid token", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/shares/xxx/at9lxuqxpogaaba7/upload")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("upload not allowed", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/shares/4jxf3jfn2k/at9lxuqxpogaaba7/upload")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("no files", func(t *testing.T) {
+		link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+		link.CanUpload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		r := PerformRequest(app, "POST", "/api/v1/shares/"+link.LinkToken+"/at9lxuqxpogaaba7/upload")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("busy", func(t *testing.T) {
+		link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+		link.CanUpload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		r := PerformRequest(app, "POST", "/api/v1/shares/"+link.LinkToken+"/at9lxuqxpogaaba7/upload")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+}
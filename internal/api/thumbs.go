@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -51,6 +52,12 @@ func GetThumb(router *gin.RouterGroup) {
 			return
 		}
 
+		// Sprite sheets are created from video frames.
+		if thumbType.Sprite() {
+			videoSprite(c, fileHash)
+			return
+		}
+
 		if thumbType.ExceedsSize() && !conf.ThumbUncached() {
 			typeName, thumbType = thumb.Find(conf.ThumbSize())
 
@@ -61,8 +68,16 @@ func GetThumb(router *gin.RouterGroup) {
 			}
 		}
 
+		// Negotiate the thumbnail file format, downloads are always JPEG.
+		format := thumb.FormatJpeg
+
+		if c.Query("download") == "" {
+			format = thumbFormat(c)
+			c.Header("Vary", "Accept")
+		}
+
 		cache := service.Cache()
-		cacheKey := fmt.Sprintf("thumbs:%s:%s", fileHash, typeName)
+		cacheKey := thumbCacheKey(fileHash, typeName, format)
 
 		if cacheData, err := cache.Get(cacheKey); err == nil {
 			log.Debugf("cache hit for %s [%s]", cacheKey, time.Since(start))
@@ -75,19 +90,18 @@ func GetThumb(router *gin.RouterGroup) {
 				return
 			}
 
-			if !fs.FileExists(cached.FileName) {
-				log.Errorf("thumbs: %s not found", fileHash)
-				c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
-				return
-			}
+			// Thumbnails evicted from the cache are rendered again.
+			if fs.FileExists(cached.FileName) {
+				if c.Query("download") != "" {
+					c.FileAttachment(cached.FileName, cached.ShareName)
+				} else {
+					thumbFile(c, cached.FileName)
+				}
 
-			if c.Query("download") != "" {
-				c.FileAttachment(cached.FileName, cached.ShareName)
-			} else {
-				c.File(cached.FileName)
+				return
 			}
 
-			return
+			log.Debugf("thumbs: %s was removed from cache", filepath.Base(cached.FileName))
 		}
 
 		f, err := query.FileByHash(fileHash)
@@ -142,10 +156,17 @@ func GetThumb(router *gin.RouterGroup) {
 
 		var thumbnail string
 
-		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
-		} else {
-			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+		if format != thumb.FormatJpeg {
+			thumbnail, err = thumbEncoded(fileName, f.FileHash, thumbType, format)
+		}
+
+		if format == thumb.FormatJpeg || err != nil {
+			if err != nil {
+				log.Warnf("thumbs: %s, using jpeg", err)
+				cacheKey = thumbCacheKey(fileHash, typeName, thumb.FormatJpeg)
+			}
+
+			thumbnail, err = thumbJpeg(fileName, f.FileHash, thumbType)
 		}
 
 		if err != nil {
@@ -167,11 +188,83 @@ func GetThumb(router *gin.RouterGroup) {
 		if c.Query("download") != "" {
 			c.FileAttachment(thumbnail, f.ShareFileName())
 		} else {
-			c.File(thumbnail)
+			thumbFile(c, thumbnail)
 		}
 	})
 }
 
+// thumbFormat returns the best thumbnail file format accepted by the client.
+func thumbFormat(c *gin.Context) fs.FileType {
+	accept := c.GetHeader("Accept")
+
+	for _, format := range []fs.FileType{thumb.FormatAvif, thumb.FormatWebp} {
+		if strings.Contains(accept, thumb.FormatMimeTypes[format]) && thumb.FormatSupported(format) {
+			return format
+		}
+	}
+
+	return thumb.FormatJpeg
+}
+
+// thumbCacheKey returns the cache key for a thumbnail file name.
+func thumbCacheKey(fileHash, typeName string, format fs.FileType) string {
+	if format == thumb.FormatJpeg {
+		return fmt.Sprintf("thumbs:%s:%s", fileHash, typeName)
+	}
+
+	return fmt.Sprintf("thumbs:%s:%s:%s", fileHash, typeName, format)
+}
+
+// thumbJpeg returns a JPEG thumbnail, which is only created if on-demand rendering is enabled.
+func thumbJpeg(fileName, fileHash string, thumbType thumb.Type) (string, error) {
+	conf := service.Config()
+
+	if conf.ThumbUncached() || thumbType.OnDemand() {
+		return thumb.FromEditedFile(fileName, fileHash, conf.ThumbPath(), photoprism.FileEdit(fileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
+	}
+
+	return thumb.FromCache(fileName, fileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+}
+
+// thumbEncoded returns a WebP or AVIF thumbnail, which is created from the JPEG thumbnail if needed.
+func thumbEncoded(fileName, fileHash string, thumbType thumb.Type, format fs.FileType) (string, error) {
+	conf := service.Config()
+	opts := thumb.WithFormat(thumbType.Options, format)
+
+	if thumbnail, err := thumb.FromCache(fileName, fileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, opts...); err == nil {
+		return thumbnail, nil
+	}
+
+	// Resampling the existing JPEG thumbnail is much faster than using the original, and it is already edited.
+	if jpegName, err := thumb.FromCache(fileName, fileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...); err == nil {
+		return thumb.FromFile(jpegName, fileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, opts...)
+	} else if !conf.ThumbUncached() && !thumbType.OnDemand() {
+		return "", err
+	}
+
+	return thumb.FromEditedFile(fileName, fileHash, conf.ThumbPath(), photoprism.FileEdit(fileHash), thumbType.Width, thumbType.Height, opts...)
+}
+
+// flushThumbCache removes the cached thumbnail file names of a file hash, e.g. after a photo was edited.
+func flushThumbCache(hash string) {
+	cache := service.Cache()
+
+	for typeName := range thumb.Types {
+		for format := range thumb.FormatMimeTypes {
+			_ = cache.Delete(thumbCacheKey(hash, typeName, format))
+		}
+	}
+}
+
+// thumbFile sends a thumbnail file with the content type matching its format.
+func thumbFile(c *gin.Context, fileName string) {
+	if mimeType, ok := thumb.FormatMimeTypes[fs.FileType(strings.TrimPrefix(filepath.Ext(fileName), "."))]; ok {
+		c.Header("Content-Type", mimeType)
+	}
+
+	c.File(fileName)
+}
+
 // GET /api/v1/albums/:uid/t/:token/:type
 //
 // Parameters:
@@ -192,7 +285,7 @@ func AlbumThumb(router *gin.RouterGroup) {
 
 		thumbType, ok := thumb.Types[typeName]
 
-		if !ok {
+		if !ok || thumbType.Sprite() {
 			log.Errorf("album-thumbs: invalid type %s", typeName)
 			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
 			return
@@ -257,7 +350,7 @@ func AlbumThumb(router *gin.RouterGroup) {
 		var thumbnail string
 
 		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err = thumb.FromEditedFile(fileName, f.FileHash, conf.ThumbPath(), photoprism.FileEdit(f.FileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 		}
@@ -305,7 +398,7 @@ func LabelThumb(router *gin.RouterGroup) {
 
 		thumbType, ok := thumb.Types[typeName]
 
-		if !ok {
+		if !ok || thumbType.Sprite() {
 			log.Errorf("label-thumbs: invalid type %s", txt.Quote(typeName))
 			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
 			return
@@ -372,7 +465,7 @@ func LabelThumb(router *gin.RouterGroup) {
 		var thumbnail string
 
 		if conf.ThumbUncached() || thumbType.OnDemand() {
-			thumbnail, err = thumb.FromFile(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			thumbnail, err = thumb.FromEditedFile(fileName, f.FileHash, conf.ThumbPath(), photoprism.FileEdit(f.FileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
 		} else {
 			thumbnail, err = thumb.FromCache(fileName, f.FileHash, conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 		}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// GET /api/v1/thumbs/status
+//
+// Returns the number and size of cached thumbnails per type.
+func GetThumbsStatus(router *gin.RouterGroup) {
+	router.GET("/thumbs/status", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceConfig, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		stats, err := service.Thumbs().Stats()
+
+		if err != nil {
+			log.Errorf("thumbs: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, stats)
+	})
+}
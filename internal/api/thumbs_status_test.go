@@ -0,0 +1,20 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetThumbsStatus(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetThumbsStatus(router)
+		r := PerformRequest(app, "GET", "/api/v1/thumbs/status")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "types").IsObject())
+		assert.Equal(t, int64(-1), gjson.Get(r.Body.String(), "quota").Int())
+	})
+}
@@ -2,8 +2,11 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,6 +25,14 @@ func TestGetThumb(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("sprite for invalid hash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetThumb(router)
+		r := PerformRequest(app, "GET", "/api/v1/t/1/"+conf.PreviewToken()+"/sprite")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "image/svg+xml", r.Header().Get("Content-Type"))
+	})
 	t.Run("could not find original", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		GetThumb(router)
@@ -30,6 +41,40 @@ func TestGetThumb(t *testing.T) {
 	})
 }
 
+func TestThumbFormat(t *testing.T) {
+	cwebp, avifenc := thumb.CwebpBin, thumb.AvifencBin
+
+	defer func() {
+		thumb.CwebpBin, thumb.AvifencBin = cwebp, avifenc
+	}()
+
+	format := func(accept string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/v1/t/1/public/tile_500", nil)
+		c.Request.Header.Set("Accept", accept)
+
+		return string(thumbFormat(c))
+	}
+
+	thumb.CwebpBin, thumb.AvifencBin = "/usr/bin/cwebp", ""
+
+	assert.Equal(t, "webp", format("image/avif,image/webp,image/apng,image/*,*/*;q=0.8"))
+	assert.Equal(t, "jpg", format("image/*,*/*;q=0.8"))
+
+	thumb.AvifencBin = "/usr/bin/avifenc"
+
+	assert.Equal(t, "avif", format("image/avif,image/webp,image/apng,image/*,*/*;q=0.8"))
+
+	thumb.CwebpBin, thumb.AvifencBin = "", ""
+
+	assert.Equal(t, "jpg", format("image/avif,image/webp"))
+}
+
+func TestThumbCacheKey(t *testing.T) {
+	assert.Equal(t, "thumbs:abc:tile_500", thumbCacheKey("abc", "tile_500", thumb.FormatJpeg))
+	assert.Equal(t, "thumbs:abc:tile_500:webp", thumbCacheKey("abc", "tile_500", thumb.FormatWebp))
+}
+
 func TestAlbumThumb(t *testing.T) {
 	t.Run("invalid type", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -11,6 +11,7 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -68,38 +69,20 @@ func Upload(router *gin.RouterGroup) {
 			uploads = append(uploads, filename)
 		}
 
-		if !conf.UploadNSFW() {
-			nd := service.NsfwDetector()
-
-			containsNSFW := false
-
+		if !conf.UploadNSFW() && containsNSFW(uploads) {
 			for _, filename := range uploads {
-				labels, err := nd.File(filename)
-
-				if err != nil {
-					log.Debug(err)
-					continue
+				if err := os.Remove(filename); err != nil {
+					log.Errorf("nsfw: could not delete %s", txt.Quote(filename))
 				}
-
-				if labels.IsSafe() {
-					continue
-				}
-
-				log.Infof("nsfw: %s might be offensive", txt.Quote(filename))
-
-				containsNSFW = true
 			}
 
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", txt.Quote(filename))
-					}
-				}
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
 
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		// Restricted users may not start imports, so their uploads are imported right away.
+		if userUID := LibraryUID(s); userUID != "" {
+			workers.ImportUpload(conf, p, userUID)
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -111,3 +94,27 @@ func Upload(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
 	})
 }
+
+// containsNSFW returns true if one of the uploaded files might be offensive.
+func containsNSFW(fileNames []string) (result bool) {
+	nd := service.NsfwDetector()
+
+	for _, fileName := range fileNames {
+		labels, err := nd.File(fileName)
+
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+
+		if labels.IsSafe() {
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", txt.Quote(fileName))
+
+		result = true
+	}
+
+	return result
+}
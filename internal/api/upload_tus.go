@@ -0,0 +1,381 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"hash"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Resumable uploads implement the tus protocol, see https://tus.io/protocols/resumable-upload.html
+const (
+	TusVersion    = "1.0.0"
+	TusExtensions = "creation,expiration,checksum,termination"
+	TusChecksums  = "sha1"
+	TusMediaType  = "application/offset+octet-stream"
+
+	// StatusChecksumMismatch is returned if the checksum of uploaded bytes doesn't match.
+	StatusChecksumMismatch = 460
+)
+
+// parseUploadMetadata parses the Upload-Metadata header, e.g. "filename d29ybGRfZG9taW5hdGlvbl9wbGFuLnBkZg==,is_confidential".
+func parseUploadMetadata(s string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, " ", 2)
+
+		if len(kv) == 1 {
+			result[kv[0]] = ""
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(kv[1]))
+
+		if err != nil {
+			return result, errors.New("invalid upload metadata")
+		}
+
+		result[kv[0]] = string(value)
+	}
+
+	return result, nil
+}
+
+// tusHeaders adds headers to all responses, clients may only continue if the version is supported.
+func tusHeaders(c *gin.Context) bool {
+	c.Header("Tus-Resumable", TusVersion)
+	c.Header("Cache-Control", "no-store")
+
+	if c.GetHeader("Tus-Resumable") != TusVersion {
+		c.Header("Tus-Version", TusVersion)
+		c.AbortWithStatus(http.StatusPreconditionFailed)
+		return false
+	}
+
+	return true
+}
+
+// tusAuth checks the session and whether uploads are enabled.
+func tusAuth(c *gin.Context) (s session.Data, ok bool) {
+	conf := service.Config()
+
+	if conf.ReadOnly() || !conf.Settings().Features.Upload {
+		Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+		return s, false
+	}
+
+	s = Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return s, false
+	}
+
+	return s, true
+}
+
+// tusUpload returns the upload for the request if it belongs to the session user.
+func tusUpload(c *gin.Context, s session.Data, uploads *photoprism.Uploads) (*photoprism.Upload, bool) {
+	u, err := uploads.Find(c.Param("id"))
+
+	if err == photoprism.ErrUploadNotFound || err == photoprism.ErrUploadExpired || err == nil && u.Owner != s.User.UserUID {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	} else if err != nil {
+		log.Errorf("upload: %s", err)
+		AbortUnexpected(c)
+		return nil, false
+	}
+
+	return u, true
+}
+
+// tusError aborts with the status code matching the error.
+func tusError(c *gin.Context, err error) {
+	switch err {
+	case photoprism.ErrUploadOffset, photoprism.ErrUploadLocked:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+	case photoprism.ErrUploadSize, photoprism.ErrUploadLimit:
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": txt.UcFirst(err.Error())})
+	case photoprism.ErrUploadSpace:
+		c.AbortWithStatusJSON(http.StatusInsufficientStorage, gin.H{"error": txt.UcFirst(err.Error())})
+	case photoprism.ErrUploadChecksum:
+		c.AbortWithStatusJSON(StatusChecksumMismatch, gin.H{"error": txt.UcFirst(err.Error())})
+	default:
+		log.Errorf("upload: %s", err)
+		AbortUnexpected(c)
+	}
+}
+
+// OPTIONS /api/v1/uploads
+func UploadOptions(router *gin.RouterGroup) {
+	router.OPTIONS("/uploads", func(c *gin.Context) {
+		c.Header("Tus-Resumable", TusVersion)
+		c.Header("Tus-Version", TusVersion)
+		c.Header("Tus-Extension", TusExtensions)
+		c.Header("Tus-Checksum-Algorithm", TusChecksums)
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// POST /api/v1/uploads
+//
+// Creates a new resumable upload. The Upload-Metadata header must contain the file name
+// and may contain the import sub folder ("path") and the SHA1 hash of the complete file ("checksum").
+func CreateUpload(router *gin.RouterGroup) {
+	router.POST("/uploads", func(c *gin.Context) {
+		if !tusHeaders(c) {
+			return
+		}
+
+		s, ok := tusAuth(c)
+
+		if !ok {
+			return
+		}
+
+		size, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+
+		if err != nil || size < 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		meta, err := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		fileName := meta["filename"]
+
+		if fileName == "" {
+			fileName = meta["name"]
+		}
+
+		u, err := photoprism.NewUploads(service.Config()).Create(s.User.UserUID, meta["path"], fileName, size, meta["checksum"], meta)
+
+		if err == photoprism.ErrUploadInvalidName || err == photoprism.ErrUploadSizeUnsupported {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else if err != nil {
+			tusError(c, err)
+			return
+		}
+
+		log.Debugf("upload: started %s (%d bytes)", txt.Quote(u.FileName), u.Size)
+
+		event.Publish("upload.start", event.Data{"time": u.CreatedAt})
+
+		c.Header("Location", path.Join(c.Request.URL.Path, u.ID))
+		c.Header("Upload-Expires", u.Expires().Format(http.TimeFormat))
+		c.Status(http.StatusCreated)
+	})
+}
+
+// HEAD /api/v1/uploads/:id
+//
+// Parameters:
+//   id: string Upload ID as returned by the API
+func GetUploadOffset(router *gin.RouterGroup) {
+	router.HEAD("/uploads/:id", func(c *gin.Context) {
+		if !tusHeaders(c) {
+			return
+		}
+
+		s, ok := tusAuth(c)
+
+		if !ok {
+			return
+		}
+
+		u, ok := tusUpload(c, s, photoprism.NewUploads(service.Config()))
+
+		if !ok {
+			return
+		}
+
+		c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+		c.Header("Upload-Length", strconv.FormatInt(u.Size, 10))
+		c.Header("Upload-Expires", u.Expires().Format(http.TimeFormat))
+		c.Status(http.StatusOK)
+	})
+}
+
+// PATCH /api/v1/uploads/:id
+//
+// Appends bytes at the Upload-Offset, completed uploads are moved to the import folder and imported.
+//
+// Parameters:
+//   id: string Upload ID as returned by the API
+func ResumeUpload(router *gin.RouterGroup) {
+	router.PATCH("/uploads/:id", func(c *gin.Context) {
+		if !tusHeaders(c) {
+			return
+		}
+
+		s, ok := tusAuth(c)
+
+		if !ok {
+			return
+		}
+
+		if c.ContentType() != TusMediaType {
+			c.AbortWithStatus(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+
+		if err != nil || offset < 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		uploads := photoprism.NewUploads(service.Config())
+
+		unlock, err := uploads.Lock(c.Param("id"))
+
+		if err != nil {
+			tusError(c, err)
+			return
+		}
+
+		defer unlock()
+
+		u, ok := tusUpload(c, s, uploads)
+
+		if !ok {
+			return
+		}
+
+		// Optional checksum of the bytes in this request, e.g. "sha1 Kq5sNclPz7QV2+lfQIuc6R7oRu0=".
+		var checksum hash.Hash
+		var expected []byte
+
+		if header := c.GetHeader("Upload-Checksum"); header != "" {
+			parts := strings.SplitN(header, " ", 2)
+			h, supported := photoprism.NewUploadChecksum(parts[0])
+
+			if !supported || len(parts) != 2 {
+				AbortBadRequest(c)
+				return
+			} else if expected, err = base64.StdEncoding.DecodeString(parts[1]); err != nil {
+				AbortBadRequest(c)
+				return
+			}
+
+			checksum = h
+		}
+
+		offset, err = uploads.Write(u, offset, c.Request.Body, checksum, expected)
+
+		c.Header("Upload-Offset", strconv.FormatInt(offset, 10))
+		c.Header("Upload-Expires", u.Expires().Format(http.TimeFormat))
+
+		if err != nil {
+			tusError(c, err)
+			return
+		}
+
+		if !u.Complete() {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		fileName, err := uploads.Finish(u)
+
+		if err != nil {
+			tusError(c, err)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.UploadNSFW() {
+			if labels, err := service.NsfwDetector().File(fileName); err != nil {
+				log.Debug(err)
+			} else if !labels.IsSafe() {
+				log.Infof("nsfw: %s might be offensive", txt.Quote(fileName))
+
+				if err := os.Remove(fileName); err != nil {
+					log.Errorf("nsfw: could not delete %s", txt.Quote(fileName))
+				}
+
+				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+				return
+			}
+		}
+
+		log.Infof("upload: completed %s", txt.Quote(u.FileName))
+
+		workers.ImportUpload(conf, filepath.Dir(fileName), u.Owner)
+
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// DELETE /api/v1/uploads/:id
+//
+// Parameters:
+//   id: string Upload ID as returned by the API
+func DeleteUpload(router *gin.RouterGroup) {
+	router.DELETE("/uploads/:id", func(c *gin.Context) {
+		if !tusHeaders(c) {
+			return
+		}
+
+		s, ok := tusAuth(c)
+
+		if !ok {
+			return
+		}
+
+		uploads := photoprism.NewUploads(service.Config())
+
+		unlock, err := uploads.Lock(c.Param("id"))
+
+		if err != nil {
+			tusError(c, err)
+			return
+		}
+
+		defer unlock()
+
+		u, ok := tusUpload(c, s, uploads)
+
+		if !ok {
+			return
+		}
+
+		if err := uploads.Delete(u.ID); err != nil {
+			tusError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// performTusRequest performs a tus protocol request with the given headers and body.
+func performTusRequest(r http.Handler, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Tus-Resumable", TusVersion)
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestParseUploadMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		meta, err := parseUploadMetadata("filename SU1HXzEyMzQuanBn, path dHJpcA==,is_confidential")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "IMG_1234.jpg", meta["filename"])
+		assert.Equal(t, "trip", meta["path"])
+		assert.Equal(t, "", meta["is_confidential"])
+		assert.Len(t, meta, 3)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseUploadMetadata("filename %%%")
+		assert.Error(t, err)
+	})
+}
+
+func TestUploadOptions(t *testing.T) {
+	app, router, _ := NewApiTest()
+	UploadOptions(router)
+	r := PerformRequest(app, "OPTIONS", "/api/v1/uploads")
+	assert.Equal(t, http.StatusNoContent, r.Code)
+	assert.Equal(t, TusVersion, r.Header().Get("Tus-Version"))
+	assert.Equal(t, TusExtensions, r.Header().Get("Tus-Extension"))
+	assert.Equal(t, "sha1", r.Header().Get("Tus-Checksum-Algorithm"))
+}
+
+func TestCreateUpload(t *testing.T) {
+	t.Run("version not supported", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/uploads")
+		assert.Equal(t, http.StatusPreconditionFailed, r.Code)
+		assert.Equal(t, TusVersion, r.Header().Get("Tus-Version"))
+	})
+
+	t.Run("missing length", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUpload(router)
+		r := performTusRequest(app, "POST", "/api/v1/uploads", "", map[string]string{
+			"Upload-Metadata": "filename SU1HXzEyMzQuanBn",
+		})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUpload(router)
+		r := performTusRequest(app, "POST", "/api/v1/uploads", "", map[string]string{
+			"Upload-Length": "10",
+		})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestResumeUpload(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateUpload(router)
+	GetUploadOffset(router)
+	ResumeUpload(router)
+	DeleteUpload(router)
+
+	r := performTusRequest(app, "POST", "/api/v1/uploads", "", map[string]string{
+		"Upload-Length":   "10",
+		"Upload-Metadata": "filename SU1HXzEyMzQuanBn",
+	})
+
+	assert.Equal(t, http.StatusCreated, r.Code)
+	assert.NotEmpty(t, r.Header().Get("Upload-Expires"))
+
+	location := r.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, "/api/v1/uploads/"))
+
+	t.Run("media type", func(t *testing.T) {
+		r := performTusRequest(app, "PATCH", location, "hello", map[string]string{
+			"Upload-Offset": "0",
+		})
+		assert.Equal(t, http.StatusUnsupportedMediaType, r.Code)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		sum := sha1.Sum([]byte("hallo"))
+		r := performTusRequest(app, "PATCH", location, "hello", map[string]string{
+			"Content-Type":    TusMediaType,
+			"Upload-Offset":   "0",
+			"Upload-Checksum": "sha1 " + base64.StdEncoding.EncodeToString(sum[:]),
+		})
+		assert.Equal(t, StatusChecksumMismatch, r.Code)
+		assert.Equal(t, "0", r.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		sum := sha1.Sum([]byte("hello"))
+		r := performTusRequest(app, "PATCH", location, "hello", map[string]string{
+			"Content-Type":    TusMediaType,
+			"Upload-Offset":   "0",
+			"Upload-Checksum": "sha1 " + base64.StdEncoding.EncodeToString(sum[:]),
+		})
+		assert.Equal(t, http.StatusNoContent, r.Code)
+		assert.Equal(t, "5", r.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("offset conflict", func(t *testing.T) {
+		r := performTusRequest(app, "PATCH", location, "world", map[string]string{
+			"Content-Type":  TusMediaType,
+			"Upload-Offset": "0",
+		})
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, "5", r.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		r := performTusRequest(app, "HEAD", location, "", nil)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "5", r.Header().Get("Upload-Offset"))
+		assert.Equal(t, "10", r.Header().Get("Upload-Length"))
+		assert.Equal(t, "no-store", r.Header().Get("Cache-Control"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		r := performTusRequest(app, "DELETE", location, "", nil)
+		assert.Equal(t, http.StatusNoContent, r.Code)
+
+		r = performTusRequest(app, "HEAD", location, "", nil)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := performTusRequest(app, "HEAD", "/api/v1/uploads/xxx", "", nil)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -6,11 +6,183 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// GET /api/v1/users
+func GetUsers(router *gin.RouterGroup) {
+	router.GET("/users", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		result, err := query.Users()
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrUnexpected)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/users/:uid
+func GetUser(router *gin.RouterGroup) {
+	router.GET("/users/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.UserByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/users
+func CreateUser(router *gin.RouterGroup) {
+	router.POST("/users", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.Public() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		}
+
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.User
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if entity.FindUserByName(f.UserName) != nil {
+			AbortAlreadyExists(c, txt.Quote(f.UserName))
+			return
+		}
+
+		m, err := entity.CreateUser(f)
+
+		if err != nil {
+			log.Errorf("user: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgUserCreated)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// PUT /api/v1/users/:uid
+func UpdateUser(router *gin.RouterGroup) {
+	router.PUT("/users/:uid", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.Public() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		}
+
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.UserByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		var f form.User
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.SetForm(f); err != nil {
+			log.Errorf("user: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("user: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgUserSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/users/:uid
+func DeleteUser(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.Public() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		}
+
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.UserByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgUserDeleted)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
 // PUT /api/v1/users/:uid/password
 func ChangePassword(router *gin.RouterGroup) {
 	router.PUT("/users/:uid/password", func(c *gin.Context) {
@@ -21,7 +193,7 @@ func ChangePassword(router *gin.RouterGroup) {
 			return
 		}
 
-		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionUpdateSelf)
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
 
 		if s.Invalid() {
 			AbortUnauthorized(c)
@@ -29,6 +201,13 @@ func ChangePassword(router *gin.RouterGroup) {
 		}
 
 		uid := c.Param("uid")
+
+		// Only admins may change the password of other users.
+		if uid != s.User.UserUID && acl.Permissions.Deny(acl.ResourceUsers, s.User.Role(), acl.ActionUpdate) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		m := entity.FindUserByUID(uid)
 
 		if m == nil {
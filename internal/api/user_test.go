@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestChangePassword(t *testing.T) {
@@ -15,3 +16,47 @@ func TestChangePassword(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestGetUsers(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetUsers(router)
+	r := PerformRequest(app, "GET", "/api/v1/users")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.LessOrEqual(t, int64(3), gjson.Get(r.Body.String(), "#").Int())
+}
+
+func TestGetUser(t *testing.T) {
+	t.Run("alice", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUser(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/ut9lxuqxkkvd1000")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "alice", gjson.Get(r.Body.String(), "UserName").String())
+		assert.True(t, gjson.Get(r.Body.String(), "RoleFamily").Bool())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUser(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		assert.Equal(t, "User not found", gjson.Get(r.Body.String(), "error").String())
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUser(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users", `{"UserName": "carol", "Role": "child"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUser(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/ut9lxuqxkkvd1001")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
@@ -1,17 +1,26 @@
 package api
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
-
-	"github.com/photoprism/photoprism/internal/service"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// videoRetryAfter is the number of seconds clients should wait before requesting a video that is being transcoded.
+const videoRetryAfter = 10
+
 // GET /api/v1/videos/:hash/:token/:type
 //
 // Parameters:
@@ -24,10 +33,9 @@ func GetVideo(router *gin.RouterGroup) {
 			return
 		}
 
-		fileHash := c.Param("hash")
 		typeName := c.Param("type")
 
-		_, ok := video.Types[typeName]
+		videoType, ok := video.Types[typeName]
 
 		if !ok {
 			log.Errorf("video: invalid type %s", txt.Quote(typeName))
@@ -35,48 +43,53 @@ func GetVideo(router *gin.RouterGroup) {
 			return
 		}
 
-		f, err := query.FileByHash(fileHash)
+		f, mf, ok := videoFile(c, c.Param("hash"))
 
-		if err != nil {
-			log.Errorf("video: %s", err.Error())
-			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+		if !ok {
 			return
 		}
 
-		if !f.FileVideo {
-			f, err = query.VideoByPhotoUID(f.PhotoUID)
+		conv := service.Convert()
+		fileName := mf.FileName()
 
-			if err != nil {
-				log.Errorf("video: %s", err.Error())
-				c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+		if videoType.Sprite {
+			_, vttName, err := conv.SpriteFiles(f.FileHash)
+
+			if err != nil || !fs.FileExists(vttName) {
+				videoPending(c, mf, typeName)
 				return
 			}
-		}
 
-		if f.FileError != "" {
-			log.Errorf("video: file error %s", f.FileError)
-			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
-			return
-		}
+			vtt, err := ioutil.ReadFile(vttName)
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+			if err != nil {
+				log.Errorf("video: %s", err)
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
 
-		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
-			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
-			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+			// Sprite sheets are served by the thumbnail API, relative to this URL.
+			spriteUrl := fmt.Sprintf("../../../t/%s/%s/sprite", c.Param("hash"), c.Param("token"))
+			spriteName := strings.TrimSuffix(filepath.Base(vttName), ".vtt") + ".jpg"
 
-			// Set missing flag so that the file doesn't show up in search results anymore.
-			logError("video", f.Update("FileMissing", true))
+			c.Data(http.StatusOK, video.VttMimeType, []byte(strings.ReplaceAll(string(vtt), spriteName+"#", spriteUrl+"#")))
+			return
+		} else if videoType.Streaming {
+			playlist, err := conv.HlsPlaylist(f.FileHash)
 
+			if err != nil {
+				videoPending(c, mf, typeName)
+				return
+			}
+
+			c.Header("Content-Type", video.HlsMimeType)
+			c.File(playlist)
 			return
 		} else if !mf.IsPlayableVideo() {
-			conv := service.Convert()
-			avcFile, err := conv.ToAvc1(mf)
+			avcFile, err := conv.AvcFile(mf)
 
 			if err != nil {
-				log.Errorf("video: failed transcoding %s", txt.Quote(f.FileName))
-				c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
-
+				videoPending(c, mf, typeName)
 				return
 			}
 
@@ -92,3 +105,128 @@ func GetVideo(router *gin.RouterGroup) {
 		return
 	})
 }
+
+// GET /api/v1/videos/:hash/:token/:type/:name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   type: string Rendition name, e.g. 720p
+//   name: string Rendition playlist or segment file name
+func GetVideoStream(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/:type/:name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		renditionName := c.Param("type")
+		name := c.Param("name")
+
+		if _, ok := video.HlsRenditions.Get(renditionName); !ok || !video.HlsFile(name) {
+			log.Errorf("video: invalid stream file %s", txt.Quote(renditionName+"/"+name))
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		f, err := query.FileByHash(c.Param("hash"))
+
+		if err == nil && !f.FileVideo {
+			f, err = query.VideoByPhotoUID(f.PhotoUID)
+		}
+
+		if err != nil {
+			log.Errorf("video: %s", err.Error())
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		playlist, err := service.Convert().HlsPlaylist(f.FileHash)
+
+		if err != nil {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		fileName := filepath.Join(filepath.Dir(playlist), renditionName, name)
+
+		if filepath.Ext(name) == ".ts" {
+			c.Header("Content-Type", video.TsMimeType)
+		} else {
+			c.Header("Content-Type", video.HlsMimeType)
+		}
+
+		c.File(fileName)
+	})
+}
+
+// videoFile returns the video file for a file hash, sends a placeholder and returns false if not found.
+func videoFile(c *gin.Context, fileHash string) (f entity.File, mf *photoprism.MediaFile, ok bool) {
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		log.Errorf("video: %s", err.Error())
+		c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+		return f, nil, false
+	}
+
+	if !f.FileVideo {
+		f, err = query.VideoByPhotoUID(f.PhotoUID)
+
+		if err != nil {
+			log.Errorf("video: %s", err.Error())
+			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+			return f, nil, false
+		}
+	}
+
+	if f.FileError != "" {
+		log.Errorf("video: file error %s", f.FileError)
+		c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+		return f, nil, false
+	}
+
+	fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+	mf, err = photoprism.NewMediaFile(fileName)
+
+	if err != nil {
+		log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
+		c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+
+		// Set missing flag so that the file doesn't show up in search results anymore.
+		logError("video", f.Update("FileMissing", true))
+
+		return f, nil, false
+	}
+
+	return f, mf, true
+}
+
+// videoSprite sends the sprite sheet of a video, queues it for creation if it doesn't exist yet.
+func videoSprite(c *gin.Context, fileHash string) {
+	f, mf, ok := videoFile(c, fileHash)
+
+	if !ok {
+		return
+	}
+
+	spriteName, vttName, err := service.Convert().SpriteFiles(f.FileHash)
+
+	if err != nil || !fs.FileExists(spriteName) || !fs.FileExists(vttName) {
+		videoPending(c, mf, "vtt")
+		return
+	}
+
+	c.File(spriteName)
+}
+
+// videoPending queues a video for transcoding and tells the client to retry later.
+func videoPending(c *gin.Context, mf *photoprism.MediaFile, typeName string) {
+	if !service.VideoQueue().Add(mf, typeName) {
+		c.Data(http.StatusServiceUnavailable, "image/svg+xml", videoIconSvg)
+		return
+	}
+
+	c.Header("Retry-After", strconv.Itoa(videoRetryAfter))
+	c.Data(http.StatusAccepted, "image/svg+xml", videoIconSvg)
+}
@@ -36,6 +36,13 @@ func TestGetVideo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("vtt for invalid hash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/xxx/"+conf.PreviewToken()+"/vtt")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
 	t.Run("invalid token", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		GetVideo(router)
@@ -49,3 +56,30 @@ func TestGetVideo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestGetVideoStream(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/720p/index.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("invalid rendition", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/4k/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid file name", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/720p/passwd")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("file not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/xxx/"+conf.PreviewToken()+"/720p/segment_000.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/webhooks
+func GetWebhooks(router *gin.RouterGroup) {
+	router.GET("/webhooks", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		result, err := query.Webhooks()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/webhooks/:id
+//
+// Parameters:
+//   id: string Webhook ID as returned by the API
+func GetWebhook(router *gin.RouterGroup) {
+	router.GET("/webhooks/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if m, err := query.WebhookByID(ParseUint(c.Param("id"))); err == nil {
+			c.JSON(http.StatusOK, m)
+		} else {
+			AbortEntityNotFound(c)
+		}
+	})
+}
+
+// GET /api/v1/webhooks/:id/deliveries
+//
+// Returns the delivery log of a webhook, newest first.
+//
+// Parameters:
+//   id: string Webhook ID as returned by the API
+//   count: int Max result count
+//   offset: int Result offset
+func GetWebhookDeliveries(router *gin.RouterGroup) {
+	router.GET("/webhooks/:id/deliveries", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.WebhookByID(ParseUint(c.Param("id")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.WebhookDeliveries(m.ID, limit, offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(limit))
+		c.Header("X-Offset", strconv.Itoa(offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/webhooks
+func CreateWebhook(router *gin.RouterGroup) {
+	router.POST("/webhooks", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionCreate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Webhook
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := entity.CreateWebhook(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("webhook: created %s", txt.Quote(m.HookURL))
+
+		// The secret for verifying signatures is only returned once.
+		c.JSON(http.StatusOK, struct {
+			*entity.Webhook
+			HookSecret string `json:"HookSecret"`
+		}{m, m.HookSecret})
+	})
+}
+
+// PUT /api/v1/webhooks/:id
+//
+// Parameters:
+//   id: string Webhook ID as returned by the API
+func UpdateWebhook(router *gin.RouterGroup) {
+	router.PUT("/webhooks/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		id := ParseUint(c.Param("id"))
+
+		m, err := query.WebhookByID(id)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// 1) Init form with model values, the secret is only changed if a new value is sent
+		f, err := form.NewWebhook(m)
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// 2) Update form with values from request
+		if err := c.BindJSON(&f); err != nil {
+			log.Error(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// 3) Save model with values from form
+		if err := m.SaveForm(f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err = query.WebhookByID(id)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/webhooks/:id
+//
+// Parameters:
+//   id: string Webhook ID as returned by the API
+func DeleteWebhook(router *gin.RouterGroup) {
+	router.DELETE("/webhooks/:id", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionDelete)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.WebhookByID(ParseUint(c.Param("id")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Error(err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("webhook: deleted %s", txt.Quote(m.HookURL))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
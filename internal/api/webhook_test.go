@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetWebhooks(router)
+	GetWebhook(router)
+	GetWebhookDeliveries(router)
+	CreateWebhook(router)
+	UpdateWebhook(router)
+	DeleteWebhook(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"HookURL": "https://example.com/hook", "HookSecret": "s3cr3t", "HookTopics": "albums.*", "HookEnabled": true}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	id := gjson.Get(r.Body.String(), "ID").Int()
+	uri := fmt.Sprintf("/api/v1/webhooks/%d", id)
+
+	assert.Equal(t, "albums.*", gjson.Get(r.Body.String(), "HookTopics").String())
+	assert.Equal(t, "s3cr3t", gjson.Get(r.Body.String(), "HookSecret").String())
+
+	t.Run("generated secret", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"HookURL": "https://example.com/generated"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Get(r.Body.String(), "HookSecret").String(), 32)
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/webhooks/%d", gjson.Get(r.Body.String(), "ID").Int()))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"HookURL": "example.com"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/webhooks")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "https://example.com/hook", gjson.Get(r.Body.String(), fmt.Sprintf("#(ID==%d).HookURL", id)).String())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", uri, `{"HookTopics": "photos.created, index.failed"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "photos.created,index.failed", gjson.Get(r.Body.String(), "HookTopics").String())
+		assert.True(t, gjson.Get(r.Body.String(), "HookEnabled").Bool())
+		assert.False(t, gjson.Get(r.Body.String(), "HookSecret").Exists())
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		r := PerformRequest(app, "GET", uri+"/deliveries?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "0", r.Header().Get("X-Count"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", uri)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", uri)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/999000/deliveries")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/999000", `{}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
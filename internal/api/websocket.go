@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -61,9 +62,9 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 				var clientConfig config.ClientConfig
 
 				if sess.User.Guest() {
-					clientConfig = conf.GuestConfig()
+					clientConfig = GuestConfig(sess)
 				} else if sess.User.Registered() {
-					clientConfig = conf.UserConfig()
+					clientConfig = UserConfig(sess)
 				} else {
 					clientConfig = conf.PublicConfig()
 				}
@@ -134,6 +135,13 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			wsAuth.mutex.RUnlock()
 
 			if user.Registered() {
+				// Users whose library is restricted must not receive the global download token.
+				if msg.Name == "config.updated" {
+					if s := (session.Data{User: user}); LibraryUID(s) != "" {
+						msg.Fields = event.Data{"config": UserConfig(s)}
+					}
+				}
+
 				writeMutex.Lock()
 				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
 
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
@@ -63,8 +64,55 @@ func CreateZip(router *gin.RouterGroup) {
 			return
 		}
 
+		// Guests may only download files shared with them.
+		if s.Guest() {
+			links := SessionDownloadLinks(s)
+
+			for _, f := range files {
+				shared := false
+
+				for _, link := range links {
+					if query.SharedPhoto(link, f.PhotoUID) {
+						shared = true
+						break
+					}
+				}
+
+				if !shared {
+					AbortUnauthorized(c)
+					return
+				}
+			}
+		} else {
+			photoUIDs := make([]string, 0, len(files))
+
+			for _, f := range files {
+				photoUIDs = append(photoUIDs, f.PhotoUID)
+			}
+
+			// Users may only download files in their library and albums shared with them.
+			if !PhotosInLibrary(s, photoUIDs...) {
+				AbortUnauthorized(c)
+				return
+			}
+		}
+
+		// Only the creator may download the archive, see DownloadZip.
+		var downloadToken string
+
+		if s.Guest() {
+			downloadToken = GuestConfig(s).DownloadToken
+		} else {
+			downloadToken = UserConfig(s).DownloadToken
+		}
+
+		if downloadToken == "" {
+			AbortUnauthorized(c)
+			return
+		}
+
 		zipPath := path.Join(conf.TempPath(), "zip")
-		zipToken := rnd.Token(3)
+		zipToken := rnd.UUID()
 		zipYear := time.Now().Format("January-2006")
 		zipBaseName := fmt.Sprintf("Photos-%s-%s.zip", zipYear, zipToken)
 		zipFileName := path.Join(zipPath, zipBaseName)
@@ -74,6 +122,11 @@ func CreateZip(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := ioutil.WriteFile(zipOwnerFileName(zipFileName), []byte(downloadToken), 0600); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
+			return
+		}
+
 		newZipFile, err := os.Create(zipFileName)
 
 		if err != nil {
@@ -91,7 +144,7 @@ func CreateZip(router *gin.RouterGroup) {
 			fileAlias := f.ShareFileName()
 
 			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, fileAlias); err != nil {
+				if err := addFileToZip(zipWriter, editedFileName(fileName, f.FileHash), fileAlias); err != nil {
 					Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
 					return
 				}
@@ -113,11 +166,6 @@ func CreateZip(router *gin.RouterGroup) {
 // GET /api/v1/zip/:filename
 func DownloadZip(router *gin.RouterGroup) {
 	router.GET("/zip/:filename", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
-			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
-			return
-		}
-
 		conf := service.Config()
 		zipBaseName := filepath.Base(c.Param("filename"))
 		zipPath := path.Join(conf.TempPath(), "zip")
@@ -131,14 +179,29 @@ func DownloadZip(router *gin.RouterGroup) {
 			return
 		}
 
+		// Archives can only be downloaded with the token of the session that created them.
+		if owner, err := ioutil.ReadFile(zipOwnerFileName(zipFileName)); err != nil || len(owner) == 0 || string(owner) != c.Query("t") {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		c.File(zipFileName)
 
 		if err := os.Remove(zipFileName); err != nil {
 			log.Errorf("zip: failed removing %s (%s)", txt.Quote(zipFileName), err.Error())
 		}
+
+		if err := os.Remove(zipOwnerFileName(zipFileName)); err != nil {
+			log.Errorf("zip: failed removing owner of %s (%s)", txt.Quote(zipFileName), err.Error())
+		}
 	})
 }
 
+// zipOwnerFileName returns the name of the file that contains the download token of a zip archive.
+func zipOwnerFileName(zipFileName string) string {
+	return zipFileName + ".owner"
+}
+
 func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
 	fileToZip, err := os.Open(fileName)
 	if err != nil {
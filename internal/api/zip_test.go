@@ -1,9 +1,17 @@
 package api
 
 import (
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -50,6 +58,44 @@ func TestDownloadZip(t *testing.T) {
 		})
 	*/
 
+	t.Run("owner", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		DownloadZip(router)
+
+		zipPath := path.Join(conf.TempPath(), "zip")
+		zipFileName := path.Join(zipPath, "Photos-Test-"+rnd.UUID()+".zip")
+
+		if err := os.MkdirAll(zipPath, 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(zipFileName, []byte("zip"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(zipOwnerFileName(zipFileName), []byte(conf.DownloadToken()), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+		link.CanDownload = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		// Share tokens can't be used to download archives created by others.
+		r := PerformRequest(app, "GET", "/api/v1/zip/"+filepath.Base(zipFileName)+"?t="+link.DownloadToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/zip/"+filepath.Base(zipFileName)+"?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, fs.FileExists(zipFileName))
+		assert.False(t, fs.FileExists(zipOwnerFileName(zipFileName)))
+	})
+
 	t.Run("zip not existing", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		DownloadZip(router)
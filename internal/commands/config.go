@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -44,6 +45,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "tf-model-path", conf.TensorFlowModelPath())
 	fmt.Printf("%-25s %t\n", "detect-nsfw", conf.DetectNSFW())
 	fmt.Printf("%-25s %t\n", "upload-nsfw", conf.UploadNSFW())
+	fmt.Printf("%-25s %t\n", "detect-faces", conf.DetectFaces())
+	fmt.Printf("%-25s %s\n", "face-model-path", conf.FaceModelPath())
 
 	// Passwords.
 	fmt.Printf("%-25s %s\n", "admin-password", conf.AdminPassword())
@@ -51,6 +54,9 @@ func configAction(ctx *cli.Context) error {
 	// Background workers and logging.
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
+	fmt.Printf("%-25s %t\n", "watch", conf.Watch())
+	fmt.Printf("%-25s %t\n", "watch-flagged", conf.WatchFlagged())
+	fmt.Printf("%-25s %d\n", "watch-delay", conf.WatchDelay()/time.Second)
 	fmt.Printf("%-25s %s\n", "log-level", conf.LogLevel())
 	fmt.Printf("%-25s %s\n", "log-filename", conf.LogFilename())
 	fmt.Printf("%-25s %s\n", "pid-filename", conf.PIDFilename())
@@ -59,6 +65,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "http-host", conf.HttpServerHost())
 	fmt.Printf("%-25s %d\n", "http-port", conf.HttpServerPort())
 	fmt.Printf("%-25s %s\n", "http-mode", conf.HttpServerMode())
+	fmt.Printf("%-25s %t\n", "dlna", conf.DLNA())
+	fmt.Printf("%-25s %d\n", "dlna-port", conf.DLNAPort())
 
 	// Database configuration.
 	fmt.Printf("%-25s %s\n", "database-driver", dbDriver)
@@ -92,10 +100,16 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
+	fmt.Printf("%-25s %s\n", "ffprobe-bin", conf.FFprobeBin())
+	fmt.Printf("%-25s %s\n", "cwebp-bin", conf.CwebpBin())
+	fmt.Printf("%-25s %s\n", "avifenc-bin", conf.AvifencBin())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
 	fmt.Printf("%-25s %t\n", "sidecar-json", conf.SidecarJson())
 	fmt.Printf("%-25s %t\n", "sidecar-yaml", conf.SidecarYaml())
+	fmt.Printf("%-25s %t\n", "sidecar-xmp", conf.SidecarXmp())
+	fmt.Printf("%-25s %t\n", "embed-meta", conf.EmbedMeta())
 	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
+	fmt.Printf("%-25s %s\n", "local-paths", strings.Join(conf.LocalPaths(), ","))
 
 	// Geo data API.
 	fmt.Printf("%-25s %s\n", "geo-api", conf.GeoApi())
@@ -107,9 +121,13 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "thumb-uncached", conf.ThumbUncached())
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSize())
 	fmt.Printf("%-25s %d\n", "thumb-size-uncached", conf.ThumbSizeUncached())
+	fmt.Printf("%-25s %d\n", "thumb-cache-quota", conf.ThumbCacheQuota())
 	fmt.Printf("%-25s %s\n", "thumb-path", conf.ThumbPath())
+	fmt.Printf("%-25s %s\n", "hls-path", conf.HlsPath())
 	fmt.Printf("%-25s %d\n", "jpeg-size", conf.JpegSize())
 	fmt.Printf("%-25s %d\n", "jpeg-quality", conf.JpegQuality())
+	fmt.Printf("%-25s %d\n", "webp-quality", conf.WebpQuality())
+	fmt.Printf("%-25s %d\n", "avif-quality", conf.AvifQuality())
 
 	return nil
 }
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// DuplicatesCommand is used to register the duplicates cli command
+var DuplicatesCommand = cli.Command{
+	Name:  "duplicates",
+	Usage: "Lists groups of visually similar photos",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "distance, d",
+			Usage: "maximum number of different perceptual hash bits (0-64)",
+			Value: query.SimilarDistance,
+		},
+		cli.BoolFlag{
+			Name:  "archive",
+			Usage: "archive near-duplicates with a lower quality score",
+		},
+	},
+	Action: duplicatesAction,
+}
+
+// duplicatesAction lists near-duplicates and optionally archives them.
+func duplicatesAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.DuplicatesOptions{
+		Distance: ctx.Int("distance"),
+		Archive:  ctx.Bool("archive"),
+	}
+
+	if opt.Distance < 0 || opt.Distance > 64 {
+		return fmt.Errorf("distance must be between 0 and 64")
+	}
+
+	groups, archived, err := service.Duplicates().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	for i, group := range groups {
+		fmt.Printf("\nGROUP %d\n", i+1)
+		fmt.Printf("%-18s %-8s %-8s %-11s %s\n", "PHOTO", "QUALITY", "DISTANCE", "RESOLUTION", "FILE")
+
+		for _, p := range group {
+			fmt.Printf("%-18s %-8d %-8d %-11s %s\n", p.PhotoUID, p.PhotoQuality, p.Distance, fmt.Sprintf("%d MP", p.PhotoResolution), p.FileName)
+		}
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("found %d groups of near-duplicates, archived %d photos [%s]", len(groups), archived, elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
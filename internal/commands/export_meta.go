@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// ExportMetaCommand is used to register the export-meta cli command
+var ExportMetaCommand = cli.Command{
+	Name:  "export-meta",
+	Usage: "Writes metadata to XMP sidecar files so that other applications can use it",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "embed",
+			Usage: "also embed metadata in JPEG originals using Exiftool (modifies files)",
+		},
+	},
+	Action: exportMetaAction,
+}
+
+// exportMetaAction writes photo metadata to XMP sidecar files and optionally embeds it in originals.
+func exportMetaAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	if !conf.SidecarWritable() {
+		return errors.New("can't write sidecar files in read-only mode")
+	}
+
+	opt := photoprism.ExportMetaOptions{
+		Xmp:   true,
+		Embed: ctx.Bool("embed"),
+	}
+
+	if opt.Embed && (conf.ReadOnly() || conf.ExifToolBin() == "") {
+		return errors.New("embedding metadata requires exiftool and write access to originals")
+	}
+
+	updated, err := service.ExportMeta().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("metadata of %d photos written in %s", updated, elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
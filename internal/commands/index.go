@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -26,6 +27,10 @@ var indexFlags = []cli.Flag{
 		Name:  "all, a",
 		Usage: "re-index all originals, including unchanged files",
 	},
+	cli.BoolFlag{
+		Name:  "rebuild-terms",
+		Usage: "rebuild the full-text search index of all photos",
+	},
 }
 
 // indexAction indexes all photos in originals directory (photo library)
@@ -81,6 +86,14 @@ func indexAction(ctx *cli.Context) error {
 		log.Infof("removed %d files and %d photos", len(files), len(photos))
 	}
 
+	if ctx.Bool("rebuild-terms") {
+		if count, err := entity.IndexAllPhotoTerms(true); err != nil {
+			log.Error(err)
+		} else {
+			log.Infof("updated full-text index of %d photos", count)
+		}
+	}
+
 	elapsed := time.Since(start)
 
 	log.Infof("indexed %d files in %s", len(indexed), elapsed)
@@ -1,28 +1,37 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/urfave/cli"
 )
 
-// ResampleCommand is used to register the thumbs cli command
+// ResampleCommand is used to register the resample cli command
 var ResampleCommand = cli.Command{
-	Name:    "resample",
-	Aliases: []string{"thumbs"},
-	Usage:   "Pre-renders thumbnails (significantly reduces memory and cpu usage)",
-	Flags: []cli.Flag{
-		cli.BoolFlag{
-			Name:  "force, f",
-			Usage: "re-create existing thumbnails",
-		},
-	},
+	Name:   "resample",
+	Usage:  "Pre-renders thumbnails (significantly reduces memory and cpu usage)",
+	Flags:  resampleFlags,
 	Action: resampleAction,
 }
 
+var resampleFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "force, f",
+		Usage: "re-create existing thumbnails",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "additional thumbnail `FORMATS` to pre-render, e.g. webp,avif",
+	},
+}
+
 // resampleAction pre-render the thumbnails
 func resampleAction(ctx *cli.Context) error {
 	start := time.Now()
@@ -34,11 +43,29 @@ func resampleAction(ctx *cli.Context) error {
 		return err
 	}
 
+	var formats []fs.FileType
+
+	for _, name := range strings.Split(ctx.String("format"), ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		format, err := thumb.ParseFormat(name)
+
+		if err != nil {
+			return err
+		} else if !thumb.FormatSupported(format) {
+			return fmt.Errorf("resample: no encoder found for %s", format)
+		} else if format != thumb.FormatJpeg {
+			formats = append(formats, format)
+		}
+	}
+
 	log.Infof("creating thumbnails in %s", txt.Quote(conf.ThumbPath()))
 
 	rs := service.Resample()
 
-	if err := rs.Start(ctx.Bool("force")); err != nil {
+	if err := rs.Start(ctx.Bool("force"), formats...); err != nil {
 		log.Error(err)
 		return err
 	}
@@ -110,6 +110,13 @@ func startAction(ctx *cli.Context) error {
 	// start share & sync workers
 	workers.Start(conf)
 
+	// start watching originals for changes
+	if conf.Watch() {
+		if err := service.Watch().Start(); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	}
+
 	// set up proper shutdown of daemon and web server
 	quit := make(chan os.Signal)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -119,6 +126,11 @@ func startAction(ctx *cli.Context) error {
 	// stop share & sync workers
 	workers.Stop()
 
+	// stop watching originals
+	if conf.Watch() {
+		service.Watch().Stop()
+	}
+
 	log.Info("shutting down...")
 	conf.Shutdown()
 	cancel()
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/urfave/cli"
+)
+
+// SyncCommand is used to register the sync cli command.
+var SyncCommand = cli.Command{
+	Name:      "sync",
+	Usage:     "Syncs files with remote accounts",
+	ArgsUsage: "[account id]",
+	Flags:     syncFlags,
+	Action:    syncAction,
+}
+
+var syncFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run, n",
+		Usage: "show planned changes without syncing",
+	},
+}
+
+// syncAction syncs files with remote accounts.
+func syncAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	var accounts entity.Accounts
+
+	if arg := ctx.Args().First(); arg != "" {
+		id, err := strconv.ParseUint(arg, 10, 32)
+
+		if err != nil {
+			return fmt.Errorf("invalid account id %s", arg)
+		}
+
+		a, err := query.AccountByID(uint(id))
+
+		if err != nil {
+			return fmt.Errorf("account %d not found", id)
+		}
+
+		accounts = append(accounts, a)
+	}
+
+	worker := workers.NewSync(conf)
+
+	if !ctx.Bool("dry-run") {
+		if conf.ReadOnly() {
+			return config.ErrReadOnly
+		}
+
+		if err := worker.Start(accounts...); err != nil {
+			return err
+		}
+
+		log.Infof("completed in %s", time.Since(start))
+
+		conf.Shutdown()
+
+		return nil
+	}
+
+	if len(accounts) == 0 {
+		result, err := query.AccountSearch(form.AccountSearch{Sync: true})
+
+		if err != nil {
+			return err
+		}
+
+		accounts = result
+	}
+
+	for _, a := range accounts {
+		plan, err := worker.Plan(a)
+
+		if err != nil {
+			log.Errorf("sync: %s (%s)", err, a.AccName)
+			continue
+		}
+
+		fmt.Printf("\n%s (%d changes)\n\n", a.AccName, len(plan))
+
+		if len(plan) == 0 {
+			continue
+		}
+
+		fmt.Printf("%-14s %-40s %s\n", "ACTION", "REMOTE NAME", "REASON")
+
+		for _, action := range plan {
+			fmt.Printf("%-14s %-40s %s\n", action.Action, action.RemoteName, action.Reason)
+		}
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// ThumbsCommand is used to register the thumbs cli command, it pre-renders thumbnails if no sub-command is given
+var ThumbsCommand = cli.Command{
+	Name:   "thumbs",
+	Usage:  "Thumbnail cache sub-commands, pre-renders thumbnails by default",
+	Flags:  resampleFlags,
+	Action: resampleAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "stats",
+			Usage:  "Shows the thumbnail cache size per type",
+			Action: thumbsStatsAction,
+		},
+		{
+			Name:  "clean",
+			Usage: "Removes orphaned thumbnails and evicts on-demand thumbnails exceeding the cache quota",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "dry",
+					Usage: "dry run, don't actually remove anything",
+				},
+			},
+			Action: thumbsCleanAction,
+		},
+	},
+}
+
+// thumbsStatsAction prints the thumbnail cache size per type.
+func thumbsStatsAction(ctx *cli.Context) error {
+	return withThumbs(ctx, func(conf *config.Config, cache *photoprism.ThumbCache) error {
+		stats, err := cache.Stats()
+
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(stats.Types))
+
+		for name := range stats.Types {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		fmt.Printf("%-12s %10s %10s\n", "TYPE", "FILES", "SIZE")
+
+		for _, name := range names {
+			s := stats.Types[name]
+			fmt.Printf("%-12s %10d %10s\n", name, s.Files, humanize.Bytes(uint64(s.Size)))
+		}
+
+		fmt.Printf("%-12s %10d %10s\n", "total", stats.Files, humanize.Bytes(uint64(stats.Size)))
+
+		if stats.Quota > 0 {
+			fmt.Printf("%-12s %10s %10s\n", "quota", "", humanize.Bytes(uint64(stats.Quota)))
+		}
+
+		return nil
+	})
+}
+
+// thumbsCleanAction removes orphaned thumbnails and evicts on-demand thumbnails exceeding the cache quota.
+func thumbsCleanAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	return withThumbs(ctx, func(conf *config.Config, cache *photoprism.ThumbCache) error {
+		result, err := cache.Clean(ctx.Bool("dry"))
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("removed %d orphaned and %d evicted files (%s) in %s", result.Orphans, result.Evicted, humanize.Bytes(uint64(result.Size)), time.Since(start))
+
+		return nil
+	})
+}
+
+// withThumbs initializes the config and database before running a thumbnail cache action.
+func withThumbs(ctx *cli.Context, action func(conf *config.Config, cache *photoprism.ThumbCache) error) error {
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	defer conf.Shutdown()
+
+	return action(conf, service.Thumbs())
+}
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// UsersCommand is used to register the users cli command
+var UsersCommand = cli.Command{
+	Name:  "users",
+	Usage: "User management sub-commands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Adds a new user",
+			ArgsUsage: "[username]",
+			Flags:     usersAddFlags,
+			Action:    usersAddAction,
+		},
+		{
+			Name:      "rm",
+			Usage:     "Removes an existing user",
+			ArgsUsage: "[username]",
+			Action:    usersRemoveAction,
+		},
+		{
+			Name:   "ls",
+			Usage:  "Lists registered users",
+			Action: usersListAction,
+		},
+		{
+			Name:      "share",
+			Usage:     "Shares an album with a user",
+			ArgsUsage: "[username] [album uid]",
+			Action:    usersShareAction,
+		},
+		{
+			Name:      "unshare",
+			Usage:     "Stops sharing an album with a user",
+			ArgsUsage: "[username] [album uid]",
+			Action:    usersUnshareAction,
+		},
+	},
+}
+
+var usersAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "fullname, n",
+		Usage: "full name of the user",
+	},
+	cli.StringFlag{
+		Name:  "email, m",
+		Usage: "email address of the user",
+	},
+	cli.StringFlag{
+		Name:  "role, r",
+		Usage: "user role (admin, family, friend, child or guest)",
+		Value: "family",
+	},
+	cli.StringFlag{
+		Name:  "storage-path, s",
+		Usage: "originals sub folder containing the user library",
+	},
+	cli.StringFlag{
+		Name:  "password, p",
+		Usage: "initial password, prompts for a password if empty",
+	},
+}
+
+// usersAddAction adds a new user.
+func usersAddAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	f := form.User{
+		UserName:     userName,
+		FullName:     ctx.String("fullname"),
+		PrimaryEmail: ctx.String("email"),
+		Role:         ctx.String("role"),
+		StoragePath:  ctx.String("storage-path"),
+		Password:     ctx.String("password"),
+	}
+
+	if f.Password == "" {
+		f.Password = getPassword("Password: ")
+
+		if len(f.Password) < 6 {
+			return errors.New("password is too short, please try again")
+		}
+
+		if getPassword("Retype Password: ") != f.Password {
+			return errors.New("passwords did not match, please try again")
+		}
+	}
+
+	return withUsers(ctx, func() error {
+		user, err := entity.CreateUser(f)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("added %s user %s", user.Role(), txt.Quote(user.UserName))
+
+		return nil
+	})
+}
+
+// usersRemoveAction removes an existing user.
+func usersRemoveAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	return withUsers(ctx, func() error {
+		user := entity.FindUserByName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		if err := user.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("removed user %s", txt.Quote(userName))
+
+		return nil
+	})
+}
+
+// usersListAction lists registered users.
+func usersListAction(ctx *cli.Context) error {
+	return withUsers(ctx, func() error {
+		users, err := query.Users()
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-20s %-8s %-25s %s\n", "USERNAME", "ROLE", "FULL NAME", "STORAGE PATH")
+
+		for _, user := range users {
+			fmt.Printf("%-20s %-8s %-25s %s\n", user.UserName, user.Role(), user.FullName, user.StoragePath)
+		}
+
+		return nil
+	})
+}
+
+// usersShareAction shares an album with a user.
+func usersShareAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().Get(0))
+	albumUID := strings.TrimSpace(ctx.Args().Get(1))
+
+	if userName == "" || albumUID == "" {
+		return errors.New("please provide a user name and an album uid")
+	}
+
+	return withUsers(ctx, func() error {
+		user := entity.FindUserByName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		album, err := query.AlbumByUID(albumUID)
+
+		if err != nil {
+			return fmt.Errorf("album %s not found", txt.Quote(albumUID))
+		}
+
+		if entity.FirstOrCreateUserShare(entity.NewUserShare(user.UserUID, album.AlbumUID)) == nil {
+			return fmt.Errorf("failed sharing %s with %s", txt.Quote(album.Title()), txt.Quote(userName))
+		}
+
+		log.Infof("shared %s with %s", txt.Quote(album.Title()), txt.Quote(userName))
+
+		return nil
+	})
+}
+
+// usersUnshareAction stops sharing an album with a user.
+func usersUnshareAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().Get(0))
+	albumUID := strings.TrimSpace(ctx.Args().Get(1))
+
+	if userName == "" || albumUID == "" {
+		return errors.New("please provide a user name and an album uid")
+	}
+
+	return withUsers(ctx, func() error {
+		user := entity.FindUserByName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		if err := entity.NewUserShare(user.UserUID, albumUID).Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("stopped sharing %s with %s", txt.Quote(albumUID), txt.Quote(userName))
+
+		return nil
+	})
+}
+
+// withUsers initializes the config and database before running a user management action.
+func withUsers(ctx *cli.Context, action func() error) error {
+	conf := config.NewConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	defer conf.Shutdown()
+
+	return action()
+}
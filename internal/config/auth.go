@@ -1,7 +1,11 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"golang.org/x/crypto/bcrypt"
@@ -41,6 +45,32 @@ func (c *Config) DownloadToken() string {
 	return c.params.DownloadToken
 }
 
+// UserDownloadToken returns a download token that is bound to a registered user, so that downloads
+// can be restricted to their library.
+func (c *Config) UserDownloadToken(userUID string) string {
+	mac := hmac.New(sha256.New, []byte(c.DownloadToken()))
+	mac.Write([]byte(userUID))
+
+	return userUID + "-" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// DownloadTokenUser returns the UID of the user a download token is bound to, or an empty string.
+func (c *Config) DownloadTokenUser(t string) string {
+	i := strings.LastIndex(t, "-")
+
+	if i < 1 {
+		return ""
+	}
+
+	userUID := t[:i]
+
+	if !hmac.Equal([]byte(c.UserDownloadToken(userUID)), []byte(t)) {
+		return ""
+	}
+
+	return userUID
+}
+
 // InvalidPreviewToken returns true if the preview token is invalid.
 func (c *Config) InvalidPreviewToken(t string) bool {
 	return c.PreviewToken() != t && c.DownloadToken() != t
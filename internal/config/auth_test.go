@@ -53,3 +53,14 @@ func TestConfig_InvalidPreviewToken(t *testing.T) {
 
 	assert.True(t, c.InvalidPreviewToken("xxx"))
 }
+
+func TestConfig_UserDownloadToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	token := c.UserDownloadToken("uqxc08w3d0ej2283")
+
+	assert.True(t, c.InvalidDownloadToken(token))
+	assert.Equal(t, "uqxc08w3d0ej2283", c.DownloadTokenUser(token))
+	assert.Equal(t, "", c.DownloadTokenUser("uqxc08w3d0ej2283-0000000000000000"))
+	assert.Equal(t, "", c.DownloadTokenUser(c.DownloadToken()))
+}
@@ -156,6 +156,7 @@ func (c *Config) PublicConfig() ClientConfig {
 }
 
 // GuestConfig returns client config values for the sharing with guests.
+// The download token is empty as downloads must be allowed by a share link.
 func (c *Config) GuestConfig() ClientConfig {
 	settings := c.Settings()
 
@@ -186,7 +187,6 @@ func (c *Config) GuestConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
-		DownloadToken:   c.DownloadToken(),
 		PreviewToken:    c.PreviewToken(),
 		JSHash:          fs.Checksum(c.BuildPath() + "/share.js"),
 		CSSHash:         fs.Checksum(c.BuildPath() + "/share.css"),
@@ -246,8 +246,8 @@ func (c *Config) UserConfig() ClientConfig {
 		Take(&result.Count)
 
 	c.Db().Table("photos").
-		Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0) AS videos, SUM(photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = 0) AS review, SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','live') AND photo_private = 0 AND photo_quality >= 0) AS photos, SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality >= 0) AS favorites, SUM(photo_private = 1 AND photo_quality >= 0) AS private").
-		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+		Select("COUNT(CASE WHEN photo_type = 'video' AND photo_quality >= 0 AND photo_private = FALSE THEN 1 END) AS videos, COUNT(CASE WHEN photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = FALSE THEN 1 END) AS review, COUNT(CASE WHEN photo_quality = -1 THEN 1 END) AS hidden, COUNT(CASE WHEN photo_type IN ('image','raw','live') AND photo_private = FALSE AND photo_quality >= 0 THEN 1 END) AS photos, COUNT(CASE WHEN photo_favorite = TRUE AND photo_private = FALSE AND photo_quality >= 0 THEN 1 END) AS favorites, COUNT(CASE WHEN photo_private = TRUE AND photo_quality >= 0 THEN 1 END) AS private").
+		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND (file_missing = TRUE OR file_error <> ''))").
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
@@ -255,17 +255,17 @@ func (c *Config) UserConfig() ClientConfig {
 		Select("MAX(photo_count) as label_max_photos, COUNT(*) AS labels").
 		Where("photo_count > 0").
 		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
+		Where("(label_priority >= 0 OR label_favorite = TRUE)").
 		Take(&result.Count)
 
 	c.Db().Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+		Select("COUNT(CASE WHEN album_type = ? THEN 1 END) AS albums, COUNT(CASE WHEN album_type = ? THEN 1 END) AS moments, COUNT(CASE WHEN album_type = ? THEN 1 END) AS months, COUNT(CASE WHEN album_type = ? THEN 1 END) AS states, COUNT(CASE WHEN album_type = ? THEN 1 END) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
 	c.Db().Table("files").
 		Select("COUNT(*) AS files").
-		Where("file_missing = 0").
+		Where("file_missing = FALSE").
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
@@ -274,7 +274,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Take(&result.Count)
 
 	c.Db().Table("places").
-		Select("SUM(photo_count > 0) AS places").
+		Select("COUNT(CASE WHEN photo_count > 0 THEN 1 END) AS places").
 		Where("id != 'zz'").
 		Take(&result.Count)
 
@@ -289,7 +289,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Limit(10000).Order("lens_slug").
 		Find(&result.Lenses)
 
-	c.Db().Where("deleted_at IS NULL AND album_favorite = 1").
+	c.Db().Where("deleted_at IS NULL AND album_favorite = TRUE").
 		Limit(20).Order("album_title").
 		Find(&result.Albums)
 
@@ -302,7 +302,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Select("l.label_uid, l.custom_slug, l.label_name").
 		Joins("JOIN labels l ON categories.category_id = l.id").
 		Where("l.deleted_at IS NULL").
-		Group("l.custom_slug").
+		Group("l.custom_slug, l.label_uid, l.label_name").
 		Order("l.custom_slug").
 		Limit(1000).Offset(0).
 		Scan(&result.Categories)
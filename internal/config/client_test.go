@@ -24,6 +24,7 @@ func TestConfig_GuestConfig(t *testing.T) {
 	assert.Equal(t, true, result.Public)
 	assert.Equal(t, false, result.Experimental)
 	assert.Equal(t, true, result.ReadOnly)
+	assert.Equal(t, "", result.DownloadToken)
 }
 
 func TestConfig_Flags(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	"github.com/photoprism/photoprism/internal/hub"
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/remote/local"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/sirupsen/logrus"
@@ -86,8 +87,13 @@ func (c *Config) Propagate() {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.WebpQuality = c.WebpQuality()
+	thumb.AvifQuality = c.AvifQuality()
+	thumb.CwebpBin = c.CwebpBin()
+	thumb.AvifencBin = c.AvifencBin()
 	places.UserAgent = c.UserAgent()
 	entity.GeoApi = c.GeoApi()
+	local.Roots = c.LocalPaths()
 
 	c.Settings().Propagate()
 	c.Hub().Propagate()
@@ -224,6 +230,11 @@ func (c *Config) DetectNSFW() bool {
 	return c.params.DetectNSFW
 }
 
+// DetectFaces returns true if faces should be detected and clustered by person.
+func (c *Config) DetectFaces() bool {
+	return c.params.DetectFaces
+}
+
 // UploadNSFW returns true if NSFW photos can be uploaded.
 func (c *Config) UploadNSFW() bool {
 	return c.params.UploadNSFW
@@ -290,6 +301,25 @@ func (c *Config) WakeupInterval() time.Duration {
 	return time.Duration(c.params.WakeupInterval) * time.Second
 }
 
+// Watch returns true if originals should be watched for changes and indexed automatically.
+func (c *Config) Watch() bool {
+	return c.params.Watch
+}
+
+// WatchFlagged returns true if only folders with the watch flag set should be watched.
+func (c *Config) WatchFlagged() bool {
+	return c.params.WatchFlagged
+}
+
+// WatchDelay returns the time to wait for further changes before indexing.
+func (c *Config) WatchDelay() time.Duration {
+	if c.params.WatchDelay <= 0 {
+		return 5 * time.Second
+	}
+
+	return time.Duration(c.params.WatchDelay) * time.Second
+}
+
 // GeoApi returns the preferred geo coding api (none, osm or places).
 func (c *Config) GeoApi() string {
 	switch c.params.GeoApi {
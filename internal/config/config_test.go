@@ -167,6 +167,12 @@ func TestConfig_DatabaseDriver(t *testing.T) {
 
 	driver := c.DatabaseDriver()
 	assert.Equal(t, SQLite, driver)
+
+	c.params.DatabaseDriver = "postgresql"
+	c.params.DatabaseDsn = ""
+
+	assert.Equal(t, Postgres, c.DatabaseDriver())
+	assert.Contains(t, c.DatabaseDsn(), "dbname=photoprism host=photoprism-postgres")
 }
 
 func TestConfig_DatabaseDsn(t *testing.T) {
@@ -189,6 +195,18 @@ func TestConfig_ThumbnailsPath(t *testing.T) {
 	assert.True(t, strings.HasSuffix(c.ThumbPath(), "storage/testdata/cache/thumbnails"))
 }
 
+func TestConfig_HlsPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasSuffix(c.HlsPath(), "storage/testdata/cache/hls"))
+}
+
+func TestConfig_EditsPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasSuffix(c.EditsPath(), "storage/testdata/cache/edits"))
+}
+
 func TestConfig_AssetsPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -202,6 +220,18 @@ func TestConfig_DetectNSFW(t *testing.T) {
 	assert.Equal(t, true, result)
 }
 
+func TestConfig_DetectFaces(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DetectFaces())
+}
+
+func TestConfig_FaceModelPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.FaceModelPath(), "/assets/faces")
+}
+
 func TestConfig_AdminPassword(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -279,6 +309,14 @@ func TestConfig_Workers(t *testing.T) {
 	assert.GreaterOrEqual(t, c.Workers(), 1)
 }
 
+func TestConfig_Watch(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.Watch())
+	assert.False(t, c.WatchFlagged())
+	assert.Equal(t, 5*time.Second, c.WatchDelay())
+}
+
 func TestConfig_WakeupInterval(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, time.Duration(900000000000), c.WakeupInterval())
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
@@ -20,6 +21,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.params.DatabaseDriver) {
 	case MySQL, "mariadb":
 		c.params.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql":
+		c.params.DatabaseDriver = Postgres
 	case SQLite, "sqlite", "sqllite", "test", "file", "":
 		c.params.DatabaseDriver = SQLite
 	case "tidb":
@@ -41,6 +44,8 @@ func (c *Config) DatabaseDsn() string {
 		switch c.DatabaseDriver() {
 		case MySQL:
 			return "photoprism:photoprism@tcp(photoprism-db:3306)/photoprism?parseTime=true"
+		case Postgres:
+			return "user=photoprism password=photoprism dbname=photoprism host=photoprism-postgres port=5432 sslmode=disable TimeZone=UTC"
 		case SQLite:
 			return filepath.Join(c.StoragePath(), "index.db")
 		default:
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -95,6 +96,18 @@ func (c *Config) CreateDirectories() error {
 		return createError(c.ThumbPath(), err)
 	}
 
+	if c.HlsPath() == "" {
+		return notFoundError("hls")
+	} else if err := os.MkdirAll(c.HlsPath(), os.ModePerm); err != nil {
+		return createError(c.HlsPath(), err)
+	}
+
+	if c.EditsPath() == "" {
+		return notFoundError("edits")
+	} else if err := os.MkdirAll(c.EditsPath(), os.ModePerm); err != nil {
+		return createError(c.EditsPath(), err)
+	}
+
 	if c.SettingsPath() == "" {
 		return notFoundError("settings")
 	} else if err := os.MkdirAll(c.SettingsPath(), os.ModePerm); err != nil {
@@ -223,6 +236,24 @@ func (c *Config) SidecarYaml() bool {
 	return c.params.SidecarYaml
 }
 
+// SidecarXmp returns true if metadata changes should be written to XMP sidecar files.
+func (c *Config) SidecarXmp() bool {
+	if !c.SidecarWritable() {
+		return false
+	}
+
+	return c.params.SidecarXmp
+}
+
+// EmbedMeta returns true if metadata changes should be embedded in originals using Exiftool.
+func (c *Config) EmbedMeta() bool {
+	if c.ReadOnly() || c.ExifToolBin() == "" {
+		return false
+	}
+
+	return c.params.EmbedMeta
+}
+
 // SidecarPath returns the storage path for generated sidecar files (relative or absolute).
 func (c *Config) SidecarPath() string {
 	if c.params.SidecarPath == "" {
@@ -242,11 +273,37 @@ func (c *Config) SidecarWritable() bool {
 	return !c.ReadOnly() || c.SidecarPathIsAbs()
 }
 
+// LocalPaths returns the server folders that can be used by local accounts for syncing and sharing.
+func (c *Config) LocalPaths() (result []string) {
+	for _, p := range strings.Split(c.params.LocalPaths, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, fs.Abs(p))
+		}
+	}
+
+	return result
+}
+
 // FFmpegBin returns the ffmpeg executable file name.
 func (c *Config) FFmpegBin() string {
 	return findExecutable(c.params.FFmpegBin, "ffmpeg")
 }
 
+// FFprobeBin returns the ffprobe executable file name.
+func (c *Config) FFprobeBin() string {
+	return findExecutable(c.params.FFprobeBin, "ffprobe")
+}
+
+// CwebpBin returns the cwebp executable file name.
+func (c *Config) CwebpBin() string {
+	return findExecutable(c.params.CwebpBin, "cwebp")
+}
+
+// AvifencBin returns the avifenc executable file name.
+func (c *Config) AvifencBin() string {
+	return findExecutable(c.params.AvifencBin, "avifenc")
+}
+
 // TempPath returns a temporary directory name for uploads and downloads.
 func (c *Config) TempPath() string {
 	if c.params.TempPath == "" {
@@ -265,6 +322,16 @@ func (c *Config) CachePath() string {
 	return fs.Abs(c.params.CachePath)
 }
 
+// HlsPath returns the path for cached video streaming files.
+func (c *Config) HlsPath() string {
+	return c.CachePath() + "/hls"
+}
+
+// EditsPath returns the path for cached renderings of edited photos.
+func (c *Config) EditsPath() string {
+	return c.CachePath() + "/edits"
+}
+
 // StoragePath returns the path for generated files like cache and index.
 func (c *Config) StoragePath() string {
 	if c.params.StoragePath == "" {
@@ -25,6 +25,24 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, false, c.SidecarJson())
 }
 
+func TestConfig_SidecarXmp(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, false, c.SidecarXmp())
+	c.params.SidecarXmp = true
+	assert.Equal(t, true, c.SidecarXmp())
+	c.params.ReadOnly = true
+	c.params.SidecarPath = ".photoprism"
+	assert.Equal(t, false, c.SidecarXmp())
+}
+
+func TestConfig_EmbedMeta(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, false, c.EmbedMeta())
+	c.params.EmbedMeta = true
+	c.params.ReadOnly = true
+	assert.Equal(t, false, c.EmbedMeta())
+}
+
 func TestConfig_SidecarPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, ".photoprism", c.SidecarPath())
@@ -49,6 +67,24 @@ func TestConfig_FFmpegBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/ffmpeg", c.FFmpegBin())
 }
 
+func TestConfig_FFprobeBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.params.FFprobeBin = "/xxx/ffprobe"
+	assert.Equal(t, "", c.FFprobeBin())
+}
+
+func TestConfig_CwebpBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.params.CwebpBin = "/xxx/cwebp"
+	assert.Equal(t, "", c.CwebpBin())
+}
+
+func TestConfig_AvifencBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.params.AvifencBin = "/xxx/avifenc"
+	assert.Equal(t, "", c.AvifencBin())
+}
+
 func TestConfig_TempPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/storage/testdata/temp", c.TempPath())
@@ -46,6 +46,21 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "background worker wakeup interval in seconds",
 		EnvVar: "PHOTOPRISM_WAKEUP_INTERVAL",
 	},
+	cli.BoolFlag{
+		Name:   "watch",
+		Usage:  "watch originals for changes and index them automatically",
+		EnvVar: "PHOTOPRISM_WATCH",
+	},
+	cli.BoolFlag{
+		Name:   "watch-flagged",
+		Usage:  "only watch folders with the watch flag set",
+		EnvVar: "PHOTOPRISM_WATCH_FLAGGED",
+	},
+	cli.IntFlag{
+		Name:   "watch-delay",
+		Usage:  "seconds to wait for further changes before indexing",
+		EnvVar: "PHOTOPRISM_WATCH_DELAY",
+	},
 	cli.StringFlag{
 		Name:   "site-url",
 		Usage:  "public site `URL`",
@@ -95,9 +110,20 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "debug, release or test",
 		EnvVar: "PHOTOPRISM_HTTP_MODE",
 	},
+	cli.BoolFlag{
+		Name:   "dlna",
+		Usage:  "share albums with TVs and other media renderers in your local network (read-only)",
+		EnvVar: "PHOTOPRISM_DLNA",
+	},
+	cli.IntFlag{
+		Name:   "dlna-port",
+		Value:  2343,
+		Usage:  "media server port `NUMBER`, only reachable from the local network",
+		EnvVar: "PHOTOPRISM_DLNA_PORT",
+	},
 	cli.StringFlag{
 		Name:   "database-driver",
-		Usage:  "database driver `NAME` (sqlite or mysql)",
+		Usage:  "database driver `NAME` (sqlite, mysql or postgres)",
 		Value:  "sqlite",
 		EnvVar: "PHOTOPRISM_DATABASE_DRIVER",
 	},
@@ -224,6 +250,24 @@ var GlobalFlags = []cli.Flag{
 		Value:  "ffmpeg",
 		EnvVar: "PHOTOPRISM_FFMPEG_BIN",
 	},
+	cli.StringFlag{
+		Name:   "ffprobe-bin",
+		Usage:  "ffprobe executable `FILENAME`",
+		Value:  "ffprobe",
+		EnvVar: "PHOTOPRISM_FFPROBE_BIN",
+	},
+	cli.StringFlag{
+		Name:   "cwebp-bin",
+		Usage:  "cwebp executable `FILENAME` for WebP thumbnails",
+		Value:  "cwebp",
+		EnvVar: "PHOTOPRISM_CWEBP_BIN",
+	},
+	cli.StringFlag{
+		Name:   "avifenc-bin",
+		Usage:  "avifenc executable `FILENAME` for AVIF thumbnails",
+		Value:  "avifenc",
+		EnvVar: "PHOTOPRISM_AVIFENC_BIN",
+	},
 	cli.StringFlag{
 		Name:   "exiftool-bin",
 		Usage:  "exiftool executable `FILENAME`",
@@ -240,11 +284,26 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "automatically backup metadata to YAML sidecar files",
 		EnvVar: "PHOTOPRISM_SIDECAR_YAML",
 	},
+	cli.BoolFlag{
+		Name:   "sidecar-xmp",
+		Usage:  "write metadata changes to XMP sidecar files",
+		EnvVar: "PHOTOPRISM_SIDECAR_XMP",
+	},
+	cli.BoolFlag{
+		Name:   "embed-meta",
+		Usage:  "embed metadata changes in originals using Exiftool (modifies files)",
+		EnvVar: "PHOTOPRISM_EMBED_META",
+	},
 	cli.StringFlag{
 		Name:   "sidecar-path",
 		Usage:  "storage `PATH` for generated sidecar files (relative or absolute)",
 		EnvVar: "PHOTOPRISM_SIDECAR_PATH",
 	},
+	cli.StringFlag{
+		Name:   "local-paths",
+		Usage:  "comma separated list of server `PATHS` that can be used by local accounts",
+		EnvVar: "PHOTOPRISM_LOCAL_PATHS",
+	},
 	cli.BoolFlag{
 		Name:   "detect-nsfw",
 		Usage:  "flag photos as private that may be offensive",
@@ -255,6 +314,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "allow uploads that may be offensive",
 		EnvVar: "PHOTOPRISM_UPLOAD_NSFW",
 	},
+	cli.BoolFlag{
+		Name:   "detect-faces",
+		Usage:  "detect faces and cluster them by person",
+		EnvVar: "PHOTOPRISM_DETECT_FACES",
+	},
 	cli.StringFlag{
 		Name:   "geo-api, g",
 		Usage:  "geo data api (none, osm or places)",
@@ -295,6 +359,11 @@ var GlobalFlags = []cli.Flag{
 		Value:  7680,
 		EnvVar: "PHOTOPRISM_THUMB_SIZE_UNCACHED",
 	},
+	cli.IntFlag{
+		Name:   "thumb-cache-quota",
+		Usage:  "thumbnail cache size limit in `MEGABYTE`, on-demand thumbnails are evicted first (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_THUMB_CACHE_QUOTA",
+	},
 	cli.IntFlag{
 		Name:   "jpeg-size",
 		Usage:  "size limit for converted image files in `PIXELS` (720-30000)",
@@ -307,4 +376,16 @@ var GlobalFlags = []cli.Flag{
 		Value:  92,
 		EnvVar: "PHOTOPRISM_JPEG_QUALITY",
 	},
+	cli.IntFlag{
+		Name:   "webp-quality",
+		Usage:  "WebP thumbnail quality (25-100)",
+		Value:  80,
+		EnvVar: "PHOTOPRISM_WEBP_QUALITY",
+	},
+	cli.IntFlag{
+		Name:   "avif-quality",
+		Usage:  "AVIF thumbnail quality (25-100)",
+		Value:  60,
+		EnvVar: "PHOTOPRISM_AVIF_QUALITY",
+	},
 }
@@ -15,9 +15,9 @@ import (
 
 // Database drivers (sql dialects).
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
-	// Postgres = "postgres" // TODO: Requires GORM 2.0 for generic column data types
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+	Postgres = "postgres"
 )
 
 // Params provides a struct in which application configuration is stored.
@@ -45,6 +45,9 @@ type Params struct {
 	TensorFlowOff      bool   `yaml:"tf-off" flag:"tf-off"`
 	Workers            int    `yaml:"workers" flag:"workers"`
 	WakeupInterval     int    `yaml:"wakeup-interval" flag:"wakeup-interval"`
+	Watch              bool   `yaml:"watch" flag:"watch"`
+	WatchFlagged       bool   `yaml:"watch-flagged" flag:"watch-flagged"`
+	WatchDelay         int    `yaml:"watch-delay" flag:"watch-delay"`
 	AdminPassword      string `yaml:"admin-password" flag:"admin-password"`
 	LogLevel           string `yaml:"log-level" flag:"log-level"`
 	AssetsPath         string `yaml:"assets-path" flag:"assets-path"`
@@ -66,21 +69,30 @@ type Params struct {
 	HttpServerPort     int    `yaml:"http-port" flag:"http-port"`
 	HttpServerMode     string `yaml:"http-mode" flag:"http-mode"`
 	HttpServerPassword string `yaml:"http-password" flag:"http-password"`
+	DLNA               bool   `yaml:"dlna" flag:"dlna"`
+	DLNAPort           int    `yaml:"dlna-port" flag:"dlna-port"`
 	SipsBin            string `yaml:"sips-bin" flag:"sips-bin"`
 	RawtherapeeBin     string `yaml:"rawtherapee-bin" flag:"rawtherapee-bin"`
 	DarktableBin       string `yaml:"darktable-bin" flag:"darktable-bin"`
 	DarktablePresets   bool   `yaml:"darktable-presets" flag:"darktable-presets"`
 	HeifConvertBin     string `yaml:"heifconvert-bin" flag:"heifconvert-bin"`
 	FFmpegBin          string `yaml:"ffmpeg-bin" flag:"ffmpeg-bin"`
+	FFprobeBin         string `yaml:"ffprobe-bin" flag:"ffprobe-bin"`
+	CwebpBin           string `yaml:"cwebp-bin" flag:"cwebp-bin"`
+	AvifencBin         string `yaml:"avifenc-bin" flag:"avifenc-bin"`
 	ExifToolBin        string `yaml:"exiftool-bin" flag:"exiftool-bin"`
 	SidecarJson        bool   `yaml:"sidecar-json" flag:"sidecar-json"`
 	SidecarYaml        bool   `yaml:"sidecar-yaml" flag:"sidecar-yaml"`
+	SidecarXmp         bool   `yaml:"sidecar-xmp" flag:"sidecar-xmp"`
+	EmbedMeta          bool   `yaml:"embed-meta" flag:"embed-meta"`
 	SidecarPath        string `yaml:"sidecar-path" flag:"sidecar-path"`
+	LocalPaths         string `yaml:"local-paths" flag:"local-paths"`
 	PIDFilename        string `yaml:"pid-filename" flag:"pid-filename"`
 	LogFilename        string `yaml:"log-filename" flag:"log-filename"`
 	DetachServer       bool   `yaml:"detach-server" flag:"detach-server"`
 	DetectNSFW         bool   `yaml:"detect-nsfw" flag:"detect-nsfw"`
 	UploadNSFW         bool   `yaml:"upload-nsfw" flag:"upload-nsfw"`
+	DetectFaces        bool   `yaml:"detect-faces" flag:"detect-faces"`
 	GeoApi             string `yaml:"geo-api" flag:"geo-api"`
 	DownloadToken      string `yaml:"download-token" flag:"download-token"`
 	PreviewToken       string `yaml:"preview-token" flag:"preview-token"`
@@ -88,16 +100,19 @@ type Params struct {
 	ThumbUncached      bool   `yaml:"thumb-uncached" flag:"thumb-uncached"`
 	ThumbSize          int    `yaml:"thumb-size" flag:"thumb-size"`
 	ThumbSizeUncached  int    `yaml:"thumb-size-uncached" flag:"thumb-size-uncached"`
+	ThumbCacheQuota    int64  `yaml:"thumb-cache-quota" flag:"thumb-cache-quota"`
 	JpegSize           int    `yaml:"jpeg-size" flag:"jpeg-size"`
 	JpegQuality        int    `yaml:"jpeg-quality" flag:"jpeg-quality"`
+	WebpQuality        int    `yaml:"webp-quality" flag:"webp-quality"`
+	AvifQuality        int    `yaml:"avif-quality" flag:"avif-quality"`
 }
 
 // NewParams creates a new configuration entity by using two methods:
 //
 // 1. Load: This will initialize values from a yaml config file.
 //
-// 2. SetContext: Which comes after Load and overrides
-//    any previous values giving an option two override file configs through the CLI.
+//  2. SetContext: Which comes after Load and overrides
+//     any previous values giving an option two override file configs through the CLI.
 func NewParams(ctx *cli.Context) *Params {
 	c := &Params{}
 
@@ -30,6 +30,32 @@ func (c *Config) JpegQuality() int {
 	return c.params.JpegQuality
 }
 
+// WebpQuality returns the WebP thumbnail quality (25-100).
+func (c *Config) WebpQuality() int {
+	if c.params.WebpQuality > 100 {
+		return 100
+	}
+
+	if c.params.WebpQuality < 25 {
+		return 25
+	}
+
+	return c.params.WebpQuality
+}
+
+// AvifQuality returns the AVIF thumbnail quality (25-100).
+func (c *Config) AvifQuality() int {
+	if c.params.AvifQuality > 100 {
+		return 100
+	}
+
+	if c.params.AvifQuality < 25 {
+		return 25
+	}
+
+	return c.params.AvifQuality
+}
+
 // ThumbFilter returns the thumbnail resample filter (best to worst: blackman, lanczos, cubic or linear).
 func (c *Config) ThumbFilter() thumb.ResampleFilter {
 	switch strings.ToLower(c.params.ThumbFilter) {
@@ -85,3 +111,12 @@ func (c *Config) ThumbSizeUncached() int {
 
 	return limit
 }
+
+// ThumbCacheQuota returns the thumbnail cache size limit in bytes, or -1 if unlimited.
+func (c *Config) ThumbCacheQuota() int64 {
+	if c.params.ThumbCacheQuota <= 0 {
+		return -1
+	}
+
+	return c.params.ThumbCacheQuota * 1024 * 1024
+}
@@ -27,6 +27,26 @@ func TestConfig_JpegQuality(t *testing.T) {
 	assert.Equal(t, int(98), c.JpegQuality())
 }
 
+func TestConfig_WebpQuality(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int(25), c.WebpQuality())
+	c.params.WebpQuality = 110
+	assert.Equal(t, int(100), c.WebpQuality())
+	c.params.WebpQuality = 80
+	assert.Equal(t, int(80), c.WebpQuality())
+}
+
+func TestConfig_AvifQuality(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int(25), c.AvifQuality())
+	c.params.AvifQuality = 110
+	assert.Equal(t, int(100), c.AvifQuality())
+	c.params.AvifQuality = 60
+	assert.Equal(t, int(60), c.AvifQuality())
+}
+
 func TestConfig_ThumbFilter(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -65,3 +85,11 @@ func TestConfig_ThumbSizeUncached2(t *testing.T) {
 	c.params.ThumbSize = 900
 	assert.Equal(t, int(900), c.ThumbSizeUncached())
 }
+
+func TestConfig_ThumbCacheQuota(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(-1), c.ThumbCacheQuota())
+	c.params.ThumbCacheQuota = 100
+	assert.Equal(t, int64(104857600), c.ThumbCacheQuota())
+}
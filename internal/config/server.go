@@ -47,6 +47,21 @@ func (c *Config) HttpServerPassword() string {
 	return c.params.HttpServerPassword
 }
 
+// DLNA returns true if the DLNA/UPnP media server is enabled.
+func (c *Config) DLNA() bool {
+	return c.params.DLNA
+}
+
+// DLNAPort returns the DLNA/UPnP media server port, it is separate from the HTTP server port
+// so that the media server can't be reached via reverse proxies.
+func (c *Config) DLNAPort() int {
+	if c.params.DLNAPort == 0 {
+		return 2343
+	}
+
+	return c.params.DLNAPort
+}
+
 // TemplatesPath returns the server templates path.
 func (c *Config) TemplatesPath() string {
 	return filepath.Join(c.AssetsPath(), "templates")
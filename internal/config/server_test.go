@@ -22,6 +22,22 @@ func TestConfig_HttpServerPort2(t *testing.T) {
 	assert.Equal(t, int(1234), c.HttpServerPort())
 }
 
+func TestConfig_DLNAPort(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 2343, c.DLNAPort())
+	c.params.DLNAPort = 1234
+	assert.Equal(t, 1234, c.DLNAPort())
+}
+
+func TestConfig_DLNA(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DLNA())
+	c.params.DLNA = true
+	assert.True(t, c.DLNA())
+}
+
 func TestConfig_HttpServerMode2(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
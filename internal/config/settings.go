@@ -66,6 +66,13 @@ type StackSettings struct {
 	Name bool `json:"name" yaml:"name"`
 }
 
+// EventSettings represents thresholds for detecting events and trips.
+type EventSettings struct {
+	Gap      int `json:"gap" yaml:"gap"`           // Max hours between two photos of the same event.
+	Distance int `json:"distance" yaml:"distance"` // Max km between two photos of the same event.
+	Photos   int `json:"photos" yaml:"photos"`     // Min number of photos per event.
+}
+
 // Settings represents user settings for Web UI, indexing, and import.
 type Settings struct {
 	Theme     string           `json:"theme" yaml:"theme"`
@@ -76,6 +83,7 @@ type Settings struct {
 	Import    ImportSettings   `json:"import" yaml:"import"`
 	Index     IndexSettings    `json:"index" yaml:"index"`
 	Stack     StackSettings    `json:"stack" yaml:"stack"`
+	Events    EventSettings    `json:"events" yaml:"events"`
 }
 
 // NewSettings creates a new Settings instance.
@@ -121,6 +129,11 @@ func NewSettings() *Settings {
 			Meta: true,
 			Name: false,
 		},
+		Events: EventSettings{
+			Gap:      18,
+			Distance: 150,
+			Photos:   10,
+		},
 	}
 }
 
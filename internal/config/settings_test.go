@@ -11,6 +11,8 @@ func TestNewSettings(t *testing.T) {
 	c := NewSettings()
 
 	assert.IsType(t, new(Settings), c)
+	assert.Equal(t, 18, c.Events.Gap)
+	assert.Equal(t, 150, c.Events.Distance)
 }
 
 func TestSettings_Load(t *testing.T) {
@@ -44,6 +44,10 @@ func NewTestParams() *Params {
 	// Config example for MySQL / MariaDB:
 	//   dbDriver = MySQL,
 	//   dbDsn = "photoprism:photoprism@tcp(photoprism-db:4001)/photoprism?parseTime=true",
+	//
+	// Config example for PostgreSQL:
+	//   dbDriver = Postgres,
+	//   dbDsn = "user=photoprism password=photoprism dbname=photoprism host=localhost port=5432 sslmode=disable",
 
 	if dbDriver == "test" || dbDriver == "sqlite" || dbDriver == "" || dbDsn == "" {
 		dbDriver = SQLite
@@ -138,10 +142,19 @@ func NewTestConfig() *Config {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.WebpQuality = c.WebpQuality()
+	thumb.AvifQuality = c.AvifQuality()
+	thumb.CwebpBin = c.CwebpBin()
+	thumb.AvifencBin = c.AvifencBin()
 
 	return c
 }
 
+// SetPublic enables or disables public mode, e.g. to test user restrictions.
+func (c *Config) SetPublic(enabled bool) {
+	c.params.Public = enabled
+}
+
 // NewTestErrorConfig inits invalid config used for testing
 func NewTestErrorConfig() *Config {
 	c := &Config{params: NewTestParamsError()}
@@ -0,0 +1,439 @@
+package dlna
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Object IDs of the root container and its children.
+const (
+	RootID    = "0"
+	AlbumsID  = "albums"
+	FoldersID = "folders"
+	MomentsID = "moments"
+	LabelsID  = "labels"
+)
+
+// Object ID prefixes of albums, labels and photos.
+const (
+	AlbumPrefix = "album/"
+	LabelPrefix = "label/"
+	PhotoPrefix = "photo/"
+)
+
+// Browse flags as defined by the ContentDirectory service.
+const (
+	BrowseMetadata       = "BrowseMetadata"
+	BrowseDirectChildren = "BrowseDirectChildren"
+)
+
+// SystemUpdateID is reported to clients, the content directory does not send change events.
+const SystemUpdateID = 1
+
+// Thumbnail types used for previews and album art.
+const (
+	PreviewType  = "fit_1920"
+	AlbumArtType = "tile_224"
+)
+
+// rootContainers maps the child containers of the root to their titles and album types.
+var rootContainers = []struct {
+	ID        string
+	Title     string
+	AlbumType string
+}{
+	{AlbumsID, "Albums", entity.AlbumDefault},
+	{FoldersID, "Folders", entity.AlbumFolder},
+	{MomentsID, "Moments", entity.AlbumMoment},
+	{LabelsID, "Labels", ""},
+}
+
+// Object represents a container or item in DIDL-Lite format.
+type Object struct {
+	ID         string
+	ParentID   string
+	Title      string
+	Class      string
+	Container  bool
+	ChildCount int
+	Date       string
+	AlbumArt   string
+	Res        []Res
+}
+
+// Res represents a resource of an item.
+type Res struct {
+	URL          string
+	ProtocolInfo string
+	Resolution   string
+	Duration     string
+}
+
+// BrowseResult represents the result of a Browse action.
+type BrowseResult struct {
+	Objects []Object
+	Total   int
+}
+
+// Content implements the read-only ContentDirectory service.
+type Content struct {
+	baseUrl      string
+	previewToken string
+}
+
+// NewContent returns a new content directory, baseUrl is the server url as seen by the client.
+func NewContent(baseUrl, previewToken string) *Content {
+	return &Content{baseUrl: strings.TrimRight(baseUrl, "/"), previewToken: previewToken}
+}
+
+// Browse returns the metadata or the direct children of an object.
+func (c *Content) Browse(objectID, browseFlag string, start, count int) (result BrowseResult, err error) {
+	if start < 0 || count < 0 {
+		return result, Error{ErrInvalidArgs, "invalid index or count"}
+	}
+
+	switch browseFlag {
+	case BrowseMetadata:
+		obj, err := c.object(objectID)
+
+		if err != nil {
+			return result, err
+		}
+
+		return BrowseResult{Objects: []Object{obj}, Total: 1}, nil
+	case BrowseDirectChildren:
+		children, err := c.children(objectID)
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Total = len(children)
+
+		if start > len(children) {
+			start = len(children)
+		}
+
+		end := len(children)
+
+		if count > 0 && start+count < end {
+			end = start + count
+		}
+
+		result.Objects = children[start:end]
+
+		return result, nil
+	default:
+		return result, Error{ErrInvalidArgs, fmt.Sprintf("invalid browse flag %s", browseFlag)}
+	}
+}
+
+// object returns the metadata of a single object.
+func (c *Content) object(objectID string) (Object, error) {
+	if objectID == RootID {
+		return Object{ID: RootID, ParentID: "-1", Title: "PhotoPrism", Class: "object.container", Container: true, ChildCount: len(rootContainers)}, nil
+	}
+
+	for _, r := range rootContainers {
+		if objectID == r.ID {
+			return Object{ID: r.ID, ParentID: RootID, Title: r.Title, Class: "object.container", Container: true}, nil
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(objectID, AlbumPrefix):
+		albums, err := query.AlbumSearch(form.AlbumSearch{ID: strings.TrimPrefix(objectID, AlbumPrefix), Count: 1})
+
+		if err != nil || len(albums) == 0 || albums[0].AlbumPrivate {
+			return Object{}, Error{ErrNoSuchObject, "no such object"}
+		}
+
+		a := albums[0]
+
+		return c.albumObject(a.AlbumUID, a.AlbumType, a.AlbumTitle, a.PhotoCount), nil
+	case strings.HasPrefix(objectID, LabelPrefix):
+		l, err := query.LabelBySlug(strings.TrimPrefix(objectID, LabelPrefix))
+
+		if err != nil {
+			return Object{}, Error{ErrNoSuchObject, "no such object"}
+		}
+
+		return c.labelObject(l.LabelUID, l.LabelSlug, l.LabelName, l.PhotoCount), nil
+	case strings.HasPrefix(objectID, PhotoPrefix):
+		photos, _, err := query.PhotoSearch(form.PhotoSearch{
+			ID:      strings.TrimPrefix(objectID, PhotoPrefix),
+			Public:  true,
+			Primary: true,
+		})
+
+		if err != nil || len(photos) == 0 {
+			return Object{}, Error{ErrNoSuchObject, "no such object"}
+		}
+
+		return c.photoObject(photos[0], RootID), nil
+	}
+
+	return Object{}, Error{ErrNoSuchObject, "no such object"}
+}
+
+// children returns the direct children of a container.
+func (c *Content) children(objectID string) (results []Object, err error) {
+	if objectID == RootID {
+		for _, r := range rootContainers {
+			obj, _ := c.object(r.ID)
+			results = append(results, obj)
+		}
+
+		return results, nil
+	}
+
+	for _, r := range rootContainers {
+		if objectID != r.ID {
+			continue
+		}
+
+		if r.ID == LabelsID {
+			labels, err := query.Labels(form.LabelSearch{Count: query.MaxResults})
+
+			if err != nil {
+				return results, Error{ErrActionFailed, err.Error()}
+			}
+
+			for _, l := range labels {
+				results = append(results, c.labelObject(l.LabelUID, l.LabelSlug, l.LabelName, l.PhotoCount))
+			}
+
+			return results, nil
+		}
+
+		albums, err := query.AlbumSearch(form.AlbumSearch{Type: r.AlbumType, Count: query.MaxResults})
+
+		if err != nil {
+			return results, Error{ErrActionFailed, err.Error()}
+		}
+
+		for _, a := range albums {
+			if a.AlbumPrivate {
+				continue
+			}
+
+			results = append(results, c.albumObject(a.AlbumUID, a.AlbumType, a.AlbumTitle, a.PhotoCount))
+		}
+
+		return results, nil
+	}
+
+	// Album filters can't reveal private, archived or hidden photos to renderers on the network.
+	f := form.PhotoSearch{Public: true, Primary: true, Shared: true, Count: query.MaxResults}
+
+	switch {
+	case strings.HasPrefix(objectID, AlbumPrefix):
+		a, err := query.AlbumByUID(strings.TrimPrefix(objectID, AlbumPrefix))
+
+		if err != nil || a.AlbumPrivate {
+			return results, Error{ErrNoSuchObject, "no such object"}
+		}
+
+		// Folders, moments and months are found by their filter, like smart albums.
+		f.Album = a.AlbumUID
+		f.Filter = a.AlbumFilter
+	case strings.HasPrefix(objectID, LabelPrefix):
+		l, err := query.LabelBySlug(strings.TrimPrefix(objectID, LabelPrefix))
+
+		if err != nil {
+			return results, Error{ErrNoSuchObject, "no such object"}
+		}
+
+		f.Label = l.LabelSlug
+	case strings.HasPrefix(objectID, PhotoPrefix):
+		// Items have no children.
+		return results, nil
+	default:
+		return results, Error{ErrNoSuchObject, "no such object"}
+	}
+
+	photos, _, err := query.PhotoSearch(f)
+
+	if err != nil {
+		return results, Error{ErrActionFailed, err.Error()}
+	}
+
+	for _, p := range photos {
+		results = append(results, c.photoObject(p, objectID))
+	}
+
+	return results, nil
+}
+
+// albumObject returns an album container.
+func (c *Content) albumObject(uid, albumType, title string, count int) Object {
+	parentID := AlbumsID
+
+	switch albumType {
+	case entity.AlbumFolder:
+		parentID = FoldersID
+	case entity.AlbumMoment:
+		parentID = MomentsID
+	}
+
+	return Object{
+		ID:         AlbumPrefix + uid,
+		ParentID:   parentID,
+		Title:      title,
+		Class:      "object.container.album.photoAlbum",
+		Container:  true,
+		ChildCount: count,
+		AlbumArt:   fmt.Sprintf("%s/api/v1/albums/%s/t/%s/%s", c.baseUrl, uid, c.previewToken, AlbumArtType),
+	}
+}
+
+// labelObject returns a label container.
+func (c *Content) labelObject(uid, slug, name string, count int) Object {
+	return Object{
+		ID:         LabelPrefix + slug,
+		ParentID:   LabelsID,
+		Title:      name,
+		Class:      "object.container.album.photoAlbum",
+		Container:  true,
+		ChildCount: count,
+		AlbumArt:   fmt.Sprintf("%s/api/v1/labels/%s/t/%s/%s", c.baseUrl, uid, c.previewToken, AlbumArtType),
+	}
+}
+
+// photoObject returns an image or video item.
+func (c *Content) photoObject(p query.PhotoResult, parentID string) Object {
+	title := p.PhotoTitle
+
+	if title == "" {
+		title = p.PhotoUID
+	}
+
+	obj := Object{
+		ID:       PhotoPrefix + p.PhotoUID,
+		ParentID: parentID,
+		Title:    title,
+		Date:     p.TakenAt.UTC().Format("2006-01-02T15:04:05"),
+		AlbumArt: fmt.Sprintf("%s/api/v1/t/%s/%s/%s", c.baseUrl, p.FileHash, c.previewToken, AlbumArtType),
+	}
+
+	if p.PhotoType == entity.TypeVideo {
+		obj.Class = "object.item.videoItem"
+		obj.Res = append(obj.Res, Res{
+			URL:          fmt.Sprintf("%s/api/v1/videos/%s/%s/mp4", c.baseUrl, p.FileHash, c.previewToken),
+			ProtocolInfo: "http-get:*:video/mp4:DLNA.ORG_OP=01;DLNA.ORG_CI=0",
+			Duration:     Duration(p.FileDuration.Seconds()),
+		})
+	} else {
+		obj.Class = "object.item.imageItem.photo"
+	}
+
+	obj.Res = append(obj.Res, Res{
+		URL:          fmt.Sprintf("%s/api/v1/t/%s/%s/%s", c.baseUrl, p.FileHash, c.previewToken, PreviewType),
+		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_LRG;DLNA.ORG_OP=01;DLNA.ORG_CI=1",
+		Resolution:   Resolution(p.FileWidth, p.FileHeight, 1920, 1200),
+	})
+
+	return obj
+}
+
+// Duration formats seconds as H:MM:SS.mmm, an empty string is returned if unknown.
+func Duration(sec float64) string {
+	if sec <= 0 {
+		return ""
+	}
+
+	ms := int64(sec*1000 + 0.5)
+
+	return fmt.Sprintf("%d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// Resolution returns the size of an image that fits into the bounding box, e.g. "1920x1080".
+func Resolution(width, height, maxWidth, maxHeight int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	if width > maxWidth || height > maxHeight {
+		ratio := float64(width) / float64(height)
+
+		if float64(maxWidth)/float64(maxHeight) > ratio {
+			width, height = int(float64(maxHeight)*ratio+0.5), maxHeight
+		} else {
+			width, height = maxWidth, int(float64(maxWidth)/ratio+0.5)
+		}
+	}
+
+	return fmt.Sprintf("%dx%d", width, height)
+}
+
+// DIDL returns the objects as DIDL-Lite XML document.
+func DIDL(objects []Object) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<DIDL-Lite xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:dlna="urn:schemas-dlna-org:metadata-1-0/">`)
+
+	for _, obj := range objects {
+		if obj.Container {
+			fmt.Fprintf(&buf, `<container id="%s" parentID="%s" restricted="1" searchable="0"`, escape(obj.ID), escape(obj.ParentID))
+
+			// Smart albums have no static photo count.
+			if obj.ChildCount > 0 {
+				fmt.Fprintf(&buf, ` childCount="%d"`, obj.ChildCount)
+			}
+
+			buf.WriteString(`>`)
+		} else {
+			fmt.Fprintf(&buf, `<item id="%s" parentID="%s" restricted="1">`, escape(obj.ID), escape(obj.ParentID))
+		}
+
+		fmt.Fprintf(&buf, `<dc:title>%s</dc:title><upnp:class>%s</upnp:class>`, escape(obj.Title), obj.Class)
+
+		if obj.Date != "" {
+			fmt.Fprintf(&buf, `<dc:date>%s</dc:date>`, obj.Date)
+		}
+
+		if obj.AlbumArt != "" {
+			fmt.Fprintf(&buf, `<upnp:albumArtURI dlna:profileID="JPEG_TN">%s</upnp:albumArtURI>`, escape(obj.AlbumArt))
+		}
+
+		for _, res := range obj.Res {
+			buf.WriteString(`<res protocolInfo="` + escape(res.ProtocolInfo) + `"`)
+
+			if res.Resolution != "" {
+				buf.WriteString(` resolution="` + res.Resolution + `"`)
+			}
+
+			if res.Duration != "" {
+				buf.WriteString(` duration="` + res.Duration + `"`)
+			}
+
+			buf.WriteString(`>` + escape(res.URL) + `</res>`)
+		}
+
+		if obj.Container {
+			buf.WriteString(`</container>`)
+		} else {
+			buf.WriteString(`</item>`)
+		}
+	}
+
+	buf.WriteString(`</DIDL-Lite>`)
+
+	return buf.String()
+}
+
+// BrowseArgs returns the output arguments of a Browse action.
+func BrowseArgs(result BrowseResult) []Arg {
+	return []Arg{
+		{"Result", DIDL(result.Objects)},
+		{"NumberReturned", strconv.Itoa(len(result.Objects))},
+		{"TotalMatches", strconv.Itoa(result.Total)},
+		{"UpdateID", strconv.Itoa(SystemUpdateID)},
+	}
+}
@@ -0,0 +1,173 @@
+package dlna
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContent_Browse(t *testing.T) {
+	c := NewContent("http://192.168.1.2:2342/", "public")
+
+	t.Run("root", func(t *testing.T) {
+		result, err := c.Browse(RootID, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, AlbumsID, result.Objects[0].ID)
+		assert.Equal(t, LabelsID, result.Objects[3].ID)
+	})
+	t.Run("paging", func(t *testing.T) {
+		result, err := c.Browse(RootID, BrowseDirectChildren, 1, 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, result.Total)
+		assert.Len(t, result.Objects, 2)
+		assert.Equal(t, FoldersID, result.Objects[0].ID)
+	})
+	t.Run("albums", func(t *testing.T) {
+		result, err := c.Browse(AlbumsID, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.Objects)
+
+		for _, obj := range result.Objects {
+			assert.True(t, obj.Container)
+			assert.Equal(t, AlbumsID, obj.ParentID)
+		}
+	})
+	t.Run("album photos", func(t *testing.T) {
+		result, err := c.Browse(AlbumPrefix+"at9lxuqxpogaaba9", BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result.Objects)
+
+		for _, obj := range result.Objects {
+			assert.False(t, obj.Container)
+			assert.Contains(t, obj.Res[len(obj.Res)-1].URL, "http://192.168.1.2:2342/api/v1/t/")
+			assert.Contains(t, obj.Res[len(obj.Res)-1].URL, "/public/fit_1920")
+		}
+	})
+	t.Run("folder photos", func(t *testing.T) {
+		result, err := c.Browse(AlbumPrefix+"at1lxuqipogaaba1", BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, result.Objects) {
+			assert.Equal(t, PhotoPrefix+"pt9jtdre2lvl0yh0", result.Objects[0].ID)
+			assert.Equal(t, AlbumPrefix+"at1lxuqipogaaba1", result.Objects[0].ParentID)
+		}
+	})
+	t.Run("moment photos", func(t *testing.T) {
+		a := entity.NewMomentsAlbum("1990", "1990", "public:true year:1990")
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(a)
+
+		result, err := c.Browse(AlbumPrefix+a.AlbumUID, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make(map[string]bool)
+
+		for _, obj := range result.Objects {
+			assert.False(t, obj.Container)
+			ids[obj.ID] = true
+		}
+
+		assert.True(t, ids[PhotoPrefix+"pt9jtdre2lvl0yh0"])
+	})
+	t.Run("private moment filter", func(t *testing.T) {
+		a := entity.NewMomentsAlbum("Private", "private-moment", "private:true")
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(a)
+
+		result, err := c.Browse(AlbumPrefix+a.AlbumUID, BrowseDirectChildren, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, obj := range result.Objects {
+			assert.NotEqual(t, PhotoPrefix+"pt9jtdre2lvl0y12", obj.ID)
+		}
+	})
+	t.Run("label", func(t *testing.T) {
+		result, err := c.Browse(LabelPrefix+"flower", BrowseMetadata, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, LabelPrefix+"flower", result.Objects[0].ID)
+		assert.Equal(t, LabelsID, result.Objects[0].ParentID)
+	})
+	t.Run("private photo", func(t *testing.T) {
+		_, err := c.Browse(PhotoPrefix+"pt9jtdre2lvl0y12", BrowseMetadata, 0, 0)
+
+		assert.Equal(t, Error{ErrNoSuchObject, "no such object"}, err)
+	})
+	t.Run("archived photo", func(t *testing.T) {
+		_, err := c.Browse(PhotoPrefix+"pt9jtxrexxvl0yh0", BrowseMetadata, 0, 0)
+
+		assert.Equal(t, Error{ErrNoSuchObject, "no such object"}, err)
+	})
+	t.Run("unknown object", func(t *testing.T) {
+		_, err := c.Browse("foo", BrowseDirectChildren, 0, 0)
+
+		assert.Equal(t, Error{ErrNoSuchObject, "no such object"}, err)
+	})
+	t.Run("invalid flag", func(t *testing.T) {
+		_, err := c.Browse(RootID, "foo", 0, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, "", Duration(0))
+	assert.Equal(t, "0:00:05.500", Duration(5.5))
+	assert.Equal(t, "1:01:01.000", Duration(3661))
+}
+
+func TestResolution(t *testing.T) {
+	assert.Equal(t, "", Resolution(0, 0, 1920, 1200))
+	assert.Equal(t, "800x600", Resolution(800, 600, 1920, 1200))
+	assert.Equal(t, "1920x1080", Resolution(3840, 2160, 1920, 1200))
+	assert.Equal(t, "900x1200", Resolution(3000, 4000, 1920, 1200))
+}
+
+func TestDIDL(t *testing.T) {
+	result := DIDL([]Object{
+		{ID: AlbumsID, ParentID: RootID, Title: "Albums & More", Class: "object.container", Container: true, ChildCount: 2},
+		{ID: PhotoPrefix + "pt9jtdre2lvl0y11", ParentID: AlbumsID, Title: "Photo", Class: "object.item.imageItem.photo", Res: []Res{{URL: "http://host/a?b&c", ProtocolInfo: "http-get:*:image/jpeg:*", Resolution: "10x10"}}},
+	})
+
+	assert.Contains(t, result, `<container id="albums" parentID="0" restricted="1" searchable="0" childCount="2">`)
+	assert.Contains(t, result, `<dc:title>Albums &amp; More</dc:title>`)
+	assert.Contains(t, result, `<res protocolInfo="http-get:*:image/jpeg:*" resolution="10x10">http://host/a?b&amp;c</res>`)
+}
@@ -0,0 +1,62 @@
+/*
+Package dlna contains a read-only UPnP/DLNA media server for casting photos and videos to TVs.
+
+Albums, folders, moments and labels are exposed as containers by the ContentDirectory service,
+private and archived pictures are never shown. Media renderers discover the server via SSDP.
+It listens on a separate port and only accepts requests from the local network.
+
+Additional information can be found in the UPnP Device Architecture and MediaServer specifications:
+
+https://openconnectivity.org/developer/specifications/upnp-resources/upnp/
+*/
+package dlna
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	uuid "github.com/satori/go.uuid"
+)
+
+var log = event.Log
+
+// BasePath is the path the media server is mounted at.
+const BasePath = "/dlna"
+
+const (
+	DeviceType                  = "urn:schemas-upnp-org:device:MediaServer:1"
+	ContentDirectoryType        = "urn:schemas-upnp-org:service:ContentDirectory:1"
+	ConnectionManagerType       = "urn:schemas-upnp-org:service:ConnectionManager:1"
+	ContentDirectoryID          = "urn:upnp-org:serviceId:ContentDirectory"
+	ConnectionManagerID         = "urn:upnp-org:serviceId:ConnectionManager"
+	RootDevice                  = "upnp:rootdevice"
+	DescriptionPath             = "/device.xml"
+	ContentDirectorySCPDPath    = "/ContentDirectory.xml"
+	ContentDirectoryControlPath = "/ContentDirectory/control"
+	ContentDirectoryEventPath   = "/ContentDirectory/event"
+	ConnectionManagerSCPDPath   = "/ConnectionManager.xml"
+	ConnectionManagerControl    = "/ConnectionManager/control"
+	ConnectionManagerEventPath  = "/ConnectionManager/event"
+)
+
+// Server represents a UPnP/DLNA media server.
+type Server struct {
+	conf *config.Config
+	uuid string
+}
+
+// NewServer returns a new media server for the given configuration.
+func NewServer(conf *config.Config) *Server {
+	return &Server{conf: conf, uuid: DeviceUUID(conf)}
+}
+
+// UDN returns the unique device name.
+func (s *Server) UDN() string {
+	return "uuid:" + s.uuid
+}
+
+// DeviceUUID returns a UUID that is stable for the same site url and storage path.
+func DeviceUUID(conf *config.Config) string {
+	return uuid.NewV5(uuid.NamespaceURL, fmt.Sprintf("%s#%s", conf.SiteUrl(), conf.StoragePath())).String()
+}
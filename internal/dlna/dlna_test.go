@@ -0,0 +1,30 @@
+package dlna
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	c := config.TestConfig()
+
+	code := m.Run()
+
+	_ = c.CloseDb()
+
+	os.Exit(code)
+}
+
+func TestNewServer(t *testing.T) {
+	s := NewServer(config.TestConfig())
+
+	assert.Len(t, s.UDN(), 41)
+	assert.Equal(t, s.UDN(), NewServer(config.TestConfig()).UDN())
+}
@@ -0,0 +1,58 @@
+package dlna
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// localNetworks contains the loopback, private and link-local address ranges.
+var localNetworks = func() (result []*net.IPNet) {
+	for _, cidr := range []string{
+		"127.0.0.0/8",
+		"10.0.0.0/8",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"169.254.0.0/16",
+		"::1/128",
+		"fc00::/7",
+		"fe80::/10",
+	} {
+		_, network, _ := net.ParseCIDR(cidr)
+		result = append(result, network)
+	}
+
+	return result
+}()
+
+// LocalAddr tests if the address belongs to the local network.
+func LocalAddr(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range localNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LocalNetwork returns a middleware that rejects requests from outside the local network. The remote
+// address of the connection is used, since forwarding headers can be set by any client.
+func LocalNetwork() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+
+		if err != nil || !LocalAddr(net.ParseIP(host)) {
+			log.Debugf("dlna: rejected request from %s", c.Request.RemoteAddr)
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package dlna
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalAddr(t *testing.T) {
+	assert.True(t, LocalAddr(net.ParseIP("127.0.0.1")))
+	assert.True(t, LocalAddr(net.ParseIP("192.168.1.20")))
+	assert.True(t, LocalAddr(net.ParseIP("172.20.0.3")))
+	assert.True(t, LocalAddr(net.ParseIP("fe80::1")))
+	assert.False(t, LocalAddr(net.ParseIP("172.32.0.1")))
+	assert.False(t, LocalAddr(net.ParseIP("8.8.8.8")))
+	assert.False(t, LocalAddr(net.ParseIP("2001:db8::1")))
+	assert.False(t, LocalAddr(nil))
+}
+
+func TestLocalNetwork(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(LocalNetwork())
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(remoteAddr, forwardedFor string) int {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remoteAddr
+
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("192.168.1.20:51234", ""))
+	assert.Equal(t, http.StatusForbidden, request("8.8.8.8:51234", ""))
+	assert.Equal(t, http.StatusForbidden, request("8.8.8.8:51234", "192.168.1.20"))
+	assert.Equal(t, http.StatusForbidden, request("invalid", ""))
+}
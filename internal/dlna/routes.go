@@ -0,0 +1,186 @@
+package dlna
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// xmlContentType is the content type of description documents and control responses.
+const xmlContentType = `text/xml; charset="utf-8"`
+
+// subscriptionTimeout is the event subscription timeout in seconds.
+const subscriptionTimeout = 1800
+
+// protocolInfo lists the formats that can be served to media renderers.
+const protocolInfo = "http-get:*:image/jpeg:*,http-get:*:video/mp4:*"
+
+// Register adds the media server routes to the router group, see BasePath.
+func (s *Server) Register(router *gin.RouterGroup) {
+	router.GET(DescriptionPath, func(c *gin.Context) {
+		data, err := s.Description()
+
+		if err != nil {
+			log.Errorf("dlna: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Data(http.StatusOK, xmlContentType, data)
+	})
+
+	router.GET(ContentDirectorySCPDPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, xmlContentType, []byte(contentDirectorySCPD))
+	})
+
+	router.GET(ConnectionManagerSCPDPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, xmlContentType, []byte(connectionManagerSCPD))
+	})
+
+	router.POST(ContentDirectoryControlPath, func(c *gin.Context) {
+		s.control(c, ContentDirectoryType, s.contentDirectory)
+	})
+
+	router.POST(ConnectionManagerControl, func(c *gin.Context) {
+		s.control(c, ConnectionManagerType, s.connectionManager)
+	})
+
+	// Events are not sent since the content is read-only, but renderers expect subscriptions to succeed.
+	for _, path := range []string{ContentDirectoryEventPath, ConnectionManagerEventPath} {
+		router.Handle("SUBSCRIBE", path, func(c *gin.Context) {
+			sid := c.GetHeader("SID")
+
+			if sid == "" {
+				sid = "uuid:" + rnd.UUID()
+			}
+
+			c.Header("SID", sid)
+			c.Header("TIMEOUT", fmt.Sprintf("Second-%d", subscriptionTimeout))
+			c.Status(http.StatusOK)
+		})
+
+		router.Handle("UNSUBSCRIBE", path, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+	}
+}
+
+// actionHandler handles a SOAP action and returns the output arguments.
+type actionHandler func(c *gin.Context, action Action) ([]Arg, error)
+
+// control parses a SOAP request and writes the response of the action handler.
+func (s *Server) control(c *gin.Context, serviceType string, handler actionHandler) {
+	action, err := ParseAction(c.Request.Body)
+
+	if err != nil {
+		log.Debugf("dlna: %s", err)
+		c.Data(http.StatusInternalServerError, xmlContentType, FaultResponse(Error{ErrInvalidAction, "invalid action"}))
+		return
+	}
+
+	if name := ActionName(c.GetHeader("SOAPACTION")); name != "" && name != action.Name {
+		log.Debugf("dlna: action %s does not match header", txt.Quote(action.Name))
+		c.Data(http.StatusInternalServerError, xmlContentType, FaultResponse(Error{ErrInvalidAction, "invalid action"}))
+		return
+	}
+
+	args, err := handler(c, action)
+
+	if err != nil {
+		e, ok := err.(Error)
+
+		if !ok {
+			e = Error{ErrActionFailed, err.Error()}
+		}
+
+		log.Debugf("dlna: %s %s", action.Name, e)
+		c.Data(http.StatusInternalServerError, xmlContentType, FaultResponse(e))
+		return
+	}
+
+	c.Header("EXT", "")
+	c.Data(http.StatusOK, xmlContentType, ActionResponse(serviceType, action.Name, args))
+}
+
+// contentDirectory handles ContentDirectory actions.
+func (s *Server) contentDirectory(c *gin.Context, action Action) ([]Arg, error) {
+	switch action.Name {
+	case "GetSearchCapabilities":
+		return []Arg{{"SearchCaps", ""}}, nil
+	case "GetSortCapabilities":
+		return []Arg{{"SortCaps", ""}}, nil
+	case "GetSystemUpdateID":
+		return []Arg{{"Id", strconv.Itoa(SystemUpdateID)}}, nil
+	case "Browse":
+		start, err := uintArg(action, "StartingIndex")
+
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := uintArg(action, "RequestedCount")
+
+		if err != nil {
+			return nil, err
+		}
+
+		content := NewContent(fmt.Sprintf("http://%s", c.Request.Host), s.conf.PreviewToken())
+
+		result, err := content.Browse(action.Arg("ObjectID"), action.Arg("BrowseFlag"), start, count)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return BrowseArgs(result), nil
+	default:
+		return nil, Error{ErrInvalidAction, "invalid action"}
+	}
+}
+
+// connectionManager handles ConnectionManager actions.
+func (s *Server) connectionManager(c *gin.Context, action Action) ([]Arg, error) {
+	switch action.Name {
+	case "GetProtocolInfo":
+		return []Arg{{"Source", protocolInfo}, {"Sink", ""}}, nil
+	case "GetCurrentConnectionIDs":
+		return []Arg{{"ConnectionIDs", "0"}}, nil
+	case "GetCurrentConnectionInfo":
+		if action.Arg("ConnectionID") != "0" {
+			return nil, Error{ErrInvalidArgs, "invalid connection id"}
+		}
+
+		return []Arg{
+			{"RcsID", "-1"},
+			{"AVTransportID", "-1"},
+			{"ProtocolInfo", ""},
+			{"PeerConnectionManager", ""},
+			{"PeerConnectionID", "-1"},
+			{"Direction", "Output"},
+			{"Status", "OK"},
+		}, nil
+	default:
+		return nil, Error{ErrInvalidAction, "invalid action"}
+	}
+}
+
+// uintArg returns a non-negative integer argument, empty values default to 0.
+func uintArg(action Action, name string) (int, error) {
+	s := action.Arg(name)
+
+	if s == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(s)
+
+	if err != nil || i < 0 {
+		return 0, Error{ErrInvalidArgs, fmt.Sprintf("invalid %s", name)}
+	}
+
+	return i, nil
+}
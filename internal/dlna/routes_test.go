@@ -0,0 +1,68 @@
+package dlna
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	NewServer(config.TestConfig()).Register(app.Group(BasePath))
+	return app
+}
+
+func TestServer_Register(t *testing.T) {
+	app := newRouter()
+
+	t.Run("description", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", BasePath+DescriptionPath, nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<deviceType>urn:schemas-upnp-org:device:MediaServer:1</deviceType>")
+		assert.Contains(t, w.Body.String(), "<controlURL>/dlna/ContentDirectory/control</controlURL>")
+	})
+	t.Run("scpd", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", BasePath+ContentDirectorySCPDPath, nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<name>Browse</name>")
+	})
+	t.Run("browse", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", BasePath+ContentDirectoryControlPath, strings.NewReader(browseRequest))
+		req.Header.Set("SOAPACTION", `"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<NumberReturned>4</NumberReturned>")
+		assert.Contains(t, w.Body.String(), "&lt;DIDL-Lite")
+	})
+	t.Run("invalid action", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", BasePath+ConnectionManagerControl, strings.NewReader(browseRequest))
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "<errorCode>401</errorCode>")
+	})
+	t.Run("subscribe", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("SUBSCRIBE", BasePath+ContentDirectoryEventPath, nil)
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Header().Get("SID"), "uuid:"))
+		assert.Equal(t, "Second-1800", w.Header().Get("TIMEOUT"))
+	})
+}
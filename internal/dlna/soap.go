@@ -0,0 +1,136 @@
+package dlna
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UPnP error codes, see UPnP Device Architecture 1.0 section 3.2.2.
+const (
+	ErrInvalidAction = 401
+	ErrInvalidArgs   = 402
+	ErrActionFailed  = 501
+	ErrNoSuchObject  = 701
+)
+
+// Error represents a UPnP control error.
+type Error struct {
+	Code        int
+	Description string
+}
+
+// Error returns the error message.
+func (e Error) Error() string {
+	return fmt.Sprintf("upnp error %d: %s", e.Code, e.Description)
+}
+
+// Arg represents a named action argument.
+type Arg struct {
+	Name  string
+	Value string
+}
+
+// Action represents a SOAP action request.
+type Action struct {
+	Name string
+	Args map[string]string
+}
+
+// Arg returns the argument value for the name.
+func (a Action) Arg(name string) string {
+	return a.Args[name]
+}
+
+// soapArg represents an action argument element.
+type soapArg struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// soapAction represents the action element of a request body.
+type soapAction struct {
+	XMLName xml.Name
+	Args    []soapArg `xml:",any"`
+}
+
+// soapEnvelope represents a SOAP request envelope.
+type soapEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		Action soapAction `xml:",any"`
+	} `xml:"Body"`
+}
+
+// ParseAction parses a SOAP action request.
+func ParseAction(r io.Reader) (action Action, err error) {
+	var env soapEnvelope
+
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return action, err
+	}
+
+	if env.Body.Action.XMLName.Local == "" {
+		return action, fmt.Errorf("soap: missing action")
+	}
+
+	action.Name = env.Body.Action.XMLName.Local
+	action.Args = make(map[string]string, len(env.Body.Action.Args))
+
+	for _, arg := range env.Body.Action.Args {
+		action.Args[arg.XMLName.Local] = strings.TrimSpace(arg.Value)
+	}
+
+	return action, nil
+}
+
+// ActionName returns the action name from a SOAPACTION header like "urn:...:ContentDirectory:1#Browse".
+func ActionName(header string) string {
+	header = strings.Trim(header, `"`)
+
+	if i := strings.LastIndex(header, "#"); i >= 0 {
+		return header[i+1:]
+	}
+
+	return ""
+}
+
+// escape returns the string with XML special characters escaped.
+func escape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// ActionResponse returns the SOAP response envelope for an action.
+func ActionResponse(serviceType, actionName string, args []Arg) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	fmt.Fprintf(&buf, `<u:%sResponse xmlns:u="%s">`, actionName, serviceType)
+
+	for _, arg := range args {
+		fmt.Fprintf(&buf, "<%s>%s</%s>", arg.Name, escape(arg.Value), arg.Name)
+	}
+
+	fmt.Fprintf(&buf, `</u:%sResponse>`, actionName)
+	buf.WriteString(`</s:Body></s:Envelope>`)
+
+	return buf.Bytes()
+}
+
+// FaultResponse returns the SOAP fault envelope for a UPnP error.
+func FaultResponse(e Error) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	buf.WriteString(`<s:Fault><faultcode>s:Client</faultcode><faultstring>UPnPError</faultstring><detail>`)
+	fmt.Fprintf(&buf, `<UPnPError xmlns="urn:schemas-upnp-org:control-1-0"><errorCode>%d</errorCode><errorDescription>%s</errorDescription></UPnPError>`, e.Code, escape(e.Description))
+	buf.WriteString(`</detail></s:Fault></s:Body></s:Envelope>`)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,62 @@
+package dlna
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const browseRequest = `<?xml version="1.0" encoding="utf-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+  <s:Body>
+    <u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">
+      <ObjectID>0</ObjectID>
+      <BrowseFlag>BrowseDirectChildren</BrowseFlag>
+      <Filter>*</Filter>
+      <StartingIndex>0</StartingIndex>
+      <RequestedCount>10</RequestedCount>
+      <SortCriteria></SortCriteria>
+    </u:Browse>
+  </s:Body>
+</s:Envelope>`
+
+func TestParseAction(t *testing.T) {
+	t.Run("browse", func(t *testing.T) {
+		action, err := ParseAction(strings.NewReader(browseRequest))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Browse", action.Name)
+		assert.Equal(t, "0", action.Arg("ObjectID"))
+		assert.Equal(t, "BrowseDirectChildren", action.Arg("BrowseFlag"))
+		assert.Equal(t, "10", action.Arg("RequestedCount"))
+		assert.Equal(t, "", action.Arg("SortCriteria"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseAction(strings.NewReader("foo"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestActionName(t *testing.T) {
+	assert.Equal(t, "Browse", ActionName(`"urn:schemas-upnp-org:service:ContentDirectory:1#Browse"`))
+	assert.Equal(t, "", ActionName(""))
+}
+
+func TestActionResponse(t *testing.T) {
+	result := string(ActionResponse(ContentDirectoryType, "Browse", []Arg{{"Result", "<DIDL-Lite/>"}}))
+
+	assert.Contains(t, result, `<u:BrowseResponse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1">`)
+	assert.Contains(t, result, "<Result>&lt;DIDL-Lite/&gt;</Result>")
+}
+
+func TestFaultResponse(t *testing.T) {
+	result := string(FaultResponse(Error{ErrNoSuchObject, "no such object"}))
+
+	assert.Contains(t, result, "<errorCode>701</errorCode>")
+	assert.Contains(t, result, "<errorDescription>no such object</errorDescription>")
+}
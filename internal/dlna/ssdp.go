@@ -0,0 +1,286 @@
+package dlna
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// SSDP multicast address and timing, see UPnP Device Architecture 1.0 section 1.
+const (
+	SSDPAddr       = "239.255.255.250:1900"
+	SSDPMaxAge     = 1800
+	NotifyInterval = 5 * time.Minute
+	MaxSearchDelay = 3
+)
+
+// Notification sub types.
+const (
+	NotifyAlive  = "ssdp:alive"
+	NotifyByeBye = "ssdp:byebye"
+)
+
+// SearchAll is the search target for all devices and services.
+const SearchAll = "ssdp:all"
+
+// Targets returns the notification and search targets of the media server.
+func Targets(udn string) []string {
+	return []string{RootDevice, udn, DeviceType, ContentDirectoryType, ConnectionManagerType}
+}
+
+// USN returns the unique service name for a target.
+func USN(udn, target string) string {
+	if target == udn {
+		return udn
+	}
+
+	return udn + "::" + target
+}
+
+// MatchTargets returns the targets matching a search target.
+func MatchTargets(st, udn string) []string {
+	if st == SearchAll {
+		return Targets(udn)
+	}
+
+	for _, t := range Targets(udn) {
+		if st == t {
+			return []string{t}
+		}
+	}
+
+	return nil
+}
+
+// ParseSearch parses an M-SEARCH request and returns the search target and maximum wait time in seconds.
+func ParseSearch(data []byte) (st string, mx int, err error) {
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(data)))
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	if req.Method != "M-SEARCH" {
+		return "", 0, fmt.Errorf("ssdp: unexpected method %s", req.Method)
+	}
+
+	if man := strings.Trim(req.Header.Get("MAN"), `"`); man != "ssdp:discover" {
+		return "", 0, fmt.Errorf("ssdp: unexpected MAN header %s", man)
+	}
+
+	st = req.Header.Get("ST")
+
+	if st == "" {
+		return "", 0, fmt.Errorf("ssdp: missing ST header")
+	}
+
+	mx, _ = strconv.Atoi(req.Header.Get("MX"))
+
+	return st, mx, nil
+}
+
+// SearchResponse returns the unicast response to a search request.
+func SearchResponse(location, st, usn, server string) []byte {
+	return []byte(strings.Join([]string{
+		"HTTP/1.1 200 OK",
+		fmt.Sprintf("CACHE-CONTROL: max-age=%d", SSDPMaxAge),
+		"DATE: " + time.Now().UTC().Format(http.TimeFormat),
+		"EXT:",
+		"LOCATION: " + location,
+		"SERVER: " + server,
+		"ST: " + st,
+		"USN: " + usn,
+		"", "",
+	}, "\r\n"))
+}
+
+// NotifyMessage returns a multicast alive or byebye notification.
+func NotifyMessage(location, nt, usn, nts, server string) []byte {
+	lines := []string{
+		"NOTIFY * HTTP/1.1",
+		"HOST: " + SSDPAddr,
+		"NT: " + nt,
+		"NTS: " + nts,
+		"USN: " + usn,
+	}
+
+	if nts == NotifyAlive {
+		lines = append(lines,
+			fmt.Sprintf("CACHE-CONTROL: max-age=%d", SSDPMaxAge),
+			"LOCATION: "+location,
+			"SERVER: "+server,
+		)
+	}
+
+	return []byte(strings.Join(append(lines, "", ""), "\r\n"))
+}
+
+// ServerHeader returns the SERVER header value.
+func ServerHeader(conf *config.Config) string {
+	return fmt.Sprintf("%s/1.0 UPnP/1.0 PhotoPrism/%s", runtime.GOOS, conf.Version())
+}
+
+// localIP returns the local address used to reach the remote host.
+func localIP(remote *net.UDPAddr) (net.IP, error) {
+	conn, err := net.DialUDP("udp4", nil, remote)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// advertiser announces the media server via SSDP.
+type advertiser struct {
+	udn    string
+	port   int
+	server string
+	conn   *net.UDPConn
+	group  *net.UDPAddr
+}
+
+// location returns the device description url as seen from the remote host.
+func (a *advertiser) location(remote *net.UDPAddr) (string, error) {
+	ip, err := localIP(remote)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("http://%s%s%s", net.JoinHostPort(ip.String(), strconv.Itoa(a.port)), BasePath, DescriptionPath), nil
+}
+
+// notify sends alive or byebye notifications for all targets.
+func (a *advertiser) notify(nts string) {
+	location, err := a.location(a.group)
+
+	if err != nil {
+		log.Debugf("dlna: %s", err)
+		return
+	}
+
+	for _, nt := range Targets(a.udn) {
+		if _, err := a.conn.WriteToUDP(NotifyMessage(location, nt, USN(a.udn, nt), nts, a.server), a.group); err != nil {
+			log.Debugf("dlna: %s", err)
+		}
+	}
+}
+
+// respond answers a search request after a random delay.
+func (a *advertiser) respond(st string, mx int, remote *net.UDPAddr) {
+	targets := MatchTargets(st, a.udn)
+
+	if len(targets) == 0 {
+		return
+	}
+
+	location, err := a.location(remote)
+
+	if err != nil {
+		log.Debugf("dlna: %s", err)
+		return
+	}
+
+	if mx > MaxSearchDelay {
+		mx = MaxSearchDelay
+	}
+
+	if mx > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(mx) * int64(time.Second))))
+	}
+
+	for _, t := range targets {
+		if _, err := a.conn.WriteToUDP(SearchResponse(location, t, USN(a.udn, t), a.server), remote); err != nil {
+			log.Debugf("dlna: %s", err)
+		}
+	}
+}
+
+// Advertise announces the media server in the local network until the context is done.
+func Advertise(ctx context.Context, conf *config.Config) {
+	group, err := net.ResolveUDPAddr("udp4", SSDPAddr)
+
+	if err != nil {
+		log.Errorf("dlna: %s", err)
+		return
+	}
+
+	listener, err := net.ListenMulticastUDP("udp4", nil, group)
+
+	if err != nil {
+		log.Errorf("dlna: %s", err)
+		return
+	}
+
+	conn, err := net.ListenUDP("udp4", nil)
+
+	if err != nil {
+		_ = listener.Close()
+		log.Errorf("dlna: %s", err)
+		return
+	}
+
+	a := &advertiser{
+		udn:    NewServer(conf).UDN(),
+		port:   conf.DLNAPort(),
+		server: ServerHeader(conf),
+		conn:   conn,
+		group:  group,
+	}
+
+	go func() {
+		buf := make([]byte, 2048)
+
+		for {
+			n, remote, err := listener.ReadFromUDP(buf)
+
+			if err != nil {
+				return
+			}
+
+			st, mx, err := ParseSearch(buf[:n])
+
+			if err != nil {
+				continue
+			}
+
+			go a.respond(st, mx, remote)
+		}
+	}()
+
+	log.Infof("dlna: media server %s waiting for connection", a.udn)
+
+	a.notify(NotifyAlive)
+
+	ticker := time.NewTicker(NotifyInterval)
+
+	defer func() {
+		ticker.Stop()
+		a.notify(NotifyByeBye)
+		_ = listener.Close()
+		_ = conn.Close()
+		log.Info("dlna: media server shutdown complete")
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.notify(NotifyAlive)
+		}
+	}
+}
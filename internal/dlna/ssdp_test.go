@@ -0,0 +1,71 @@
+package dlna
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const udn = "uuid:8a1b8d6c-2f0c-5b4e-9d33-5a8c8f3e0c11"
+
+func TestParseSearch(t *testing.T) {
+	t.Run("rootdevice", func(t *testing.T) {
+		msg := "M-SEARCH * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nMAN: \"ssdp:discover\"\r\nMX: 2\r\nST: upnp:rootdevice\r\n\r\n"
+
+		st, mx, err := ParseSearch([]byte(msg))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, RootDevice, st)
+		assert.Equal(t, 2, mx)
+	})
+	t.Run("notify", func(t *testing.T) {
+		_, _, err := ParseSearch(NotifyMessage("http://192.168.1.2:2342/dlna/device.xml", RootDevice, USN(udn, RootDevice), NotifyAlive, "Linux/1.0"))
+
+		assert.Error(t, err)
+	})
+	t.Run("missing target", func(t *testing.T) {
+		_, _, err := ParseSearch([]byte("M-SEARCH * HTTP/1.1\r\nMAN: \"ssdp:discover\"\r\n\r\n"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMatchTargets(t *testing.T) {
+	assert.Len(t, MatchTargets(SearchAll, udn), 5)
+	assert.Equal(t, []string{DeviceType}, MatchTargets(DeviceType, udn))
+	assert.Equal(t, []string{udn}, MatchTargets(udn, udn))
+	assert.Empty(t, MatchTargets("urn:schemas-upnp-org:device:MediaRenderer:1", udn))
+}
+
+func TestUSN(t *testing.T) {
+	assert.Equal(t, udn, USN(udn, udn))
+	assert.Equal(t, udn+"::upnp:rootdevice", USN(udn, RootDevice))
+}
+
+func TestSearchResponse(t *testing.T) {
+	result := string(SearchResponse("http://192.168.1.2:2342/dlna/device.xml", RootDevice, USN(udn, RootDevice), "Linux/1.0"))
+
+	assert.True(t, strings.HasPrefix(result, "HTTP/1.1 200 OK\r\n"))
+	assert.Contains(t, result, "\r\nLOCATION: http://192.168.1.2:2342/dlna/device.xml\r\n")
+	assert.Contains(t, result, "\r\nST: upnp:rootdevice\r\n")
+	assert.True(t, strings.HasSuffix(result, "\r\n\r\n"))
+}
+
+func TestNotifyMessage(t *testing.T) {
+	t.Run("alive", func(t *testing.T) {
+		result := string(NotifyMessage("http://192.168.1.2:2342/dlna/device.xml", DeviceType, USN(udn, DeviceType), NotifyAlive, "Linux/1.0"))
+
+		assert.Contains(t, result, "\r\nNTS: ssdp:alive\r\n")
+		assert.Contains(t, result, "\r\nCACHE-CONTROL: max-age=1800\r\n")
+	})
+	t.Run("byebye", func(t *testing.T) {
+		result := string(NotifyMessage("http://192.168.1.2:2342/dlna/device.xml", DeviceType, USN(udn, DeviceType), NotifyByeBye, "Linux/1.0"))
+
+		assert.Contains(t, result, "\r\nNTS: ssdp:byebye\r\n")
+		assert.NotContains(t, result, "LOCATION")
+	})
+}
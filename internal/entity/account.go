@@ -7,7 +7,6 @@ import (
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/ulule/deepcopier"
 )
@@ -29,6 +28,7 @@ type Account struct {
 	AccURL        string `gorm:"type:VARBINARY(512);"`
 	AccType       string `gorm:"type:VARBINARY(255);"`
 	AccKey        string `gorm:"type:VARBINARY(255);"`
+	AccHostKey    string `gorm:"type:VARBINARY(255);"`
 	AccUser       string `gorm:"type:VARBINARY(255);"`
 	AccPass       string `gorm:"type:VARBINARY(255);"`
 	AccError      string `gorm:"type:VARBINARY(512);"`
@@ -47,6 +47,7 @@ type Account struct {
 	SyncDownload  bool
 	SyncFilenames bool
 	SyncRaw       bool
+	SyncDelete    bool
 	CreatedAt     time.Time  `deepcopier:"skip"`
 	UpdatedAt     time.Time  `deepcopier:"skip"`
 	DeletedAt     *time.Time `deepcopier:"skip" sql:"index"`
@@ -74,8 +75,7 @@ func (m *Account) SaveForm(form form.Account) error {
 		return err
 	}
 
-	if m.AccType != string(remote.ServiceWebDAV) {
-		// TODO: Only WebDAV supported at the moment
+	if !remote.Supported(m.AccType) {
 		m.AccShare = false
 		m.AccSync = false
 	}
@@ -102,13 +102,44 @@ func (m *Account) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// TwoWay tests if changes are synced in both directions, which is the case if both
+// downloads and uploads are enabled.
+func (m *Account) TwoWay() bool {
+	return m.SyncDownload && m.SyncUpload
+}
+
+// Backend returns a client for syncing and sharing files with the account.
+func (m *Account) Backend() (remote.Backend, error) {
+	c, err := remote.NewBackend(m.AccType, m.AccURL, m.AccUser, m.AccPass, m.AccHostKey)
+
+	if err != nil {
+		return c, err
+	}
+
+	// Pin the host key on first use, so that connections fail if it changes.
+	if k, ok := c.(remote.HostKeyBackend); ok && m.AccHostKey == "" && k.HostKey() != "" {
+		if err := m.Update("AccHostKey", k.HostKey()); err != nil {
+			log.Errorf("account: %s", err)
+		}
+	}
+
+	return c, nil
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Account) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		c := webdav.New(m.AccURL, m.AccUser, m.AccPass)
-		result, err = c.Directories("/", true, webdav.SyncTimeout)
+	c, err := m.Backend()
+
+	if err == remote.ErrUnsupported {
+		return result, nil
+	} else if err != nil {
+		return result, err
 	}
 
+	defer c.Close()
+
+	result, err = c.Directories("/", true, remote.SyncTimeout)
+
 	sort.Sort(result)
 
 	return result, err
@@ -20,6 +20,14 @@ const (
 	AlbumMoment  = "moment"
 	AlbumMonth   = "month"
 	AlbumState   = "state"
+	AlbumSmart   = "smart"
+	AlbumEvent   = "event"
+)
+
+// Title and slug of the album that contains uploads of users without storage path.
+const (
+	UploadAlbumTitle = "Uploads"
+	UploadAlbumSlug  = "uploads"
 )
 
 type Albums []Album
@@ -48,6 +56,7 @@ type Album struct {
 	AlbumDay         int        `json:"Day" yaml:"Day,omitempty"`
 	AlbumFavorite    bool       `json:"Favorite" yaml:"Favorite,omitempty"`
 	AlbumPrivate     bool       `json:"Private" yaml:"Private,omitempty"`
+	CreatedBy        string     `gorm:"type:VARBINARY(42);index;" json:"CreatedBy" yaml:"CreatedBy,omitempty"`
 	CreatedAt        time.Time  `json:"CreatedAt" yaml:"-"`
 	UpdatedAt        time.Time  `json:"UpdatedAt" yaml:"-"`
 	DeletedAt        *time.Time `sql:"index" json:"-" yaml:"-"`
@@ -95,6 +104,8 @@ func AddPhotoToAlbums(photo string, albums []string) (err error) {
 		}
 	}
 
+	IndexPhotoTerms([]string{photo})
+
 	return err
 }
 
@@ -118,6 +129,19 @@ func NewAlbum(albumTitle, albumType string) *Album {
 	return result
 }
 
+// NewSmartAlbum creates a new album whose photos are found by running the filter.
+func NewSmartAlbum(albumTitle, albumFilter string) *Album {
+	if albumFilter == "" {
+		return nil
+	}
+
+	result := NewAlbum(albumTitle, AlbumSmart)
+	result.AlbumOrder = SortOrderNewest
+	result.AlbumFilter = albumFilter
+
+	return result
+}
+
 // NewFolderAlbum creates a new folder album.
 func NewFolderAlbum(albumTitle, albumPath, albumFilter string) *Album {
 	if albumTitle == "" || albumPath == "" || albumFilter == "" {
@@ -161,6 +185,27 @@ func NewMomentsAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	return result
 }
 
+// NewEventAlbum creates a new album for photos taken at an event or on a trip,
+// its photos are added like to a manual album.
+func NewEventAlbum(albumTitle, albumSlug string) *Album {
+	if albumTitle == "" || albumSlug == "" {
+		return nil
+	}
+
+	now := Timestamp()
+
+	result := &Album{
+		AlbumOrder: SortOrderOldest,
+		AlbumType:  AlbumEvent,
+		AlbumTitle: albumTitle,
+		AlbumSlug:  albumSlug,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	return result
+}
+
 // NewStateAlbum creates a new moment.
 func NewStateAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	if albumTitle == "" || albumSlug == "" || albumFilter == "" {
@@ -279,6 +324,11 @@ func (m *Album) IsMoment() bool {
 	return m.AlbumType == AlbumMoment
 }
 
+// IsSmart checks if the album contents are found by running the album filter.
+func (m *Album) IsSmart() bool {
+	return m.AlbumType == AlbumSmart
+}
+
 // SetTitle changes the album name.
 func (m *Album) SetTitle(title string) {
 	title = strings.TrimSpace(title)
@@ -289,7 +339,7 @@ func (m *Album) SetTitle(title string) {
 
 	m.AlbumTitle = txt.Clip(title, txt.ClipDefault)
 
-	if m.AlbumType == AlbumDefault {
+	if m.AlbumType == AlbumDefault || m.AlbumType == AlbumSmart {
 		if len(m.AlbumTitle) < txt.ClipSlug {
 			m.AlbumSlug = slug.Make(m.AlbumTitle)
 		} else {
@@ -346,7 +396,7 @@ func (m *Album) Create() error {
 	}
 
 	switch m.AlbumType {
-	case AlbumDefault:
+	case AlbumDefault, AlbumSmart:
 		event.Publish("count.albums", event.Data{"count": 1})
 	case AlbumMoment:
 		event.Publish("count.moments", event.Data{"count": 1})
@@ -375,9 +425,27 @@ func (m *Album) AddPhotos(UIDs []string) (added []PhotoAlbum) {
 		}
 	}
 
+	IndexPhotoTerms(UIDs)
+
 	return added
 }
 
+// Freeze converts a smart album into a regular album containing the photos currently found by its filter.
+func (m *Album) Freeze(photoUIDs []string) (added []PhotoAlbum, err error) {
+	if !m.IsSmart() {
+		return added, fmt.Errorf("album: %s is not a smart album", m)
+	}
+
+	if err := UnscopedDb().Model(m).UpdateColumns(map[string]interface{}{
+		"AlbumType":   AlbumDefault,
+		"AlbumFilter": "",
+	}).Error; err != nil {
+		return added, err
+	}
+
+	return m.AddPhotos(photoUIDs), nil
+}
+
 // RemovePhotos removes photos from an album.
 func (m *Album) RemovePhotos(UIDs []string) (removed []PhotoAlbum) {
 	for _, uid := range UIDs {
@@ -390,9 +458,23 @@ func (m *Album) RemovePhotos(UIDs []string) (removed []PhotoAlbum) {
 		}
 	}
 
+	IndexPhotoTerms(UIDs)
+
 	return removed
 }
 
+// IndexPhotoTerms updates the full-text index of all photos in the album, e.g. after the title was changed.
+func (m *Album) IndexPhotoTerms() {
+	var photoUIDs []string
+
+	if err := UnscopedDb().Model(PhotoAlbum{}).Where("album_uid = ? AND hidden = FALSE", m.AlbumUID).Pluck("photo_uid", &photoUIDs).Error; err != nil {
+		log.Errorf("album: %s (index photo terms)", err)
+		return
+	}
+
+	IndexPhotoTerms(photoUIDs)
+}
+
 // Links returns all share links for this entity.
 func (m *Album) Links() Links {
 	return FindLinks("", m.AlbumUID)
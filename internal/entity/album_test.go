@@ -178,6 +178,22 @@ func TestNewFolderAlbum(t *testing.T) {
 	})
 }
 
+func TestNewSmartAlbum(t *testing.T) {
+	t.Run("cats and dogs", func(t *testing.T) {
+		album := NewSmartAlbum("Cats & Dogs", "label:cat OR label:dog")
+		assert.Equal(t, "Cats & Dogs", album.AlbumTitle)
+		assert.Equal(t, "cats-and-dogs", album.AlbumSlug)
+		assert.Equal(t, AlbumSmart, album.AlbumType)
+		assert.Equal(t, SortOrderNewest, album.AlbumOrder)
+		assert.Equal(t, "label:cat OR label:dog", album.AlbumFilter)
+		assert.True(t, album.IsSmart())
+	})
+	t.Run("filter empty", func(t *testing.T) {
+		album := NewSmartAlbum("Cats & Dogs", "")
+		assert.Nil(t, album)
+	})
+}
+
 func TestNewMomentsAlbum(t *testing.T) {
 	t.Run("name Christmas 2018", func(t *testing.T) {
 		album := NewMomentsAlbum("Dogs", "dogs", "label:dog")
@@ -435,3 +451,39 @@ func TestAlbum_RemovePhotos(t *testing.T) {
 		assert.Equal(t, 2, len(removed))
 	})
 }
+
+func TestAlbum_Freeze(t *testing.T) {
+	t.Run("smart album", func(t *testing.T) {
+		album := NewSmartAlbum("Frozen", "year:2014 | year:2015")
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		added, err := album.Freeze([]string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0y12"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, added, 2)
+		assert.Equal(t, AlbumDefault, album.AlbumType)
+		assert.Equal(t, "", album.AlbumFilter)
+
+		saved := Album{}
+
+		if err := Db().First(&saved, "album_uid = ?", album.AlbumUID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, AlbumDefault, saved.AlbumType)
+		assert.Equal(t, "", saved.AlbumFilter)
+	})
+	t.Run("regular album", func(t *testing.T) {
+		album := AlbumFixtures.Get("christmas2030")
+
+		_, err := album.Freeze([]string{"pt9jtdre2lvl0y11"})
+
+		assert.Error(t, err)
+	})
+}
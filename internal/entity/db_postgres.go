@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+// postgresTypes maps MySQL specific column types used in struct tags to PostgreSQL data types.
+var postgresTypes = []struct {
+	expr *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY(\(\d+\))?`), "VARCHAR$1"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)TEXT`), "TEXT"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)?BLOB`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^DATETIME`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^FLOAT`), "REAL"},
+	{regexp.MustCompile(`(?i)^DOUBLE`), "DOUBLE PRECISION"},
+}
+
+// postgresDialect is the type of the original gorm PostgreSQL dialect.
+var postgresDialect reflect.Type
+
+// postgres wraps the gorm PostgreSQL dialect so that entities can be migrated without changing their struct tags.
+type postgres struct {
+	gorm.Dialect
+}
+
+func init() {
+	if d, ok := gorm.GetDialect(Postgres); ok {
+		postgresDialect = reflect.TypeOf(d).Elem()
+		gorm.RegisterDialect(Postgres, &postgres{})
+	}
+}
+
+// SetDB creates a new instance of the original dialect for the given connection.
+func (p *postgres) SetDB(db gorm.SQLCommon) {
+	p.Dialect = reflect.New(postgresDialect).Interface().(gorm.Dialect)
+	p.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type for a struct field.
+func (p *postgres) DataTypeOf(field *gorm.StructField) string {
+	return PostgresType(p.Dialect.DataTypeOf(field))
+}
+
+// PostgresType returns the PostgreSQL equivalent of a MySQL column type.
+func PostgresType(sqlType string) string {
+	for _, t := range postgresTypes {
+		if t.expr.MatchString(sqlType) {
+			return t.expr.ReplaceAllString(sqlType, t.repl)
+		}
+	}
+
+	return sqlType
+}
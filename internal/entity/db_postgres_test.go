@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	assert.Equal(t, "VARCHAR(42)", PostgresType("VARBINARY(42)"))
+	assert.Equal(t, "VARCHAR(255) NOT NULL", PostgresType("varbinary(255) NOT NULL"))
+	assert.Equal(t, "TIMESTAMP", PostgresType("datetime"))
+	assert.Equal(t, "TEXT", PostgresType("LONGTEXT"))
+	assert.Equal(t, "BYTEA", PostgresType("MEDIUMBLOB"))
+	assert.Equal(t, "REAL", PostgresType("FLOAT"))
+	assert.Equal(t, "SMALLINT", PostgresType("SMALLINT"))
+	assert.Equal(t, "VARCHAR(64)", PostgresType("VARCHAR(64)"))
+}
+
+func TestPostgres_DataTypeOf(t *testing.T) {
+	d, ok := gorm.GetDialect(Postgres)
+
+	if !ok {
+		t.Fatal("postgres dialect not registered")
+	}
+
+	assert.IsType(t, &postgres{}, d)
+
+	p := &postgres{}
+	p.SetDB(nil)
+
+	assert.Equal(t, Postgres, p.GetName())
+
+	types := make(map[string]string)
+
+	for _, m := range []interface{}{&File{}, &Photo{}} {
+		scope := &gorm.Scope{Value: m}
+
+		for _, field := range scope.GetModelStruct().StructFields {
+			if field.IsNormal {
+				types[field.DBName] = p.DataTypeOf(field)
+			}
+		}
+	}
+
+	assert.Equal(t, "VARCHAR(42)", types["file_uid"])
+	assert.Equal(t, "VARCHAR(16)", types["file_phash"])
+	assert.Equal(t, "boolean", types["file_primary"])
+	assert.Equal(t, "TIMESTAMP", types["taken_at"])
+	assert.Equal(t, "REAL", types["photo_lat"])
+}
@@ -30,31 +30,39 @@ type Types map[string]interface{}
 
 // List of database entities and their table names.
 var Entities = Types{
-	"errors":          &Error{},
-	"addresses":       &Address{},
-	"users":           &User{},
-	"accounts":        &Account{},
-	"folders":         &Folder{},
-	"duplicates":      &Duplicate{},
-	"files":           &File{},
-	"files_share":     &FileShare{},
-	"files_sync":      &FileSync{},
-	"photos":          &Photo{},
-	"details":         &Details{},
-	"places":          &Place{},
-	"cells":           &Cell{},
-	"cameras":         &Camera{},
-	"lenses":          &Lens{},
-	"countries":       &Country{},
-	"albums":          &Album{},
-	"photos_albums":   &PhotoAlbum{},
-	"labels":          &Label{},
-	"categories":      &Category{},
-	"photos_labels":   &PhotoLabel{},
-	"keywords":        &Keyword{},
-	"photos_keywords": &PhotoKeyword{},
-	"passwords":       &Password{},
-	"links":           &Link{},
+	"errors":              &Error{},
+	"addresses":           &Address{},
+	"users":               &User{},
+	"users_shares":        &UserShare{},
+	"accounts":            &Account{},
+	"folders":             &Folder{},
+	"duplicates":          &Duplicate{},
+	"files":               &File{},
+	"files_share":         &FileShare{},
+	"files_sync":          &FileSync{},
+	"photos":              &Photo{},
+	"details":             &Details{},
+	"places":              &Place{},
+	"cells":               &Cell{},
+	"cameras":             &Camera{},
+	"lenses":              &Lens{},
+	"countries":           &Country{},
+	"albums":              &Album{},
+	"photos_albums":       &PhotoAlbum{},
+	"labels":              &Label{},
+	"categories":          &Category{},
+	"photos_labels":       &PhotoLabel{},
+	"keywords":            &Keyword{},
+	"photos_keywords":     &PhotoKeyword{},
+	"photos_terms":        &PhotoTerm{},
+	"photos_edits":        &PhotoEdit{},
+	"passwords":           &Password{},
+	"links":               &Link{},
+	"links_photos":        &LinkPhoto{},
+	"people":              &Person{},
+	"faces":               &Face{},
+	"webhooks":            &Webhook{},
+	"webhooks_deliveries": &WebhookDelivery{},
 }
 
 type RowCount struct {
@@ -86,7 +94,7 @@ func (list Types) WaitForMigration() {
 // Truncate removes all data from tables without dropping them.
 func (list Types) Truncate() {
 	for name := range list {
-		if err := Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE 1", name)).Error; err == nil {
+		if err := Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE 1 = 1", name)).Error; err == nil {
 			// log.Debugf("entity: removed all data from %s", name)
 			break
 		} else if err.Error() != "record not found" {
@@ -158,6 +166,8 @@ func InitTestDb(driver, dsn string) *Gorm {
 	if driver == "test" || driver == "sqlite" || driver == "" || dsn == "" {
 		driver = "sqlite3"
 		dsn = ".test.db"
+	} else if driver == "postgresql" {
+		driver = Postgres
 	}
 
 	log.Infof("initializing %s test db in %s", driver, dsn)
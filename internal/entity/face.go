@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+type Faces []Face
+
+// Face represents a face region found in a photo and the embedding used to cluster it by person.
+type Face struct {
+	ID        uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	FaceUID   string    `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	PhotoID   uint      `gorm:"index;" json:"-" yaml:"-"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	FileID    uint      `gorm:"index;" json:"-" yaml:"-"`
+	FileUID   string    `gorm:"type:VARBINARY(42);index;" json:"FileUID" yaml:"FileUID"`
+	PersonUID string    `gorm:"type:VARBINARY(42);index;" json:"PersonUID" yaml:"PersonUID,omitempty"`
+	Person    *Person   `gorm:"foreignkey:PersonUID;association_foreignkey:PersonUID;association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Person,omitempty" yaml:"-"`
+	FaceSrc   string    `gorm:"type:VARBINARY(8);" json:"Src" yaml:"Src,omitempty"`
+	FaceX     float32   `gorm:"type:FLOAT;" json:"X" yaml:"X"`
+	FaceY     float32   `gorm:"type:FLOAT;" json:"Y" yaml:"Y"`
+	FaceW     float32   `gorm:"type:FLOAT;" json:"W" yaml:"W"`
+	FaceH     float32   `gorm:"type:FLOAT;" json:"H" yaml:"H"`
+	FaceScore float32   `gorm:"type:FLOAT;" json:"Score" yaml:"Score,omitempty"`
+	Embedding string    `gorm:"type:TEXT;" json:"-" yaml:"-"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Face) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.FaceUID, 'm') {
+		return nil
+	}
+
+	return scope.SetColumn("FaceUID", rnd.PPID('m'))
+}
+
+// NewFace returns a new face entity for a file.
+func NewFace(file File, f face.Face, src string) *Face {
+	result := &Face{
+		PhotoID:   file.PhotoID,
+		PhotoUID:  file.PhotoUID,
+		FileID:    file.ID,
+		FileUID:   file.FileUID,
+		FaceSrc:   src,
+		FaceX:     f.Area.X,
+		FaceY:     f.Area.Y,
+		FaceW:     f.Area.W,
+		FaceH:     f.Area.H,
+		FaceScore: f.Score,
+		Embedding: f.Embedding.JSON(),
+	}
+
+	return result
+}
+
+// Create inserts the face to the database.
+func (m *Face) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new face.
+func (m *Face) Save() error {
+	return Db().Save(m).Error
+}
+
+// Update a face property in the database.
+func (m *Face) Update(attr string, value interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
+}
+
+// GetEmbedding returns the face embedding.
+func (m *Face) GetEmbedding() face.Embedding {
+	return face.UnmarshalEmbedding(m.Embedding)
+}
+
+// Area returns the relative face region.
+func (m *Face) Area() face.Area {
+	return face.Area{X: m.FaceX, Y: m.FaceY, W: m.FaceW, H: m.FaceH}
+}
+
+// SetPerson assigns the face to a person.
+func (m *Face) SetPerson(person *Person) error {
+	if person == nil {
+		m.PersonUID = ""
+	} else {
+		m.PersonUID = person.PersonUID
+	}
+
+	m.Person = person
+
+	if m.ID == 0 {
+		return nil
+	}
+
+	return m.Update("PersonUID", m.PersonUID)
+}
+
+// DeleteFileFaces removes automatically detected faces of a file, e.g. before detecting them again,
+// and updates the embeddings of the people they belonged to.
+func DeleteFileFaces(fileID uint) (removed Faces, err error) {
+	if err := Db().Where("file_id = ? AND face_src = ?", fileID, SrcImage).Find(&removed).Error; err != nil {
+		return removed, err
+	} else if len(removed) == 0 {
+		return removed, nil
+	}
+
+	if err := Db().Where("file_id = ? AND face_src = ?", fileID, SrcImage).Delete(&Face{}).Error; err != nil {
+		return removed, err
+	}
+
+	mutex.People.Lock()
+	defer mutex.People.Unlock()
+
+	for _, uid := range removed.PersonUIDs() {
+		person := Person{}
+
+		if err := Db().Where("person_uid = ?", uid).First(&person).Error; err != nil {
+			continue
+		}
+
+		if err := person.UpdateEmbedding(); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// PersonUIDs returns the unique UIDs of the people the faces belong to.
+func (f Faces) PersonUIDs() (result []string) {
+	found := make(map[string]bool)
+
+	for _, m := range f {
+		if m.PersonUID == "" || found[m.PersonUID] {
+			continue
+		}
+
+		found[m.PersonUID] = true
+		result = append(result, m.PersonUID)
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+)
+
+type FaceMap map[string]Face
+
+func (m FaceMap) Get(name string) Face {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Face{}
+}
+
+func (m FaceMap) Pointer(name string) *Face {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Face{}
+}
+
+var FaceFixtures = FaceMap{
+	"jane-doe-bridge": {
+		ID:        1000000,
+		FaceUID:   "mt9lxuqxkkvd1000",
+		PhotoID:   FileFixtures["bridge.jpg"].PhotoID,
+		PhotoUID:  FileFixtures["bridge.jpg"].PhotoUID,
+		FileID:    FileFixtures["bridge.jpg"].ID,
+		FileUID:   FileFixtures["bridge.jpg"].FileUID,
+		PersonUID: PersonFixtures.Pointer("jane-doe").PersonUID,
+		FaceSrc:   SrcImage,
+		FaceX:     0.3,
+		FaceY:     0.2,
+		FaceW:     0.1,
+		FaceH:     0.15,
+		FaceScore: 0.98,
+		Embedding: "[0.6,0.8,0]",
+		CreatedAt: time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+	"unnamed-reunion": {
+		ID:        1000001,
+		FaceUID:   "mt9lxuqxkkvd1001",
+		PhotoID:   FileFixtures["reunion.jpg"].PhotoID,
+		PhotoUID:  FileFixtures["reunion.jpg"].PhotoUID,
+		FileID:    FileFixtures["reunion.jpg"].ID,
+		FileUID:   FileFixtures["reunion.jpg"].FileUID,
+		PersonUID: PersonFixtures.Pointer("unnamed").PersonUID,
+		FaceSrc:   SrcImage,
+		FaceX:     0.5,
+		FaceY:     0.4,
+		FaceW:     0.2,
+		FaceH:     0.25,
+		FaceScore: 0.91,
+		Embedding: "[0,0,1]",
+		CreatedAt: time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+}
+
+// CreateFaceFixtures inserts known entities into the database for testing.
+func CreateFaceFixtures() {
+	for _, entity := range FaceFixtures {
+		Db().Create(&entity)
+	}
+}
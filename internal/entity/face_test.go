@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFace(t *testing.T) {
+	file := FileFixtures["bridge.jpg"]
+	f := face.Face{Score: 0.9, Area: face.Area{X: 0.1, Y: 0.2, W: 0.3, H: 0.4}, Embedding: face.Embedding{1, 0}}
+
+	m := NewFace(file, f, SrcImage)
+
+	assert.Equal(t, file.PhotoUID, m.PhotoUID)
+	assert.Equal(t, file.FileUID, m.FileUID)
+	assert.Equal(t, f.Area, m.Area())
+	assert.Equal(t, face.Embedding{1, 0}, m.GetEmbedding())
+	assert.Equal(t, SrcImage, m.FaceSrc)
+}
+
+func TestFace_SetPerson(t *testing.T) {
+	file := FileFixtures["Photo18.jpg"]
+	f := face.Face{Score: 0.9, Area: face.Area{X: 0.1, Y: 0.2, W: 0.3, H: 0.4}, Embedding: face.Embedding{1, 0}}
+
+	m := NewFace(file, f, SrcImage)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	person := NewPerson(face.Embedding{1, 0})
+
+	if err := person.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SetPerson(person); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, person.PersonUID, m.PersonUID)
+
+	removed, err := DeleteFileFaces(file.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{person.PersonUID}, removed.PersonUIDs())
+
+	var count int
+
+	Db().Model(&Face{}).Where("file_id = ?", file.ID).Count(&count)
+
+	assert.Equal(t, 0, count)
+
+	if err := person.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteFileFaces(t *testing.T) {
+	file := FileFixtures["bridge2.jpg"]
+	person := NewPerson(face.Embedding{0, 1})
+	person.FaceCount = 0
+
+	if err := person.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer person.Delete()
+
+	for _, e := range []face.Embedding{{0, 1}, {1, 0}} {
+		m := NewFace(file, face.Face{Embedding: e}, SrcImage)
+		m.PersonUID = person.PersonUID
+
+		if err := person.AddFace(e); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, 2, person.FaceCount)
+
+	removed, err := DeleteFileFaces(file.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, removed, 2)
+
+	if m := FindPerson(person.PersonUID); m == nil {
+		t.Fatal("person should not be nil")
+	} else {
+		assert.Equal(t, 0, m.FaceCount)
+		assert.NotEmpty(t, m.Embedding)
+	}
+}
+
+func TestFaces_PersonUIDs(t *testing.T) {
+	faces := Faces{{PersonUID: "a"}, {PersonUID: ""}, {PersonUID: "b"}, {PersonUID: "a"}}
+
+	assert.Equal(t, []string{"a", "b"}, faces.PersonUIDs())
+	assert.Empty(t, Faces{}.PersonUIDs())
+}
@@ -23,6 +23,7 @@ type File struct {
 	PhotoID         uint          `gorm:"index;" json:"-" yaml:"-"`
 	PhotoUID        string        `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
 	InstanceID      string        `gorm:"type:VARBINARY(42);index;" json:"InstanceID,omitempty" yaml:"InstanceID,omitempty"`
+	ContentID       string        `gorm:"type:VARBINARY(42);index;" json:"ContentID,omitempty" yaml:"ContentID,omitempty"`
 	FileUID         string        `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
 	FileName        string        `gorm:"type:VARBINARY(768);unique_index:idx_files_name_root;" json:"Name" yaml:"Name"`
 	FileRoot        string        `gorm:"type:VARBINARY(16);default:'/';unique_index:idx_files_name_root;" json:"Root" yaml:"Root,omitempty"`
@@ -38,6 +39,10 @@ type File struct {
 	FilePortrait    bool          `json:"Portrait" yaml:"Portrait,omitempty"`
 	FileVideo       bool          `json:"Video" yaml:"Video,omitempty"`
 	FileDuration    time.Duration `json:"Duration" yaml:"Duration,omitempty"`
+	FileBitrate     int           `json:"Bitrate" yaml:"Bitrate,omitempty"`
+	FileFPS         float64       `json:"FPS" yaml:"FPS,omitempty"`
+	FileAudio       string        `gorm:"type:VARBINARY(64);" json:"Audio" yaml:"Audio,omitempty"`
+	FileTrack       string        `gorm:"type:TEXT;" json:"Track,omitempty" yaml:"Track,omitempty"`
 	FileWidth       int           `json:"Width" yaml:"Width,omitempty"`
 	FileHeight      int           `json:"Height" yaml:"Height,omitempty"`
 	FileOrientation int           `json:"Orientation" yaml:"Orientation,omitempty"`
@@ -48,6 +53,7 @@ type File struct {
 	FileLuminance   string        `gorm:"type:VARBINARY(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff        uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma      uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePhash       string        `gorm:"type:VARBINARY(16);index;" json:"Phash" yaml:"Phash,omitempty"`
 	FileError       string        `gorm:"type:varbinary(512)" json:"Error" yaml:"Error,omitempty"`
 	ModTime         int64         `json:"ModTime" yaml:"-"`
 	CreatedAt       time.Time     `json:"CreatedAt" yaml:"-"`
@@ -84,7 +90,7 @@ func FirstFileByHash(fileHash string) (File, error) {
 func PrimaryFile(photoUID string) (File, error) {
 	var file File
 
-	q := Db().Unscoped().First(&file, "file_primary = 1 AND photo_uid = ?", photoUID)
+	q := Db().Unscoped().First(&file, "file_primary = TRUE AND photo_uid = ?", photoUID)
 
 	return file, q.Error
 }
@@ -168,7 +174,7 @@ func (m *File) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.PhotoID).
+		Where("photo_id = ? AND file_missing = FALSE", m.PhotoID).
 		Count(&count).Error; err != nil {
 		log.Errorf("file: %s", err.Error())
 	}
@@ -212,7 +218,7 @@ func (m *File) UpdateVideoInfos() error {
 		return err
 	}
 
-	return Db().Model(File{}).Where("photo_id = ? AND file_video = 1", m.PhotoID).Updates(values).Error
+	return Db().Model(File{}).Where("photo_id = ? AND file_video = TRUE", m.PhotoID).Updates(values).Error
 }
 
 // Updates a column in the database.
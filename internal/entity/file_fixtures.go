@@ -147,6 +147,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "f0e0c8d8b8b0f0e0",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -184,6 +185,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        800,
 		FileChroma:      4,
+		FilePhash:       "0f1f3727474f0f1f",
 		FileError:       "Error",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -406,6 +408,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "f0e0c8d8b8b0f0e3",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -443,6 +446,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "f0e0c8d8b8b0f1e1",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -501,11 +505,16 @@ var FileFixtures = map[string]File{
 		FileHash:        "pcad9168fa6acc5c5ba965adf6ec465ca42fd819",
 		ModTime:         time.Date(2017, 2, 6, 2, 6, 51, 0, time.UTC).Unix(),
 		FileSize:        921851,
+		FileCodec:       "hvc1",
 		FileType:        "mp4",
 		FileMime:        "image/mp4",
 		FilePrimary:     false,
 		FileSidecar:     false,
 		FileVideo:       true,
+		FileDuration:    45 * time.Second,
+		FileBitrate:     8166912,
+		FileFPS:         29.97,
+		FileAudio:       "aac",
 		FileMissing:     false,
 		FilePortrait:    false,
 		FileWidth:       1200,
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 const (
@@ -19,6 +21,8 @@ type FileSync struct {
 	FileID     uint   `gorm:"index;"`
 	RemoteDate time.Time
 	RemoteSize int64
+	RemoteETag string `gorm:"type:VARBINARY(128);"`
+	LocalHash  string `gorm:"type:VARBINARY(128);"`
 	Status     string `gorm:"type:VARBINARY(16);"`
 	Error      string `gorm:"type:VARBINARY(512);"`
 	Errors     int
@@ -28,6 +32,20 @@ type FileSync struct {
 	UpdatedAt  time.Time
 }
 
+// RemoteChanged tests if the remote file differs from the version that was last synced.
+func (m *FileSync) RemoteChanged(info fs.FileInfo) bool {
+	if m.RemoteETag != "" && info.ETag != "" {
+		return m.RemoteETag != info.ETag
+	}
+
+	return m.RemoteSize != info.Size || m.RemoteDate.Unix() != info.Date.Unix()
+}
+
+// LocalChanged tests if the local file differs from the version that was last synced.
+func (m *FileSync) LocalChanged(file File) bool {
+	return m.LocalHash != "" && m.LocalHash != file.FileHash
+}
+
 // TableName returns the entity database table name.
 func (FileSync) TableName() string {
 	return "files_sync"
@@ -59,6 +77,11 @@ func (m *FileSync) Save() error {
 	return Db().Save(m).Error
 }
 
+// Delete deletes the entity from the database.
+func (m *FileSync) Delete() error {
+	return Db().Where("account_id = ? AND remote_name = ?", m.AccountID, m.RemoteName).Delete(&FileSync{}).Error
+}
+
 // Create inserts a new row to the database.
 func (m *FileSync) Create() error {
 	return Db().Create(m).Error
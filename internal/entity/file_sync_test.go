@@ -2,7 +2,9 @@ package entity
 
 import (
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +106,45 @@ func TestFileSync_Save(t *testing.T) {
 		assert.True(t, afterDate.After(initialDate))
 	})
 }
+
+func TestFileSync_RemoteChanged(t *testing.T) {
+	date := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("etag", func(t *testing.T) {
+		m := FileSync{RemoteDate: date, RemoteSize: 100, RemoteETag: "abc"}
+
+		assert.False(t, m.RemoteChanged(fs.FileInfo{Date: date.Add(time.Hour), Size: 200, ETag: "abc"}))
+		assert.True(t, m.RemoteChanged(fs.FileInfo{Date: date, Size: 100, ETag: "def"}))
+	})
+	t.Run("date and size", func(t *testing.T) {
+		m := FileSync{RemoteDate: date, RemoteSize: 100}
+
+		assert.False(t, m.RemoteChanged(fs.FileInfo{Date: date.Add(time.Millisecond), Size: 100, ETag: "abc"}))
+		assert.True(t, m.RemoteChanged(fs.FileInfo{Date: date.Add(time.Minute), Size: 100}))
+		assert.True(t, m.RemoteChanged(fs.FileInfo{Date: date, Size: 200}))
+	})
+}
+
+func TestFileSync_LocalChanged(t *testing.T) {
+	assert.False(t, (&FileSync{}).LocalChanged(File{FileHash: "abc"}))
+	assert.False(t, (&FileSync{LocalHash: "abc"}).LocalChanged(File{FileHash: "abc"}))
+	assert.True(t, (&FileSync{LocalHash: "abc"}).LocalChanged(File{FileHash: "def"}))
+}
+
+func TestFileSync_Delete(t *testing.T) {
+	m := FirstOrCreateFileSync(&FileSync{AccountID: 123, FileID: 889, RemoteName: "delete.jpg"})
+
+	if m == nil {
+		t.Fatal("result should not be nil")
+	}
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	Db().Model(&FileSync{}).Where("account_id = ? AND remote_name = ?", 123, "delete.jpg").Count(&count)
+
+	assert.Equal(t, 0, count)
+}
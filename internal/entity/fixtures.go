@@ -24,4 +24,8 @@ func CreateTestFixtures() {
 	CreateFileShareFixtures()
 	CreateFileSyncFixtures()
 	CreateLensFixtures()
+	CreatePersonFixtures()
+	CreateFaceFixtures()
+	CreateUserFixtures()
+	CreateUserShareFixtures()
 }
@@ -1,7 +1,10 @@
 package entity
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gosimple/slug"
@@ -25,6 +28,8 @@ type Link struct {
 	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
 	CanComment  bool      `json:"CanComment" yaml:"CanComment,omitempty"`
 	CanEdit     bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
+	CanDownload bool      `json:"CanDownload" yaml:"CanDownload,omitempty"`
+	CanUpload   bool      `json:"CanUpload" yaml:"CanUpload,omitempty"`
 	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
 	ModifiedAt  time.Time `deepcopier:"skip" yaml:"ModifiedAt"`
 }
@@ -110,6 +115,24 @@ func (m *Link) InvalidPassword(password string) bool {
 	return pw.InvalidPassword(password)
 }
 
+// DownloadToken returns the token for downloading shared files. If the link is password protected,
+// a hash of the password is appended so that the share token alone can't be used for downloads.
+func (m *Link) DownloadToken() string {
+	if !m.HasPassword {
+		return m.LinkToken
+	}
+
+	pw := FindPassword(m.LinkUID)
+
+	if pw == nil {
+		return m.LinkToken
+	}
+
+	hash := sha1.Sum([]byte(m.LinkUID + pw.Hash))
+
+	return m.LinkToken + "-" + hex.EncodeToString(hash[:])[:16]
+}
+
 // Save inserts a new row to the database or updates a row if the primary key already exists.
 func (m *Link) Save() error {
 	if !rnd.IsPPID(m.ShareUID, 0) {
@@ -131,9 +154,52 @@ func (m *Link) Delete() error {
 		return fmt.Errorf("link: empty share token")
 	}
 
+	if m.Selection() {
+		if err := UnscopedDb().Where("link_uid = ?", m.LinkUID).Delete(&LinkPhoto{}).Error; err != nil {
+			return err
+		}
+	}
+
 	return Db().Delete(m).Error
 }
 
+// Selection tests if the link shares a selection of photos instead of a single album, label, folder or photo.
+func (m *Link) Selection() bool {
+	return m.ShareUID != "" && m.ShareUID == m.LinkUID
+}
+
+// SetPhotos replaces the photos shared by a selection link.
+func (m *Link) SetPhotos(photoUIDs []string) error {
+	if !m.Selection() {
+		return fmt.Errorf("link: %s does not share a selection", m.LinkUID)
+	}
+
+	if err := UnscopedDb().Where("link_uid = ?", m.LinkUID).Delete(&LinkPhoto{}).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range photoUIDs {
+		if err := UnscopedDb().Create(&LinkPhoto{LinkUID: m.LinkUID, PhotoUID: uid}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PhotoUIDs returns the UIDs of the photos shared by a selection link.
+func (m *Link) PhotoUIDs() (result []string) {
+	if !m.Selection() {
+		return result
+	}
+
+	if err := UnscopedDb().Model(&LinkPhoto{}).Where("link_uid = ?", m.LinkUID).Pluck("photo_uid", &result).Error; err != nil {
+		log.Errorf("link: %s (find photos)", err)
+	}
+
+	return result
+}
+
 // FindLink returns an entity pointer if exists.
 func FindLink(linkUID string) *Link {
 	result := Link{}
@@ -161,8 +227,8 @@ func FindLinks(token, share string) (result Links) {
 	}
 
 	if share != "" {
-		if rnd.IsPPID(share, 'a') {
-			q = q.Where("share_uid = ?", share)
+		if rnd.IsPPID(share, 0) {
+			q = q.Where("share_uid = ? OR share_slug = ?", share, share)
 		} else {
 			q = q.Where("share_slug = ?", share)
 		}
@@ -186,6 +252,23 @@ func FindValidLinks(token, share string) (result Links) {
 	return result
 }
 
+// FindDownloadLinks returns a slice of non-expired links that allow downloads with the given download token.
+func FindDownloadLinks(downloadToken string) (result Links) {
+	if downloadToken == "" {
+		return result
+	}
+
+	token := strings.SplitN(downloadToken, "-", 2)[0]
+
+	for _, link := range FindValidLinks(token, "") {
+		if link.CanDownload && link.DownloadToken() == downloadToken {
+			result = append(result, link)
+		}
+	}
+
+	return result
+}
+
 // String returns an human readable identifier for logging.
 func (m *Link) String() string {
 	return m.LinkUID
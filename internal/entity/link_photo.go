@@ -0,0 +1,12 @@
+package entity
+
+// LinkPhoto represents a photo that is shared by a selection link.
+type LinkPhoto struct {
+	LinkUID  string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false"`
+	PhotoUID string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index"`
+}
+
+// TableName returns the entity database table name.
+func (LinkPhoto) TableName() string {
+	return "links_photos"
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -144,6 +145,104 @@ func TestLink_Delete(t *testing.T) {
 	})
 }
 
+func TestLink_Selection(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba7", false, false)
+		assert.False(t, link.Selection())
+	})
+	t.Run("selection", func(t *testing.T) {
+		link := NewLink("", false, false)
+		link.ShareUID = link.LinkUID
+		assert.True(t, link.Selection())
+	})
+}
+
+func TestLink_SetPhotos(t *testing.T) {
+	t.Run("selection", func(t *testing.T) {
+		link := NewLink("", false, false)
+		link.ShareUID = link.LinkUID
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := link.SetPhotos([]string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, link.PhotoUIDs())
+
+		if err := link.SetPhotos([]string{"pt9jtdre2lvl0yh9"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9"}, link.PhotoUIDs())
+
+		if err := link.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, link.PhotoUIDs())
+	})
+	t.Run("album", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba7", false, false)
+		assert.Error(t, link.SetPhotos([]string{"pt9jtdre2lvl0yh7"}))
+		assert.Empty(t, link.PhotoUIDs())
+	})
+}
+
+func TestLink_DownloadToken(t *testing.T) {
+	t.Run("no password", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba7", false, false)
+		assert.Equal(t, link.LinkToken, link.DownloadToken())
+	})
+	t.Run("password", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba7", false, false)
+
+		if err := link.SetPassword("foobar"); err != nil {
+			t.Fatal(err)
+		}
+
+		token := link.DownloadToken()
+
+		assert.True(t, strings.HasPrefix(token, link.LinkToken+"-"))
+		assert.Len(t, token, len(link.LinkToken)+17)
+		assert.Equal(t, token, link.DownloadToken())
+	})
+}
+
+func TestFindDownloadLinks(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba7", false, false)
+	link.CanDownload = true
+
+	if err := link.SetPassword("foobar"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("download token", func(t *testing.T) {
+		r := FindDownloadLinks(link.DownloadToken())
+
+		if assert.Len(t, r, 1) {
+			assert.Equal(t, link.LinkUID, r[0].LinkUID)
+		}
+	})
+	t.Run("share token", func(t *testing.T) {
+		assert.Empty(t, FindDownloadLinks(link.LinkToken))
+	})
+	t.Run("no download", func(t *testing.T) {
+		assert.Empty(t, FindDownloadLinks("1jxf3jfn2k"))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, FindDownloadLinks(""))
+	})
+}
+
 func TestFindLink(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		m := NewLink("lhfjfjhffgtrjoft", false, false)
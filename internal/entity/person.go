@@ -0,0 +1,325 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type People []Person
+
+// peopleIndex caches the face embeddings of people by UID for matching, it is guarded by mutex.People
+// and loaded on demand.
+var peopleIndex map[string]face.Embedding
+
+// Person represents a cluster of faces that belong to the same person, named or unnamed.
+type Person struct {
+	ID         uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	PersonUID  string     `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	PersonSlug string     `gorm:"type:VARBINARY(255);index;" json:"Slug" yaml:"-"`
+	PersonName string     `gorm:"type:VARCHAR(255);" json:"Name" yaml:"Name,omitempty"`
+	PersonSrc  string     `gorm:"type:VARBINARY(8);" json:"Src" yaml:"Src,omitempty"`
+	FaceCount  int        `json:"FaceCount" yaml:"-"`
+	PhotoCount int        `json:"PhotoCount" yaml:"-"`
+	Embedding  string     `gorm:"type:TEXT;" json:"-" yaml:"-"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt  *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Person) TableName() string {
+	return "people"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Person) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.PersonUID, 'r') {
+		return nil
+	}
+
+	return scope.SetColumn("PersonUID", rnd.PPID('r'))
+}
+
+// NewPerson returns a new unnamed person with an initial face embedding.
+func NewPerson(embedding face.Embedding) *Person {
+	result := &Person{
+		PersonSrc: SrcImage,
+		FaceCount: 1,
+		Embedding: embedding.JSON(),
+	}
+
+	return result
+}
+
+// Create inserts the person to the database.
+func (m *Person) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new person.
+func (m *Person) Save() error {
+	return Db().Save(m).Error
+}
+
+// Update a person property in the database.
+func (m *Person) Update(attr string, value interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
+}
+
+// Updates multiple person properties in the database.
+func (m *Person) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Delete removes the person and unassigns its faces.
+func (m *Person) Delete() error {
+	mutex.People.Lock()
+	defer mutex.People.Unlock()
+
+	return m.delete()
+}
+
+// delete removes the person and unassigns its faces, the caller must hold mutex.People.
+func (m *Person) delete() error {
+	if err := Db().Model(&Face{}).Where("person_uid = ?", m.PersonUID).UpdateColumn("person_uid", "").Error; err != nil {
+		return err
+	}
+
+	if peopleIndex != nil {
+		delete(peopleIndex, m.PersonUID)
+	}
+
+	return Db().Delete(m).Error
+}
+
+// HasName tests if the person has been named.
+func (m *Person) HasName() bool {
+	return m.PersonName != ""
+}
+
+// SetName changes the person name and slug.
+func (m *Person) SetName(name string) {
+	name = txt.Clip(name, txt.ClipDefault)
+
+	if name == "" {
+		m.PersonName = ""
+		m.PersonSlug = ""
+		return
+	}
+
+	m.PersonName = txt.Title(name)
+	m.PersonSlug = slug.Make(txt.Clip(name, txt.ClipSlug))
+	m.PersonSrc = SrcManual
+}
+
+// GetEmbedding returns the mean face embedding of the person.
+func (m *Person) GetEmbedding() face.Embedding {
+	return face.UnmarshalEmbedding(m.Embedding)
+}
+
+// AddFace adds a face embedding to the person cluster, the caller must hold mutex.People.
+func (m *Person) AddFace(embedding face.Embedding) error {
+	m.Embedding = m.GetEmbedding().Merge(embedding, m.FaceCount).Normalize().JSON()
+	m.FaceCount++
+
+	m.index()
+
+	return m.Updates(map[string]interface{}{"Embedding": m.Embedding, "FaceCount": m.FaceCount})
+}
+
+// UpdateEmbedding recomputes the mean face embedding and the face count from the faces assigned to the person,
+// the caller must hold mutex.People. The embedding is kept if no faces are left, so that they are matched again
+// when a file is indexed again.
+func (m *Person) UpdateEmbedding() error {
+	rows, err := Db().Model(&Face{}).Where("person_uid = ? AND embedding <> ''", m.PersonUID).Select("embedding").Rows()
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var sum face.Embedding
+	count := 0
+
+	for rows.Next() {
+		var s string
+
+		if err := rows.Scan(&s); err != nil {
+			return err
+		}
+
+		sum = sum.Combine(face.UnmarshalEmbedding(s), count, 1)
+		count++
+	}
+
+	if count > 0 {
+		m.Embedding = sum.Normalize().JSON()
+	}
+
+	m.FaceCount = count
+	m.index()
+
+	return m.Updates(map[string]interface{}{"Embedding": m.Embedding, "FaceCount": m.FaceCount})
+}
+
+// Merge moves the faces of another person to this person and removes the other person,
+// e.g. after both were given the same name.
+func (m *Person) Merge(other *Person) error {
+	if other == nil || other.PersonUID == m.PersonUID {
+		return nil
+	}
+
+	mutex.People.Lock()
+	defer mutex.People.Unlock()
+
+	if err := Db().Model(&Face{}).Where("person_uid = ?", other.PersonUID).UpdateColumn("person_uid", m.PersonUID).Error; err != nil {
+		return err
+	}
+
+	m.Embedding = m.GetEmbedding().Combine(other.GetEmbedding(), m.FaceCount, other.FaceCount).Normalize().JSON()
+	m.index()
+
+	if err := m.Update("Embedding", m.Embedding); err != nil {
+		return err
+	}
+
+	if err := other.delete(); err != nil {
+		return err
+	}
+
+	return m.UpdateCounts()
+}
+
+// UpdateCounts updates the face and photo counts of the person.
+func (m *Person) UpdateCounts() error {
+	if err := Db().Model(&Face{}).Where("person_uid = ?", m.PersonUID).Count(&m.FaceCount).Error; err != nil {
+		return err
+	}
+
+	if err := UnscopedDb().Table("faces f").
+		Joins("JOIN photos p ON p.id = f.photo_id").
+		Where("f.person_uid = ? AND p.photo_quality >= 0 AND p.photo_private = FALSE AND p.deleted_at IS NULL", m.PersonUID).
+		Select("COUNT(DISTINCT f.photo_id)").Row().Scan(&m.PhotoCount); err != nil {
+		return err
+	}
+
+	return m.Updates(map[string]interface{}{"FaceCount": m.FaceCount, "PhotoCount": m.PhotoCount})
+}
+
+// index updates the cached embedding of the person, the caller must hold mutex.People.
+func (m *Person) index() {
+	if peopleIndex == nil {
+		return
+	} else if m.Embedding == "" {
+		delete(peopleIndex, m.PersonUID)
+	} else {
+		peopleIndex[m.PersonUID] = m.GetEmbedding()
+	}
+}
+
+// UpdatePeople updates the face and photo counts of people, e.g. after their faces changed,
+// and removes unnamed people without faces.
+func UpdatePeople(personUIDs []string) error {
+	for _, uid := range personUIDs {
+		m := Person{}
+
+		if err := Db().Where("person_uid = ?", uid).First(&m).Error; err != nil {
+			continue
+		}
+
+		if err := m.UpdateCounts(); err != nil {
+			return err
+		}
+
+		if m.FaceCount == 0 && !m.HasName() {
+			if err := m.Delete(); err != nil {
+				return err
+			}
+
+			event.EntitiesDeleted("people", []string{m.PersonUID})
+		}
+	}
+
+	return nil
+}
+
+// FindPerson returns an existing person by UID or slug.
+func FindPerson(s string) *Person {
+	result := Person{}
+
+	if s == "" {
+		return nil
+	}
+
+	if err := Db().Where("person_uid = ? OR person_slug = ?", s, slug.Make(s)).First(&result).Error; err == nil {
+		return &result
+	}
+
+	return nil
+}
+
+// MatchPerson returns the person with the closest matching face embedding
+// and creates a new unnamed person if none is close enough.
+func MatchPerson(embedding face.Embedding) (*Person, error) {
+	mutex.People.Lock()
+	defer mutex.People.Unlock()
+
+	// Embeddings are cached, so that people don't have to be loaded for every face.
+	if peopleIndex == nil {
+		var people People
+
+		if err := Db().Select("person_uid, embedding").Where("embedding <> ''").Find(&people).Error; err != nil {
+			return nil, err
+		}
+
+		peopleIndex = make(map[string]face.Embedding, len(people))
+
+		for _, p := range people {
+			peopleIndex[p.PersonUID] = p.GetEmbedding()
+		}
+	}
+
+	matchUID := ""
+	dist := face.ClusterDist
+
+	for uid, e := range peopleIndex {
+		if d := e.Dist(embedding); d < dist {
+			dist = d
+			matchUID = uid
+		}
+	}
+
+	if matchUID != "" {
+		match := &Person{}
+
+		if err := Db().Where("person_uid = ?", matchUID).First(match).Error; err == nil {
+			return match, match.AddFace(embedding)
+		}
+
+		delete(peopleIndex, matchUID)
+	}
+
+	m := NewPerson(embedding)
+
+	if err := m.Create(); err != nil {
+		return nil, err
+	}
+
+	m.index()
+
+	event.EntitiesCreated("people", []*Person{m})
+
+	event.Publish("count.people", event.Data{
+		"count": 1,
+	})
+
+	return m, nil
+}
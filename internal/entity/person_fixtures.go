@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+)
+
+type PersonMap map[string]Person
+
+func (m PersonMap) Get(name string) Person {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Person{}
+}
+
+func (m PersonMap) Pointer(name string) *Person {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Person{}
+}
+
+var PersonFixtures = PersonMap{
+	"jane-doe": {
+		ID:         1000000,
+		PersonUID:  "rt9lxuqxkkvd1000",
+		PersonSlug: "jane-doe",
+		PersonName: "Jane Doe",
+		PersonSrc:  SrcManual,
+		FaceCount:  1,
+		PhotoCount: 1,
+		Embedding:  "[0.6,0.8,0]",
+		CreatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+	"unnamed": {
+		ID:         1000001,
+		PersonUID:  "rt9lxuqxkkvd1001",
+		PersonSlug: "",
+		PersonName: "",
+		PersonSrc:  SrcImage,
+		FaceCount:  1,
+		PhotoCount: 1,
+		Embedding:  "[0,0,1]",
+		CreatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+}
+
+// CreatePersonFixtures inserts known entities into the database for testing.
+func CreatePersonFixtures() {
+	for _, entity := range PersonFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPerson(t *testing.T) {
+	m := NewPerson(face.Embedding{1, 0, 0})
+
+	assert.Equal(t, "", m.PersonName)
+	assert.Equal(t, 1, m.FaceCount)
+	assert.Equal(t, face.Embedding{1, 0, 0}, m.GetEmbedding())
+	assert.False(t, m.HasName())
+}
+
+func TestPerson_SetName(t *testing.T) {
+	t.Run("jane", func(t *testing.T) {
+		m := PersonFixtures.Get("unnamed")
+		m.SetName("jane smith")
+
+		assert.Equal(t, "Jane Smith", m.PersonName)
+		assert.Equal(t, "jane-smith", m.PersonSlug)
+		assert.Equal(t, SrcManual, m.PersonSrc)
+		assert.True(t, m.HasName())
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := PersonFixtures.Get("jane-doe")
+		m.SetName("")
+
+		assert.Equal(t, "", m.PersonName)
+		assert.Equal(t, "", m.PersonSlug)
+	})
+}
+
+func TestFindPerson(t *testing.T) {
+	t.Run("slug", func(t *testing.T) {
+		m := FindPerson("Jane Doe")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "rt9lxuqxkkvd1000", m.PersonUID)
+	})
+	t.Run("uid", func(t *testing.T) {
+		m := FindPerson("rt9lxuqxkkvd1001")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "", m.PersonName)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindPerson("xxx"))
+		assert.Nil(t, FindPerson(""))
+	})
+}
+
+func TestMatchPerson(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		m, err := MatchPerson(face.Embedding{0.6, 0.75, 0.1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "rt9lxuqxkkvd1000", m.PersonUID)
+		assert.Equal(t, 2, m.FaceCount)
+	})
+	t.Run("new", func(t *testing.T) {
+		m, err := MatchPerson(face.Embedding{-1, 0, 0})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, "rt9lxuqxkkvd1000", m.PersonUID)
+		assert.NotEqual(t, "rt9lxuqxkkvd1001", m.PersonUID)
+		assert.Equal(t, 1, m.FaceCount)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPerson_UpdateEmbedding(t *testing.T) {
+	file := FileFixtures["bridge2.jpg"]
+	m := NewPerson(face.Embedding{1, 0})
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	for _, e := range []face.Embedding{{1, 0}, {0, 1}} {
+		f := NewFace(file, face.Face{Embedding: e}, SrcImage)
+		f.PersonUID = m.PersonUID
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := m.UpdateEmbedding(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, m.FaceCount)
+	assert.InDelta(t, 0.7071, m.GetEmbedding()[0], 0.001)
+	assert.InDelta(t, 0.7071, m.GetEmbedding()[1], 0.001)
+
+	if _, err := DeleteFileFaces(file.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if found := FindPerson(m.PersonUID); found == nil {
+		t.Fatal("person should not be nil")
+	} else {
+		assert.Equal(t, 0, found.FaceCount)
+		assert.Equal(t, m.Embedding, found.Embedding)
+	}
+}
+
+func TestPerson_Merge(t *testing.T) {
+	file := FileFixtures["bridge3.jpg"]
+	a := NewPerson(face.Embedding{1, 0})
+	b := NewPerson(face.Embedding{0, 1})
+
+	for _, p := range []*Person{a, b} {
+		if err := p.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		f := NewFace(file, face.Face{Embedding: p.GetEmbedding()}, SrcImage)
+		f.PersonUID = p.PersonUID
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer DeleteFileFaces(file.ID)
+	defer a.Delete()
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, a.FaceCount)
+	assert.InDelta(t, a.GetEmbedding()[0], a.GetEmbedding()[1], 0.001)
+	assert.Nil(t, FindPerson(b.PersonUID))
+
+	var count int
+
+	Db().Model(&Face{}).Where("person_uid = ?", a.PersonUID).Count(&count)
+
+	assert.Equal(t, 2, count)
+}
+
+func TestPerson_UpdateCounts(t *testing.T) {
+	m := PersonFixtures.Get("jane-doe")
+
+	if err := m.UpdateCounts(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, m.FaceCount)
+	assert.Equal(t, 1, m.PhotoCount)
+}
+
+func TestUpdatePeople(t *testing.T) {
+	t.Run("unnamed without faces", func(t *testing.T) {
+		m := NewPerson(face.Embedding{1, 0})
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := UpdatePeople([]string{m.PersonUID}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindPerson(m.PersonUID))
+	})
+	t.Run("named without faces", func(t *testing.T) {
+		m := NewPerson(face.Embedding{1, 0})
+		m.SetName("Max Mustermann")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		if err := UpdatePeople([]string{m.PersonUID}); err != nil {
+			t.Fatal(err)
+		}
+
+		if found := FindPerson(m.PersonUID); found == nil {
+			t.Fatal("person should not be nil")
+		} else {
+			assert.Equal(t, 0, found.FaceCount)
+		}
+	})
+}
@@ -89,6 +89,7 @@ type Photo struct {
 	Albums           []Album      `json:"-" yaml:"-"`
 	Files            []File       `yaml:"-"`
 	Labels           []PhotoLabel `yaml:"-"`
+	Faces            []Face       `gorm:"-" json:"Markers,omitempty" yaml:"-"`
 	CreatedAt        time.Time    `yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time    `yaml:"UpdatedAt,omitempty"`
 	EditedAt         *time.Time   `yaml:"EditedAt,omitempty"`
@@ -160,6 +161,10 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 		log.Errorf("photo: %s", err.Error())
 	}
 
+	if err := model.IndexTerms(); err != nil {
+		log.Errorf("photo: %s", err.Error())
+	}
+
 	edited := Timestamp()
 	model.EditedAt = &edited
 	model.PhotoQuality = model.QualityScore()
@@ -286,6 +291,10 @@ func (m *Photo) SaveLabels() error {
 		log.Errorf("photo: %s", err.Error())
 	}
 
+	if err := m.IndexTerms(); err != nil {
+		log.Errorf("photo: %s", err.Error())
+	}
+
 	m.PhotoQuality = m.QualityScore()
 
 	if err := m.Save(); err != nil {
@@ -466,12 +475,23 @@ func (m *Photo) PreloadAlbums() {
 	logError(q.Scan(&m.Albums))
 }
 
-// PreloadMany prepares gorm scope to retrieve photo file, albums and keywords
+// PreloadFaces prepares gorm scope to retrieve face markers and the people they belong to
+func (m *Photo) PreloadFaces() {
+	q := Db().
+		Preload("Person").
+		Where("photo_id = ?", m.ID).
+		Order("face_x ASC")
+
+	logError(q.Find(&m.Faces))
+}
+
+// PreloadMany prepares gorm scope to retrieve photo file, albums, keywords and faces
 func (m *Photo) PreloadMany() {
 	m.PreloadFiles()
 	// m.PreloadLabels()
 	m.PreloadKeywords()
 	m.PreloadAlbums()
+	m.PreloadFaces()
 }
 
 // HasID checks if the photo has a database id and uid.
@@ -890,7 +910,7 @@ func (m *Photo) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND b.file_missing = 0", m.ID).
+		Where("photo_id = ? AND b.file_missing = FALSE", m.ID).
 		Count(&count).Error; err != nil {
 		log.Error(err)
 	}
@@ -918,11 +938,22 @@ func (m *Photo) Delete(permanently bool) error {
 	return m.Updates(map[string]interface{}{"DeletedAt": Timestamp(), "PhotoQuality": -1})
 }
 
+// Archive removes the photo from search results and hides it in albums.
+func (m *Photo) Archive() error {
+	if err := Db().Delete(m).Error; err != nil {
+		return err
+	}
+
+	return Db().Model(&PhotoAlbum{}).Where("photo_uid = ?", m.PhotoUID).UpdateColumn("hidden", true).Error
+}
+
 // Delete permanently deletes the entity from the database.
 func (m *Photo) DeletePermanently() error {
 	Db().Unscoped().Delete(File{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(Details{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoKeyword{}, "photo_id = ?", m.ID)
+	Db().Unscoped().Delete(PhotoTerm{}, "photo_id = ?", m.ID)
+	Db().Unscoped().Delete(PhotoEdit{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoLabel{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID)
 
@@ -1019,19 +1050,19 @@ func (m *Photo) Stack(stackMeta, stackUuid bool) (identical Photos, err error) {
 
 	switch {
 	case stackMeta && stackUuid && m.HasLocation() && m.HasLatLng() && m.TakenSrc == SrcMeta && rnd.IsUUID(m.UUID):
-		if err := Db().Where("id > ? AND photo_single = 0", m.ID).
+		if err := Db().Where("id > ? AND photo_single = FALSE", m.ID).
 			Where("(taken_at = ? AND taken_src = 'meta' AND cell_id = ? AND camera_serial = ? AND camera_id = ?) OR (uuid <> '' AND uuid = ?)",
 				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID, m.UUID).Find(&identical).Error; err != nil {
 			return identical, err
 		}
 	case stackMeta && m.HasLocation() && m.HasLatLng() && m.TakenSrc == SrcMeta:
-		if err := Db().Where("id > ? AND photo_single = 0", m.ID).
+		if err := Db().Where("id > ? AND photo_single = FALSE", m.ID).
 			Where("taken_at = ? AND taken_src = 'meta' AND cell_id = ? AND camera_serial = ? AND camera_id = ?",
 				m.TakenAt, m.CellID, m.CameraSerial, m.CameraID).Error; err != nil {
 			return identical, err
 		}
 	case stackUuid && rnd.IsUUID(m.UUID):
-		if err := Db().Where("id > ? AND photo_single = 0", m.ID).
+		if err := Db().Where("id > ? AND photo_single = FALSE", m.ID).
 			Where("uuid <> '' AND uuid = ?", m.UUID).Error; err != nil {
 			return identical, err
 		}
@@ -1057,6 +1088,10 @@ func (m *Photo) Stack(stackMeta, stackUuid bool) (identical Photos, err error) {
 			UnscopedDb().Exec("UPDATE OR IGNORE `photos_keywords` SET `photo_id` = ? WHERE (photo_id = ?)", m.ID, photo.ID)
 			UnscopedDb().Exec("UPDATE OR IGNORE `photos_labels` SET `photo_id` = ? WHERE (photo_id = ?)", m.ID, photo.ID)
 			UnscopedDb().Exec("UPDATE OR IGNORE `photos_albums` SET `photo_uid` = ? WHERE (photo_uid = ?)", m.PhotoUID, photo.PhotoUID)
+		case Postgres:
+			UnscopedDb().Exec("UPDATE photos_keywords SET photo_id = ? WHERE photo_id = ? AND keyword_id NOT IN (SELECT keyword_id FROM photos_keywords WHERE photo_id = ?)", m.ID, photo.ID, m.ID)
+			UnscopedDb().Exec("UPDATE photos_labels SET photo_id = ? WHERE photo_id = ? AND label_id NOT IN (SELECT label_id FROM photos_labels WHERE photo_id = ?)", m.ID, photo.ID, m.ID)
+			UnscopedDb().Exec("UPDATE photos_albums SET photo_uid = ? WHERE photo_uid = ? AND album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", m.PhotoUID, photo.PhotoUID, m.PhotoUID)
 		default:
 			log.Warnf("photo: unknown SQL dialect (stack)")
 		}
@@ -23,7 +23,7 @@ func LabelCounts() LabelPhotoCounts {
 		JOIN photos ph ON pl.photo_id = ph.id
 		WHERE pl.uncertainty < 100
 		AND ph.photo_quality >= 0
-		AND ph.photo_private = 0
+		AND ph.photo_private = FALSE
 		AND ph.deleted_at IS NULL GROUP BY l.id
 		UNION ALL
 		SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -32,7 +32,7 @@ func LabelCounts() LabelPhotoCounts {
 		JOIN photos ph ON pl.photo_id = ph.id
 		WHERE pl.uncertainty < 100
 		AND ph.photo_quality >= 0
-		AND ph.photo_private = 0
+		AND ph.photo_private = FALSE
 		AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id
 		`).Scan(&result).Error; err != nil {
 		log.Errorf("label-count: %s", err.Error())
@@ -49,7 +49,17 @@ func UpdatePhotoCounts() error {
 		UpdateColumn("photo_count", gorm.Expr("(SELECT COUNT(*) FROM photos p "+
 			"WHERE places.id = p.place_id "+
 			"AND p.photo_quality >= 0 "+
-			"AND p.photo_private = 0 "+
+			"AND p.photo_private = FALSE "+
+			"AND p.deleted_at IS NULL)")).Error; err != nil {
+		return err
+	}
+
+	if err := Db().Table("people").
+		UpdateColumn("photo_count", gorm.Expr("(SELECT COUNT(DISTINCT f.photo_id) FROM faces f "+
+			"JOIN photos p ON p.id = f.photo_id "+
+			"WHERE people.person_uid = f.person_uid "+
+			"AND p.photo_quality >= 0 "+
+			"AND p.photo_private = FALSE "+
 			"AND p.deleted_at IS NULL)")).Error; err != nil {
 		return err
 	}
@@ -63,7 +73,7 @@ func UpdatePhotoCounts() error {
 	            JOIN photos ph ON pl.photo_id = ph.id
 				WHERE pl.uncertainty < 100
 				AND ph.photo_quality >= 0
-				AND ph.photo_private = 0
+				AND ph.photo_private = FALSE
 				AND ph.deleted_at IS NULL GROUP BY l.id)
 	UNION ALL
 	(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -72,7 +82,7 @@ func UpdatePhotoCounts() error {
 	            JOIN photos ph ON pl.photo_id = ph.id
 				WHERE pl.uncertainty < 100
 				AND ph.photo_quality >= 0
-				AND ph.photo_private = 0
+				AND ph.photo_private = FALSE
 				AND ph.deleted_at IS NULL GROUP BY l.id)) counts GROUP BY label_id
 	*/
 
@@ -96,7 +106,7 @@ func UpdatePhotoCounts() error {
 			            JOIN photos ph ON pl.photo_id = ph.id
 						WHERE pl.uncertainty < 100
 						AND ph.photo_quality >= 0
-						AND ph.photo_private = 0
+						AND ph.photo_private = FALSE
 						AND ph.deleted_at IS NULL GROUP BY l.id)
 			UNION ALL
 			(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -105,12 +115,12 @@ func UpdatePhotoCounts() error {
 			            JOIN photos ph ON pl.photo_id = ph.id
 						WHERE pl.uncertainty < 100
 						AND ph.photo_quality >= 0
-						AND ph.photo_private = 0
+						AND ph.photo_private = FALSE
 						AND ph.deleted_at IS NULL GROUP BY l.id)) counts GROUP BY label_id
 			) label_counts WHERE label_id = labels.id)`)).Error; err != nil {
 			return err
 		}
-	} else if IsDialect(SQLite) {
+	} else if IsDialect(SQLite) || IsDialect(Postgres) {
 		if err := Db().
 			Table("labels").
 			UpdateColumn("photo_count",
@@ -120,7 +130,7 @@ func UpdatePhotoCounts() error {
 					JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality >= 0
-					AND ph.photo_private = 0
+					AND ph.photo_private = FALSE
 					AND ph.deleted_at IS NULL GROUP BY l.id
 					UNION ALL
 					SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -129,7 +139,7 @@ func UpdatePhotoCounts() error {
 					JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality >= 0
-					AND ph.photo_private = 0
+					AND ph.photo_private = FALSE
 					AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id) label_counts WHERE label_id = labels.id)`)).Error; err != nil {
 			return err
 		}
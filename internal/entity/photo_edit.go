@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// PhotoEdit stores non-destructive adjustments that are applied when rendering thumbnails, originals stay untouched.
+type PhotoEdit struct {
+	PhotoID     uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	CropX       float32   `gorm:"type:FLOAT;" json:"CropX" yaml:"CropX,omitempty"`
+	CropY       float32   `gorm:"type:FLOAT;" json:"CropY" yaml:"CropY,omitempty"`
+	CropW       float32   `gorm:"type:FLOAT;" json:"CropW" yaml:"CropW,omitempty"`
+	CropH       float32   `gorm:"type:FLOAT;" json:"CropH" yaml:"CropH,omitempty"`
+	Rotate      int       `json:"Rotate" yaml:"Rotate,omitempty"`
+	Angle       float32   `gorm:"type:FLOAT;" json:"Angle" yaml:"Angle,omitempty"`
+	Exposure    float32   `gorm:"type:FLOAT;" json:"Exposure" yaml:"Exposure,omitempty"`
+	Contrast    float32   `gorm:"type:FLOAT;" json:"Contrast" yaml:"Contrast,omitempty"`
+	Saturation  float32   `gorm:"type:FLOAT;" json:"Saturation" yaml:"Saturation,omitempty"`
+	Temperature float32   `gorm:"type:FLOAT;" json:"Temperature" yaml:"Temperature,omitempty"`
+	Tint        float32   `gorm:"type:FLOAT;" json:"Tint" yaml:"Tint,omitempty"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEdit) TableName() string {
+	return "photos_edits"
+}
+
+// Save updates the existing photo edit or inserts a new row.
+func (m *PhotoEdit) Save() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("edit: photo id must not be empty (save)")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete removes the photo edit.
+func (m *PhotoEdit) Delete() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("edit: photo id must not be empty (delete)")
+	}
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// NoChanges returns true if the edit does not change the photo.
+func (m *PhotoEdit) NoChanges() bool {
+	adjustments := PhotoEdit{
+		CropX:       m.CropX,
+		CropY:       m.CropY,
+		CropW:       m.CropW,
+		CropH:       m.CropH,
+		Rotate:      m.Rotate,
+		Angle:       m.Angle,
+		Exposure:    m.Exposure,
+		Contrast:    m.Contrast,
+		Saturation:  m.Saturation,
+		Temperature: m.Temperature,
+		Tint:        m.Tint,
+	}
+
+	return adjustments == PhotoEdit{} || adjustments == PhotoEdit{CropW: 1, CropH: 1}
+}
+
+// FindPhotoEdit returns the edit of a photo or nil if it wasn't edited.
+func FindPhotoEdit(photoID uint) *PhotoEdit {
+	result := PhotoEdit{}
+
+	if err := Db().Where("photo_id = ?", photoID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindPhotoEditByHash returns the edit of the photo a file belongs to or nil if it wasn't edited.
+func FindPhotoEditByHash(fileHash string) *PhotoEdit {
+	result := PhotoEdit{}
+
+	if fileHash == "" {
+		return nil
+	}
+
+	if err := Db().Where("photo_id IN (SELECT photo_id FROM files WHERE file_hash = ?)", fileHash).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// SavePhotoEdit saves the edit of a photo, or removes it if nothing is changed, and sets the photo edit time.
+func SavePhotoEdit(photo *Photo, f form.PhotoEdit) (*PhotoEdit, error) {
+	if !photo.HasID() {
+		return nil, fmt.Errorf("edit: photo id must not be empty (save)")
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	m := &PhotoEdit{
+		PhotoID:     photo.ID,
+		CropX:       f.CropX,
+		CropY:       f.CropY,
+		CropW:       f.CropW,
+		CropH:       f.CropH,
+		Rotate:      f.Rotate,
+		Angle:       f.Angle,
+		Exposure:    f.Exposure,
+		Contrast:    f.Contrast,
+		Saturation:  f.Saturation,
+		Temperature: f.Temperature,
+		Tint:        f.Tint,
+	}
+
+	if m.NoChanges() {
+		if err := m.Delete(); err != nil {
+			return nil, err
+		}
+	} else if existing := FindPhotoEdit(photo.ID); existing != nil {
+		m.CreatedAt = existing.CreatedAt
+
+		if err := m.Save(); err != nil {
+			return nil, err
+		}
+	} else if err := UnscopedDb().Create(m).Error; err != nil {
+		return nil, err
+	}
+
+	edited := Timestamp()
+	photo.EditedAt = &edited
+
+	if err := photo.Update("EditedAt", photo.EditedAt); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEdit_NoChanges(t *testing.T) {
+	assert.True(t, (&PhotoEdit{PhotoID: 1}).NoChanges())
+	assert.True(t, (&PhotoEdit{PhotoID: 1, CropW: 1, CropH: 1}).NoChanges())
+	assert.False(t, (&PhotoEdit{PhotoID: 1, Rotate: 90}).NoChanges())
+}
+
+func TestSavePhotoEdit(t *testing.T) {
+	t.Run("save and remove", func(t *testing.T) {
+		photo := PhotoFixtures.Pointer("19800101_000002_D640C559")
+		fileHash := FileFixtures["exampleFileName.jpg"].FileHash
+
+		m, err := SavePhotoEdit(photo, form.PhotoEdit{Rotate: -90, Exposure: 0.5})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 270, m.Rotate)
+		assert.NotNil(t, photo.EditedAt)
+
+		if found := FindPhotoEditByHash(fileHash); found == nil {
+			t.Fatal("edit should exist")
+		} else {
+			assert.Equal(t, float32(0.5), found.Exposure)
+		}
+
+		if _, err := SavePhotoEdit(photo, form.PhotoEdit{}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindPhotoEdit(photo.ID))
+		assert.Nil(t, FindPhotoEditByHash(fileHash))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		photo := PhotoFixtures.Pointer("19800101_000002_D640C559")
+
+		_, err := SavePhotoEdit(photo, form.PhotoEdit{Angle: 90})
+
+		assert.Error(t, err)
+	})
+	t.Run("no id", func(t *testing.T) {
+		_, err := SavePhotoEdit(&Photo{}, form.PhotoEdit{Rotate: 90})
+
+		assert.Error(t, err)
+	})
+}
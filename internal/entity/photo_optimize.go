@@ -61,6 +61,8 @@ func (m *Photo) EstimatePlace() {
 		dateExpr = "ABS(DATEDIFF(taken_at, ?)) ASC"
 	case SQLite:
 		dateExpr = "ABS(JulianDay(taken_at) - JulianDay(?)) ASC"
+	case Postgres:
+		dateExpr = "ABS(EXTRACT(EPOCH FROM taken_at - CAST(? AS TIMESTAMP))) ASC"
 	default:
 		log.Errorf("photo: unknown sql dialect %s", DbDialect())
 		return
@@ -126,6 +128,10 @@ func (m *Photo) Optimize(stackMeta, stackUuid bool) (updated bool, merged Photos
 		log.Errorf("photo: %s", err.Error())
 	}
 
+	if err := m.IndexTerms(); err != nil {
+		log.Errorf("photo: %s", err.Error())
+	}
+
 	m.PhotoQuality = m.QualityScore()
 
 	checked := Timestamp()
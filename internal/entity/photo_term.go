@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Full-text index fields.
+const (
+	TermTitle       uint8 = 1
+	TermDescription uint8 = 2
+	TermSubject     uint8 = 3
+	TermKeywords    uint8 = 4
+	TermNotes       uint8 = 5
+	TermAlbum       uint8 = 6
+	TermFilename    uint8 = 7
+)
+
+// TermWeights contains the relevance weight of each full-text index field.
+var TermWeights = map[uint8]int{
+	TermTitle:       8,
+	TermDescription: 4,
+	TermSubject:     4,
+	TermKeywords:    3,
+	TermNotes:       2,
+	TermAlbum:       2,
+	TermFilename:    1,
+}
+
+// TermsMax is the maximum number of terms indexed per field.
+const TermsMax = 512
+
+// termsGap separates the values of multi-value fields so that phrases don't match across them.
+const termsGap = 8
+
+// PhotoTerm represents a term occurrence in the full-text index.
+type PhotoTerm struct {
+	PhotoID   uint   `gorm:"primary_key;auto_increment:false"`
+	TermField uint8  `gorm:"primary_key;auto_increment:false"`
+	TermPos   uint16 `gorm:"primary_key;auto_increment:false"`
+	Term      string `gorm:"type:VARBINARY(160);index;"`
+}
+
+// TableName returns PhotoTerm table identifier "photos_terms"
+func (PhotoTerm) TableName() string {
+	return "photos_terms"
+}
+
+// PhotoTerms represents a list of full-text index entries.
+type PhotoTerms []PhotoTerm
+
+// Add appends the terms in a string to the list, starting at the given position.
+func (list PhotoTerms) Add(photoID uint, field uint8, s string, pos int) (PhotoTerms, int) {
+	next := pos
+
+	for _, t := range txt.Terms(s) {
+		if t.Pos+pos >= TermsMax {
+			break
+		}
+
+		next = t.Pos + pos + 1
+		list = append(list, PhotoTerm{PhotoID: photoID, TermField: field, TermPos: uint16(t.Pos + pos), Term: t.Word})
+	}
+
+	return list, next
+}
+
+// String returns the list as comparable string.
+func (list PhotoTerms) String() string {
+	s := make([]string, len(list))
+
+	for i, t := range list {
+		s[i] = fmt.Sprintf("%d:%d:%s", t.TermField, t.TermPos, t.Term)
+	}
+
+	sort.Strings(s)
+
+	return strings.Join(s, " ")
+}
+
+// Terms returns the full-text index entries for the photo.
+func (m *Photo) Terms() (result PhotoTerms) {
+	details := m.GetDetails()
+
+	result, _ = result.Add(m.ID, TermTitle, m.PhotoTitle, 0)
+	result, _ = result.Add(m.ID, TermDescription, m.PhotoDescription, 0)
+	result, _ = result.Add(m.ID, TermSubject, details.Subject, 0)
+	result, _ = result.Add(m.ID, TermKeywords, details.Keywords, 0)
+	result, _ = result.Add(m.ID, TermNotes, details.Notes, 0)
+
+	pos := 0
+
+	for _, name := range []string{m.PhotoName, m.OriginalName} {
+		if name != "" {
+			result, pos = result.Add(m.ID, TermFilename, name, pos+termsGap)
+		}
+	}
+
+	if m.PhotoUID == "" {
+		return result
+	}
+
+	var albums Albums
+
+	if err := UnscopedDb().Table("albums").
+		Select("albums.album_title, albums.album_caption").
+		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.hidden = FALSE AND pa.photo_uid = ?", m.PhotoUID).
+		Where("albums.deleted_at IS NULL").
+		Order("albums.id").
+		Scan(&albums).Error; err != nil {
+		log.Errorf("photo: %s (find albums)", err)
+	}
+
+	pos = 0
+
+	for _, a := range albums {
+		result, pos = result.Add(m.ID, TermAlbum, a.AlbumTitle, pos+termsGap)
+		result, pos = result.Add(m.ID, TermAlbum, a.AlbumCaption, pos+termsGap)
+	}
+
+	return result
+}
+
+// IndexTerms updates the full-text index for the photo.
+func (m *Photo) IndexTerms() error {
+	if !m.HasID() {
+		return fmt.Errorf("photo: can't index terms without id")
+	}
+
+	var existing PhotoTerms
+
+	if err := UnscopedDb().Where("photo_id = ?", m.ID).Find(&existing).Error; err != nil {
+		return err
+	}
+
+	terms := m.Terms()
+
+	if terms.String() == existing.String() {
+		return nil
+	}
+
+	return UnscopedDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(PhotoTerm{}, "photo_id = ?", m.ID).Error; err != nil {
+			return err
+		}
+
+		for i := 0; i < len(terms); i += 100 {
+			j := i + 100
+
+			if j > len(terms) {
+				j = len(terms)
+			}
+
+			values := make([]string, 0, j-i)
+			args := make([]interface{}, 0, (j-i)*4)
+
+			for _, t := range terms[i:j] {
+				values = append(values, "(?, ?, ?, ?)")
+				args = append(args, t.PhotoID, t.TermField, t.TermPos, t.Term)
+			}
+
+			if err := tx.Exec("INSERT INTO photos_terms (photo_id, term_field, term_pos, term) VALUES "+strings.Join(values, ", "), args...).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// IndexPhotoTerms updates the full-text index for the photos with the given UIDs.
+func IndexPhotoTerms(photoUIDs []string) {
+	for _, uid := range photoUIDs {
+		photo := Photo{}
+
+		if err := UnscopedDb().Where("photo_uid = ?", uid).Preload("Details").First(&photo).Error; err != nil {
+			log.Debugf("photo: %s (index terms of %s)", err, uid)
+		} else if err := photo.IndexTerms(); err != nil {
+			log.Errorf("photo: %s (index terms of %s)", err, uid)
+		}
+	}
+}
+
+// IndexAllPhotoTerms updates the full-text index of photos that have no index entries yet, e.g. because they were
+// indexed before full-text search was added, or of all photos if rebuild is true. Returns the number of photos.
+func IndexAllPhotoTerms(rebuild bool) (count int, err error) {
+	var lastID uint
+
+	for {
+		var photos Photos
+
+		stmt := UnscopedDb().Preload("Details").Where("photos.id > ?", lastID)
+
+		if !rebuild {
+			stmt = stmt.Where("NOT EXISTS (SELECT 1 FROM photos_terms t WHERE t.photo_id = photos.id)")
+		}
+
+		if err := stmt.Order("photos.id").Limit(1000).Find(&photos).Error; err != nil {
+			return count, err
+		}
+
+		if len(photos) == 0 {
+			return count, nil
+		}
+
+		for _, photo := range photos {
+			lastID = photo.ID
+
+			if err := photo.IndexTerms(); err != nil {
+				log.Errorf("photo: %s (index terms of %s)", err, photo.PhotoUID)
+			} else {
+				count++
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoTerms_Add(t *testing.T) {
+	var terms PhotoTerms
+
+	terms, next := terms.Add(1, TermTitle, "The Golden Gate Bridge", 0)
+
+	assert.Equal(t, 4, next)
+	assert.Len(t, terms, 3)
+	assert.Equal(t, "1:1:golden 1:2:gate 1:3:bridg", terms.String())
+}
+
+func TestPhoto_Terms(t *testing.T) {
+	m := &Photo{
+		ID:               1,
+		PhotoTitle:       "Black Beach",
+		PhotoDescription: "Waves at sunset",
+		PhotoName:        "20190101_beach",
+		OriginalName:     "IMG_4711",
+		Details:          &Details{Notes: "Taken with a borrowed camera", Subject: "Holidays"},
+	}
+
+	terms := m.Terms()
+
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermTitle, TermPos: 0, Term: "black"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermTitle, TermPos: 1, Term: "beach"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermDescription, TermPos: 0, Term: "wave"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermNotes, TermPos: 3, Term: "borrow"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermSubject, TermPos: 0, Term: "holidai"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermFilename, TermPos: 9, Term: "beach"})
+	assert.Contains(t, terms, PhotoTerm{PhotoID: 1, TermField: TermFilename, TermPos: 19, Term: "4711"})
+}
+
+func TestPhoto_IndexTerms(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo08")
+
+		if err := m.IndexTerms(); err != nil {
+			t.Fatal(err)
+		}
+
+		var terms PhotoTerms
+
+		if err := Db().Where("photo_id = ?", m.ID).Find(&terms).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.Terms().String(), terms.String())
+		assert.Contains(t, terms.String(), "1:1:beach")
+
+		m.PhotoTitle = "White Beach"
+
+		if err := m.IndexTerms(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Db().Where("photo_id = ? AND term = ?", m.ID, "white").Find(&terms).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, terms, 1)
+	})
+
+	t.Run("no id", func(t *testing.T) {
+		m := &Photo{PhotoTitle: "Black Beach"}
+
+		assert.Error(t, m.IndexTerms())
+	})
+}
+
+func TestIndexAllPhotoTerms(t *testing.T) {
+	m := PhotoFixtures.Get("Photo08")
+
+	if err := UnscopedDb().Delete(PhotoTerm{}, "photo_id = ?", m.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		count, err := IndexAllPhotoTerms(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, count, 1)
+
+		var terms PhotoTerms
+
+		if err := Db().Where("photo_id = ?", m.ID).Find(&terms).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, terms.String(), "1:1:beach")
+	})
+	t.Run("rebuild", func(t *testing.T) {
+		var photos int
+
+		if err := UnscopedDb().Model(Photo{}).Count(&photos).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := IndexAllPhotoTerms(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, photos, count)
+	})
+}
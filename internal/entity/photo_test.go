@@ -627,6 +627,26 @@ func TestPhoto_Delete(t *testing.T) {
 	})
 }
 
+func TestPhoto_Archive(t *testing.T) {
+	m := NewPhoto()
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Archive(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Photo
+
+	if err := UnscopedDb().Where("photo_uid = ?", m.PhotoUID).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, result.DeletedAt)
+}
+
 func TestPhotos_UIDs(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		photo1 := Photo{PhotoUID: "abc123"}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MetaData returns photo metadata that may be written back to sidecar or media files.
+func (m *Photo) MetaData() meta.Data {
+	data := meta.Data{
+		Description: m.PhotoDescription,
+	}
+
+	// Skip generated titles.
+	if m.TitleSrc != SrcAuto {
+		data.Title = m.PhotoTitle
+	}
+
+	// Skip estimated dates.
+	if m.TakenSrc != SrcAuto {
+		data.TakenAt = m.TakenAt
+		data.TakenAtLocal = m.TakenAtLocal
+		data.TimeZone = m.TimeZone
+	}
+
+	if m.HasLatLng() {
+		data.Lat = m.PhotoLat
+		data.Lng = m.PhotoLng
+		data.Altitude = m.PhotoAltitude
+	}
+
+	if details := m.Details; details != nil {
+		data.Keywords = details.Keywords
+		data.Artist = details.Artist
+		data.Copyright = details.Copyright
+	}
+
+	return data
+}
+
+// ClearedMetaData returns the names of metadata fields that were explicitly cleared by the user,
+// so that they are also removed when writing to media files.
+func (m *Photo) ClearedMetaData() (result []string) {
+	if m.TitleSrc == SrcManual && m.PhotoTitle == "" {
+		result = append(result, "Title")
+	}
+
+	if m.DescriptionSrc == SrcManual && m.PhotoDescription == "" {
+		result = append(result, "Description")
+	}
+
+	return result
+}
+
+// SaveAsXmp writes photo metadata to an XMP sidecar file.
+func (m *Photo) SaveAsXmp(fileName string) error {
+	return m.MetaData().SaveXMP(fileName)
+}
+
+// XmpFileName returns the XMP sidecar file name.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string) string {
+	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.XmpExt, false)
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_MetaData(t *testing.T) {
+	t.Run("create from fixture", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		data := m.MetaData()
+
+		assert.Equal(t, "", data.Title)
+		assert.Equal(t, "photo description blacklist", data.Description)
+		assert.Equal(t, "nature, frog", data.Keywords)
+		assert.Equal(t, "Hans", data.Artist)
+		assert.Equal(t, "copy", data.Copyright)
+		assert.Equal(t, float32(48.519234), data.Lat)
+		assert.Equal(t, "2006-01-01 02:00:00 +0000 UTC", data.TakenAt.String())
+	})
+	t.Run("estimated date", func(t *testing.T) {
+		m := Photo{TakenSrc: SrcAuto, PhotoTitle: "Foo", TitleSrc: SrcManual}
+		data := m.MetaData()
+
+		assert.Equal(t, "Foo", data.Title)
+		assert.True(t, data.TakenAt.IsZero())
+	})
+}
+
+func TestPhoto_ClearedMetaData(t *testing.T) {
+	m := Photo{TitleSrc: SrcManual, DescriptionSrc: SrcAuto}
+
+	assert.Equal(t, []string{"Title"}, m.ClearedMetaData())
+
+	m.PhotoTitle = "Foo"
+
+	assert.Empty(t, m.ClearedMetaData())
+}
+
+func TestPhoto_SaveAsXmp(t *testing.T) {
+	t.Run("create from fixture", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test.xmp")
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Hans", data.Artist)
+
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	t.Run("create from fixture", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.Equal(t, "xxx/2790/02/yyy/Photo01.xmp", m.XmpFileName("xxx", "yyy"))
+
+		if err := os.RemoveAll("xxx"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
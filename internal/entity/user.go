@@ -2,10 +2,13 @@ package entity
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -136,6 +139,19 @@ func (m *User) Save() error {
 	return Db().Save(m).Error
 }
 
+// Delete marks the user as deleted and removes albums shared with them.
+func (m *User) Delete() error {
+	if m.ID == Admin.ID || !m.Registered() {
+		return fmt.Errorf("user: %s can't be deleted", txt.Quote(m.String()))
+	}
+
+	if err := UnscopedDb().Delete(UserShare{}, "user_uid = ?", m.UserUID).Error; err != nil {
+		return err
+	}
+
+	return Db().Delete(m).Error
+}
+
 // BeforeCreate creates a random UID if needed before inserting a new row to the database.
 func (m *User) BeforeCreate(scope *gorm.Scope) error {
 	if rnd.IsUID(m.UserUID, 'u') {
@@ -159,6 +175,81 @@ func FirstOrCreateUser(m *User) *User {
 	return m
 }
 
+// CreateUser creates a new registered user based on a form and sets the initial password.
+func CreateUser(f form.User) (*User, error) {
+	userName := strings.TrimSpace(f.UserName)
+
+	if userName == "" {
+		return nil, fmt.Errorf("user name must not be empty")
+	}
+
+	if FindUserByName(userName) != nil {
+		return nil, fmt.Errorf("user %s already exists", txt.Quote(userName))
+	}
+
+	m := &User{AddressID: 1, UserUID: rnd.PPID('u'), UserName: userName}
+
+	if err := m.SetForm(f); err != nil {
+		return nil, err
+	}
+
+	var pw *Password
+
+	// Validate and hash the password first, so that users are never saved without it.
+	if f.Password != "" {
+		if len(f.Password) < 6 {
+			return nil, fmt.Errorf("new password for %s must be at least 6 characters", txt.Quote(userName))
+		}
+
+		pw = &Password{UID: m.UserUID}
+
+		if err := pw.SetPassword(f.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	err := Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+
+		if pw == nil {
+			return nil
+		}
+
+		return tx.Create(pw).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SetForm updates the user profile and role based on a form, without saving it.
+func (m *User) SetForm(f form.User) error {
+	if f.FullName != "" {
+		m.FullName = txt.Clip(f.FullName, 128)
+	}
+
+	if f.PrimaryEmail != "" {
+		m.PrimaryEmail = txt.Clip(f.PrimaryEmail, 255)
+	}
+
+	if f.Role != "" {
+		if err := m.SetRole(acl.Role(f.Role)); err != nil {
+			return err
+		}
+	}
+
+	if f.StoragePath != "" {
+		m.StoragePath = strings.Trim(filepath.ToSlash(filepath.Clean("/"+f.StoragePath)), "/")
+	}
+
+	return nil
+}
+
 // FindUserByName returns an existing user or nil if not found.
 func FindUserByName(userName string) *User {
 	if userName == "" {
@@ -214,6 +305,24 @@ func (m *User) Admin() bool {
 	return m.Registered() && m.RoleAdmin
 }
 
+// UploadAlbum returns the album for uploads of the user and creates it if needed.
+func (m *User) UploadAlbum() (*Album, error) {
+	result := Album{}
+
+	if err := Db().Where("created_by = ? AND album_type = ? AND album_slug = ?", m.UserUID, AlbumDefault, UploadAlbumSlug).First(&result).Error; err == nil {
+		return &result, nil
+	}
+
+	album := NewAlbum(UploadAlbumTitle, AlbumDefault)
+	album.CreatedBy = m.UserUID
+
+	if err := album.Create(); err != nil {
+		return nil, err
+	}
+
+	return album, nil
+}
+
 // Anonymous returns true if the user is unknown.
 func (m *User) Anonymous() bool {
 	return !rnd.IsPPID(m.UserUID, 'u') || m.ID == UnknownUser.ID || m.UserUID == UnknownUser.UserUID
@@ -297,6 +406,23 @@ func (m *User) InvalidPassword(password string) bool {
 	return false
 }
 
+// SetRole sets the role flags of the user so that Role() returns the given ACL role.
+func (m *User) SetRole(role acl.Role) error {
+	switch role {
+	case acl.RoleAdmin, acl.RoleChild, acl.RoleFamily, acl.RoleFriend, acl.RoleGuest:
+	default:
+		return fmt.Errorf("unknown role %s", txt.Quote(string(role)))
+	}
+
+	m.RoleAdmin = role == acl.RoleAdmin
+	m.RoleChild = role == acl.RoleChild
+	m.RoleFamily = role == acl.RoleFamily
+	m.RoleFriend = role == acl.RoleFriend
+	m.RoleGuest = role == acl.RoleGuest
+
+	return nil
+}
+
 // Role returns the user role for ACL permission checks.
 func (m *User) Role() acl.Role {
 	if m.RoleAdmin {
@@ -0,0 +1,70 @@
+package entity
+
+type UserMap map[string]User
+
+func (m UserMap) Get(name string) User {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return User{}
+}
+
+func (m UserMap) Pointer(name string) *User {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &User{}
+}
+
+var UserFixtures = UserMap{
+	"alice": {
+		ID:          1000000,
+		AddressID:   1,
+		UserUID:     "ut9lxuqxkkvd1000",
+		UserName:    "alice",
+		FullName:    "Alice",
+		RoleFamily:  true,
+		StoragePath: "2790",
+	},
+	"bob": {
+		ID:         1000001,
+		AddressID:  1,
+		UserUID:    "ut9lxuqxkkvd1001",
+		UserName:   "bob",
+		FullName:   "Bob",
+		RoleFriend: true,
+	},
+}
+
+// CreateUserFixtures inserts known entities into the database for testing.
+func CreateUserFixtures() {
+	for _, entity := range UserFixtures {
+		Db().Create(&entity)
+	}
+}
+
+type UserShareMap map[string]UserShare
+
+func (m UserShareMap) Get(name string) UserShare {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return UserShare{}
+}
+
+var UserShareFixtures = UserShareMap{
+	"bob-holiday-2030": {
+		UserUID:  UserFixtures.Get("bob").UserUID,
+		ShareUID: AlbumFixtures.Get("holiday-2030").AlbumUID,
+	},
+}
+
+// CreateUserShareFixtures inserts known entities into the database for testing.
+func CreateUserShareFixtures() {
+	for _, entity := range UserShareFixtures {
+		Db().Create(&entity)
+	}
+}
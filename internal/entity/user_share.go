@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+)
+
+type UserShares []UserShare
+
+// UserShare represents an album shared with a registered user.
+type UserShare struct {
+	UserUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UserUID" yaml:"UserUID"`
+	ShareUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"ShareUID" yaml:"ShareUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (UserShare) TableName() string {
+	return "users_shares"
+}
+
+// NewUserShare returns a new user share entity.
+func NewUserShare(userUID, shareUID string) *UserShare {
+	result := &UserShare{
+		UserUID:  userUID,
+		ShareUID: shareUID,
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *UserShare) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete removes the share from the database.
+func (m *UserShare) Delete() error {
+	return UnscopedDb().Delete(m).Error
+}
+
+// FirstOrCreateUserShare returns the existing row, inserts a new row or nil in case of errors.
+func FirstOrCreateUserShare(m *UserShare) *UserShare {
+	result := UserShare{}
+
+	if err := Db().Where("user_uid = ? AND share_uid = ?", m.UserUID, m.ShareUID).First(&result).Error; err == nil {
+		return &result
+	} else if err := m.Create(); err != nil {
+		log.Errorf("user share: %s", err)
+		return nil
+	}
+
+	return m
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstOrCreateUserShare(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		fixture := UserShareFixtures.Get("bob-holiday-2030")
+		m := FirstOrCreateUserShare(NewUserShare(fixture.UserUID, fixture.ShareUID))
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, fixture.ShareUID, m.ShareUID)
+	})
+	t.Run("new", func(t *testing.T) {
+		m := FirstOrCreateUserShare(NewUserShare(UserFixtures.Get("alice").UserUID, AlbumFixtures.Get("berlin-2019").AlbumUID))
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.False(t, m.CreatedAt.IsZero())
+		assert.NoError(t, m.Delete())
+	})
+}
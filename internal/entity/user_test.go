@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -270,3 +271,68 @@ func TestUser_Role(t *testing.T) {
 		assert.Equal(t, acl.Role("*"), p.Role())
 	})
 }
+
+func TestUser_SetRole(t *testing.T) {
+	t.Run("family", func(t *testing.T) {
+		m := User{RoleAdmin: true}
+
+		assert.NoError(t, m.SetRole(acl.RoleFamily))
+		assert.False(t, m.RoleAdmin)
+		assert.True(t, m.RoleFamily)
+		assert.Equal(t, acl.RoleFamily, m.Role())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		m := User{RoleFriend: true}
+
+		assert.Error(t, m.SetRole("superuser"))
+		assert.Equal(t, acl.RoleFriend, m.Role())
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		f := form.User{UserName: "carol", FullName: "Carol", Role: "child", StoragePath: "/../kids/carol/", Password: "carol123"}
+		m, err := CreateUser(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Registered())
+		assert.Equal(t, acl.RoleChild, m.Role())
+		assert.Equal(t, "kids/carol", m.StoragePath)
+		assert.False(t, m.InvalidPassword("carol123"))
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindUserByName("carol"))
+	})
+	t.Run("exists", func(t *testing.T) {
+		_, err := CreateUser(form.User{UserName: "alice", Role: "family"})
+		assert.Error(t, err)
+	})
+	t.Run("empty name", func(t *testing.T) {
+		_, err := CreateUser(form.User{UserName: " ", Role: "family"})
+		assert.Error(t, err)
+	})
+	t.Run("invalid role", func(t *testing.T) {
+		_, err := CreateUser(form.User{UserName: "dave", Role: "root"})
+		assert.Error(t, err)
+	})
+	t.Run("short password", func(t *testing.T) {
+		_, err := CreateUser(form.User{UserName: "erin", Role: "friend", Password: "123"})
+		assert.Error(t, err)
+		assert.Nil(t, FindUserByName("erin"))
+	})
+}
+
+func TestUser_Delete(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		assert.Error(t, Admin.Delete())
+	})
+	t.Run("guest", func(t *testing.T) {
+		assert.Error(t, Guest.Delete())
+	})
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/ulule/deepcopier"
+)
+
+type Webhooks []Webhook
+
+// Webhook represents a URL that receives signed JSON payloads for library events.
+type Webhook struct {
+	ID          uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	HookURL     string     `gorm:"type:VARBINARY(512);" json:"HookURL" yaml:"HookURL"`
+	HookSecret  string     `gorm:"type:VARBINARY(255);" json:"-" yaml:"-"`
+	HookTopics  string     `gorm:"type:VARBINARY(512);" json:"HookTopics" yaml:"HookTopics"`
+	HookEnabled bool       `json:"HookEnabled" yaml:"HookEnabled"`
+	HookError   string     `gorm:"type:VARBINARY(512);" json:"HookError" yaml:"-"`
+	HookErrors  int        `json:"HookErrors" yaml:"-"`
+	CreatedAt   time.Time  `deepcopier:"skip" json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time  `deepcopier:"skip" json:"UpdatedAt" yaml:"-"`
+	DeletedAt   *time.Time `deepcopier:"skip" sql:"index" json:"-" yaml:"-"`
+}
+
+// CreateWebhook creates a new webhook entity in the database.
+func CreateWebhook(form form.Webhook) (model *Webhook, err error) {
+	model = &Webhook{}
+
+	err = model.SaveForm(form)
+
+	return model, err
+}
+
+// SaveForm saves the entity using form data and stores it in the database.
+func (m *Webhook) SaveForm(form form.Webhook) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	// Payloads are always signed, an empty secret keeps the current one or generates a new one.
+	if form.HookSecret == "" {
+		form.HookSecret = m.HookSecret
+	}
+
+	if form.HookSecret == "" {
+		form.HookSecret = rnd.Secret()
+	}
+
+	if err := deepcopier.Copy(m).From(form); err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete deletes the entity from the database.
+func (m *Webhook) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *Webhook) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Topics returns the event topic patterns the webhook is subscribed to, e.g. "photos.*".
+func (m *Webhook) Topics() (result []string) {
+	for _, topic := range strings.Split(m.HookTopics, ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			result = append(result, topic)
+		}
+	}
+
+	return result
+}
+
+// Matches tests if the webhook is enabled and subscribed to an event.
+func (m *Webhook) Matches(eventName string) bool {
+	if !m.HookEnabled {
+		return false
+	}
+
+	for _, topic := range m.Topics() {
+		if topic == "*" {
+			return true
+		} else if ok, _ := path.Match(topic, eventName); ok {
+			return true
+		}
+	}
+
+	return false
+}
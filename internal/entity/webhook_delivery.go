@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+)
+
+type WebhookDeliveries []WebhookDelivery
+
+// WebhookDelivery represents a single attempt to post an event to a webhook, all attempts
+// to deliver the same event share a delivery UID.
+type WebhookDelivery struct {
+	ID          uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	WebhookID   uint      `gorm:"index;" json:"WebhookID" yaml:"-"`
+	DeliveryUID string    `gorm:"type:VARBINARY(42);index;" json:"DeliveryUID" yaml:"DeliveryUID"`
+	EventName   string    `gorm:"type:VARBINARY(128);" json:"EventName" yaml:"EventName"`
+	Attempt     int       `json:"Attempt" yaml:"Attempt"`
+	StatusCode  int       `json:"StatusCode" yaml:"StatusCode"`
+	Error       string    `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	Duration    int       `json:"Duration" yaml:"Duration"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (WebhookDelivery) TableName() string {
+	return "webhooks_deliveries"
+}
+
+// Success tests if the webhook accepted the event.
+func (m *WebhookDelivery) Success() bool {
+	return m.Error == "" && m.StatusCode >= 200 && m.StatusCode < 300
+}
+
+// Create inserts a new row to the database.
+func (m *WebhookDelivery) Create() error {
+	return Db().Create(m).Error
+}
+
+// PurgeWebhookDeliveries removes delivery log entries that are older than the given age.
+func PurgeWebhookDeliveries(age time.Duration) error {
+	return Db().Where("created_at < ?", time.Now().Add(-1*age)).Delete(&WebhookDelivery{}).Error
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDelivery_Success(t *testing.T) {
+	assert.True(t, (&WebhookDelivery{StatusCode: 204}).Success())
+	assert.False(t, (&WebhookDelivery{StatusCode: 500}).Success())
+	assert.False(t, (&WebhookDelivery{StatusCode: 200, Error: "timeout"}).Success())
+}
+
+func TestPurgeWebhookDeliveries(t *testing.T) {
+	old := WebhookDelivery{WebhookID: 1000, DeliveryUID: "a", EventName: "photos.created", Attempt: 1, StatusCode: 200, CreatedAt: time.Now().Add(-48 * time.Hour)}
+	recent := WebhookDelivery{WebhookID: 1000, DeliveryUID: "b", EventName: "photos.created", Attempt: 1, StatusCode: 200}
+
+	if err := old.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recent.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PurgeWebhookDeliveries(24 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	Db().Model(&WebhookDelivery{}).Where("webhook_id = 1000").Count(&count)
+
+	assert.Equal(t, 1, count)
+}
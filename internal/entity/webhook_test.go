@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := CreateWebhook(form.Webhook{HookURL: "https://example.com/hook", HookSecret: "secret", HookEnabled: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, m.ID)
+		assert.Equal(t, "*", m.HookTopics)
+		assert.Equal(t, "secret", m.HookSecret)
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := CreateWebhook(form.Webhook{HookURL: "example.com"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	m := Webhook{HookTopics: "photos.created, albums.*", HookEnabled: true}
+
+	assert.Equal(t, []string{"photos.created", "albums.*"}, m.Topics())
+	assert.True(t, m.Matches("photos.created"))
+	assert.True(t, m.Matches("albums.updated"))
+	assert.False(t, m.Matches("photos.updated"))
+	assert.False(t, m.Matches("index.failed"))
+
+	m.HookTopics = "*"
+	assert.True(t, m.Matches("index.failed"))
+
+	m.HookEnabled = false
+	assert.False(t, m.Matches("index.failed"))
+}
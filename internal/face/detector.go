@@ -0,0 +1,309 @@
+package face
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+)
+
+const (
+	CropSize = 160 // Input size of the embedding model.
+	CropPad  = 0.2 // Padding added around face regions before computing embeddings.
+)
+
+// Detector uses TensorFlow to find faces in images and compute their embeddings.
+type Detector struct {
+	detectModel *tf.SavedModel
+	netModel    *tf.SavedModel
+	modelPath   string
+	modelTags   []string
+	disabled    bool
+	mutex       sync.Mutex
+}
+
+// New returns a new detector instance.
+func New(modelPath string, disabled bool) *Detector {
+	return &Detector{modelPath: modelPath, modelTags: []string{"serve"}, disabled: disabled}
+}
+
+// Init loads the TensorFlow models if not disabled.
+func (t *Detector) Init() (err error) {
+	if t.disabled {
+		return nil
+	}
+
+	return t.loadModels()
+}
+
+// File returns the faces found in a jpeg media file.
+func (t *Detector) File(filename string) (result Faces, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	if fs.MimeType(filename) != "image/jpeg" {
+		return result, fmt.Errorf("face: %s is not a jpeg file", txt.Quote(filepath.Base(filename)))
+	}
+
+	imageBuffer, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return result, err
+	}
+
+	return t.Faces(imageBuffer)
+}
+
+// Faces returns the faces found in a jpeg image.
+func (t *Detector) Faces(img []byte) (result Faces, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	if err := t.loadModels(); err != nil {
+		return result, err
+	}
+
+	decoded, err := imaging.Decode(bytes.NewReader(img), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return result, fmt.Errorf("face: %s", err)
+	}
+
+	areas, scores, err := t.detect(decoded)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i, area := range areas {
+		embedding, err := t.embedding(decoded, area)
+
+		if err != nil {
+			log.Debugf("face: %s", err)
+			continue
+		}
+
+		result = append(result, Face{Score: scores[i], Area: area, Embedding: embedding})
+	}
+
+	if len(result) > 0 {
+		log.Tracef("face: found %d faces", len(result))
+	}
+
+	return result, nil
+}
+
+// detect returns face areas and their scores.
+func (t *Detector) detect(img image.Image) (areas []Area, scores []float32, err error) {
+	tensor, err := imageToTensorUint8(img)
+
+	if err != nil {
+		return areas, scores, fmt.Errorf("face: %s", err)
+	}
+
+	output, err := t.detectModel.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			t.detectModel.Graph.Operation("image_tensor").Output(0): tensor,
+		},
+		[]tf.Output{
+			t.detectModel.Graph.Operation("detection_boxes").Output(0),
+			t.detectModel.Graph.Operation("detection_scores").Output(0),
+			t.detectModel.Graph.Operation("num_detections").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		return areas, scores, fmt.Errorf("face: %s (run detection)", err.Error())
+	}
+
+	if len(output) < 3 {
+		return areas, scores, fmt.Errorf("face: detection failed, no output")
+	}
+
+	boxes := output[0].Value().([][][]float32)[0]
+	probabilities := output[1].Value().([][]float32)[0]
+	num := int(output[2].Value().([]float32)[0])
+
+	for i := 0; i < num && i < len(boxes) && i < len(probabilities); i++ {
+		if probabilities[i] < ScoreThreshold || len(boxes[i]) < 4 {
+			continue
+		}
+
+		// Boxes are returned as [ymin, xmin, ymax, xmax].
+		area := Area{
+			X: boxes[i][1],
+			Y: boxes[i][0],
+			W: boxes[i][3] - boxes[i][1],
+			H: boxes[i][2] - boxes[i][0],
+		}
+
+		if !area.Valid() {
+			continue
+		}
+
+		areas = append(areas, area)
+		scores = append(scores, probabilities[i])
+	}
+
+	return areas, scores, nil
+}
+
+// embedding returns the normalized embedding of a face area.
+func (t *Detector) embedding(img image.Image, area Area) (Embedding, error) {
+	crop := Crop(img, area)
+
+	tensor, err := imageToTensorFloat(crop)
+
+	if err != nil {
+		return nil, fmt.Errorf("face: %s", err)
+	}
+
+	phaseTrain, err := tf.NewTensor(false)
+
+	if err != nil {
+		return nil, fmt.Errorf("face: %s", err)
+	}
+
+	output, err := t.netModel.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			t.netModel.Graph.Operation("input").Output(0):       tensor,
+			t.netModel.Graph.Operation("phase_train").Output(0): phaseTrain,
+		},
+		[]tf.Output{
+			t.netModel.Graph.Operation("embeddings").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("face: %s (run inference)", err.Error())
+	}
+
+	if len(output) < 1 {
+		return nil, fmt.Errorf("face: inference failed, no output")
+	}
+
+	return Embedding(output[0].Value().([][]float32)[0]).Normalize(), nil
+}
+
+func (t *Detector) loadModels() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.detectModel != nil && t.netModel != nil {
+		// Already loaded
+		return nil
+	}
+
+	detectPath := filepath.Join(t.modelPath, "detector")
+	netPath := filepath.Join(t.modelPath, "facenet")
+
+	log.Infof("face: loading %s", txt.Quote(filepath.Base(detectPath)))
+
+	detectModel, err := tf.LoadSavedModel(detectPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("face: loading %s", txt.Quote(filepath.Base(netPath)))
+
+	netModel, err := tf.LoadSavedModel(netPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	t.detectModel = detectModel
+	t.netModel = netModel
+
+	return nil
+}
+
+// Crop returns the padded face area of an image, resized to the input size of the embedding model.
+func Crop(img image.Image, area Area) image.Image {
+	bounds := img.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+
+	padX := float64(area.W) * CropPad
+	padY := float64(area.H) * CropPad
+
+	x0 := int(math.Max(0, (float64(area.X)-padX)*w))
+	y0 := int(math.Max(0, (float64(area.Y)-padY)*h))
+	x1 := int(math.Min(w, (float64(area.X+area.W)+padX)*w))
+	y1 := int(math.Min(h, (float64(area.Y+area.H)+padY)*h))
+
+	cropped := imaging.Crop(img, image.Rect(bounds.Min.X+x0, bounds.Min.Y+y0, bounds.Min.X+x1, bounds.Min.Y+y1))
+
+	return imaging.Fill(cropped, CropSize, CropSize, imaging.Center, imaging.Lanczos)
+}
+
+func imageToTensorUint8(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	pixels := make([][][3]uint8, height)
+
+	for y := 0; y < height; y++ {
+		pixels[y] = make([][3]uint8, width)
+
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y][x] = [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+		}
+	}
+
+	return tf.NewTensor([][][][3]uint8{pixels})
+}
+
+// imageToTensorFloat returns a prewhitened float tensor as expected by the embedding model.
+func imageToTensorFloat(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	pixels := make([][][3]float32, height)
+
+	var sum, sumSq float64
+
+	for y := 0; y < height; y++ {
+		pixels[y] = make([][3]float32, width)
+
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y][x] = [3]float32{float32(r >> 8), float32(g >> 8), float32(b >> 8)}
+
+			for _, v := range pixels[y][x] {
+				sum += float64(v)
+				sumSq += float64(v) * float64(v)
+			}
+		}
+	}
+
+	n := float64(width * height * 3)
+
+	if n == 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+
+	mean := sum / n
+	std := math.Max(math.Sqrt(sumSq/n-mean*mean), 1/math.Sqrt(n))
+
+	for y := range pixels {
+		for x := range pixels[y] {
+			for c := range pixels[y][x] {
+				pixels[y][x][c] = float32((float64(pixels[y][x][c]) - mean) / std)
+			}
+		}
+	}
+
+	return tf.NewTensor([][][][3]float32{pixels})
+}
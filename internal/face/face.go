@@ -0,0 +1,180 @@
+/*
+
+Package face uses TensorFlow to detect faces in images and compute embeddings for clustering them by person.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package face
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+const (
+	ScoreThreshold = 0.75 // Minimum detection score.
+	MinSize        = 0.04 // Minimum face width and height relative to the image size.
+	ClusterDist    = 0.9  // Maximum euclidean distance between embeddings of the same person.
+)
+
+var log = event.Log
+
+// Area represents a face region with coordinates relative to the image size (0-1).
+type Area struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	W float32 `json:"w"`
+	H float32 `json:"h"`
+}
+
+// Valid tests if the area has a usable size.
+func (a Area) Valid() bool {
+	return a.W >= MinSize && a.H >= MinSize && a.X >= 0 && a.Y >= 0 && a.X+a.W <= 1.01 && a.Y+a.H <= 1.01
+}
+
+// Embedding represents a face embedding vector.
+type Embedding []float32
+
+// Dist returns the euclidean distance to another embedding.
+func (e Embedding) Dist(other Embedding) float64 {
+	if len(e) == 0 || len(e) != len(other) {
+		return math.MaxFloat64
+	}
+
+	var sum float64
+
+	for i := range e {
+		d := float64(e[i] - other[i])
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Normalize scales the embedding to unit length.
+func (e Embedding) Normalize() Embedding {
+	var sum float64
+
+	for _, v := range e {
+		sum += float64(v * v)
+	}
+
+	if sum == 0 {
+		return e
+	}
+
+	norm := float32(math.Sqrt(sum))
+	result := make(Embedding, len(e))
+
+	for i, v := range e {
+		result[i] = v / norm
+	}
+
+	return result
+}
+
+// Merge returns the weighted mean of the embedding (representing count faces) and another embedding.
+func (e Embedding) Merge(other Embedding, count int) Embedding {
+	if len(e) == 0 || count < 1 {
+		return other
+	} else if len(e) != len(other) {
+		return e
+	}
+
+	result := make(Embedding, len(e))
+	w := float32(count)
+
+	for i := range e {
+		result[i] = (e[i]*w + other[i]) / (w + 1)
+	}
+
+	return result
+}
+
+// Combine returns the weighted mean of the embedding (representing count faces)
+// and another embedding (representing otherCount faces).
+func (e Embedding) Combine(other Embedding, count, otherCount int) Embedding {
+	if len(e) == 0 || count < 1 {
+		return other
+	} else if len(other) == 0 || otherCount < 1 || len(e) != len(other) {
+		return e
+	}
+
+	result := make(Embedding, len(e))
+	w, o := float32(count), float32(otherCount)
+
+	for i := range e {
+		result[i] = (e[i]*w + other[i]*o) / (w + o)
+	}
+
+	return result
+}
+
+// JSON returns the embedding as JSON encoded string.
+func (e Embedding) JSON() string {
+	if len(e) == 0 {
+		return ""
+	}
+
+	if b, err := json.Marshal(e); err != nil {
+		log.Errorf("face: %s", err)
+		return ""
+	} else {
+		return string(b)
+	}
+}
+
+// UnmarshalEmbedding parses a JSON encoded embedding.
+func UnmarshalEmbedding(s string) (result Embedding) {
+	if s == "" {
+		return result
+	}
+
+	if err := json.Unmarshal([]byte(s), &result); err != nil {
+		log.Errorf("face: %s", err)
+	}
+
+	return result
+}
+
+// Face represents a detected face.
+type Face struct {
+	Score     float32   `json:"score"`
+	Area      Area      `json:"area"`
+	Embedding Embedding `json:"-"`
+}
+
+// Faces is a list of detected faces.
+type Faces []Face
+
+// Count returns the number of faces.
+func (f Faces) Count() int {
+	return len(f)
+}
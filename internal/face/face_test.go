@@ -0,0 +1,135 @@
+package face
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+var modelPath = fs.Abs("../../assets/faces")
+var examplesPath = fs.Abs("../../assets/examples")
+
+func TestArea_Valid(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.True(t, Area{X: 0.1, Y: 0.2, W: 0.3, H: 0.3}.Valid())
+	})
+	t.Run("too small", func(t *testing.T) {
+		assert.False(t, Area{X: 0.1, Y: 0.2, W: 0.01, H: 0.01}.Valid())
+	})
+	t.Run("out of bounds", func(t *testing.T) {
+		assert.False(t, Area{X: 0.9, Y: 0.2, W: 0.3, H: 0.3}.Valid())
+	})
+}
+
+func TestEmbedding_Dist(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		e := Embedding{0.5, 0.5}
+		assert.Equal(t, float64(0), e.Dist(e))
+	})
+	t.Run("different", func(t *testing.T) {
+		assert.Equal(t, float64(5), Embedding{0, 0}.Dist(Embedding{3, 4}))
+	})
+	t.Run("length mismatch", func(t *testing.T) {
+		assert.Greater(t, Embedding{0, 0}.Dist(Embedding{3}), ClusterDist)
+	})
+}
+
+func TestEmbedding_Normalize(t *testing.T) {
+	assert.Equal(t, Embedding{0.6, 0.8}, Embedding{3, 4}.Normalize())
+	assert.Equal(t, Embedding{0, 0}, Embedding{0, 0}.Normalize())
+}
+
+func TestEmbedding_Merge(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, Embedding{1, 2}, Embedding{}.Merge(Embedding{1, 2}, 0))
+	})
+	t.Run("mean", func(t *testing.T) {
+		assert.Equal(t, Embedding{2, 3}, Embedding{1, 2}.Merge(Embedding{4, 5}, 2))
+	})
+}
+
+func TestEmbedding_Combine(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, Embedding{1, 2}, Embedding{}.Combine(Embedding{1, 2}, 0, 3))
+		assert.Equal(t, Embedding{1, 2}, Embedding{1, 2}.Combine(Embedding{}, 3, 0))
+	})
+	t.Run("mean", func(t *testing.T) {
+		assert.Equal(t, Embedding{2, 3}, Embedding{1, 2}.Combine(Embedding{4, 5}, 2, 1))
+	})
+}
+
+func TestEmbedding_JSON(t *testing.T) {
+	e := Embedding{0.25, -0.5}
+
+	assert.Equal(t, "[0.25,-0.5]", e.JSON())
+	assert.Equal(t, e, UnmarshalEmbedding(e.JSON()))
+	assert.Equal(t, "", Embedding{}.JSON())
+	assert.Len(t, UnmarshalEmbedding(""), 0)
+}
+
+func TestCrop(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	result := Crop(img, Area{X: 0.25, Y: 0.25, W: 0.5, H: 0.5})
+
+	assert.Equal(t, CropSize, result.Bounds().Dx())
+	assert.Equal(t, CropSize, result.Bounds().Dy())
+}
+
+func TestDetector_File(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		d := New("testdata", true)
+		faces, err := d.File("testdata/missing.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, faces.Count())
+	})
+	t.Run("model not found", func(t *testing.T) {
+		d := New("testdata/missing", false)
+		_, err := d.File(examplesPath + "/clowns_colorful.jpg")
+
+		assert.Error(t, err)
+	})
+	t.Run("clowns", func(t *testing.T) {
+		if !fs.PathExists(modelPath + "/detector") {
+			t.Skip("face models not found, run scripts/download-faces.sh")
+		}
+
+		d := New(modelPath, false)
+		faces, err := d.File(examplesPath + "/clowns_colorful.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, faces.Count(), 0)
+
+		for _, f := range faces {
+			var sum float64
+
+			for _, v := range f.Embedding {
+				sum += float64(v) * float64(v)
+			}
+
+			assert.True(t, f.Area.Valid())
+			assert.GreaterOrEqual(t, f.Score, float32(ScoreThreshold))
+			assert.InDelta(t, 1, math.Sqrt(sum), 0.001)
+		}
+	})
+	t.Run("no faces", func(t *testing.T) {
+		if !fs.PathExists(modelPath + "/detector") {
+			t.Skip("face models not found, run scripts/download-faces.sh")
+		}
+
+		d := New(modelPath, false)
+		faces, err := d.File(examplesPath + "/beach_sand.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, faces.Count())
+	})
+}
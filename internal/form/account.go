@@ -12,6 +12,7 @@ type Account struct {
 	AccURL        string `json:"AccURL"`
 	AccType       string `json:"AccType"`
 	AccKey        string `json:"AccKey"`
+	AccHostKey    string `json:"AccHostKey"`
 	AccUser       string `json:"AccUser"`
 	AccPass       string `json:"AccPass"`
 	AccError      string `json:"AccError"`
@@ -27,6 +28,7 @@ type Account struct {
 	SyncDownload  bool   `json:"SyncDownload"`
 	SyncFilenames bool   `json:"SyncFilenames"`
 	SyncRaw       bool   `json:"SyncRaw"`
+	SyncDelete    bool   `json:"SyncDelete"`
 }
 
 func NewAccount(m interface{}) (f Account, err error) {
@@ -18,6 +18,7 @@ type AlbumSearch struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	UserUID  string `form:"-" serialize:"-"` // Restricts results to the albums of this user.
 }
 
 func (f *AlbumSearch) GetQuery() string {
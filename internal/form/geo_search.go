@@ -1,6 +1,11 @@
 package form
 
-import "time"
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // GeoSearch represents search form fields for "/api/v1/geo".
 type GeoSearch struct {
@@ -24,6 +29,8 @@ type GeoSearch struct {
 	S2       string    `form:"s2"`
 	Olc      string    `form:"olc"`
 	Dist     uint      `form:"dist"`
+	BBox     string    `form:"bbox"` // Bounding box as "minLng,minLat,maxLng,maxLat"
+	Zoom     int       `form:"zoom"` // Web map zoom level for clustering
 	Album    string    `form:"album"`
 	Country  string    `form:"country"`
 	Year     int       `form:"year"`
@@ -31,6 +38,7 @@ type GeoSearch struct {
 	Color    string    `form:"color"`
 	Camera   int       `form:"camera"`
 	Lens     int       `form:"lens"`
+	UserUID  string    `form:"-" serialize:"-"` // Restricts results to the library of this user.
 }
 
 // GetQuery returns the query parameter as string.
@@ -64,6 +72,35 @@ func (f *GeoSearch) SerializeAll() string {
 	return Serialize(f, true)
 }
 
+// BoundingBox returns the parsed bounding box, ok is false if none was specified.
+func (f *GeoSearch) BoundingBox() (minLng, minLat, maxLng, maxLat float64, ok bool, err error) {
+	if f.BBox == "" {
+		return 0, 0, 0, 0, false, nil
+	}
+
+	values := strings.Split(f.BBox, ",")
+
+	if len(values) != 4 {
+		return 0, 0, 0, 0, false, errors.New("bbox must contain four coordinates")
+	}
+
+	var c [4]float64
+
+	for i, v := range values {
+		if c[i], err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			return 0, 0, 0, 0, false, errors.New("bbox contains invalid coordinates")
+		}
+	}
+
+	minLng, minLat, maxLng, maxLat = c[0], c[1], c[2], c[3]
+
+	if minLat < -90 || maxLat > 90 || minLat > maxLat || minLng < -180 || maxLng > 180 {
+		return 0, 0, 0, 0, false, errors.New("bbox is out of range")
+	}
+
+	return minLng, minLat, maxLng, maxLat, true, nil
+}
+
 func NewGeoSearch(query string) GeoSearch {
 	return GeoSearch{Query: query}
 }
@@ -56,6 +56,47 @@ func TestGeoSearch_SerializeAll(t *testing.T) {
 	assert.Equal(t, "q:\"query:fooBar baz\" favorite:true", form.SerializeAll())
 }
 
+func TestGeoSearch_BoundingBox(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		form := &GeoSearch{}
+
+		_, _, _, _, ok, err := form.BoundingBox()
+
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("berlin", func(t *testing.T) {
+		form := &GeoSearch{BBox: "13.1,52.3, 13.7,52.7"}
+
+		minLng, minLat, maxLng, maxLat, ok, err := form.BoundingBox()
+
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Equal(t, 13.1, minLng)
+		assert.Equal(t, 52.3, minLat)
+		assert.Equal(t, 13.7, maxLng)
+		assert.Equal(t, 52.7, maxLat)
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		form := &GeoSearch{BBox: "170,-20,-170,0"}
+
+		_, _, _, _, ok, err := form.BoundingBox()
+
+		assert.True(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, bbox := range []string{"1,2,3", "a,b,c,d", "0,60,10,50", "0,-100,10,50"} {
+			form := &GeoSearch{BBox: bbox}
+
+			_, _, _, _, ok, err := form.BoundingBox()
+
+			assert.False(t, ok)
+			assert.Error(t, err, bbox)
+		}
+	})
+}
+
 func TestNewGeoSearch(t *testing.T) {
 	r := NewGeoSearch("Berlin")
 	assert.IsType(t, GeoSearch{}, r)
@@ -11,6 +11,7 @@ type LabelSearch struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	UserUID  string `form:"-" serialize:"-"` // Restricts results to labels in the library of this user.
 }
 
 func (f *LabelSearch) GetQuery() string {
@@ -9,4 +9,13 @@ type Link struct {
 	MaxViews    uint   `json:"MaxViews"`
 	CanComment  bool   `json:"CanComment"`
 	CanEdit     bool   `json:"CanEdit"`
+	CanDownload bool   `json:"CanDownload"`
+	CanUpload   bool   `json:"CanUpload"`
+}
+
+// ShareLink represents a form for sharing a folder or an ad-hoc selection of photos.
+type ShareLink struct {
+	Link
+	Folder    string    `json:"Folder"`
+	Selection Selection `json:"Selection"`
 }
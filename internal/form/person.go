@@ -0,0 +1,6 @@
+package form
+
+// Person represents a person edit form.
+type Person struct {
+	PersonName string `json:"Name"`
+}
@@ -0,0 +1,31 @@
+package form
+
+// PersonSearch represents search form fields for "/api/v1/people".
+type PersonSearch struct {
+	Query  string `form:"q"`
+	ID     string `form:"id"`
+	Slug   string `form:"slug"`
+	Name   string `form:"name"`
+	Named  bool   `form:"named"`
+	Count  int    `form:"count" binding:"required" serialize:"-"`
+	Offset int    `form:"offset" serialize:"-"`
+	Order  string `form:"order" serialize:"-"`
+
+	UserUID string `form:"-" serialize:"-"` // Restricts results to people in the library of this user.
+}
+
+func (f *PersonSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *PersonSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *PersonSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewPersonSearch(query string) PersonSearch {
+	return PersonSearch{Query: query}
+}
@@ -0,0 +1,34 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonSearchForm(t *testing.T) {
+	form := &PersonSearch{}
+
+	assert.IsType(t, new(PersonSearch), form)
+}
+
+func TestParseQueryStringPerson(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &PersonSearch{Query: "name:jane named:true count:10 query:\"jane doe\""}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jane", form.Name)
+		assert.Equal(t, true, form.Named)
+		assert.Equal(t, 10, form.Count)
+		assert.Equal(t, "jane doe", form.Query)
+	})
+}
+
+func TestNewPersonSearch(t *testing.T) {
+	r := NewPersonSearch("jane")
+	assert.IsType(t, PersonSearch{}, r)
+	assert.Equal(t, "jane", r.Query)
+}
@@ -0,0 +1,56 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PhotoEdit represents a non-destructive photo edit form.
+type PhotoEdit struct {
+	CropX       float32 `json:"CropX"`
+	CropY       float32 `json:"CropY"`
+	CropW       float32 `json:"CropW"`
+	CropH       float32 `json:"CropH"`
+	Rotate      int     `json:"Rotate"`
+	Angle       float32 `json:"Angle"`
+	Exposure    float32 `json:"Exposure"`
+	Contrast    float32 `json:"Contrast"`
+	Saturation  float32 `json:"Saturation"`
+	Temperature float32 `json:"Temperature"`
+	Tint        float32 `json:"Tint"`
+}
+
+// Validate returns an error if a value is out of range, and normalizes the rotation to 0-270 degrees.
+func (f *PhotoEdit) Validate() error {
+	const epsilon = 0.001
+
+	if f.CropX < 0 || f.CropY < 0 || f.CropW < 0 || f.CropH < 0 {
+		return errors.New("crop values must not be negative")
+	} else if f.CropX+f.CropW > 1+epsilon || f.CropY+f.CropH > 1+epsilon {
+		return errors.New("crop rectangle exceeds image bounds")
+	} else if (f.CropW == 0) != (f.CropH == 0) {
+		return errors.New("crop width and height must both be set")
+	}
+
+	if f.Rotate%90 != 0 {
+		return errors.New("rotation must be a multiple of 90 degrees")
+	}
+
+	f.Rotate = ((f.Rotate % 360) + 360) % 360
+
+	if f.Angle < -45 || f.Angle > 45 {
+		return errors.New("angle must be between -45 and 45 degrees")
+	}
+
+	if f.Exposure < -5 || f.Exposure > 5 {
+		return errors.New("exposure must be between -5 and 5")
+	}
+
+	for name, v := range map[string]float32{"contrast": f.Contrast, "saturation": f.Saturation, "temperature": f.Temperature, "tint": f.Tint} {
+		if v < -100 || v > 100 {
+			return fmt.Errorf("%s must be between -100 and 100", name)
+		}
+	}
+
+	return nil
+}
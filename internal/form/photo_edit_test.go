@@ -0,0 +1,46 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEdit_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		f := PhotoEdit{CropX: 0.1, CropY: 0.2, CropW: 0.9, CropH: 0.5, Rotate: -90, Angle: 2.5, Exposure: 1, Temperature: -20}
+
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, 270, f.Rotate)
+	})
+	t.Run("empty", func(t *testing.T) {
+		f := PhotoEdit{}
+
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("crop exceeds bounds", func(t *testing.T) {
+		f := PhotoEdit{CropX: 0.5, CropW: 0.6, CropH: 1}
+
+		assert.EqualError(t, f.Validate(), "crop rectangle exceeds image bounds")
+	})
+	t.Run("crop height missing", func(t *testing.T) {
+		f := PhotoEdit{CropW: 0.5}
+
+		assert.Error(t, f.Validate())
+	})
+	t.Run("rotation", func(t *testing.T) {
+		f := PhotoEdit{Rotate: 45}
+
+		assert.EqualError(t, f.Validate(), "rotation must be a multiple of 90 degrees")
+	})
+	t.Run("angle", func(t *testing.T) {
+		f := PhotoEdit{Angle: 46}
+
+		assert.Error(t, f.Validate())
+	})
+	t.Run("saturation", func(t *testing.T) {
+		f := PhotoEdit{Saturation: -101}
+
+		assert.EqualError(t, f.Validate(), "saturation must be between -100 and 100")
+	})
+}
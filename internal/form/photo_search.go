@@ -42,6 +42,7 @@ type PhotoSearch struct {
 	Geo      bool      `form:"geo"`
 	Album    string    `form:"album"`
 	Label    string    `form:"label"`
+	Person   string    `form:"person"`
 	Category string    `form:"category"` // Moments
 	Country  string    `form:"country"`  // Moments
 	State    string    `form:"state"`    // Moments
@@ -53,12 +54,17 @@ type PhotoSearch struct {
 	Review   bool      `form:"review"`
 	Camera   int       `form:"camera"`
 	Lens     int       `form:"lens"`
+	Duration string    `form:"duration"` // Video duration like "30s"
+	Codec    string    `form:"codec"`    // Video codec like "hevc"
 	Before   time.Time `form:"before" time_format:"2006-01-02"`
 	After    time.Time `form:"after" time_format:"2006-01-02"`
 	Count    int       `form:"count" binding:"required" serialize:"-"`
 	Offset   int       `form:"offset" serialize:"-"`
 	Order    string    `form:"order" serialize:"-"`
 	Merged   bool      `form:"merged" serialize:"-"`
+	UserUID  string    `form:"-" serialize:"-"` // Restricts results to the library of this user.
+	Shared   bool      `form:"-" serialize:"-"` // Restricts results to public content for guests.
+	Expr     *Expr     `form:"-"`               // Boolean search expression, see ParseExpr.
 }
 
 func (f *PhotoSearch) GetQuery() string {
@@ -70,7 +76,14 @@ func (f *PhotoSearch) SetQuery(q string) {
 }
 
 func (f *PhotoSearch) ParseQueryString() error {
-	if err := ParseQueryString(f); err != nil {
+	if IsExpr(f.Query) {
+		if expr, err := ParseExpr(f.Query); err != nil {
+			return err
+		} else {
+			f.Expr = f.Expr.And(expr)
+			f.Query = ""
+		}
+	} else if err := ParseQueryString(f); err != nil {
 		return err
 	}
 
@@ -78,12 +91,27 @@ func (f *PhotoSearch) ParseQueryString() error {
 		f.Path = f.Folder
 	}
 
-	if f.Filter != "" {
+	if f.Filter != "" && IsExpr(f.Filter) {
+		if expr, err := ParseExpr(f.Filter); err != nil {
+			return err
+		} else {
+			f.Expr = f.Expr.And(expr)
+		}
+	} else if f.Filter != "" {
 		if err := Unserialize(f, f.Filter); err != nil {
 			return err
 		}
 	}
 
+	// Guests may only see public content, even if the query or a saved filter asks for more.
+	if f.Shared {
+		f.Public = true
+		f.Private = false
+		f.Hidden = false
+		f.Archived = false
+		f.Review = false
+	}
+
 	return nil
 }
 
@@ -41,6 +41,18 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "123abc/,EFG", form.Path)
 	})
+	t.Run("phrase", func(t *testing.T) {
+		form := &PhotoSearch{Query: "\"Golden Gate\" bridge label:cat"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cat", form.Label)
+		assert.Equal(t, "\"golden gate\" bridge", form.Query)
+	})
 	t.Run("valid query", func(t *testing.T) {
 		form := &PhotoSearch{Query: "label:cat query:\"fooBar baz\" before:2019-01-15 camera:23 favorite:false dist:25000 lat:33.45343166666667"}
 
@@ -91,6 +103,16 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, "label:dog", form.Filter)
 		assert.Equal(t, "fooBar baz", form.Title)
 	})
+	t.Run("valid query with person", func(t *testing.T) {
+		form := &PhotoSearch{Query: "person:jane-doe label:cat"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jane-doe", form.Person)
+		assert.Equal(t, "cat", form.Label)
+	})
 	t.Run("valid query with umlauts", func(t *testing.T) {
 		form := &PhotoSearch{Query: "title:\"tübingen\""}
 
@@ -0,0 +1,444 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ExprOp represents the type of a search expression node.
+type ExprOp string
+
+const (
+	ExprAnd  ExprOp = "and"
+	ExprOr   ExprOp = "or"
+	ExprNot  ExprOp = "not"
+	ExprTerm ExprOp = "term"
+)
+
+// Comparison operators of search expression terms.
+const (
+	CmpEqual        = ""
+	CmpGreater      = ">"
+	CmpGreaterEqual = ">="
+	CmpLess         = "<"
+	CmpLessEqual    = "<="
+	CmpRange        = ".."
+)
+
+// Expr represents a node in the syntax tree of a boolean search query like
+// "label:cat OR label:dog -country:de (year:2019 | year:2020)".
+type Expr struct {
+	Op    ExprOp  // Node type.
+	Args  []*Expr // Operands of and, or and not nodes.
+	Key   string  // Filter name of terms, empty for full-text terms.
+	Cmp   string  // Comparison operator of terms.
+	Value string  // Term value, lower bound of ranges.
+	Max   string  // Upper bound of ranges.
+	Pos   int     // Position of the term in the query string.
+}
+
+// QueryError represents a search query syntax error.
+type QueryError struct {
+	Pos   int    `json:"position"`
+	Token string `json:"token,omitempty"`
+	Msg   string `json:"message"`
+}
+
+// Error returns the error message including the position.
+func (e *QueryError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+	}
+
+	return fmt.Sprintf("%s at position %d: %s", e.Msg, e.Pos, e.Token)
+}
+
+// NewQueryError returns a new search query syntax error.
+func NewQueryError(pos int, token, msg string, args ...interface{}) *QueryError {
+	return &QueryError{Pos: pos, Token: token, Msg: fmt.Sprintf(msg, args...)}
+}
+
+// exprToken represents a lexical token of a search expression.
+type exprToken struct {
+	Kind  ExprOp // Empty for parentheses.
+	Text  string
+	Quote bool
+	Pos   int
+}
+
+// lexExpr splits a search query into tokens.
+func lexExpr(q string) (result []exprToken) {
+	runes := []rune(q)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			result = append(result, exprToken{Text: string(r), Pos: i})
+			i++
+		case r == '|':
+			start := i
+
+			for i < len(runes) && runes[i] == '|' {
+				i++
+			}
+
+			result = append(result, exprToken{Kind: ExprOr, Text: string(runes[start:i]), Pos: start})
+		case (r == '-' || r == '!') && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			result = append(result, exprToken{Kind: ExprNot, Text: string(r), Pos: i})
+			i++
+		default:
+			start := i
+			quoted := false
+			var text []rune
+
+			for i < len(runes) {
+				c := runes[i]
+
+				if c == '"' {
+					quoted = !quoted
+					i++
+					continue
+				}
+
+				if !quoted && (unicode.IsSpace(c) || c == '(' || c == ')' || c == '|') {
+					break
+				}
+
+				text = append(text, c)
+				i++
+			}
+
+			t := exprToken{Kind: ExprTerm, Text: string(text), Quote: strings.ContainsRune(string(runes[start:i]), '"'), Pos: start}
+
+			switch {
+			case t.Quote:
+			case t.Text == "OR":
+				t.Kind = ExprOr
+			case t.Text == "AND":
+				t.Kind = ExprAnd
+			case t.Text == "NOT":
+				t.Kind = ExprNot
+			}
+
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// IsExpr returns true if the search query uses boolean operators, grouping, negation or ranges
+// and must be parsed with ParseExpr instead of Unserialize.
+func IsExpr(q string) bool {
+	for _, t := range lexExpr(q) {
+		switch t.Kind {
+		case ExprTerm:
+			if t.Quote {
+				continue
+			}
+
+			if i := strings.Index(t.Text, ":"); i > 0 {
+				value := t.Text[i+1:]
+
+				if strings.HasPrefix(value, ">") || strings.HasPrefix(value, "<") || strings.Contains(value, CmpRange) {
+					return true
+				}
+			}
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// exprParser is a recursive descent parser for search expressions.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	end    int
+}
+
+// ParseExpr parses a boolean search query and returns its syntax tree.
+//
+// Terms next to each other must all match, "OR" or "|" matches either side,
+// "-", "!" or "NOT" negate a term or group, parentheses group terms. Values may
+// be compared with ">", ">=", "<" and "<=", or matched against a range like "1.4..2.8".
+func ParseExpr(q string) (*Expr, error) {
+	p := exprParser{tokens: lexExpr(q), end: len([]rune(q))}
+
+	if len(p.tokens) == 0 {
+		return nil, NewQueryError(0, "", "empty query")
+	}
+
+	expr, err := p.or()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := p.peek(); ok {
+		return nil, NewQueryError(t.Pos, t.Text, "unexpected token")
+	}
+
+	return expr, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{Pos: p.end}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// or parses terms separated by "OR".
+func (p *exprParser) or() (*Expr, error) {
+	left, err := p.and()
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := []*Expr{left}
+
+	for {
+		t, ok := p.peek()
+
+		if !ok || t.Kind != ExprOr {
+			break
+		}
+
+		p.pos++
+
+		right, err := p.and()
+
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, right)
+	}
+
+	if len(args) == 1 {
+		return left, nil
+	}
+
+	return &Expr{Op: ExprOr, Args: args, Pos: left.Pos}, nil
+}
+
+// and parses adjacent terms, optionally separated by "AND".
+func (p *exprParser) and() (*Expr, error) {
+	var args []*Expr
+
+	for {
+		t, ok := p.peek()
+
+		if !ok || t.Kind == ExprOr || t.Text == ")" && t.Kind == "" {
+			break
+		}
+
+		if t.Kind == ExprAnd {
+			if len(args) == 0 {
+				return nil, NewQueryError(t.Pos, t.Text, "missing left operand")
+			}
+
+			p.pos++
+		}
+
+		arg, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	if len(args) == 0 {
+		t, _ := p.peek()
+		return nil, NewQueryError(t.Pos, t.Text, "missing operand")
+	} else if len(args) == 1 {
+		return args[0], nil
+	}
+
+	return &Expr{Op: ExprAnd, Args: args, Pos: args[0].Pos}, nil
+}
+
+// unary parses a negated or plain term.
+func (p *exprParser) unary() (*Expr, error) {
+	t, ok := p.peek()
+
+	if !ok {
+		return nil, NewQueryError(t.Pos, "", "missing operand")
+	}
+
+	if t.Kind == ExprNot {
+		p.pos++
+
+		arg, err := p.unary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Expr{Op: ExprNot, Args: []*Expr{arg}, Pos: t.Pos}, nil
+	}
+
+	return p.primary()
+}
+
+// primary parses a term or a group in parentheses.
+func (p *exprParser) primary() (*Expr, error) {
+	t, ok := p.peek()
+
+	if !ok {
+		return nil, NewQueryError(t.Pos, "", "missing operand")
+	}
+
+	switch {
+	case t.Kind == "" && t.Text == "(":
+		p.pos++
+
+		expr, err := p.or()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if end, ok := p.peek(); !ok || end.Text != ")" || end.Kind != "" {
+			return nil, NewQueryError(t.Pos, t.Text, "missing closing parenthesis")
+		}
+
+		p.pos++
+
+		return expr, nil
+	case t.Kind == ExprTerm:
+		p.pos++
+		return newTermExpr(t)
+	}
+
+	return nil, NewQueryError(t.Pos, t.Text, "unexpected token")
+}
+
+// newTermExpr returns a term node for the token.
+func newTermExpr(t exprToken) (*Expr, error) {
+	i := strings.Index(t.Text, ":")
+
+	// Full-text term.
+	if i <= 0 || t.Quote && strings.ContainsAny(t.Text[:i], " \t") {
+		if t.Quote && strings.ContainsAny(t.Text, " \t") {
+			return &Expr{Op: ExprTerm, Value: `"` + t.Text + `"`, Pos: t.Pos}, nil
+		}
+
+		return &Expr{Op: ExprTerm, Value: t.Text, Pos: t.Pos}, nil
+	}
+
+	key := strings.ToLower(t.Text[:i])
+	value := t.Text[i+1:]
+
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return nil, NewQueryError(t.Pos, t.Text, "invalid filter name")
+		}
+	}
+
+	expr := &Expr{Op: ExprTerm, Key: key, Pos: t.Pos}
+
+	switch {
+	case strings.HasPrefix(value, CmpGreaterEqual):
+		expr.Cmp, expr.Value = CmpGreaterEqual, value[2:]
+	case strings.HasPrefix(value, CmpLessEqual):
+		expr.Cmp, expr.Value = CmpLessEqual, value[2:]
+	case strings.HasPrefix(value, CmpGreater):
+		expr.Cmp, expr.Value = CmpGreater, value[1:]
+	case strings.HasPrefix(value, CmpLess):
+		expr.Cmp, expr.Value = CmpLess, value[1:]
+	case !t.Quote && strings.Contains(value, CmpRange):
+		bounds := strings.SplitN(value, CmpRange, 2)
+		expr.Cmp, expr.Value, expr.Max = CmpRange, bounds[0], bounds[1]
+
+		if expr.Max == "" {
+			return nil, NewQueryError(t.Pos, t.Text, "missing upper bound")
+		}
+	default:
+		expr.Value = value
+	}
+
+	if expr.Value == "" {
+		return nil, NewQueryError(t.Pos, t.Text, "missing value")
+	}
+
+	return expr, nil
+}
+
+// And returns an expression that matches both x and y, either may be nil.
+func (x *Expr) And(y *Expr) *Expr {
+	if x == nil {
+		return y
+	} else if y == nil {
+		return x
+	}
+
+	return &Expr{Op: ExprAnd, Args: []*Expr{x, y}, Pos: x.Pos}
+}
+
+// String returns the expression in query syntax.
+func (x *Expr) String() string {
+	if x == nil {
+		return ""
+	}
+
+	switch x.Op {
+	case ExprAnd, ExprOr:
+		sep := " "
+
+		if x.Op == ExprOr {
+			sep = " OR "
+		}
+
+		s := make([]string, len(x.Args))
+
+		for i, arg := range x.Args {
+			if arg.Op == ExprOr || arg.Op == ExprAnd && x.Op == ExprOr {
+				s[i] = "(" + arg.String() + ")"
+			} else {
+				s[i] = arg.String()
+			}
+		}
+
+		return strings.Join(s, sep)
+	case ExprNot:
+		if len(x.Args) == 0 {
+			return ""
+		} else if arg := x.Args[0]; arg.Op == ExprAnd || arg.Op == ExprOr {
+			return "-(" + arg.String() + ")"
+		} else {
+			return "-" + arg.String()
+		}
+	}
+
+	value := x.Value
+
+	if x.Cmp == CmpRange {
+		value += CmpRange + x.Max
+	} else {
+		value = x.Cmp + value
+	}
+
+	if x.Key == "" {
+		return value
+	}
+
+	if strings.ContainsAny(value, " \t()|") {
+		value = `"` + value + `"`
+	}
+
+	return x.Key + ":" + value
+}
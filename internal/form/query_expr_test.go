@@ -0,0 +1,160 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExpr(t *testing.T) {
+	assert.False(t, IsExpr(""))
+	assert.False(t, IsExpr("label:cat favorite:true"))
+	assert.False(t, IsExpr("path:2019/* before:2019-01-15"))
+	assert.False(t, IsExpr(`"Golden Gate" bridge`))
+	assert.False(t, IsExpr(`title:"foo (bar)"`))
+	assert.True(t, IsExpr("label:cat OR label:dog"))
+	assert.True(t, IsExpr("label:cat | label:dog"))
+	assert.True(t, IsExpr("-country:de"))
+	assert.True(t, IsExpr("NOT favorite:true"))
+	assert.True(t, IsExpr("(year:2019)"))
+	assert.True(t, IsExpr("iso:>1600"))
+	assert.True(t, IsExpr("f:1.4..2.8"))
+}
+
+func TestParseExpr(t *testing.T) {
+	t.Run("example", func(t *testing.T) {
+		expr, err := ParseExpr("label:cat OR label:dog -country:de (year:2019 | year:2020)")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ExprOr, expr.Op)
+		assert.Len(t, expr.Args, 2)
+		assert.Equal(t, "label", expr.Args[0].Key)
+		assert.Equal(t, "cat", expr.Args[0].Value)
+
+		right := expr.Args[1]
+
+		assert.Equal(t, ExprAnd, right.Op)
+		assert.Len(t, right.Args, 3)
+		assert.Equal(t, ExprNot, right.Args[1].Op)
+		assert.Equal(t, "country", right.Args[1].Args[0].Key)
+		assert.Equal(t, 23, right.Args[1].Pos)
+		assert.Equal(t, ExprOr, right.Args[2].Op)
+		assert.Equal(t, "label:cat OR (label:dog -country:de (year:2019 OR year:2020))", expr.String())
+	})
+	t.Run("and", func(t *testing.T) {
+		expr, err := ParseExpr("label:cat AND favorite:true")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ExprAnd, expr.Op)
+		assert.Equal(t, "label:cat favorite:true", expr.String())
+	})
+	t.Run("comparison", func(t *testing.T) {
+		expr, err := ParseExpr("iso:>1600")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ExprTerm, expr.Op)
+		assert.Equal(t, "iso", expr.Key)
+		assert.Equal(t, CmpGreater, expr.Cmp)
+		assert.Equal(t, "1600", expr.Value)
+
+		expr, err = ParseExpr("mm:<=50")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, CmpLessEqual, expr.Cmp)
+		assert.Equal(t, "50", expr.Value)
+	})
+	t.Run("range", func(t *testing.T) {
+		expr, err := ParseExpr("taken:2019-01..2019-06 f:1.4..2.8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, CmpRange, expr.Args[0].Cmp)
+		assert.Equal(t, "2019-01", expr.Args[0].Value)
+		assert.Equal(t, "2019-06", expr.Args[0].Max)
+		assert.Equal(t, "1.4", expr.Args[1].Value)
+		assert.Equal(t, "2.8", expr.Args[1].Max)
+		assert.Equal(t, "taken:2019-01..2019-06 f:1.4..2.8", expr.String())
+	})
+	t.Run("text", func(t *testing.T) {
+		expr, err := ParseExpr(`"golden gate" OR -(bridge title:"foo bar")`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, `"golden gate"`, expr.Args[0].Value)
+		assert.Equal(t, "", expr.Args[0].Key)
+		assert.Equal(t, "foo bar", expr.Args[1].Args[0].Args[1].Value)
+		assert.Equal(t, `"golden gate" OR -(bridge title:"foo bar")`, expr.String())
+	})
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string]int{
+			"":                     0,
+			"(year:2019":           0,
+			"year:2019)":           9,
+			"label:cat OR":         12,
+			"OR label:cat":         0,
+			"label:cat ()":         11,
+			"iso:>":                0,
+			"f:1.4..":              0,
+			"label:cat AND":        13,
+			"la-bel:cat | year:19": 0,
+		}
+
+		for q, pos := range tests {
+			_, err := ParseExpr(q)
+
+			if queryErr, ok := err.(*QueryError); !ok {
+				t.Errorf("%s: expected query error, got %v", q, err)
+			} else {
+				assert.Equal(t, pos, queryErr.Pos, q)
+			}
+		}
+	})
+}
+
+func TestPhotoSearch_ParseQueryString_Expr(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		f := &PhotoSearch{Query: "label:cat OR label:dog"}
+
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", f.Query)
+		assert.Equal(t, "", f.Label)
+		assert.Equal(t, "label:cat OR label:dog", f.Expr.String())
+		assert.Equal(t, "label:cat OR label:dog", f.Serialize())
+	})
+	t.Run("filter", func(t *testing.T) {
+		f := &PhotoSearch{Query: "iso:>1600", Filter: "year:2019 | year:2020"}
+
+		if err := f.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "iso:>1600 (year:2019 OR year:2020)", f.Expr.String())
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		f := &PhotoSearch{Filter: "(year:2019"}
+
+		err := f.ParseQueryString()
+
+		assert.IsType(t, &QueryError{}, err)
+		assert.Equal(t, "missing closing parenthesis at position 0: (", err.Error())
+	})
+}
@@ -67,6 +67,10 @@ func Serialize(f interface{}, all bool) string {
 				if val := fieldValue.Bool(); val {
 					q = append(q, fmt.Sprintf("%s:%t", fieldName, fieldValue.Bool()))
 				}
+			case *Expr:
+				if t != nil {
+					q = append(q, t.String())
+				}
 			default:
 				log.Warnf("can't serialize value of type %s from form field %s", t, fieldName)
 			}
@@ -78,7 +82,7 @@ func Serialize(f interface{}, all bool) string {
 
 func Unserialize(f SearchForm, q string) (result error) {
 	var key, value []rune
-	var escaped, isKeyValue bool
+	var escaped, quoted, isKeyValue bool
 
 	f.SetQuery("")
 
@@ -132,10 +136,18 @@ func Unserialize(f SearchForm, q string) (result error) {
 					result = fmt.Errorf("unknown filter: %s", fieldName)
 				}
 			} else if len(strings.TrimSpace(string(key))) > 0 {
-				queryStrings = append(queryStrings, strings.TrimSpace(string(key)))
+				queryString := strings.TrimSpace(string(key))
+
+				// Keep quotes around phrases.
+				if quoted && strings.ContainsAny(queryString, " \t") {
+					queryString = `"` + queryString + `"`
+				}
+
+				queryStrings = append(queryStrings, queryString)
 			}
 
 			escaped = false
+			quoted = false
 			isKeyValue = false
 			key = key[:0]
 			value = value[:0]
@@ -143,6 +155,7 @@ func Unserialize(f SearchForm, q string) (result error) {
 			isKeyValue = true
 		} else if char == '"' {
 			escaped = !escaped
+			quoted = quoted || !isKeyValue
 		} else if isKeyValue {
 			value = append(value, char)
 		} else {
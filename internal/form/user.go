@@ -0,0 +1,11 @@
+package form
+
+// User represents a user account create and edit form.
+type User struct {
+	UserName     string `json:"UserName"`
+	FullName     string `json:"FullName"`
+	PrimaryEmail string `json:"PrimaryEmail"`
+	Role         string `json:"Role"`
+	StoragePath  string `json:"StoragePath"`
+	Password     string `json:"Password"`
+}
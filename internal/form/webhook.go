@@ -0,0 +1,50 @@
+package form
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ulule/deepcopier"
+)
+
+// Webhook represents a webhook form for posting library events to a URL.
+type Webhook struct {
+	HookURL     string `json:"HookURL"`
+	HookSecret  string `json:"HookSecret"`
+	HookTopics  string `json:"HookTopics"`
+	HookEnabled bool   `json:"HookEnabled"`
+}
+
+func NewWebhook(m interface{}) (f Webhook, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
+
+// Validate returns an error if the URL is not an absolute HTTP(S) URL, and normalizes the topic filter.
+func (f *Webhook) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(f.HookURL))
+
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("webhook url must start with http:// or https://")
+	}
+
+	f.HookURL = u.String()
+
+	var topics []string
+
+	for _, topic := range strings.Split(f.HookTopics, ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	if len(topics) == 0 {
+		topics = []string{"*"}
+	}
+
+	f.HookTopics = strings.Join(topics, ",")
+
+	return nil
+}
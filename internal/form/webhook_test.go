@@ -0,0 +1,50 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		f := Webhook{HookURL: " https://example.com/hook ", HookTopics: "photos.created, albums.*,,"}
+
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, "https://example.com/hook", f.HookURL)
+		assert.Equal(t, "photos.created,albums.*", f.HookTopics)
+	})
+
+	t.Run("all topics", func(t *testing.T) {
+		f := Webhook{HookURL: "http://localhost:8080/"}
+
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, "*", f.HookTopics)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		for _, s := range []string{"", "example.com/hook", "ftp://example.com/", "https://"} {
+			f := Webhook{HookURL: s}
+			assert.Error(t, f.Validate(), s)
+		}
+	})
+}
+
+func TestNewWebhook(t *testing.T) {
+	m := struct {
+		HookURL     string
+		HookSecret  string
+		HookTopics  string
+		HookEnabled bool
+	}{HookURL: "https://example.com/hook", HookSecret: "secret", HookTopics: "*", HookEnabled: true}
+
+	f, err := NewWebhook(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "https://example.com/hook", f.HookURL)
+	assert.Equal(t, "secret", f.HookSecret)
+	assert.True(t, f.HookEnabled)
+}
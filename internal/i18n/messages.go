@@ -30,6 +30,10 @@ const (
 	ErrZipFailed
 	ErrInvalidCredentials
 	ErrInvalidLink
+	ErrPersonNotFound
+	ErrInvalidQuery
+	ErrBusy
+	ErrUploadLimit
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -66,6 +70,10 @@ const (
 	MsgSelectionProtected
 	MsgAlbumsDeleted
 	MsgZipCreatedIn
+	MsgPersonSaved
+	MsgUserCreated
+	MsgUserSaved
+	MsgUserDeleted
 )
 
 var Messages = MessageMap{
@@ -99,6 +107,10 @@ var Messages = MessageMap{
 	ErrZipFailed:          gettext("Failed to create zip file"),
 	ErrInvalidCredentials: gettext("Invalid credentials"),
 	ErrInvalidLink:        gettext("Invalid link"),
+	ErrPersonNotFound:     gettext("Person not found"),
+	ErrInvalidQuery:       gettext("Invalid search query"),
+	ErrBusy:               gettext("Busy, please try again later"),
+	ErrUploadLimit:        gettext("Upload exceeds the limit"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -136,4 +148,8 @@ var Messages = MessageMap{
 	MsgSelectionProtected:    gettext("Selection marked as private"),
 	MsgAlbumsDeleted:         gettext("Albums deleted"),
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
+	MsgPersonSaved:           gettext("Person saved"),
+	MsgUserCreated:           gettext("User created"),
+	MsgUserSaved:             gettext("User saved"),
+	MsgUserDeleted:           gettext("User deleted"),
 }
@@ -13,11 +13,16 @@ import (
 type Data struct {
 	DocumentID   string        `meta:"ImageUniqueID,OriginalDocumentID,DocumentID"`
 	InstanceID   string        `meta:"InstanceID,DocumentID"`
+	ContentID    string        `meta:"ContentIdentifier,MediaGroupUUID"`
 	TakenAt      time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
 	TakenAtLocal time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
 	TimeZone     string        `meta:"-"`
 	Duration     time.Duration `meta:"Duration,MediaDuration,TrackDuration"`
 	Codec        string        `meta:"CompressorID,Compression,FileType"`
+	Bitrate      int           `meta:"-"`
+	FrameRate    float64       `meta:"VideoFrameRate"`
+	Audio        []AudioStream `meta:"-"`
+	Track        Track         `meta:"-"`
 	Title        string        `meta:"Title"`
 	Subject      string        `meta:"Subject,PersonInImage,ObjectName"`
 	Keywords     string        `meta:"Keywords"`
@@ -95,6 +100,11 @@ func (data Data) HasInstanceID() bool {
 	return rnd.IsUUID(data.InstanceID)
 }
 
+// HasContentID returns true if a live photo ContentIdentifier exists.
+func (data Data) HasContentID() bool {
+	return rnd.IsUUID(data.ContentID)
+}
+
 // HasTimeAndPlace if data contains a time and gps position.
 func (data Data) HasTimeAndPlace() bool {
 	return !data.TakenAt.IsZero() && data.Lat != 0 && data.Lng != 0
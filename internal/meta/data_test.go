@@ -124,6 +124,24 @@ func TestData_HasInstanceID(t *testing.T) {
 	})
 }
 
+func TestData_HasContentID(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		data := Data{
+			ContentID: "4aa0a7d5-2f3b-4b0e-8d0a-2e6f3c4c5b11",
+		}
+
+		assert.Equal(t, true, data.HasContentID())
+	})
+
+	t.Run("false", func(t *testing.T) {
+		data := Data{
+			ContentID: "",
+		}
+
+		assert.Equal(t, false, data.HasContentID())
+	})
+}
+
 func TestData_HasTimeAndPlace(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		data := Data{
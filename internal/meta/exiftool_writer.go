@@ -0,0 +1,103 @@
+package meta
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExiftoolArgs returns the Exiftool command arguments for embedding the metadata in a media file.
+// Empty values are skipped so that existing tags are kept, unless the field was explicitly cleared,
+// e.g. "Title" or "Description".
+func (data Data) ExiftoolArgs(fileName string, cleared ...string) []string {
+	args := []string{"-overwrite_original", "-P", "-m", "-sep", ", "}
+
+	set := func(value string, tags ...string) {
+		for _, tag := range tags {
+			args = append(args, fmt.Sprintf("-%s=%s", tag, value))
+		}
+	}
+
+	setField := func(field, value string, tags ...string) {
+		if value != "" {
+			set(value, tags...)
+			return
+		}
+
+		for _, f := range cleared {
+			if f == field {
+				set(value, tags...)
+				return
+			}
+		}
+	}
+
+	setField("Title", data.Title, "XMP-dc:Title", "IPTC:ObjectName")
+	setField("Description", data.Description, "EXIF:ImageDescription", "XMP-dc:Description", "IPTC:Caption-Abstract")
+	setField("Keywords", strings.Join(data.KeywordList(), ", "), "XMP-dc:Subject", "IPTC:Keywords")
+	setField("Artist", data.Artist, "EXIF:Artist", "XMP-dc:Creator", "IPTC:By-line")
+	setField("Copyright", data.Copyright, "EXIF:Copyright", "XMP-dc:Rights", "IPTC:CopyrightNotice")
+
+	if !data.TakenAtLocal.IsZero() {
+		set(data.TakenAtLocal.Format("2006:01:02 15:04:05"), "EXIF:DateTimeOriginal", "EXIF:CreateDate")
+	}
+
+	if taken := data.xmpDate(); taken != "" {
+		set(taken, "XMP-photoshop:DateCreated", "XMP-exif:DateTimeOriginal")
+	}
+
+	if data.Lat != 0 || data.Lng != 0 {
+		set(fmt.Sprintf("%f", math.Abs(float64(data.Lat))), "EXIF:GPSLatitude")
+		set(gpsRef(float64(data.Lat), "N", "S"), "EXIF:GPSLatitudeRef")
+		set(fmt.Sprintf("%f", math.Abs(float64(data.Lng))), "EXIF:GPSLongitude")
+		set(gpsRef(float64(data.Lng), "E", "W"), "EXIF:GPSLongitudeRef")
+		set(xmpGps(float64(data.Lat), 'N', 'S'), "XMP-exif:GPSLatitude")
+		set(xmpGps(float64(data.Lng), 'E', 'W'), "XMP-exif:GPSLongitude")
+
+		if data.Altitude != 0 {
+			set(fmt.Sprintf("%d", int(math.Abs(float64(data.Altitude)))), "EXIF:GPSAltitude")
+			set(gpsRef(float64(data.Altitude), "Above Sea Level", "Below Sea Level"), "EXIF:GPSAltitudeRef")
+		}
+	}
+
+	return append(args, fileName)
+}
+
+// SaveExiftool embeds the metadata in a media file using Exiftool, see ExiftoolArgs.
+func (data Data) SaveExiftool(exiftoolBin, fileName string, cleared ...string) error {
+	if exiftoolBin == "" {
+		return errors.New("metadata: exiftool not found")
+	}
+
+	cmd := exec.Command(exiftoolBin, data.ExiftoolArgs(fileName, cleared...)...)
+
+	// Fetch command output.
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Run exiftool command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return fmt.Errorf("metadata: %s in %s (exiftool)", strings.TrimSpace(stderr.String()), txt.Quote(filepath.Base(fileName)))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// gpsRef returns the reference string for positive or negative values.
+func gpsRef(v float64, pos, neg string) string {
+	if v < 0 {
+		return neg
+	}
+
+	return pos
+}
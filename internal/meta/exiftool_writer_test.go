@@ -0,0 +1,44 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_ExiftoolArgs(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		args := testXmpData().ExiftoolArgs("/photos/elephants.jpg")
+
+		assert.Equal(t, "-overwrite_original", args[0])
+		assert.Equal(t, "/photos/elephants.jpg", args[len(args)-1])
+		assert.Contains(t, args, "-XMP-dc:Title=Elephants & Friends")
+		assert.Contains(t, args, "-IPTC:Keywords=elephant, africa, safari")
+		assert.Contains(t, args, "-EXIF:DateTimeOriginal=2013:11:26 13:30:00")
+		assert.Contains(t, args, "-EXIF:GPSLatitude=33.456219")
+		assert.Contains(t, args, "-EXIF:GPSLatitudeRef=S")
+		assert.Contains(t, args, "-EXIF:GPSAltitude=190")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		args := Data{}.ExiftoolArgs("foo.jpg")
+
+		assert.Equal(t, []string{"-overwrite_original", "-P", "-m", "-sep", ", ", "foo.jpg"}, args)
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		args := Data{Artist: "Jane"}.ExiftoolArgs("foo.jpg", "Title")
+
+		assert.Contains(t, args, "-XMP-dc:Title=")
+		assert.Contains(t, args, "-IPTC:ObjectName=")
+		assert.Contains(t, args, "-EXIF:Artist=Jane")
+		assert.NotContains(t, args, "-XMP-dc:Description=")
+		assert.NotContains(t, args, "-EXIF:GPSLatitudeRef=N")
+	})
+}
+
+func TestData_SaveExiftool(t *testing.T) {
+	err := Data{}.SaveExiftool("", "foo.jpg")
+
+	assert.EqualError(t, err, "metadata: exiftool not found")
+}
@@ -178,6 +178,11 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		data.InstanceID = rnd.SanitizeUUID(data.InstanceID)
 	}
 
+	// Validate and normalize optional live photo ContentIdentifier.
+	if data.ContentID != "" {
+		data.ContentID = rnd.SanitizeUUID(data.ContentID)
+	}
+
 	if data.Projection == "equirectangular" {
 		data.AddKeyword(KeywordPanorama)
 	}
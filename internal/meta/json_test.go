@@ -33,6 +33,18 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 	})
 
+	t.Run("live-photo.json", func(t *testing.T) {
+		data, err := JSON("testdata/live-photo.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, data.HasContentID())
+		assert.Equal(t, "4aa0a7d5-2f3b-4b0e-8d0a-2e6f3c4c5b11", data.ContentID)
+		assert.Equal(t, "iPhone 11", data.CameraModel)
+	})
+
 	t.Run("gopher-telegram.json", func(t *testing.T) {
 		data, err := JSON("testdata/gopher-telegram.json", "")
 
@@ -0,0 +1,146 @@
+package meta
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxTrackPoints is the maximum number of GPS positions stored for a video.
+const MaxTrackPoints = 4000
+
+// AudioStream represents an audio stream in a video file.
+type AudioStream struct {
+	Codec      string
+	Channels   int
+	SampleRate int
+	Language   string
+}
+
+// AudioCodecs returns the comma-separated audio stream codecs.
+func (data Data) AudioCodecs() string {
+	codecs := make([]string, 0, len(data.Audio))
+
+	for _, a := range data.Audio {
+		codecs = append(codecs, a.Codec)
+	}
+
+	return strings.Join(codecs, ",")
+}
+
+// TrackPoint represents a GPS position recorded in a video.
+type TrackPoint struct {
+	Lat      float64
+	Lng      float64
+	Altitude float64
+}
+
+// Track represents a GPS track as recorded by action cameras and drones.
+type Track []TrackPoint
+
+// Valid returns true if the position has valid coordinates.
+func (p TrackPoint) Valid() bool {
+	return (p.Lat != 0 || p.Lng != 0) && math.Abs(p.Lat) <= 90 && math.Abs(p.Lng) <= 180
+}
+
+// Thin returns a track with at most max points that still includes the first and the last point.
+func (t Track) Thin(max int) Track {
+	if max < 2 || len(t) <= max {
+		return t
+	}
+
+	result := make(Track, 0, max)
+	step := float64(len(t)-1) / float64(max-1)
+
+	for i := 0; i < max; i++ {
+		result = append(result, t[int(math.Round(float64(i)*step))])
+	}
+
+	return result
+}
+
+// Polyline returns the track in encoded polyline algorithm format with a precision of 5 decimal places.
+func (t Track) Polyline() string {
+	var b strings.Builder
+	var lastLat, lastLng int64
+
+	for _, p := range t.Thin(MaxTrackPoints) {
+		lat := int64(math.Round(p.Lat * 1e5))
+		lng := int64(math.Round(p.Lng * 1e5))
+
+		// Skip duplicate positions.
+		if b.Len() > 0 && lat == lastLat && lng == lastLng {
+			continue
+		}
+
+		encodePolylineValue(&b, lat-lastLat)
+		encodePolylineValue(&b, lng-lastLng)
+
+		lastLat, lastLng = lat, lng
+	}
+
+	return b.String()
+}
+
+// ParsePolyline decodes a track in encoded polyline algorithm format.
+func ParsePolyline(s string) (result Track, err error) {
+	var lat, lng int64
+
+	for i := 0; i < len(s); {
+		var values [2]int64
+
+		for j := range values {
+			var shift uint
+			var v int64
+
+			for {
+				if i >= len(s) {
+					return result, fmt.Errorf("polyline: unexpected end at position %d", i)
+				}
+
+				c := int64(s[i]) - 63
+				i++
+
+				if c < 0 || c > 63 {
+					return result, fmt.Errorf("polyline: invalid character at position %d", i-1)
+				}
+
+				v |= (c & 0x1f) << shift
+				shift += 5
+
+				if c < 0x20 {
+					break
+				}
+			}
+
+			if v&1 != 0 {
+				values[j] = ^(v >> 1)
+			} else {
+				values[j] = v >> 1
+			}
+		}
+
+		lat += values[0]
+		lng += values[1]
+
+		result = append(result, TrackPoint{Lat: float64(lat) / 1e5, Lng: float64(lng) / 1e5})
+	}
+
+	return result, nil
+}
+
+// encodePolylineValue appends a signed value in encoded polyline algorithm format.
+func encodePolylineValue(b *strings.Builder, v int64) {
+	u := v << 1
+
+	if v < 0 {
+		u = ^u
+	}
+
+	for u >= 0x20 {
+		b.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+
+	b.WriteByte(byte(u + 63))
+}
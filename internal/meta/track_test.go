@@ -0,0 +1,52 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack_Polyline(t *testing.T) {
+	// Example from the polyline algorithm documentation.
+	track := Track{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}, {Lat: 40.7, Lng: -120.95}, {Lat: 43.252, Lng: -126.453}}
+
+	assert.Equal(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", track.Polyline())
+	assert.Equal(t, "", Track{}.Polyline())
+}
+
+func TestParsePolyline(t *testing.T) {
+	track, err := ParsePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Track{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}, {Lat: 43.252, Lng: -126.453}}, track)
+
+	_, err = ParsePolyline("_p~iF~ps|U_")
+
+	assert.Error(t, err)
+}
+
+func TestTrack_Thin(t *testing.T) {
+	var track Track
+
+	for i := 0; i < 10; i++ {
+		track = append(track, TrackPoint{Lat: float64(i)})
+	}
+
+	result := track.Thin(4)
+
+	assert.Equal(t, Track{{Lat: 0}, {Lat: 3}, {Lat: 6}, {Lat: 9}}, result)
+	assert.Len(t, track.Thin(20), 10)
+}
+
+func TestTrackPoint_Valid(t *testing.T) {
+	assert.True(t, TrackPoint{Lat: 47.1, Lng: 8.2}.Valid())
+	assert.False(t, TrackPoint{}.Valid())
+	assert.False(t, TrackPoint{Lat: 91, Lng: 8.2}.Valid())
+}
+
+func TestData_AudioCodecs(t *testing.T) {
+	data := Data{Audio: []AudioStream{{Codec: "aac"}, {Codec: "ac3"}}}
+
+	assert.Equal(t, "aac,ac3", data.AudioCodecs())
+	assert.Equal(t, "", Data{}.AudioCodecs())
+}
@@ -0,0 +1,355 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// XMP namespace URIs used when writing sidecar files.
+const (
+	XmpNsX         = "adobe:ns:meta/"
+	XmpNsRdf       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XmpNsDc        = "http://purl.org/dc/elements/1.1/"
+	XmpNsExif      = "http://ns.adobe.com/exif/1.0/"
+	XmpNsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+)
+
+// XmpPrefixes maps the namespace URIs of properties written by PhotoPrism to their default prefixes.
+var XmpPrefixes = map[string]string{
+	XmpNsDc:        "dc",
+	XmpNsExif:      "exif",
+	XmpNsPhotoshop: "photoshop",
+}
+
+// XmpProperties contains the properties managed by PhotoPrism, existing values will be replaced.
+var XmpProperties = map[xml.Name]bool{
+	{Space: XmpNsDc, Local: "title"}:              true,
+	{Space: XmpNsDc, Local: "description"}:        true,
+	{Space: XmpNsDc, Local: "subject"}:            true,
+	{Space: XmpNsDc, Local: "creator"}:            true,
+	{Space: XmpNsDc, Local: "rights"}:             true,
+	{Space: XmpNsPhotoshop, Local: "DateCreated"}: true,
+	{Space: XmpNsExif, Local: "DateTimeOriginal"}: true,
+	{Space: XmpNsExif, Local: "GPSVersionID"}:     true,
+	{Space: XmpNsExif, Local: "GPSLatitude"}:      true,
+	{Space: XmpNsExif, Local: "GPSLongitude"}:     true,
+	{Space: XmpNsExif, Local: "GPSAltitude"}:      true,
+	{Space: XmpNsExif, Local: "GPSAltitudeRef"}:   true,
+}
+
+var xmpAttrRegexp = regexp.MustCompile(`\s+([A-Za-z_][\w.-]*):([A-Za-z_][\w.-]*)\s*=\s*("[^"]*"|'[^']*')`)
+
+// SaveXMP writes metadata to an XMP sidecar file. If the file already exists, only the
+// properties managed by PhotoPrism are replaced so that data written by other tools is preserved.
+func (data Data) SaveXMP(fileName string) error {
+	var doc []byte
+
+	if fs.FileExists(fileName) {
+		existing, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		if doc, err = data.UpdateXMP(existing); err != nil {
+			return fmt.Errorf("metadata: can't update %s (%s)", txt.Quote(filepath.Base(fileName)), err)
+		}
+	} else {
+		doc = data.XmpPacket()
+	}
+
+	// Make sure directory exists.
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, doc, os.ModePerm)
+}
+
+// XmpPacket returns a new XMP document containing the metadata.
+func (data Data) XmpPacket() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<?xpacket begin=\"\xef\xbb\xbf\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString(`<x:xmpmeta xmlns:x="` + XmpNsX + `" x:xmptk="PhotoPrism">` + "\n")
+	b.WriteString(` <rdf:RDF xmlns:rdf="` + XmpNsRdf + `">` + "\n")
+	b.WriteString(`  <rdf:Description rdf:about=""`)
+
+	for _, ns := range []string{XmpNsDc, XmpNsExif, XmpNsPhotoshop} {
+		b.WriteString("\n    xmlns:" + XmpPrefixes[ns] + `="` + ns + `"`)
+	}
+
+	b.WriteString(">")
+	b.WriteString(data.xmpProperties(XmpPrefixes, "rdf"))
+	b.WriteString("\n  </rdf:Description>\n </rdf:RDF>\n</x:xmpmeta>\n")
+	b.WriteString(`<?xpacket end="w"?>` + "\n")
+
+	return b.Bytes()
+}
+
+// UpdateXMP replaces the properties managed by PhotoPrism in an existing XMP document.
+func (data Data) UpdateXMP(doc []byte) ([]byte, error) {
+	var out bytes.Buffer
+	var last int64
+
+	d := xml.NewDecoder(bytes.NewReader(doc))
+
+	prefixes := make(map[string]string)
+	uris := map[string]string{"xml": "http://www.w3.org/XML/1998/namespace"}
+
+	descDepth := 0
+	depth := 0
+	inserted := false
+
+	for {
+		start := d.InputOffset()
+		token, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					prefixes[a.Value] = a.Name.Local
+					uris[a.Name.Local] = a.Value
+				}
+			}
+
+			// Remove managed properties from descriptions.
+			if descDepth > 0 && depth == descDepth && XmpProperties[t.Name] {
+				out.Write(bytes.TrimRight(doc[last:start], " \t\r\n"))
+
+				if err := d.Skip(); err != nil {
+					return nil, err
+				}
+
+				last = d.InputOffset()
+				continue
+			}
+
+			depth++
+
+			if t.Name.Space != XmpNsRdf || t.Name.Local != "Description" {
+				continue
+			}
+
+			descDepth = depth
+			end := d.InputOffset()
+			tag := string(doc[start:end])
+			closed := strings.HasSuffix(tag, "/>")
+
+			// Remove managed properties written as attributes.
+			tag = xmpAttrRegexp.ReplaceAllStringFunc(tag, func(attr string) string {
+				m := xmpAttrRegexp.FindStringSubmatch(attr)
+
+				if XmpProperties[xml.Name{Space: uris[m[1]], Local: m[2]}] {
+					return ""
+				}
+
+				return attr
+			})
+
+			out.Write(doc[last:start])
+			last = end
+
+			if inserted {
+				out.WriteString(tag)
+				continue
+			}
+
+			inserted = true
+
+			// Declare namespaces that aren't known yet.
+			var ns strings.Builder
+
+			for _, uri := range []string{XmpNsDc, XmpNsExif, XmpNsPhotoshop} {
+				if _, ok := prefixes[uri]; !ok {
+					prefixes[uri] = XmpPrefixes[uri]
+					ns.WriteString(" xmlns:" + XmpPrefixes[uri] + `="` + uri + `"`)
+				}
+			}
+
+			if closed {
+				tag = strings.TrimSuffix(tag, "/>")
+			} else {
+				tag = strings.TrimSuffix(tag, ">")
+			}
+
+			out.WriteString(strings.TrimRight(tag, " \t\r\n") + ns.String() + ">")
+			out.WriteString(data.xmpProperties(prefixes, prefixes[XmpNsRdf]))
+
+			if closed {
+				name := strings.TrimPrefix(tag, "<")
+
+				if i := strings.IndexAny(name, " \t\r\n/>"); i > 0 {
+					name = name[:i]
+				}
+
+				out.WriteString("\n  </" + name + ">")
+			}
+		case xml.EndElement:
+			if depth == descDepth {
+				descDepth = 0
+			}
+
+			depth--
+		}
+	}
+
+	if !inserted {
+		return nil, errors.New("rdf:Description not found")
+	}
+
+	out.Write(doc[last:])
+
+	return out.Bytes(), nil
+}
+
+// xmpProperties returns the managed properties as XML using the given namespace prefixes.
+func (data Data) xmpProperties(prefixes map[string]string, rdf string) string {
+	var b strings.Builder
+
+	dc := prefixes[XmpNsDc]
+	exif := prefixes[XmpNsExif]
+	photoshop := prefixes[XmpNsPhotoshop]
+
+	alt := func(name, value string) {
+		if value == "" {
+			return
+		}
+
+		fmt.Fprintf(&b, "\n   <%s:%s>\n    <%s:Alt>\n     <%s:li xml:lang=\"x-default\">%s</%s:li>\n    </%s:Alt>\n   </%s:%s>",
+			dc, name, rdf, rdf, xmpEscape(value), rdf, rdf, dc, name)
+	}
+
+	list := func(name, kind string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "\n   <%s:%s>\n    <%s:%s>", dc, name, rdf, kind)
+
+		for _, v := range values {
+			fmt.Fprintf(&b, "\n     <%s:li>%s</%s:li>", rdf, xmpEscape(v), rdf)
+		}
+
+		fmt.Fprintf(&b, "\n    </%s:%s>\n   </%s:%s>", rdf, kind, dc, name)
+	}
+
+	simple := func(prefix, name, value string) {
+		if value == "" {
+			return
+		}
+
+		fmt.Fprintf(&b, "\n   <%s:%s>%s</%s:%s>", prefix, name, xmpEscape(value), prefix, name)
+	}
+
+	alt("title", data.Title)
+	alt("description", data.Description)
+	list("subject", "Bag", data.KeywordList())
+
+	if data.Artist != "" {
+		list("creator", "Seq", []string{data.Artist})
+	}
+
+	alt("rights", data.Copyright)
+
+	if taken := data.xmpDate(); taken != "" {
+		simple(photoshop, "DateCreated", taken)
+		simple(exif, "DateTimeOriginal", taken)
+	}
+
+	if data.Lat != 0 || data.Lng != 0 {
+		simple(exif, "GPSVersionID", "2.2.0.0")
+		simple(exif, "GPSLatitude", xmpGps(float64(data.Lat), 'N', 'S'))
+		simple(exif, "GPSLongitude", xmpGps(float64(data.Lng), 'E', 'W'))
+
+		if data.Altitude != 0 {
+			ref := "0"
+
+			if data.Altitude < 0 {
+				ref = "1"
+			}
+
+			simple(exif, "GPSAltitude", fmt.Sprintf("%d/1", int(math.Abs(float64(data.Altitude)))))
+			simple(exif, "GPSAltitudeRef", ref)
+		}
+	}
+
+	return b.String()
+}
+
+// KeywordList returns the keywords as slice.
+func (data Data) KeywordList() (result []string) {
+	for _, w := range strings.Split(data.Keywords, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// xmpDate returns the local time the picture was taken, including the offset if the time zone is known.
+func (data Data) xmpDate() string {
+	if data.TakenAtLocal.IsZero() && data.TakenAt.IsZero() {
+		return ""
+	}
+
+	if data.TimeZone != "" && !data.TakenAt.IsZero() {
+		if loc, err := time.LoadLocation(data.TimeZone); err == nil {
+			return data.TakenAt.In(loc).Format("2006-01-02T15:04:05-07:00")
+		}
+	}
+
+	if data.TakenAtLocal.IsZero() {
+		return data.TakenAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	return data.TakenAtLocal.Format("2006-01-02T15:04:05")
+}
+
+// xmpGps formats a coordinate as XMP GPSCoordinate string, e.g. "52,27,34.8840N".
+func xmpGps(v float64, pos, neg byte) string {
+	ref := pos
+
+	if v < 0 {
+		ref = neg
+		v = -v
+	}
+
+	deg := math.Floor(v)
+	min := math.Floor((v - deg) * 60)
+	sec := ((v-deg)*60 - min) * 60
+
+	return fmt.Sprintf("%d,%d,%.4f%c", int(deg), int(min), sec, ref)
+}
+
+// xmpEscape returns the value with XML special characters escaped.
+func xmpEscape(s string) string {
+	var b strings.Builder
+
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return ""
+	}
+
+	return b.String()
+}
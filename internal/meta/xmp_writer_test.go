@@ -0,0 +1,163 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testXmpData() Data {
+	return Data{
+		Title:        "Elephants & Friends",
+		Description:  "Morning at the <waterhole>",
+		Keywords:     "elephant, africa, safari",
+		Artist:       "Jane Doe",
+		Copyright:    "CC BY-SA 4.0",
+		TakenAt:      time.Date(2013, 11, 26, 11, 30, 0, 0, time.UTC),
+		TakenAtLocal: time.Date(2013, 11, 26, 13, 30, 0, 0, time.UTC),
+		TimeZone:     "Africa/Johannesburg",
+		Lat:          -33.45622,
+		Lng:          25.764765,
+		Altitude:     190,
+	}
+}
+
+func TestData_SaveXMP(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		fileName := filepath.Join(os.TempDir(), "photoprism-meta-new.xmp")
+		defer os.Remove(fileName)
+
+		_ = os.Remove(fileName)
+
+		if err := testXmpData().SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Elephants & Friends", data.Title)
+		assert.Equal(t, "Morning at the <waterhole>", data.Description)
+		assert.Equal(t, "Jane Doe", data.Artist)
+		assert.Equal(t, "CC BY-SA 4.0", data.Copyright)
+
+		// Keywords, dates and coordinates aren't read from sidecar files, so check the document instead.
+		doc, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(doc)
+
+		assert.Contains(t, s, "<rdf:li>safari</rdf:li>")
+		assert.Contains(t, s, "<exif:DateTimeOriginal>2013-11-26T13:30:00+02:00</exif:DateTimeOriginal>")
+		assert.Contains(t, s, "<exif:GPSLatitude>33,27,22.3874S</exif:GPSLatitude>")
+		assert.Contains(t, s, "<exif:GPSLongitude>25,45,53.1532E</exif:GPSLongitude>")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		fileName := filepath.Join(os.TempDir(), "photoprism-meta-update.xmp")
+		defer os.Remove(fileName)
+
+		doc, err := ioutil.ReadFile("testdata/photoshop.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, doc, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := testXmpData().SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Elephants & Friends", data.Title)
+		assert.Equal(t, "Jane Doe", data.Artist)
+		assert.Equal(t, "HUAWEI", data.CameraMake)
+		assert.Equal(t, "ELE-L29", data.CameraModel)
+		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+
+		doc, err = ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(doc), "<exif:GPSLatitude>33,27,22.3874S</exif:GPSLatitude>")
+	})
+}
+
+func TestData_UpdateXMP(t *testing.T) {
+	t.Run("attributes", func(t *testing.T) {
+		doc := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:exif="http://ns.adobe.com/exif/1.0/"
+    xmlns:darktable="http://darktable.sf.net/"
+    exif:DateTimeOriginal="2010:01:01 00:00:00"
+    darktable:xmp_version="3"/>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+		result, err := testXmpData().UpdateXMP([]byte(doc))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(result)
+
+		assert.NotContains(t, s, "2010:01:01")
+		assert.Contains(t, s, `darktable:xmp_version="3"`)
+		assert.Contains(t, s, `xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+		assert.Contains(t, s, "<exif:DateTimeOriginal>2013-11-26T13:30:00+02:00</exif:DateTimeOriginal>")
+		assert.Contains(t, s, "</rdf:Description>")
+		assert.Equal(t, 1, strings.Count(s, "<dc:title>"))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		doc := testXmpData().XmpPacket()
+		data := Data{Title: "Cat"}
+
+		result, err := data.UpdateXMP(doc)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(result)
+
+		assert.Equal(t, 1, strings.Count(s, "<dc:title>"))
+		assert.Contains(t, s, ">Cat<")
+		assert.NotContains(t, s, "Elephants")
+		assert.NotContains(t, s, "GPSLatitude")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := testXmpData().UpdateXMP([]byte("<x:xmpmeta><foo></x:xmpmeta>"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestXmpGps(t *testing.T) {
+	assert.Equal(t, "52,27,34.9200N", xmpGps(52.4597, 'N', 'S'))
+	assert.Equal(t, "13,19,18.5952W", xmpGps(-13.321832, 'E', 'W'))
+}
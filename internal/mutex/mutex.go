@@ -6,10 +6,12 @@ import (
 
 var (
 	Db          = sync.Mutex{}
+	People      = sync.Mutex{}
 	MainWorker  = Busy{}
 	SyncWorker  = Busy{}
 	ShareWorker = Busy{}
 	MetaWorker  = Busy{}
+	ThumbWorker = Busy{}
 )
 
 // WorkersBusy returns true if any worker is busy.
@@ -256,9 +256,25 @@ func (c *Convert) ToJpeg(image *MediaFile) (*MediaFile, error) {
 		return NewMediaFile(jpegName)
 	}
 
+	if image.IsVideo() {
+		if err := c.VideoPoster(image, jpegName); err != nil {
+			log.Debugf("convert: %s, using first frame", err)
+		} else {
+			return NewMediaFile(jpegName)
+		}
+	}
+
 	cmd, useMutex, err := c.JpegConvertCommand(image, jpegName, xmpName)
 
-	if err != nil {
+	// Use the embedded preview if no RAW converter is available.
+	if err != nil && image.IsRaw() {
+		if previewErr := c.RawPreview(image, jpegName); previewErr != nil {
+			log.Debug(previewErr)
+			return nil, err
+		}
+
+		return NewMediaFile(jpegName)
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -298,6 +314,21 @@ func (c *Convert) AvcConvertCommand(mf *MediaFile, avcName string) (result *exec
 	return result, useMutex, nil
 }
 
+// AvcFile returns the existing AVC1 version of a video file.
+func (c *Convert) AvcFile(video *MediaFile) (*MediaFile, error) {
+	avcName := fs.TypeMp4.FindFirst(video.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), c.conf.Settings().StackSequences())
+
+	mediaFile, err := NewMediaFile(avcName)
+
+	if err != nil {
+		return nil, err
+	} else if !mediaFile.IsPlayableVideo() {
+		return nil, fmt.Errorf("convert: %s is not a playable video", txt.Quote(mediaFile.BaseName()))
+	}
+
+	return mediaFile, nil
+}
+
 // ToAvc1 converts a single video file to AVC1 if possible.
 func (c *Convert) ToAvc1(video *MediaFile) (*MediaFile, error) {
 	if !video.Exists() {
@@ -308,11 +339,7 @@ func (c *Convert) ToAvc1(video *MediaFile) (*MediaFile, error) {
 		return video, nil
 	}
 
-	avcName := fs.TypeMp4.FindFirst(video.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), c.conf.Settings().StackSequences())
-
-	mediaFile, err := NewMediaFile(avcName)
-
-	if err == nil && mediaFile.IsPlayableVideo() {
+	if mediaFile, err := c.AvcFile(video); err == nil {
 		return mediaFile, nil
 	}
 
@@ -320,7 +347,7 @@ func (c *Convert) ToAvc1(video *MediaFile) (*MediaFile, error) {
 		return nil, fmt.Errorf("convert: disabled in read only mode (%s)", video.RelName(c.conf.OriginalsPath()))
 	}
 
-	avcName = fs.FileName(video.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.AvcExt, c.conf.Settings().StackSequences())
+	avcName := fs.FileName(video.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.AvcExt, c.conf.Settings().StackSequences())
 	fileName := video.RelName(c.conf.OriginalsPath())
 
 	log.Debugf("convert: %s -> %s", fileName, filepath.Base(avcName))
@@ -0,0 +1,176 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FramesCommand returns the command for extracting video frames at the given interval as JPEG files,
+// optionally limited to the beginning of the video and scaled to fit the width and height.
+func (c *Convert) FramesCommand(mf *MediaFile, dir string, interval, limit time.Duration, width, height int) (*exec.Cmd, error) {
+	if !mf.IsVideo() {
+		return nil, fmt.Errorf("convert: file type %s not supported in %s", mf.FileType(), txt.Quote(mf.BaseName()))
+	}
+
+	args := []string{"-i", mf.FileName()}
+
+	if limit > 0 {
+		args = append(args, "-t", strconv.FormatFloat(limit.Seconds(), 'f', -1, 64))
+	}
+
+	filter := "fps=" + strconv.FormatFloat(1/interval.Seconds(), 'f', -1, 64)
+
+	if width > 0 && height > 0 {
+		filter += fmt.Sprintf(",scale=%d:%d:force_original_aspect_ratio=decrease", width, height)
+	}
+
+	args = append(args, "-vf", filter, "-q:v", "2", filepath.Join(dir, "frame_%04d.jpg"))
+
+	return exec.Command(c.conf.FFmpegBin(), args...), nil
+}
+
+// Frames extracts video frames to a temporary directory and returns their file names in order.
+// The caller is responsible for removing the directory.
+func (c *Convert) Frames(mf *MediaFile, interval, limit time.Duration, width, height int) (dir string, fileNames []string, err error) {
+	if err := os.MkdirAll(c.conf.TempPath(), os.ModePerm); err != nil {
+		return "", fileNames, err
+	}
+
+	dir, err = ioutil.TempDir(c.conf.TempPath(), "frames")
+
+	if err != nil {
+		return "", fileNames, err
+	}
+
+	cmd, err := c.FramesCommand(mf, dir, interval, limit, width, height)
+
+	if err != nil {
+		return dir, fileNames, err
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Run convert command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return dir, fileNames, errors.New(stderr.String())
+		} else {
+			return dir, fileNames, err
+		}
+	}
+
+	fileNames, err = filepath.Glob(filepath.Join(dir, "frame_*.jpg"))
+
+	if err != nil {
+		return dir, fileNames, err
+	} else if len(fileNames) == 0 {
+		return dir, fileNames, fmt.Errorf("convert: no frames found in %s", txt.Quote(mf.BaseName()))
+	}
+
+	return dir, fileNames, nil
+}
+
+// VideoPoster saves the sharpest frame that isn't black from the beginning of a video as JPEG.
+func (c *Convert) VideoPoster(mf *MediaFile, jpegName string) error {
+	dir, fileNames, err := c.Frames(mf, video.PosterInterval, video.PosterDuration, 0, 0)
+
+	if dir != "" {
+		defer os.RemoveAll(dir)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	best, err := thumb.BestFrame(fileNames)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(best)
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(jpegName, data, os.ModePerm)
+}
+
+// SpriteFiles returns the sprite sheet and WebVTT file names in the thumbnail cache.
+func (c *Convert) SpriteFiles(hash string) (spriteName, vttName string, err error) {
+	t := thumb.Types["sprite"]
+
+	spriteName, err = thumb.Filename(hash, c.conf.ThumbPath(), t.Width, t.Height, t.Options...)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	vttName = strings.TrimSuffix(spriteName, filepath.Ext(spriteName)) + ".vtt"
+
+	return spriteName, vttName, nil
+}
+
+// ToSprite creates a sprite sheet with video frames and a matching WebVTT thumbnail track for hover scrubbing.
+func (c *Convert) ToSprite(mf *MediaFile) (vttName string, err error) {
+	if !mf.Exists() {
+		return "", fmt.Errorf("convert: can not create sprite, file does not exist (%s)", mf.RelName(c.conf.OriginalsPath()))
+	}
+
+	spriteName, vttName, err := c.SpriteFiles(mf.Hash())
+
+	if err != nil {
+		return "", err
+	} else if fs.FileExists(vttName) && fs.FileExists(spriteName) {
+		return vttName, nil
+	}
+
+	t := thumb.Types["sprite"]
+	interval := video.SpriteFrameInterval(mf.MetaData().Duration)
+
+	log.Debugf("convert: %s -> %s", mf.RelName(c.conf.OriginalsPath()), filepath.Base(spriteName))
+
+	dir, fileNames, err := c.Frames(mf, interval, interval*video.SpriteFrames, t.Width, t.Height)
+
+	if dir != "" {
+		defer os.RemoveAll(dir)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(fileNames) > video.SpriteFrames {
+		fileNames = fileNames[:video.SpriteFrames]
+	}
+
+	if err := thumb.Sprite(fileNames, spriteName, t.Width, t.Height, video.SpriteCols); err != nil {
+		return "", err
+	}
+
+	vtt := video.WebVTT(filepath.Base(spriteName), len(fileNames), video.SpriteCols, t.Width, t.Height, interval)
+
+	if err := ioutil.WriteFile(vttName, []byte(vtt), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	return vttName, nil
+}
@@ -0,0 +1,160 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// HlsDir returns the cache directory for the HTTP live streaming files of a video.
+func (c *Convert) HlsDir(hash string) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("convert: invalid file hash %s", txt.Quote(hash))
+	}
+
+	return filepath.Join(c.conf.HlsPath(), hash[0:1], hash[1:2], hash[2:3], hash), nil
+}
+
+// HlsPlaylist returns the master playlist file name of a video if it exists.
+func (c *Convert) HlsPlaylist(hash string) (string, error) {
+	dir, err := c.HlsDir(hash)
+
+	if err != nil {
+		return "", err
+	}
+
+	playlist := filepath.Join(dir, video.HlsPlaylist)
+
+	if !fs.FileExists(playlist) {
+		return "", fmt.Errorf("convert: no playlist for %s", txt.Quote(hash))
+	}
+
+	return playlist, nil
+}
+
+// HlsCommand returns the command for creating the playlist and segments of a rendition in the working directory.
+func (c *Convert) HlsCommand(mf *MediaFile, r video.Rendition) (*exec.Cmd, error) {
+	if !mf.IsVideo() {
+		return nil, fmt.Errorf("convert: file type %s not supported in %s", mf.FileType(), txt.Quote(mf.BaseName()))
+	}
+
+	hlsTime := strconv.Itoa(video.HlsTime)
+
+	return exec.Command(c.conf.FFmpegBin(),
+		"-y",
+		"-i", mf.FileName(),
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-vf", fmt.Sprintf("scale=-2:min(%d\\,ih)", r.Height),
+		"-c:v", "libx264",
+		"-preset", "veryfast",
+		"-profile:v", "main",
+		"-pix_fmt", "yuv420p",
+		"-b:v", fmt.Sprintf("%dk", r.Bitrate),
+		"-maxrate", fmt.Sprintf("%dk", r.MaxRate()),
+		"-bufsize", fmt.Sprintf("%dk", r.BufSize()),
+		"-force_key_frames", "expr:gte(t,n_forced*"+hlsTime+")",
+		"-c:a", "aac",
+		"-b:a", fmt.Sprintf("%dk", r.AudioBitrate),
+		"-ac", "2",
+		"-f", "hls",
+		"-hls_time", hlsTime,
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", video.HlsSegment,
+		video.HlsPlaylist,
+	), nil
+}
+
+// ToHls creates segmented renditions with different bitrates and a master playlist for HTTP live streaming.
+func (c *Convert) ToHls(mf *MediaFile) (playlist string, err error) {
+	if !mf.Exists() {
+		return "", fmt.Errorf("convert: can not create hls stream, file does not exist (%s)", mf.RelName(c.conf.OriginalsPath()))
+	}
+
+	if playlist, err := c.HlsPlaylist(mf.Hash()); err == nil {
+		return playlist, nil
+	}
+
+	dir, err := c.HlsDir(mf.Hash())
+
+	if err != nil {
+		return "", err
+	}
+
+	// Create files in a temporary directory so that incomplete streams are never served.
+	tmpDir := dir + ".tmp"
+
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return "", err
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	fileName := mf.RelName(c.conf.OriginalsPath())
+
+	log.Debugf("convert: %s -> hls", fileName)
+
+	event.Publish("index.converting", event.Data{
+		"fileType": mf.FileType(),
+		"fileName": fileName,
+		"baseName": filepath.Base(fileName),
+		"xmpName":  "",
+	})
+
+	renditions := video.HlsRenditions.For(mf.Height())
+
+	for _, r := range renditions {
+		cmd, err := c.HlsCommand(mf, r)
+
+		if err != nil {
+			return "", err
+		}
+
+		cmd.Dir = filepath.Join(tmpDir, r.Name)
+
+		if err := os.MkdirAll(cmd.Dir, os.ModePerm); err != nil {
+			return "", err
+		}
+
+		// Fetch command output.
+		var out bytes.Buffer
+		var stderr bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+
+		// Run convert command.
+		if err := cmd.Run(); err != nil {
+			if stderr.String() != "" {
+				return "", errors.New(stderr.String())
+			} else {
+				return "", err
+			}
+		}
+	}
+
+	master := renditions.MasterPlaylist(mf.Width(), mf.Height())
+
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, video.HlsPlaylist), []byte(master), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, video.HlsPlaylist), nil
+}
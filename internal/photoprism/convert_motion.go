@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ToMotion extracts the video embedded in a motion photo to the sidecar path, if needed.
+// It returns nil without an error if the image does not contain a video.
+func (c *Convert) ToMotion(image *MediaFile) (*MediaFile, error) {
+	if !image.IsJpeg() {
+		return nil, nil
+	}
+
+	// Only read the whole file if data follows the end of the JPEG image.
+	if trailer, err := jpegTrailer(image.FileName()); err != nil {
+		return nil, err
+	} else if !trailer {
+		return nil, nil
+	}
+
+	videoName := fs.FileName(image.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.AvcExt, c.conf.Settings().StackSequences())
+
+	if videoName == "" {
+		return nil, fmt.Errorf("convert: can't create motion photo video for %s", txt.Quote(image.BaseName()))
+	}
+
+	if !fs.FileExists(videoName) {
+		data, err := ioutil.ReadFile(image.FileName())
+
+		if err != nil {
+			return nil, err
+		}
+
+		offset := video.MotionOffset(data)
+
+		if offset < 0 {
+			return nil, nil
+		}
+
+		if !c.conf.SidecarWritable() {
+			return nil, fmt.Errorf("convert: can't extract motion photo video from %s in read only mode", txt.Quote(image.BaseName()))
+		}
+
+		log.Debugf("convert: %s -> %s", image.RelName(c.conf.OriginalsPath()), filepath.Base(videoName))
+
+		if err := ioutil.WriteFile(videoName, data[offset:], os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := NewMediaFile(videoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.motion = true
+
+	return result, nil
+}
+
+// jpegTrailer tests if a JPEG file does not end with the end of image marker, e.g. because a video was appended.
+func jpegTrailer(fileName string) (bool, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return false, err
+	}
+
+	if info.Size() < 2 {
+		return false, nil
+	}
+
+	eoi := make([]byte, 2)
+
+	if _, err := f.ReadAt(eoi, info.Size()-2); err != nil {
+		return false, err
+	}
+
+	return !bytes.Equal(eoi, []byte{0xff, 0xd9}), nil
+}
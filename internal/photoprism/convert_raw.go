@@ -0,0 +1,335 @@
+package photoprism
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TIFF tags used to find embedded previews.
+const (
+	tagCompression   = 0x0103
+	tagStripOffsets  = 0x0111
+	tagOrientation   = 0x0112
+	tagStripCounts   = 0x0117
+	tagSubIFDs       = 0x014A
+	tagJpegOffset    = 0x0201
+	tagJpegLength    = 0x0202
+	tagExifIFD       = 0x8769
+	tagMakerNote     = 0x927C
+	tagOlympusCamera = 0x2020 // CameraSettings IFD, 0x2010 is the Equipment IFD.
+	tagOlympusStart  = 0x0101
+	tagOlympusLength = 0x0102
+)
+
+const rawMaxDepth = 4
+
+// RawPreview represents a JPEG image embedded in a RAW file.
+type RawPreview struct {
+	Offset int64
+	Length int64
+	Width  int
+	Height int
+}
+
+// Pixels returns the number of pixels of the preview image.
+func (p RawPreview) Pixels() int {
+	return p.Width * p.Height
+}
+
+// RawPreviews returns the JPEG previews embedded in a CR2, NEF, ARW, DNG, ORF or RAF file,
+// and the image orientation found in the main IFD.
+func RawPreviews(r io.ReaderAt, size int64) (previews []RawPreview, orientation int, err error) {
+	header := make([]byte, 92)
+
+	if n, err := r.ReadAt(header, 0); n < 8 {
+		return nil, 0, err
+	}
+
+	// Fujifilm RAF files start with a custom header pointing to the embedded JPEG.
+	if bytes.HasPrefix(header, []byte("FUJIFILMCCD-RAW")) {
+		t := &rawWalker{r: r, size: size}
+		t.add(int64(binary.BigEndian.Uint32(header[84:88])), int64(binary.BigEndian.Uint32(header[88:92])))
+
+		return t.previews, 1, nil
+	}
+
+	t := &rawWalker{r: r, size: size, visited: make(map[int64]bool)}
+
+	switch string(header[0:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, 0, errors.New("raw: unknown file format")
+	}
+
+	// Olympus ORF files use their own magic numbers instead of 42.
+	switch t.order.Uint16(header[2:4]) {
+	case 42, 0x4F52, 0x5352:
+	default:
+		return nil, 0, errors.New("raw: unknown file format")
+	}
+
+	t.walk(int64(t.order.Uint32(header[4:8])), 0, 0, false)
+
+	if t.orientation == 0 {
+		t.orientation = 1
+	}
+
+	return t.previews, t.orientation, nil
+}
+
+// rawEntry represents a single TIFF IFD entry.
+type rawEntry struct {
+	Type  uint16
+	Count uint32
+	Value []byte
+}
+
+// rawWalker walks the TIFF IFDs of a RAW file to find embedded JPEG previews.
+type rawWalker struct {
+	r           io.ReaderAt
+	size        int64
+	order       binary.ByteOrder
+	visited     map[int64]bool
+	orientation int
+	previews    []RawPreview
+}
+
+// walk reads the IFD chain at offset, the base is added to all offsets found in Olympus maker notes.
+func (t *rawWalker) walk(offset, base int64, depth int, olympus bool) {
+	if depth > rawMaxDepth {
+		return
+	}
+
+	for i := 0; offset > 0 && offset < t.size && i < 16 && !t.visited[offset]; i++ {
+		t.visited[offset] = true
+
+		entries, next, err := t.ifd(offset)
+
+		if err != nil {
+			log.Debugf("raw: %s", err)
+			return
+		}
+
+		if olympus {
+			t.add(base+t.uint(entries[tagOlympusStart]), t.uint(entries[tagOlympusLength]))
+
+			for _, sub := range t.offsets(entries[tagOlympusCamera], base) {
+				t.walk(base+sub, base, depth+1, true)
+			}
+		} else {
+			if depth == 0 && i == 0 {
+				t.orientation = int(t.uint(entries[tagOrientation]))
+			}
+
+			t.add(t.uint(entries[tagJpegOffset]), t.uint(entries[tagJpegLength]))
+
+			// Some cameras store the preview as a single JPEG compressed strip.
+			if c := t.uint(entries[tagCompression]); c == 6 || c == 7 {
+				if e := entries[tagStripOffsets]; e.Count == 1 {
+					t.add(t.uint(e), t.uint(entries[tagStripCounts]))
+				}
+			}
+
+			for _, sub := range t.offsets(entries[tagSubIFDs], 0) {
+				t.walk(sub, 0, depth+1, false)
+			}
+
+			for _, sub := range t.offsets(entries[tagExifIFD], 0) {
+				t.walk(sub, 0, depth+1, false)
+			}
+
+			if e := entries[tagMakerNote]; e.Count > 12 {
+				t.makerNote(int64(t.order.Uint32(e.Value)), depth)
+			}
+		}
+
+		if next > 0 {
+			offset = base + next
+		} else {
+			offset = 0
+		}
+	}
+}
+
+// makerNote walks Olympus maker notes, which contain the large preview of ORF files.
+func (t *rawWalker) makerNote(offset int64, depth int) {
+	header := make([]byte, 12)
+
+	if _, err := t.r.ReadAt(header, offset); err != nil {
+		return
+	}
+
+	if bytes.HasPrefix(header, []byte("OLYMPUS\x00")) {
+		t.walk(offset+12, offset, depth+1, true)
+	}
+}
+
+// ifd returns the entries of the IFD at offset and the offset of the next IFD.
+func (t *rawWalker) ifd(offset int64) (entries map[uint16]rawEntry, next int64, err error) {
+	buf := make([]byte, 2)
+
+	if _, err := t.r.ReadAt(buf, offset); err != nil {
+		return nil, 0, err
+	}
+
+	count := int(t.order.Uint16(buf))
+
+	if count == 0 || count > 1000 {
+		return nil, 0, fmt.Errorf("invalid ifd at offset %d", offset)
+	}
+
+	buf = make([]byte, count*12+4)
+
+	if _, err := t.r.ReadAt(buf, offset+2); err != nil {
+		return nil, 0, err
+	}
+
+	entries = make(map[uint16]rawEntry, count)
+
+	for i := 0; i < count; i++ {
+		e := buf[i*12 : i*12+12]
+		entries[t.order.Uint16(e[0:2])] = rawEntry{
+			Type:  t.order.Uint16(e[2:4]),
+			Count: t.order.Uint32(e[4:8]),
+			Value: e[8:12],
+		}
+	}
+
+	return entries, int64(t.order.Uint32(buf[count*12:])), nil
+}
+
+// uint returns the first value of a SHORT or LONG entry.
+func (t *rawWalker) uint(e rawEntry) int64 {
+	switch e.Type {
+	case 3:
+		return int64(t.order.Uint16(e.Value))
+	case 4, 13:
+		return int64(t.order.Uint32(e.Value))
+	default:
+		return 0
+	}
+}
+
+// offsets returns the IFD offsets of a LONG or IFD entry.
+func (t *rawWalker) offsets(e rawEntry, base int64) (result []int64) {
+	if e.Count == 0 || e.Count > 64 || e.Type != 4 && e.Type != 13 {
+		return nil
+	}
+
+	if e.Count == 1 {
+		return []int64{int64(t.order.Uint32(e.Value))}
+	}
+
+	buf := make([]byte, e.Count*4)
+
+	if _, err := t.r.ReadAt(buf, base+int64(t.order.Uint32(e.Value))); err != nil {
+		return nil
+	}
+
+	for i := 0; i < int(e.Count); i++ {
+		result = append(result, int64(t.order.Uint32(buf[i*4:])))
+	}
+
+	return result
+}
+
+// add adds the JPEG at offset to the list of previews, if it can be decoded.
+func (t *rawWalker) add(offset, length int64) {
+	if offset <= 0 || length <= 2 || offset+length > t.size {
+		return
+	}
+
+	for _, p := range t.previews {
+		if p.Offset == offset {
+			return
+		}
+	}
+
+	cfg, err := jpeg.DecodeConfig(io.NewSectionReader(t.r, offset, length))
+
+	if err != nil {
+		return
+	}
+
+	t.previews = append(t.previews, RawPreview{Offset: offset, Length: length, Width: cfg.Width, Height: cfg.Height})
+}
+
+// RawPreview saves the largest JPEG preview embedded in a RAW file, rotated according to its orientation.
+func (c *Convert) RawPreview(image *MediaFile, jpegName string) error {
+	f, err := os.Open(image.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	previews, orientation, err := RawPreviews(f, image.FileSize())
+
+	if err != nil {
+		return fmt.Errorf("convert: %s in %s", err, txt.Quote(image.BaseName()))
+	} else if len(previews) == 0 {
+		return fmt.Errorf("convert: no embedded preview found in %s", txt.Quote(image.BaseName()))
+	}
+
+	best := previews[0]
+
+	for _, p := range previews[1:] {
+		if p.Pixels() > best.Pixels() {
+			best = p
+		}
+	}
+
+	data := make([]byte, best.Length)
+
+	if _, err := f.ReadAt(data, best.Offset); err != nil {
+		return err
+	}
+
+	if orientation <= 1 || orientation > 8 {
+		return ioutil.WriteFile(jpegName, data, os.ModePerm)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return err
+	}
+
+	return imaging.Save(rawOrient(img, orientation), jpegName, imaging.JPEGQuality(c.conf.JpegQuality()))
+}
+
+// rawOrient rotates and flips an image according to its Exif orientation.
+func rawOrient(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return imaging.FlipH(img)
+	case 3:
+		return imaging.Rotate180(img)
+	case 4:
+		return imaging.FlipV(img)
+	case 5:
+		return imaging.Transpose(img)
+	case 6:
+		return imaging.Rotate270(img)
+	case 7:
+		return imaging.Transverse(img)
+	case 8:
+		return imaging.Rotate90(img)
+	default:
+		return img
+	}
+}
@@ -0,0 +1,155 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPreviews(t *testing.T) {
+	previews := func(fileName string) ([]RawPreview, int) {
+		f, err := os.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer f.Close()
+
+		info, err := f.Stat()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, orientation, err := RawPreviews(f, info.Size())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result, orientation
+	}
+
+	t.Run("cr2", func(t *testing.T) {
+		result, orientation := previews("testdata/raw-preview.cr2")
+
+		assert.Equal(t, 6, orientation)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 64, result[0].Width)
+		assert.Equal(t, 48, result[0].Height)
+		assert.Equal(t, 16, result[1].Width)
+	})
+	t.Run("nef", func(t *testing.T) {
+		result, orientation := previews("testdata/raw-preview.nef")
+
+		assert.Equal(t, 1, orientation)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 16, result[0].Width)
+		assert.Equal(t, 32, result[1].Width)
+	})
+	t.Run("orf", func(t *testing.T) {
+		result, _ := previews("testdata/raw-preview.orf")
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, 64*48, result[0].Pixels())
+	})
+	t.Run("raf", func(t *testing.T) {
+		result, _ := previews("testdata/raw-preview.raf")
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(100), result[0].Offset)
+		assert.Equal(t, 32*24, result[0].Pixels())
+	})
+	t.Run("dng", func(t *testing.T) {
+		result, _ := previews(config.TestConfig().ExamplesPath() + "/canon_eos_6d.dng")
+
+		assert.NotEmpty(t, result)
+	})
+	t.Run("jpeg", func(t *testing.T) {
+		f, err := os.Open("testdata/2015-02-04.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer f.Close()
+
+		_, _, err = RawPreviews(f, 1000)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_RawPreview(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	jpegName := filepath.Join(conf.TempPath(), "raw-preview.jpg")
+
+	defer os.Remove(jpegName)
+
+	t.Run("cr2", func(t *testing.T) {
+		mf, err := NewMediaFile("testdata/raw-preview.cr2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := convert.RawPreview(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		jpegFile, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, jpegFile.IsJpeg())
+		assert.Equal(t, 48, jpegFile.Width())
+		assert.Equal(t, 64, jpegFile.Height())
+	})
+	t.Run("raf", func(t *testing.T) {
+		mf, err := NewMediaFile("testdata/raw-preview.raf")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := convert.RawPreview(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		jpegFile, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 32, jpegFile.Width())
+	})
+	t.Run("orf", func(t *testing.T) {
+		mf, err := NewMediaFile("testdata/raw-preview.orf")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := convert.RawPreview(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		jpegFile, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 64, jpegFile.Width())
+		assert.Equal(t, 48, jpegFile.Height())
+	})
+}
@@ -1,10 +1,14 @@
 package photoprism
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
@@ -260,3 +264,184 @@ func TestConvert_Start(t *testing.T) {
 
 	assert.NotEqual(t, oldHash, newHash, "Fingerprint of old and new JPEG file must not be the same")
 }
+
+func TestConvert_HlsDir(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	dir, err := convert.HlsDir("acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+
+	assert.NoError(t, err)
+	assert.Equal(t, conf.HlsPath()+"/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831", dir)
+
+	_, err = convert.HlsDir("")
+
+	assert.Error(t, err)
+}
+
+func TestConvert_HlsCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("gopher-video.mp4", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, _ := video.HlsRenditions.Get("720p")
+
+		cmd, err := convert.HlsCommand(mf, r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, cmd.Args, "scale=-2:min(720\\,ih)")
+		assert.Contains(t, cmd.Args, "2800k")
+		assert.Contains(t, cmd.Args, video.HlsSegment)
+		assert.Equal(t, video.HlsPlaylist, cmd.Args[len(cmd.Args)-1])
+	})
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = convert.HlsCommand(mf, video.HlsRenditions[0])
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_FramesCommand(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("poster", func(t *testing.T) {
+		cmd, err := convert.FramesCommand(mf, "/tmp/frames", video.PosterInterval, video.PosterDuration, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{conf.FFmpegBin(), "-i", mf.FileName(), "-t", "10", "-vf", "fps=2", "-q:v", "2", "/tmp/frames/frame_%04d.jpg"}, cmd.Args)
+	})
+	t.Run("sprite", func(t *testing.T) {
+		cmd, err := convert.FramesCommand(mf, "/tmp/frames", 4*time.Second, 0, 160, 90)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{conf.FFmpegBin(), "-i", mf.FileName(), "-vf", "fps=0.25,scale=160:90:force_original_aspect_ratio=decrease", "-q:v", "2", "/tmp/frames/frame_%04d.jpg"}, cmd.Args)
+	})
+}
+
+func TestConvert_SpriteFiles(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	spriteName, vttName, err := convert.SpriteFiles("acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+
+	assert.NoError(t, err)
+	assert.Equal(t, conf.ThumbPath()+"/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831_160x90_sprite.jpg", spriteName)
+	assert.Equal(t, conf.ThumbPath()+"/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831_160x90_sprite.vtt", vttName)
+}
+
+func TestConvert_ToMotion(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	imageData, err := ioutil.ReadFile(conf.ExamplesPath() + "/cat_black.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	videoData, err := ioutil.ReadFile(conf.ExamplesPath() + "/gopher-video.mp4")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(conf.OriginalsPath(), "motion-photos")
+	fileName := filepath.Join(dir, "MVIMG_0001.jpg")
+	outputName := filepath.Join(dir, ".photoprism", "MVIMG_0001.mp4")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(fileName, append(imageData, videoData...), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("motion photo", func(t *testing.T) {
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := convert.ToMotion(mf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if videoFile == nil {
+			t.Fatal("videoFile should not be nil")
+		}
+
+		assert.Equal(t, outputName, videoFile.FileName())
+		assert.True(t, videoFile.IsVideo())
+		assert.True(t, videoFile.IsLive())
+		assert.Equal(t, int64(len(videoData)), videoFile.FileSize())
+	})
+
+	t.Run("still image", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/cat_black.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := convert.ToMotion(mf)
+
+		assert.NoError(t, err)
+		assert.Nil(t, videoFile)
+	})
+}
+
+func TestJpegTrailer(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("still image", func(t *testing.T) {
+		trailer, err := jpegTrailer(conf.ExamplesPath() + "/cat_black.jpg")
+
+		assert.NoError(t, err)
+		assert.False(t, trailer)
+	})
+	t.Run("video", func(t *testing.T) {
+		trailer, err := jpegTrailer(conf.ExamplesPath() + "/gopher-video.mp4")
+
+		assert.NoError(t, err)
+		assert.True(t, trailer)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := jpegTrailer(conf.ExamplesPath() + "/xxx.jpg")
+
+		assert.Error(t, err)
+	})
+}
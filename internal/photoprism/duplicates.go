@@ -0,0 +1,137 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DuplicatesOptions specifies how near-duplicates are found and handled.
+type DuplicatesOptions struct {
+	Distance int
+	Archive  bool
+}
+
+// Duplicates represents a worker that finds visually similar photos.
+type Duplicates struct {
+	conf *config.Config
+}
+
+// NewDuplicates returns a new near-duplicates worker.
+func NewDuplicates(conf *config.Config) *Duplicates {
+	instance := &Duplicates{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start returns groups of near-duplicates and archives all but the best photo of each group if requested.
+func (w *Duplicates) Start(opt DuplicatesOptions) (groups []query.SimilarResults, archived int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("duplicates: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return groups, archived, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if err := w.UpdateHashes(); err != nil {
+		return groups, archived, err
+	}
+
+	groups, err = query.NearDuplicates(opt.Distance)
+
+	if err != nil || !opt.Archive {
+		return groups, archived, err
+	}
+
+	for _, group := range groups {
+		for _, m := range group[1:] {
+			if mutex.MainWorker.Canceled() {
+				return groups, archived, fmt.Errorf("duplicates: worker canceled")
+			}
+
+			p, err := query.PhotoByUID(m.PhotoUID)
+
+			if err != nil {
+				log.Errorf("duplicates: %s (find %s)", err, m.PhotoUID)
+				continue
+			}
+
+			if err := p.Archive(); err != nil {
+				log.Errorf("duplicates: %s (archive %s)", err, m.PhotoUID)
+				continue
+			}
+
+			log.Infof("duplicates: archived %s, similar to %s", txt.Quote(m.FileName), txt.Quote(group[0].FileName))
+
+			archived++
+		}
+	}
+
+	if archived > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+	}
+
+	return groups, archived, nil
+}
+
+// UpdateHashes adds missing perceptual hashes to primary files.
+func (w *Duplicates) UpdateHashes() error {
+	limit := 500
+	offset := 0
+
+	for {
+		files, err := query.FilesWithoutPhash(limit, offset)
+
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			return nil
+		}
+
+		for _, file := range files {
+			if mutex.MainWorker.Canceled() {
+				return fmt.Errorf("duplicates: worker canceled")
+			}
+
+			fileName := filepath.Join(w.conf.OriginalsPath(), file.FileName)
+			mf, err := NewMediaFile(fileName)
+
+			if err != nil {
+				log.Debugf("duplicates: %s", err)
+				offset++
+				continue
+			}
+
+			h, err := mf.PerceptualHash(w.conf.ThumbPath())
+
+			if err != nil {
+				log.Warnf("duplicates: %s in %s (perceptual hash)", err, txt.Quote(file.FileName))
+				offset++
+				continue
+			}
+
+			if err := file.Update("FilePhash", h.Hex()); err != nil {
+				log.Errorf("duplicates: %s", err)
+				offset++
+			}
+		}
+	}
+}
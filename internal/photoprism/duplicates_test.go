@@ -0,0 +1,24 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicates_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewDuplicates(conf)
+
+	groups, archived, err := w.Start(DuplicatesOptions{Distance: query.SimilarDistance})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(groups), 1)
+	assert.Equal(t, 0, archived)
+}
@@ -0,0 +1,115 @@
+package photoprism
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ThumbEdit returns the thumbnail adjustments of a photo edit, or no adjustments if the edit is nil.
+func ThumbEdit(m *entity.PhotoEdit) thumb.Edit {
+	if m == nil {
+		return thumb.Edit{}
+	}
+
+	return thumb.Edit{
+		CropX:       float64(m.CropX),
+		CropY:       float64(m.CropY),
+		CropW:       float64(m.CropW),
+		CropH:       float64(m.CropH),
+		Rotate:      m.Rotate,
+		Angle:       float64(m.Angle),
+		Exposure:    float64(m.Exposure),
+		Contrast:    float64(m.Contrast),
+		Saturation:  float64(m.Saturation),
+		Temperature: float64(m.Temperature),
+		Tint:        float64(m.Tint),
+	}
+}
+
+// FileEdit returns the thumbnail adjustments of the photo a file belongs to.
+func FileEdit(fileHash string) thumb.Edit {
+	return ThumbEdit(entity.FindPhotoEditByHash(fileHash))
+}
+
+// EditedFileName returns the name of a JPEG rendering of an edited file for downloads, or the original
+// file name if the photo wasn't edited. Renderings are cached until the photo is edited again.
+func EditedFileName(fileName, fileHash string) (string, error) {
+	if len(fileHash) < 4 || fs.GetFileType(fileName) != fs.TypeJpeg {
+		return fileName, nil
+	}
+
+	m := entity.FindPhotoEditByHash(fileHash)
+
+	if m == nil || m.NoChanges() {
+		return fileName, nil
+	}
+
+	dir := filepath.Join(Config().EditsPath(), fileHash[0:1], fileHash[1:2], fileHash[2:3])
+	editedName := filepath.Join(dir, fmt.Sprintf("%s_%d.jpg", fileHash, m.UpdatedAt.UnixNano()))
+
+	if fs.FileExists(editedName) {
+		return editedName, nil
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fileName, err
+	}
+
+	img, err := imaging.Open(fileName, imaging.AutoOrientation(true))
+
+	if err != nil {
+		return fileName, err
+	}
+
+	// Write to a temporary file first, so that concurrent downloads never see a partial image.
+	tmp, err := ioutil.TempFile(dir, fileHash+"_*.tmp")
+
+	if err != nil {
+		return fileName, err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err := imaging.Encode(tmp, ThumbEdit(m).Apply(img), imaging.JPEG, imaging.JPEGQuality(Config().JpegQuality())); err != nil {
+		tmp.Close()
+		return fileName, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fileName, err
+	}
+
+	if err := os.Rename(tmp.Name(), editedName); err != nil {
+		return fileName, err
+	}
+
+	return editedName, nil
+}
+
+// RemoveEditedFiles removes the cached renderings of an edited file.
+func RemoveEditedFiles(fileHash string) error {
+	if len(fileHash) < 4 {
+		return fmt.Errorf("edit: file hash is empty or too short (%s)", fileHash)
+	}
+
+	fileNames, err := filepath.Glob(filepath.Join(Config().EditsPath(), fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash+"_*.jpg"))
+
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range fileNames {
+		if err := os.Remove(fileName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditedFileName(t *testing.T) {
+	conf := config.TestConfig()
+	fileName := conf.ExamplesPath() + "/beach_sand.jpg"
+
+	t.Run("not edited", func(t *testing.T) {
+		result, err := EditedFileName(fileName, "b0d1cf6d2ef2f1fa2fd3e1a0a2c6b19b09c0eac0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fileName, result)
+	})
+	t.Run("edited", func(t *testing.T) {
+		photo := entity.PhotoFixtures.Get("Photo05")
+		hash := "acad9168fa6acc5c5c2965ddf6ec465ca42fd818"
+
+		if _, err := entity.SavePhotoEdit(&photo, form.PhotoEdit{Rotate: 90}); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.SavePhotoEdit(&photo, form.PhotoEdit{})
+
+		result, err := EditedFileName(fileName, hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, fileName, result)
+		assert.True(t, fs.FileExists(result))
+
+		original, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		edited, err := NewMediaFile(result)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, original.Width(), edited.Height())
+		assert.Equal(t, original.Height(), edited.Width())
+
+		if err := RemoveEditedFiles(hash); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, fs.FileExists(result))
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		assert.Error(t, RemoveEditedFiles("a"))
+	})
+}
@@ -0,0 +1,159 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExportMeta represents a worker that writes photo metadata to XMP sidecar files and originals.
+type ExportMeta struct {
+	conf *config.Config
+}
+
+// NewExportMeta returns a new metadata export worker.
+func NewExportMeta(conf *config.Config) *ExportMeta {
+	instance := &ExportMeta{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start writes the metadata of all photos and returns the number of updated photos.
+func (w *ExportMeta) Start(opt ExportMetaOptions) (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("export-meta: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return updated, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	limit := 500
+	offset := 0
+
+	for {
+		photos, err := query.PhotosWithDetails(limit, offset)
+
+		if err != nil {
+			return updated, err
+		}
+
+		if len(photos) == 0 {
+			break
+		}
+
+		for _, p := range photos {
+			if mutex.MainWorker.Canceled() {
+				return updated, fmt.Errorf("export-meta: worker canceled")
+			}
+
+			if err := w.Photo(p, opt); err != nil {
+				log.Errorf("export-meta: %s", err)
+			} else {
+				updated++
+			}
+		}
+
+		offset += limit
+	}
+
+	return updated, nil
+}
+
+// Photo writes the metadata of a single photo.
+func (w *ExportMeta) Photo(p entity.Photo, opt ExportMetaOptions) error {
+	if !opt.Xmp && !opt.Embed {
+		return nil
+	}
+
+	p.GetDetails()
+
+	data := p.MetaData()
+
+	if opt.Xmp {
+		if !w.conf.SidecarWritable() {
+			return fmt.Errorf("can't write xmp sidecar for %s in read only mode", p.PhotoUID)
+		}
+
+		xmpName := w.XmpFileName(p)
+
+		if err := data.SaveXMP(xmpName); err != nil {
+			return err
+		}
+
+		log.Debugf("export-meta: updated %s", txt.Quote(fs.RelName(xmpName, w.conf.OriginalsPath())))
+	}
+
+	if opt.Embed {
+		if w.conf.ReadOnly() {
+			return fmt.Errorf("can't modify originals of %s in read only mode", p.PhotoUID)
+		}
+
+		file, err := query.FileByPhotoUID(p.PhotoUID)
+
+		if err != nil {
+			return fmt.Errorf("no primary file found for %s", p.PhotoUID)
+		}
+
+		// Only JPEG originals are modified.
+		if file.FileRoot != entity.RootOriginals || fs.FileType(file.FileType) != fs.TypeJpeg {
+			return nil
+		}
+
+		fileName := filepath.Join(w.conf.OriginalsPath(), file.FileName)
+
+		if !fs.FileExists(fileName) {
+			return fmt.Errorf("%s not found", txt.Quote(file.FileName))
+		}
+
+		if err := data.SaveExiftool(w.conf.ExifToolBin(), fileName, p.ClearedMetaData()...); err != nil {
+			return err
+		}
+
+		// Update hash, size and modification time, so that the file isn't indexed again as changed.
+		if info, err := os.Stat(fileName); err != nil {
+			return err
+		} else if err := file.Updates(map[string]interface{}{
+			"FileHash": fs.Hash(fileName),
+			"FileSize": info.Size(),
+			"ModTime":  info.ModTime().Unix(),
+		}); err != nil {
+			return err
+		}
+
+		log.Debugf("export-meta: embedded metadata in %s", txt.Quote(file.FileName))
+	}
+
+	return nil
+}
+
+// XmpFileName returns the XMP sidecar file name for a photo. Existing sidecar files next to
+// originals are updated, new files are created in the originals folder unless it is read-only.
+func (w *ExportMeta) XmpFileName(p entity.Photo) string {
+	originalsPath := w.conf.OriginalsPath()
+
+	if fileName := fs.TypeXMP.Find(filepath.Join(originalsPath, p.PhotoPath, p.PhotoName), false); fileName != "" {
+		return fileName
+	}
+
+	if w.conf.ReadOnly() {
+		return p.XmpFileName(originalsPath, w.conf.SidecarPath())
+	}
+
+	return p.XmpFileName(originalsPath, "")
+}
@@ -0,0 +1,17 @@
+package photoprism
+
+import "github.com/photoprism/photoprism/internal/config"
+
+// ExportMetaOptions specifies where photo metadata should be written to.
+type ExportMetaOptions struct {
+	Xmp   bool
+	Embed bool
+}
+
+// ExportMetaOptionsConfig returns the options for writing metadata changes based on the config.
+func ExportMetaOptionsConfig(conf *config.Config) ExportMetaOptions {
+	return ExportMetaOptions{
+		Xmp:   conf.SidecarXmp(),
+		Embed: conf.EmbedMeta(),
+	}
+}
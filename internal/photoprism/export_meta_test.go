@@ -0,0 +1,62 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportMeta_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewExportMeta(conf)
+
+	updated, err := w.Start(ExportMetaOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, updated, 10)
+}
+
+func TestExportMeta_Photo(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewExportMeta(conf)
+	p := entity.PhotoFixtures.Get("Photo01")
+
+	xmpName := w.XmpFileName(p)
+
+	assert.Equal(t, filepath.Join(conf.OriginalsPath(), "2790/02/Photo01.xmp"), xmpName)
+
+	defer os.Remove(xmpName)
+
+	if err := w.Photo(p, ExportMetaOptions{Xmp: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := meta.XMP(xmpName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Hans", data.Artist)
+
+	// Keywords aren't read from sidecar files, so check the document instead.
+	doc, err := ioutil.ReadFile(xmpName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(doc), "<rdf:li>nature</rdf:li>")
+	assert.Contains(t, string(doc), "<rdf:li>frog</rdf:li>")
+}
@@ -0,0 +1,63 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// detectFaces returns the faces found in a media file, or an error if detection failed.
+func (ind *Index) detectFaces(jpeg *MediaFile) (face.Faces, error) {
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbPath(), "fit_720")
+
+	if err != nil {
+		return face.Faces{}, err
+	}
+
+	faces, err := ind.faceDetector.File(filename)
+
+	if err != nil {
+		return face.Faces{}, err
+	}
+
+	log.Debugf("index: face detection took %s", time.Since(start))
+
+	return faces, nil
+}
+
+// saveFaces replaces the detected faces of a file, assigns them to matching people
+// and updates the face and photo counts of all people concerned.
+func (ind *Index) saveFaces(file entity.File, faces face.Faces) {
+	removed, err := entity.DeleteFileFaces(file.ID)
+
+	if err != nil {
+		log.Errorf("index: %s while removing faces of %s", err, txt.Quote(file.FileName))
+		return
+	}
+
+	saved := make(entity.Faces, 0, len(faces))
+
+	for _, f := range faces {
+		m := entity.NewFace(file, f, entity.SrcImage)
+
+		if person, err := entity.MatchPerson(f.Embedding); err != nil {
+			log.Errorf("index: %s while matching faces of %s", err, txt.Quote(file.FileName))
+		} else {
+			m.PersonUID = person.PersonUID
+		}
+
+		if err := m.Create(); err != nil {
+			log.Errorf("index: %s while saving faces of %s", err, txt.Quote(file.FileName))
+		} else {
+			saved = append(saved, *m)
+		}
+	}
+
+	if err := entity.UpdatePeople(append(removed, saved...).PersonUIDs()); err != nil {
+		log.Errorf("index: %s while updating people of %s", err, txt.Quote(file.FileName))
+	}
+}
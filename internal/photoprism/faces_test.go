@@ -0,0 +1,87 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_detectFaces(t *testing.T) {
+	conf := config.TestConfig()
+
+	jpeg, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ind := &Index{faceDetector: face.New(conf.FaceModelPath(), true)}
+		faces, err := ind.detectFaces(jpeg)
+
+		assert.NoError(t, err)
+		assert.Len(t, faces, 0)
+	})
+	t.Run("model not found", func(t *testing.T) {
+		ind := &Index{faceDetector: face.New("testdata/missing", false)}
+		_, err := ind.detectFaces(jpeg)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIndex_saveFaces(t *testing.T) {
+	ind := &Index{}
+	file := entity.FileFixtures["bridge1.jpg"]
+	faces := face.Faces{
+		{Score: 0.9, Area: face.Area{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}, Embedding: face.Embedding{0, -1, 0}},
+		{Score: 0.8, Area: face.Area{X: 0.5, Y: 0.1, W: 0.2, H: 0.2}, Embedding: face.Embedding{0.1, -0.99, 0}},
+	}
+
+	fileFaces := func() (result entity.Faces) {
+		entity.Db().Where("file_id = ?", file.ID).Find(&result)
+		return result
+	}
+
+	ind.saveFaces(file, faces)
+
+	saved := fileFaces()
+
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 faces, found %d", len(saved))
+	}
+
+	assert.NotEmpty(t, saved[0].PersonUID)
+	assert.Equal(t, saved[0].PersonUID, saved[1].PersonUID)
+
+	person := entity.FindPerson(saved[0].PersonUID)
+
+	if person == nil {
+		t.Fatal("person should not be nil")
+	}
+
+	assert.Equal(t, 2, person.FaceCount)
+	assert.Equal(t, 1, person.PhotoCount)
+
+	t.Run("index again", func(t *testing.T) {
+		ind.saveFaces(file, faces)
+
+		assert.Len(t, fileFaces(), 2)
+
+		if m := entity.FindPerson(person.PersonUID); m == nil {
+			t.Fatal("person should not be nil")
+		} else {
+			assert.Equal(t, 2, m.FaceCount)
+			assert.Equal(t, 1, m.PhotoCount)
+		}
+	})
+	t.Run("faces removed", func(t *testing.T) {
+		ind.saveFaces(file, face.Faces{})
+
+		assert.Len(t, fileFaces(), 0)
+		assert.Nil(t, entity.FindPerson(person.PersonUID))
+	})
+}
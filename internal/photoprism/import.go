@@ -242,8 +242,9 @@ func (imp *Import) Cancel() {
 	mutex.MainWorker.Cancel()
 }
 
-// DestinationFilename returns the destination filename of a MediaFile to be imported.
-func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
+// DestinationFilename returns the destination filename of a MediaFile to be imported
+// to the folder relative to originals, which may be empty.
+func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile, destFolder string) (string, error) {
 	fileName := mainFile.CanonicalName()
 	fileExtension := mediaFile.Extension()
 	dateCreated := mainFile.DateCreated()
@@ -260,7 +261,7 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 	}
 
 	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	pathName := filepath.Join(imp.originalsPath(), destFolder, dateCreated.Format("2006/01"))
 
 	iteration := 0
 
@@ -1,8 +1,11 @@
 package photoprism
 
+import "github.com/photoprism/photoprism/internal/entity"
+
 type ImportOptions struct {
 	Albums                 []string
 	Path                   string
+	DestFolder             string // Folder relative to originals, e.g. the storage path of a user.
 	Move                   bool
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
@@ -34,3 +37,23 @@ func ImportOptionsMove(path string) ImportOptions {
 
 	return result
 }
+
+// ImportOptionsUpload returns import options for moving uploaded files to originals. Uploads of registered
+// users other than admins are imported to their storage path, or added to their upload album if they have none.
+func ImportOptionsUpload(path string, user *entity.User) ImportOptions {
+	result := ImportOptionsMove(path)
+
+	if user == nil || !user.Registered() || user.Admin() {
+		return result
+	}
+
+	if user.StoragePath != "" {
+		result.DestFolder = user.StoragePath
+	} else if album, err := user.UploadAlbum(); err != nil {
+		log.Errorf("import: %s (upload album of %s)", err, user.UserName)
+	} else {
+		result.Albums = []string{album.AlbumUID}
+	}
+
+	return result
+}
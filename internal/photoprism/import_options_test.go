@@ -3,6 +3,7 @@ package photoprism
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +24,40 @@ func TestImportOptionsMove(t *testing.T) {
 	assert.Equal(t, true, result.RemoveExistingFiles)
 	assert.Equal(t, true, result.RemoveEmptyDirectories)
 }
+
+func TestImportOptionsUpload(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		result := ImportOptionsUpload("xxx", &entity.Admin)
+		assert.Equal(t, "xxx", result.Path)
+		assert.Equal(t, true, result.Move)
+		assert.Equal(t, "", result.DestFolder)
+		assert.Empty(t, result.Albums)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		result := ImportOptionsUpload("xxx", nil)
+		assert.Equal(t, "", result.DestFolder)
+		assert.Empty(t, result.Albums)
+	})
+	t.Run("storage path", func(t *testing.T) {
+		result := ImportOptionsUpload("xxx", entity.UserFixtures.Pointer("alice"))
+		assert.Equal(t, true, result.Move)
+		assert.Equal(t, "2790", result.DestFolder)
+		assert.Empty(t, result.Albums)
+	})
+	t.Run("upload album", func(t *testing.T) {
+		bob := entity.UserFixtures.Pointer("bob")
+		result := ImportOptionsUpload("xxx", bob)
+		assert.Equal(t, "", result.DestFolder)
+
+		if assert.Len(t, result.Albums, 1) {
+			album := entity.Album{AlbumUID: result.Albums[0]}
+
+			if err := album.Find(); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, bob.UserUID, album.CreatedBy)
+			assert.Equal(t, result.Albums, ImportOptionsUpload("xxx", bob).Albums)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,9 +15,10 @@ func TestNewImport(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 
 	assert.IsType(t, &Import{}, imp)
@@ -29,9 +31,10 @@ func TestImport_DestinationFilename(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -41,13 +44,21 @@ func TestImport_DestinationFilename(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fileName, err := imp.DestinationFilename(rawFile, rawFile)
+	fileName, err := imp.DestinationFilename(rawFile, rawFile, "")
 
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+
+	fileName, err = imp.DestinationFilename(rawFile, rawFile, "2790")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, conf.OriginalsPath()+"/2790/2019/07/20190705_153230_C167C6FD.cr2", fileName)
 }
 
 func TestImport_Start(t *testing.T) {
@@ -61,9 +72,10 @@ func TestImport_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 
@@ -45,7 +45,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		for _, f := range related.Files {
 			relativeFilename := f.RelName(importPath)
 
-			if destinationFilename, err := imp.DestinationFilename(related.Main, f); err == nil {
+			if destinationFilename, err := imp.DestinationFilename(related.Main, f, opt.DestFolder); err == nil {
 				if err := os.MkdirAll(path.Dir(destinationFilename), os.ModePerm); err != nil {
 					log.Errorf("import: failed creating folders for %s (%s)", txt.Quote(f.BaseName()), err.Error())
 				}
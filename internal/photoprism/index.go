@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -25,17 +26,19 @@ type Index struct {
 	conf         *config.Config
 	tensorFlow   *classify.TensorFlow
 	nsfwDetector *nsfw.Detector
+	faceDetector *face.Detector
 	convert      *Convert
 	files        *Files
 	photos       *Photos
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceDetector *face.Detector, convert *Convert, files *Files, photos *Photos) *Index {
 	i := &Index{
 		conf:         conf,
 		tensorFlow:   tensorFlow,
 		nsfwDetector: nsfwDetector,
+		faceDetector: faceDetector,
 		convert:      convert,
 		files:        files,
 		photos:       photos,
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
@@ -92,6 +93,8 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	photo := entity.NewPhoto()
 	metaData := meta.NewData()
 	labels := classify.Labels{}
+	faces := face.Faces{}
+	detectFaces := false
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo()
 
@@ -178,6 +181,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 				fileStacked = true
 			}
 		}
+
+		// Find the image or video of a live photo with a different file name?
+		if photoQuery.Error != nil && m.MetaData().HasContentID() {
+			photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE content_id = ? AND deleted_at IS NULL)", m.MetaData().ContentID)
+		}
 	} else {
 		photoQuery = entity.UnscopedDb().First(&photo, "id = ?", file.PhotoID)
 
@@ -236,7 +244,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	// Flag first JPEG as primary file for this photo.
 	if !file.FilePrimary {
 		if photoExists {
-			if q := entity.UnscopedDb().Where("file_type = 'jpg' AND file_primary = 1 AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
+			if q := entity.UnscopedDb().Where("file_type = 'jpg' AND file_primary = TRUE AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
 				file.FilePrimary = m.IsJpeg()
 			}
 		} else {
@@ -282,6 +290,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			file.FileChroma = p.Chroma.Value()
 		}
 
+		// Perceptual hash for finding near-duplicates
+		if h, err := m.PerceptualHash(Config().ThumbPath()); err != nil {
+			log.Errorf("index: %s in %s (perceptual hash)", err.Error(), logName)
+		} else {
+			file.FilePhash = h.Hex()
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -304,6 +319,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 				file.InstanceID = metaData.InstanceID
 			}
+
+			if metaData.HasContentID() {
+				log.Infof("index: %s has content_id %s", logName, txt.Quote(metaData.ContentID))
+
+				file.ContentID = metaData.ContentID
+			}
 		}
 	case m.IsXMP():
 		// TODO: Proof-of-concept for indexing XMP sidecar files
@@ -368,6 +389,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 				file.InstanceID = metaData.InstanceID
 			}
 
+			if metaData.HasContentID() {
+				log.Infof("index: %s has content_id %s", logName, txt.Quote(metaData.ContentID))
+
+				file.ContentID = metaData.ContentID
+			}
+
 			file.FileCodec = metaData.Codec
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -457,12 +484,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 				file.InstanceID = metaData.InstanceID
 			}
 
+			if metaData.HasContentID() {
+				log.Infof("index: %s has content_id %s", logName, txt.Quote(metaData.ContentID))
+
+				file.ContentID = metaData.ContentID
+			}
+
 			file.FileCodec = metaData.Codec
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
 			file.FileAspectRatio = m.AspectRatio()
 			file.FilePortrait = m.Portrait()
 			file.FileDuration = metaData.Duration
+			file.FileBitrate = metaData.Bitrate
+			file.FileFPS = metaData.FrameRate
+			file.FileAudio = metaData.AudioCodecs()
+			file.FileTrack = metaData.Track.Polyline()
 			file.FileProjection = metaData.Projection
 
 			if res := m.Megapixels(); res > photo.PhotoResolution {
@@ -500,7 +537,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 		if photo.TypeSrc == entity.SrcAuto {
 			// Update photo type only if not manually modified.
-			if file.FileDuration == 0 || file.FileDuration > time.Millisecond*3100 {
+			if m.IsLive() {
+				photo.PhotoType = entity.TypeLive
+			} else if file.FileDuration == 0 || file.FileDuration > time.Millisecond*3100 {
 				photo.PhotoType = entity.TypeVideo
 			} else {
 				photo.PhotoType = entity.TypeLive
@@ -538,6 +577,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
 				photo.PhotoPrivate = ind.NSFW(m)
 			}
+
+			// Face detection and clustering via TensorFlow.
+			if Config().DetectFaces() {
+				// Keep existing faces if detection failed, e.g. because the model could not be loaded.
+				if faces, err = ind.detectFaces(m); err != nil {
+					log.Errorf("index: %s in %s (detect faces)", err, logName)
+				} else {
+					detectFaces = true
+				}
+			}
 		}
 
 		// read metadata from embedded Exif and JSON sidecar file (if exists)
@@ -729,6 +778,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if err := photo.IndexKeywords(); err != nil {
 			log.Errorf("index: %s in %s (save keywords)", err, logName)
 		}
+
+		if err := photo.IndexTerms(); err != nil {
+			log.Errorf("index: %s in %s (full-text index)", err, logName)
+		}
 	} else if photo.DeletedAt == nil {
 		if photo.PhotoQuality >= 0 {
 			photo.PhotoQuality = photo.QualityScore()
@@ -780,6 +833,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		}
 	}
 
+	if detectFaces {
+		ind.saveFaces(file, faces)
+	}
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
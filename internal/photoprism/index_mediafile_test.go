@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,9 +22,10 @@ func TestIndex_MediaFile(t *testing.T) {
 
 		tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 		nd := nsfw.New(conf.NSFWModelPath())
+		fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4")
 		if err != nil {
@@ -42,9 +44,10 @@ func TestIndex_MediaFile(t *testing.T) {
 
 		tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 		nd := nsfw.New(conf.NSFWModelPath())
+		fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 
 		result := ind.MediaFile(nil, indexOpt, "blue-go-video.mp4")
@@ -3,6 +3,7 @@ package photoprism
 import (
 	"fmt"
 
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -46,6 +47,12 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 		}
 	}
 
+	if motionFile, err := ind.convert.ToMotion(f); err != nil {
+		log.Errorf("index: failed extracting motion photo video from %s (%s)", txt.Quote(f.BaseName()), err.Error())
+	} else if motionFile != nil {
+		related.Files = append(related.Files, motionFile)
+	}
+
 	if ind.conf.SidecarJson() && !f.HasJson() {
 		if jsonFile, err := ind.convert.ToJson(f); err != nil {
 			log.Errorf("index: failed creating json sidecar for %s (%s)", txt.Quote(f.BaseName()), err.Error())
@@ -77,6 +84,11 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 
 	if result.Failed() {
 		log.Error(result.Err)
+
+		if related.Main != nil && result.Err != nil {
+			event.Publish("index.failed", event.Data{"fileName": related.Main.RootRelName(), "error": result.Err.Error()})
+		}
+
 		return result
 	} else if !result.Success() || result.Stacked() {
 		// Skip related files if main file was stacked or indexing was not completely successful.
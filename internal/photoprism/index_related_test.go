@@ -6,6 +6,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -52,9 +53,10 @@ func TestIndexRelated(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 	opt := IndexOptionsAll()
 
 	result := IndexRelated(related, ind, opt)
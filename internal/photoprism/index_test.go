@@ -7,6 +7,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 )
 
@@ -21,9 +22,10 @@ func TestIndex_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
 
@@ -46,9 +48,10 @@ func TestIndex_File(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 
 	err := ind.SingleFile("xxx")
 	assert.Equal(t, IndexFailed, err.Status)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"io/ioutil"
 	"math"
 	"os"
 	"path"
@@ -43,6 +44,7 @@ type MediaFile struct {
 	metaData     meta.Data
 	metaDataOnce sync.Once
 	location     *entity.Cell
+	motion       bool
 }
 
 // NewMediaFile returns a new media file.
@@ -346,6 +348,15 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		return result, fmt.Errorf("no supported files found for %s (%s)", txt.Quote(m.BaseName()), t)
 	}
 
+	// Add image or video of a live photo with a different file name.
+	if f := result.Main.LiveFile(result.Files); f != nil {
+		if f.IsVideo() {
+			result.Main = f
+		}
+
+		result.Files = append(result.Files, f)
+	}
+
 	// Add hidden JPEG if exists.
 	if !result.ContainsJpeg() {
 		if jpegName := fs.TypeJpeg.FindFirst(result.Main.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); jpegName != "" {
@@ -360,6 +371,71 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 	return result, nil
 }
 
+// LiveTimeWindow is the maximum modification time difference of live photo images and videos with different names.
+var LiveTimeWindow = time.Minute
+
+// LiveFile returns the image or video with the same live photo content identifier from the same folder,
+// unless one of the related files already is the counterpart.
+func (m *MediaFile) LiveFile(related MediaFiles) *MediaFile {
+	data := m.MetaData()
+
+	if !data.HasContentID() {
+		return nil
+	}
+
+	findVideo := !m.IsVideo()
+
+	for _, f := range related {
+		if f.IsVideo() == findVideo && (findVideo || f.IsJpeg() || f.IsHEIF()) {
+			return nil
+		}
+	}
+
+	dir := filepath.Dir(m.FileName())
+	entries, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		log.Warnf("media: %s in %s", err, txt.Quote(filepath.Base(dir)))
+		return nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Size() == 0 {
+			continue
+		}
+
+		// Only extract metadata from files created at about the same time.
+		if diff := entry.ModTime().Sub(m.ModTime()); diff > LiveTimeWindow || diff < -LiveTimeWindow {
+			continue
+		}
+
+		fileName := filepath.Join(dir, entry.Name())
+
+		if related.Contains(fileName) {
+			continue
+		}
+
+		switch fs.GetFileType(fileName) {
+		case fs.TypeJpeg, fs.TypeHEIF:
+			if findVideo {
+				continue
+			}
+		default:
+			if !findVideo || fs.GetMediaType(fileName) != fs.MediaVideo {
+				continue
+			}
+		}
+
+		if f, err := NewMediaFile(fileName); err != nil {
+			continue
+		} else if f.MetaData().ContentID == data.ContentID {
+			return f
+		}
+	}
+
+	return nil
+}
+
 // PathNameInfo returns file name infos for indexing.
 func (m *MediaFile) PathNameInfo() (fileRoot, fileBase, relativePath, relativeName string) {
 	fileRoot = m.Root()
@@ -666,6 +742,11 @@ func (m *MediaFile) IsVideo() bool {
 	return strings.HasPrefix(m.MimeType(), "video/") || m.MediaType() == fs.MediaVideo
 }
 
+// IsLive returns true if this is the video of an Apple live photo or a video extracted from a motion photo.
+func (m *MediaFile) IsLive() bool {
+	return m.IsVideo() && (m.motion || m.MetaData().HasContentID())
+}
+
 // IsJson return true if this media file is a json sidecar file.
 func (m *MediaFile) IsJson() bool {
 	return m.HasFileType(fs.TypeJson)
@@ -889,6 +970,50 @@ func (m *MediaFile) Megapixels() int {
 	return int(math.Round(float64(m.Width()*m.Height()) / 1000000))
 }
 
+// ResampleFormat creates the default thumbnails in WebP or AVIF format from the existing JPEG thumbnails.
+func (m *MediaFile) ResampleFormat(thumbPath string, format fs.FileType, force bool) error {
+	if !thumb.FormatSupported(format) {
+		return fmt.Errorf("media: %s thumbnails not supported", format)
+	}
+
+	hash := m.Hash()
+
+	for _, name := range thumb.DefaultTypes {
+		thumbType := thumb.Types[name]
+
+		if _, _, f := thumb.ResampleOptions(thumbType.Options...); thumbType.OnDemand() || f != thumb.FormatJpeg {
+			// Skip, size exceeds limit or not a JPEG thumbnail.
+			continue
+		}
+
+		opts := thumb.WithFormat(thumbType.Options, format)
+
+		jpegName, err := thumb.FromCache(m.FileName(), hash, thumbPath, thumbType.Width, thumbType.Height, thumbType.Options...)
+
+		if err != nil {
+			return fmt.Errorf("media: failed creating %s %s (%s)", txt.Quote(name), format, err)
+		}
+
+		fileName, err := thumb.Filename(hash, thumbPath, thumbType.Width, thumbType.Height, opts...)
+
+		if err != nil {
+			return err
+		} else if fs.FileExists(fileName) {
+			if !force {
+				continue
+			} else if err := os.Remove(fileName); err != nil {
+				return err
+			}
+		}
+
+		if _, err := thumb.FromFile(jpegName, hash, thumbPath, thumbType.Width, thumbType.Height, opts...); err != nil {
+			return fmt.Errorf("media: failed creating %s %s (%s)", txt.Quote(name), format, err)
+		}
+	}
+
+	return nil
+}
+
 // Orientation returns the orientation of a MediaFile.
 func (m *MediaFile) Orientation() int {
 	if data := m.MetaData(); data.Error == nil {
@@ -907,7 +1032,7 @@ func (m *MediaFile) Thumbnail(path string, typeName string) (filename string, er
 		return "", fmt.Errorf("media: invalid type %s", typeName)
 	}
 
-	thumbnail, err := thumb.FromFile(m.FileName(), m.Hash(), path, thumbType.Width, thumbType.Height, thumbType.Options...)
+	thumbnail, err := thumb.FromEditedFile(m.FileName(), m.Hash(), path, m.Edit(), thumbType.Width, thumbType.Height, thumbType.Options...)
 
 	if err != nil {
 		err = fmt.Errorf("media: failed creating thumbnail for %s (%s)", txt.Quote(m.BaseName()), err)
@@ -918,6 +1043,11 @@ func (m *MediaFile) Thumbnail(path string, typeName string) (filename string, er
 	return thumbnail, nil
 }
 
+// Edit returns the non-destructive adjustments of the photo this file belongs to.
+func (m *MediaFile) Edit() thumb.Edit {
+	return FileEdit(m.Hash())
+}
+
 // Thumbnail returns a resampled image of the file.
 func (m *MediaFile) Resample(path string, typeName string) (img image.Image, err error) {
 	filename, err := m.Thumbnail(path, typeName)
@@ -975,7 +1105,7 @@ func (m *MediaFile) ResampleDefault(thumbPath string, force bool) (err error) {
 					return err
 				}
 
-				originalImg = img
+				originalImg = m.Edit().Apply(img)
 			}
 
 			if thumbType.Source != "" {
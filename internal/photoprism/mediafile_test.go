@@ -1,10 +1,13 @@
 package photoprism
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
@@ -2093,3 +2096,129 @@ func TestMediaFile_HasJson(t *testing.T) {
 		assert.True(t, mediaFile.HasJson())
 	})
 }
+
+func TestMediaFile_LiveFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	dir := filepath.Join(conf.OriginalsPath(), "live-photos")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	exiftool := `[{"ExifToolVersion": 12.00, "FileName": "%s", "ContentIdentifier": "4AA0A7D5-2F3B-4B0E-8D0A-2E6F3C4C5B11"}]`
+
+	files := map[string]string{
+		"IMG_0001.jpg": conf.ExamplesPath() + "/cat_black.jpg",
+		"VID_0002.mp4": conf.ExamplesPath() + "/gopher-video.mp4",
+		"VID_0003.mp4": conf.ExamplesPath() + "/gopher-video.mp4",
+	}
+
+	for name, src := range files {
+		data, err := ioutil.ReadFile(src)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"IMG_0001.jpg", "VID_0002.mp4"} {
+		jsonName := filepath.Join(dir, fs.StripExt(name)+".json")
+
+		if err := ioutil.WriteFile(jsonName, []byte(fmt.Sprintf(exiftool, name)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("image", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "IMG_0001.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := mediaFile.RelatedFiles(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, related.Files.Contains(filepath.Join(dir, "VID_0002.mp4")))
+		assert.False(t, related.Files.Contains(filepath.Join(dir, "VID_0003.mp4")))
+		assert.Equal(t, "VID_0002.mp4", related.Main.BaseName())
+		assert.True(t, related.Main.IsLive())
+	})
+
+	t.Run("no content identifier", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "VID_0003.mp4"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, mediaFile.LiveFile(nil))
+	})
+
+	t.Run("different modification time", func(t *testing.T) {
+		modTime := time.Now().Add(-2 * LiveTimeWindow)
+
+		if err := os.Chtimes(filepath.Join(dir, "VID_0002.mp4"), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "IMG_0001.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, mediaFile.LiveFile(nil))
+	})
+}
+
+func TestMediaFile_ResampleFormat(t *testing.T) {
+	conf := config.TestConfig()
+
+	cwebp := thumb.CwebpBin
+
+	defer func() {
+		thumb.CwebpBin = cwebp
+	}()
+
+	thumb.CwebpBin = ""
+
+	m, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.ResampleFormat(conf.ThumbPath(), thumb.FormatWebp, false)
+
+	assert.EqualError(t, err, "media: webp thumbnails not supported")
+}
+
+func TestMediaFile_Edit(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("not edited", func(t *testing.T) {
+		image, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, image.Edit().IsZero())
+	})
+}
+
+func TestThumbEdit(t *testing.T) {
+	assert.Equal(t, thumb.Edit{}, ThumbEdit(nil))
+	assert.Equal(t, thumb.Edit{Rotate: 90, Exposure: 0.5}, ThumbEdit(&entity.PhotoEdit{Rotate: 90, Exposure: 0.5}))
+}
@@ -24,3 +24,14 @@ func (f MediaFiles) Less(i, j int) bool {
 func (f MediaFiles) Swap(i, j int) {
 	f[i], f[j] = f[j], f[i]
 }
+
+// Contains returns true if the slice contains a file with the given name.
+func (f MediaFiles) Contains(fileName string) bool {
+	for _, m := range f {
+		if m.FileName() == fileName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -36,6 +37,15 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 			err = nil
 		}
 
+		// Add video details and embedded GPS tracks reported by ffprobe.
+		if m.IsVideo() {
+			if probeErr := m.probeVideo(); probeErr != nil {
+				log.Debugf("media: %s in %s (ffprobe)", probeErr, txt.Quote(m.BaseName()))
+			} else {
+				err = nil
+			}
+		}
+
 		if err != nil {
 			m.metaData.Error = err
 			log.Debugf("media: %s", err.Error())
@@ -44,3 +54,30 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 
 	return m.metaData
 }
+
+// probeVideo adds the video information reported by ffprobe and the embedded GPS track, if any.
+func (m *MediaFile) probeVideo() error {
+	probe, err := video.ProbeFile(Config().FFprobeBin(), m.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	probe.Meta(&m.metaData)
+
+	track, err := video.ReadTrack(Config().FFmpegBin(), m.FileName(), probe)
+
+	if err != nil {
+		log.Debugf("media: %s in %s (gps track)", err, txt.Quote(m.BaseName()))
+	} else if len(track) > 0 {
+		m.metaData.Track = track
+
+		// Use the start of the track as location if unknown.
+		if m.metaData.Lat == 0 && m.metaData.Lng == 0 {
+			m.metaData.Lat, m.metaData.Lng = float32(track[0].Lat), float32(track[0].Lng)
+			m.metaData.Altitude = int(track[0].Altitude)
+		}
+	}
+
+	return nil
+}
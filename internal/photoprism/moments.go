@@ -5,6 +5,7 @@ import (
 	"math"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
@@ -224,6 +225,11 @@ func (m *Moments) Start() (err error) {
 		}
 	}
 
+	// Events and trips.
+	if err := m.Events(); err != nil {
+		log.Errorf("moments: %s (events)", err.Error())
+	}
+
 	if err := query.UpdateFolderDates(); err != nil {
 		log.Errorf("moments: %s (update folder dates)", err.Error())
 	}
@@ -235,6 +241,75 @@ func (m *Moments) Start() (err error) {
 	return nil
 }
 
+// Events creates albums for photos taken close to each other in time and space, e.g. on a trip.
+func (m *Moments) Events() error {
+	settings := m.conf.Settings().Events
+
+	if settings.Gap <= 0 || settings.Distance <= 0 {
+		log.Debugf("moments: event detection disabled")
+		return nil
+	}
+
+	photos, err := query.EventPhotos()
+
+	if err != nil {
+		return err
+	}
+
+	gap := time.Duration(settings.Gap) * time.Hour
+	events := query.ClusterEvents(photos, gap, float64(settings.Distance), settings.Photos)
+
+	log.Debugf("moments: found %d events in %d photos", len(events), len(photos))
+
+	for _, event := range events {
+		a, count, err := query.EventAlbum(event.PhotoUIDs)
+
+		if err != nil {
+			// Albums may have lost their photos, e.g. after a restore.
+			if found := entity.FindAlbumBySlug(event.Slug(), entity.AlbumEvent); found != nil {
+				a, count = *found, 0
+			} else if created := entity.NewEventAlbum(event.Title(), event.Slug()); created == nil {
+				log.Errorf("moments: failed to create new event %s", event.Title())
+				continue
+			} else {
+				created.AlbumCountry = event.Country
+				created.AlbumLocation = event.Place()
+				created.AlbumYear = event.Start.Year()
+				created.AlbumMonth = int(event.Start.Month())
+				created.AlbumDay = event.Start.Day()
+
+				if err := created.Create(); err != nil {
+					log.Errorf("moments: %s", err.Error())
+					continue
+				}
+
+				log.Infof("moments: added %s (%d photos)", txt.Quote(created.AlbumTitle), len(event.PhotoUIDs))
+
+				a = *created
+			}
+		}
+
+		if a.DeletedAt != nil || count == len(event.PhotoUIDs) {
+			// Nothing to do.
+			log.Tracef("moments: %s already exists", txt.Quote(a.AlbumTitle))
+			continue
+		}
+
+		// Photos removed from the album by users remain hidden.
+		for _, uid := range event.PhotoUIDs {
+			if entity.FirstOrCreatePhotoAlbum(entity.NewPhotoAlbum(uid, a.AlbumUID)) == nil {
+				log.Errorf("moments: failed adding %s to %s", uid, txt.Quote(a.AlbumTitle))
+			}
+		}
+
+		if count > 0 {
+			log.Debugf("moments: added %d photos to %s", len(event.PhotoUIDs)-count, txt.Quote(a.AlbumTitle))
+		}
+	}
+
+	return nil
+}
+
 // Cancel stops the current operation.
 func (m *Moments) Cancel() {
 	mutex.MainWorker.Cancel()
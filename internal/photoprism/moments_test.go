@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMoments_Start(t *testing.T) {
@@ -16,3 +18,41 @@ func TestMoments_Start(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestMoments_Events(t *testing.T) {
+	conf := config.TestConfig()
+
+	settings := conf.Settings().Events
+	defer func() { conf.Settings().Events = settings }()
+
+	conf.Settings().Events.Photos = 1
+
+	m := NewMoments(conf)
+
+	if err := m.Events(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, count, err := query.EventAlbum([]string{"pt9jtdre2lvl0y11"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Teotihuacán, 17 July 2014", a.AlbumTitle)
+	assert.Equal(t, "", a.AlbumFilter)
+	assert.Equal(t, 1, count)
+
+	// Albums are found again by their photos.
+	if err := m.Events(); err != nil {
+		t.Fatal(err)
+	}
+
+	again, _, err := query.EventAlbum([]string{"pt9jtdre2lvl0y11"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, a.AlbumUID, again.AlbumUID)
+}
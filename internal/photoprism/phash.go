@@ -0,0 +1,24 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PerceptualHash returns the difference hash of an image for finding near-duplicates (only JPEG supported).
+func (m *MediaFile) PerceptualHash(thumbPath string) (h phash.Hash, err error) {
+	if !m.IsJpeg() {
+		return h, fmt.Errorf("%s is not a jpeg", txt.Quote(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, txt.Quote(m.BaseName()))
+		return h, err
+	}
+
+	return phash.DHash(img), nil
+}
@@ -0,0 +1,58 @@
+package photoprism
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := os.TempDir() + "/TestMediaFile_PerceptualHash"
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h, err := mediaFile.PerceptualHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, h.Hex(), 16)
+
+		catFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		other, err := catFile.PerceptualHash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, h.Distance(other), 10)
+	})
+
+	t.Run("iphone_7.heic", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/iphone_7.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(thumbsPath)
+
+		assert.Error(t, err)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/thumb"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,9 +31,10 @@ func TestResample_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert, NewFiles(), NewPhotos())
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
 
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
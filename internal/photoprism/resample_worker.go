@@ -1,9 +1,12 @@
 package photoprism
 
+import "github.com/photoprism/photoprism/pkg/fs"
+
 type ResampleJob struct {
 	mediaFile *MediaFile
 	path      string
 	force     bool
+	formats   []fs.FileType
 }
 
 func ResampleWorker(jobs <-chan ResampleJob) {
@@ -17,6 +20,13 @@ func ResampleWorker(jobs <-chan ResampleJob) {
 
 		if err := mf.ResampleDefault(job.path, job.force); err != nil {
 			log.Errorf("resample: %s", err)
+			continue
+		}
+
+		for _, format := range job.formats {
+			if err := mf.ResampleFormat(job.path, format, job.force); err != nil {
+				log.Errorf("resample: %s", err)
+			}
 		}
 	}
 }
@@ -0,0 +1,328 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// ThumbTypeHls is the type name of cached HLS video streams.
+const ThumbTypeHls = "hls"
+
+// ThumbOrphanAge is the minimum age of orphaned files, so that files of media that are still
+// being indexed are not removed.
+var ThumbOrphanAge = time.Hour
+
+// ThumbTypeStats represents the number and size of cached files of a thumbnail type.
+type ThumbTypeStats struct {
+	Files int   `json:"files"`
+	Size  int64 `json:"size"`
+}
+
+// ThumbCacheStats represents the thumbnail cache usage.
+type ThumbCacheStats struct {
+	Types  map[string]ThumbTypeStats `json:"types"`
+	Files  int                       `json:"files"`
+	Size   int64                     `json:"size"`
+	Hashes int                       `json:"hashes"`
+	Quota  int64                     `json:"quota"`
+}
+
+// ThumbCleanResult represents the files removed from the thumbnail cache.
+type ThumbCleanResult struct {
+	Orphans int   `json:"orphans"`
+	Evicted int   `json:"evicted"`
+	Files   int   `json:"files"`
+	Size    int64 `json:"size"`
+}
+
+// ThumbCache manages the size of the thumbnail cache and removes orphaned files.
+type ThumbCache struct {
+	thumbPath string
+	hlsPath   string
+	quota     int64
+}
+
+// NewThumbCache returns a new thumbnail cache manager.
+func NewThumbCache(conf *config.Config) *ThumbCache {
+	instance := &ThumbCache{
+		thumbPath: conf.ThumbPath(),
+		hlsPath:   conf.HlsPath(),
+		quota:     conf.ThumbCacheQuota(),
+	}
+
+	return instance
+}
+
+// thumbCacheFile represents a file in the thumbnail or video stream cache.
+type thumbCacheFile struct {
+	Name    string
+	Hash    string
+	Type    string
+	Size    int64
+	ModTime time.Time
+}
+
+// files returns all files in the thumbnail and video stream cache.
+func (c *ThumbCache) files() (result []thumbCacheFile, err error) {
+	if err := c.walk(c.thumbPath, func(fileName string, parts []string, info os.FileInfo) {
+		hash, typeName := thumb.ParseName(fileName)
+		result = append(result, thumbCacheFile{Name: fileName, Hash: hash, Type: typeName, Size: info.Size(), ModTime: info.ModTime()})
+	}); err != nil {
+		return result, err
+	}
+
+	if c.hlsPath == "" {
+		return result, nil
+	}
+
+	// Video streams are stored in a folder per file hash, e.g. "a/b/c/abc123/index.m3u8".
+	err = c.walk(c.hlsPath, func(fileName string, parts []string, info os.FileInfo) {
+		if len(parts) > 4 {
+			result = append(result, thumbCacheFile{Name: fileName, Hash: parts[3], Type: ThumbTypeHls, Size: info.Size(), ModTime: info.ModTime()})
+		}
+	})
+
+	return result, err
+}
+
+// walk calls fn for each regular file in dir, with the relative path split into its parts.
+func (c *ThumbCache) walk(dir string, fn func(fileName string, parts []string, info os.FileInfo)) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		fn(fileName, strings.Split(rel, string(os.PathSeparator)), info)
+
+		return nil
+	})
+}
+
+// Stats returns the number and size of cached files per thumbnail type.
+func (c *ThumbCache) Stats() (result ThumbCacheStats, err error) {
+	result = ThumbCacheStats{
+		Types: make(map[string]ThumbTypeStats),
+		Quota: c.quota,
+	}
+
+	files, err := c.files()
+
+	if err != nil {
+		return result, err
+	}
+
+	hashes := make(map[string]bool)
+
+	for _, f := range files {
+		s := result.Types[f.Type]
+		s.Files++
+		s.Size += f.Size
+		result.Types[f.Type] = s
+
+		result.Files++
+		result.Size += f.Size
+
+		if f.Hash != "" {
+			hashes[f.Hash] = true
+		}
+	}
+
+	result.Hashes = len(hashes)
+
+	return result, nil
+}
+
+// Clean removes orphaned files whose file hash no longer exists in the index,
+// and evicts on-demand thumbnails if the cache exceeds its quota.
+func (c *ThumbCache) Clean(dry bool) (result ThumbCleanResult, err error) {
+	if err := mutex.ThumbWorker.Start(); err != nil {
+		return result, fmt.Errorf("thumbs: %s", err)
+	}
+
+	defer mutex.ThumbWorker.Stop()
+
+	files, err := c.files()
+
+	if err != nil {
+		return result, err
+	}
+
+	hashes, err := query.FileHashes()
+
+	if err != nil {
+		return result, err
+	}
+
+	var kept []thumbCacheFile
+
+	for _, f := range files {
+		if f.Hash == "" || hashes[f.Hash] || time.Since(f.ModTime) < ThumbOrphanAge {
+			kept = append(kept, f)
+			continue
+		}
+
+		if mutex.ThumbWorker.Canceled() {
+			return result, errors.New("thumbs: cleanup canceled")
+		}
+
+		if c.remove(f, dry) {
+			result.Orphans++
+			result.Files++
+			result.Size += f.Size
+		}
+	}
+
+	evicted, size := c.evict(kept, dry)
+
+	result.Evicted += evicted
+	result.Files += evicted
+	result.Size += size
+
+	return result, nil
+}
+
+// Evict removes the least recently used on-demand thumbnails if the cache exceeds its quota.
+func (c *ThumbCache) Evict(dry bool) (result ThumbCleanResult, err error) {
+	if c.quota <= 0 {
+		return result, nil
+	}
+
+	if err := mutex.ThumbWorker.Start(); err != nil {
+		return result, fmt.Errorf("thumbs: %s", err)
+	}
+
+	defer mutex.ThumbWorker.Stop()
+
+	files, err := c.files()
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Evicted, result.Size = c.evict(files, dry)
+	result.Files = result.Evicted
+
+	return result, nil
+}
+
+// evict removes on-demand thumbnails, oldest first, until the total size is within the quota.
+func (c *ThumbCache) evict(files []thumbCacheFile, dry bool) (count int, size int64) {
+	if c.quota <= 0 {
+		return 0, 0
+	}
+
+	var total int64
+	var candidates []thumbCacheFile
+
+	for _, f := range files {
+		total += f.Size
+
+		if t, ok := thumb.Types[f.Type]; ok && t.OnDemand() {
+			candidates = append(candidates, f)
+		}
+	}
+
+	if total <= c.quota {
+		return 0, 0
+	}
+
+	// The modification time of on-demand thumbnails is updated when they are used.
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ModTime.Before(candidates[j].ModTime)
+	})
+
+	for _, f := range candidates {
+		if total <= c.quota || mutex.ThumbWorker.Canceled() {
+			break
+		}
+
+		if c.remove(f, dry) {
+			total -= f.Size
+			count++
+			size += f.Size
+		}
+	}
+
+	if total > c.quota {
+		log.Warnf("thumbs: cache size exceeds quota after removing %d on-demand thumbnails", count)
+	}
+
+	return count, size
+}
+
+// remove deletes a cached file, and its video stream folder if empty.
+func (c *ThumbCache) remove(f thumbCacheFile, dry bool) bool {
+	if dry {
+		log.Infof("thumbs: %s would be removed", filepath.Base(f.Name))
+		return true
+	}
+
+	if err := os.Remove(f.Name); err != nil {
+		log.Errorf("thumbs: %s", err)
+		return false
+	}
+
+	log.Debugf("thumbs: removed %s", filepath.Base(f.Name))
+
+	if f.Type == ThumbTypeHls {
+		_ = os.Remove(filepath.Dir(f.Name))
+	}
+
+	return true
+}
+
+// Remove deletes all cached thumbnails of a file except video sprite sheets, so that they are rendered again.
+func (c *ThumbCache) Remove(hash string) (count int, err error) {
+	if len(hash) < 4 {
+		return 0, fmt.Errorf("thumbs: file hash is empty or too short (%s)", hash)
+	}
+
+	fileNames, err := filepath.Glob(filepath.Join(c.thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+"_*"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, fileName := range fileNames {
+		if _, typeName := thumb.ParseName(fileName); thumb.Types[typeName].Sprite() {
+			continue
+		}
+
+		if err := os.Remove(fileName); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// Cancel stops the current operation.
+func (c *ThumbCache) Cancel() {
+	mutex.ThumbWorker.Cancel()
+}
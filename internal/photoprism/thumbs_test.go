@@ -0,0 +1,182 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/stretchr/testify/assert"
+)
+
+// thumbCacheTest creates a thumbnail cache with one default thumbnail, two on-demand thumbnails
+// and an HLS stream for an indexed file, and a thumbnail for an orphaned file hash.
+func thumbCacheTest(t *testing.T) (c *ThumbCache, fileHash, orphanHash string) {
+	dir, err := ioutil.TempDir("", "thumbs")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = NewThumbCache(config.TestConfig())
+	c.thumbPath = filepath.Join(dir, "thumbnails")
+	c.hlsPath = filepath.Join(dir, "hls")
+	c.quota = -1
+
+	fileHash = entity.FileFixturesExampleXMP.FileHash
+	orphanHash = "0123456789012345678901234567890123456789"
+
+	old := time.Now().Add(-2 * ThumbOrphanAge)
+
+	create := func(fileName string, size int, modTime time.Time) {
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, make([]byte, size), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	thumbName := func(hash, typeName string) string {
+		t := thumb.Types[typeName]
+
+		return filepath.Join(c.thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+"_"+thumb.Postfix(t.Width, t.Height, t.Options...))
+	}
+
+	create(thumbName(fileHash, "tile_500"), 100, old)
+	create(thumbName(fileHash, "fit_4096"), 300, old.Add(-time.Hour))
+	create(thumbName(fileHash, "fit_7680"), 500, old)
+	create(filepath.Join(c.hlsPath, fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash, "index.m3u8"), 10, old)
+	create(thumbName(orphanHash, "tile_500"), 50, old)
+
+	return c, fileHash, orphanHash
+}
+
+func TestThumbCache_Stats(t *testing.T) {
+	c, _, _ := thumbCacheTest(t)
+
+	defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+	stats, err := c.Stats()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 5, stats.Files)
+	assert.Equal(t, int64(960), stats.Size)
+	assert.Equal(t, 2, stats.Hashes)
+	assert.Equal(t, ThumbTypeStats{Files: 2, Size: 150}, stats.Types["tile_500"])
+	assert.Equal(t, ThumbTypeStats{Files: 1, Size: 10}, stats.Types[ThumbTypeHls])
+}
+
+func TestThumbCache_Clean(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		c, _, orphanHash := thumbCacheTest(t)
+
+		defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+		result, err := c.Clean(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Orphans)
+		assert.Equal(t, int64(50), result.Size)
+		assert.FileExists(t, filepath.Join(c.thumbPath, "0", "1", "2", orphanHash+"_500x500_center.jpg"))
+	})
+	t.Run("orphans and quota", func(t *testing.T) {
+		c, fileHash, orphanHash := thumbCacheTest(t)
+
+		defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+		c.quota = 700
+
+		result, err := c.Clean(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Orphans)
+		assert.Equal(t, 1, result.Evicted)
+		assert.Equal(t, 2, result.Files)
+		assert.Equal(t, int64(350), result.Size)
+
+		dir := filepath.Join(c.thumbPath, fileHash[0:1], fileHash[1:2], fileHash[2:3])
+
+		assert.NoFileExists(t, filepath.Join(c.thumbPath, "0", "1", "2", orphanHash+"_500x500_center.jpg"))
+		assert.NoFileExists(t, filepath.Join(dir, fileHash+"_4096x4096_fit.jpg"))
+		assert.FileExists(t, filepath.Join(dir, fileHash+"_7680x4320_fit.jpg"))
+		assert.FileExists(t, filepath.Join(dir, fileHash+"_500x500_center.jpg"))
+	})
+}
+
+func TestThumbCache_Evict(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		c, _, _ := thumbCacheTest(t)
+
+		defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+		result, err := c.Evict(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Evicted)
+	})
+	t.Run("default thumbs are kept", func(t *testing.T) {
+		c, _, _ := thumbCacheTest(t)
+
+		defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+		c.quota = 100
+
+		result, err := c.Evict(false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Evicted)
+		assert.Equal(t, int64(800), result.Size)
+
+		stats, err := c.Stats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, stats.Files)
+	})
+}
+
+func TestThumbCache_Remove(t *testing.T) {
+	c, fileHash, _ := thumbCacheTest(t)
+
+	defer os.RemoveAll(filepath.Dir(c.thumbPath))
+
+	spriteName := filepath.Join(c.thumbPath, fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash+"_160x90_sprite.jpg")
+
+	if err := ioutil.WriteFile(spriteName, []byte("jpeg"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := c.Remove(fileHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.FileExists(t, spriteName)
+
+	stats, err := c.Stats()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Files)
+	assert.Equal(t, 1, stats.Types["tile_500"].Files)
+
+	_, err = c.Remove("")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,387 @@
+package photoprism
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// UploadExpires is the time after which incomplete uploads are removed, counted from the last change.
+const UploadExpires = 24 * time.Hour
+
+var (
+	ErrUploadNotFound        = errors.New("upload not found")
+	ErrUploadOffset          = errors.New("upload offset does not match")
+	ErrUploadSize            = errors.New("upload exceeds the declared size")
+	ErrUploadLimit           = errors.New("upload exceeds the file size limit")
+	ErrUploadSpace           = errors.New("not enough free space for upload")
+	ErrUploadChecksum        = errors.New("upload checksum does not match")
+	ErrUploadIncomplete      = errors.New("upload is incomplete")
+	ErrUploadInvalidName     = errors.New("invalid upload file name")
+	ErrUploadExpired         = errors.New("upload expired")
+	ErrUploadSizeUnsupported = errors.New("upload size must be declared")
+	ErrUploadLocked          = errors.New("upload is in progress")
+)
+
+// uploadLocks contains the IDs of uploads that are currently written.
+var uploadLocks sync.Map
+
+// Upload represents a resumable upload, the uploaded bytes are stored in a separate file.
+type Upload struct {
+	ID        string            `json:"id"`
+	Owner     string            `json:"owner"`
+	Path      string            `json:"path"`
+	FileName  string            `json:"name"`
+	Size      int64             `json:"size"`
+	Offset    int64             `json:"offset"`
+	Hash      string            `json:"hash,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created"`
+	UpdatedAt time.Time         `json:"updated"`
+}
+
+// Expires returns the time after which the incomplete upload is removed.
+func (u *Upload) Expires() time.Time {
+	return u.UpdatedAt.Add(UploadExpires)
+}
+
+// Complete tests if all bytes were uploaded.
+func (u *Upload) Complete() bool {
+	return u.Offset == u.Size
+}
+
+// Uploads stores incomplete uploads so that they can be resumed.
+type Uploads struct {
+	tempPath   string
+	importPath string
+	sizeLimit  int64
+}
+
+// NewUploads returns a new upload store.
+func NewUploads(conf *config.Config) *Uploads {
+	instance := &Uploads{
+		tempPath:   filepath.Join(conf.TempPath(), "upload"),
+		importPath: filepath.Join(conf.ImportPath(), "upload"),
+		sizeLimit:  conf.OriginalsLimit(),
+	}
+
+	return instance
+}
+
+// infoName returns the file name of the upload information.
+func (s *Uploads) infoName(id string) string {
+	return filepath.Join(s.tempPath, id+".json")
+}
+
+// dataName returns the file name of the uploaded bytes.
+func (s *Uploads) dataName(id string) string {
+	return filepath.Join(s.tempPath, id+".bin")
+}
+
+// save writes the upload information.
+func (s *Uploads) save(u *Upload) error {
+	data, err := json.Marshal(u)
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(s.infoName(u.ID), data, os.ModePerm)
+}
+
+// Create starts a new upload of a file with the given size.
+func (s *Uploads) Create(owner, path, fileName string, size int64, fileHash string, metadata map[string]string) (*Upload, error) {
+	fileName = filepath.Base(filepath.Clean("/" + fileName))
+
+	if fileName == "" || fileName == "/" || fileName == "." || strings.HasPrefix(fileName, ".") {
+		return nil, ErrUploadInvalidName
+	}
+
+	if size < 0 {
+		return nil, ErrUploadSizeUnsupported
+	} else if s.sizeLimit > 0 && size > s.sizeLimit {
+		return nil, ErrUploadLimit
+	}
+
+	if err := os.MkdirAll(s.tempPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	// The file is stored in the temp folder first and then moved to the import folder, which may be on another device.
+	for _, dir := range []string{s.tempPath, filepath.Dir(s.importPath)} {
+		if free, err := fs.FreeSpace(dir); err == nil && free < uint64(size) {
+			return nil, ErrUploadSpace
+		}
+	}
+
+	now := time.Now().UTC()
+
+	u := &Upload{
+		ID:        rnd.UUID(),
+		Owner:     owner,
+		Path:      strings.Trim(filepath.Clean("/"+strings.Replace(path, ".", "", -1)), "/"),
+		FileName:  fileName,
+		Size:      size,
+		Hash:      strings.ToLower(fileHash),
+		Metadata:  metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if f, err := os.Create(s.dataName(u.ID)); err != nil {
+		return nil, err
+	} else if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Lock prevents concurrent writes to an upload, the returned function releases the lock.
+func (s *Uploads) Lock(id string) (unlock func(), err error) {
+	if _, locked := uploadLocks.LoadOrStore(id, true); locked {
+		return nil, ErrUploadLocked
+	}
+
+	return func() { uploadLocks.Delete(id) }, nil
+}
+
+// Find returns an existing upload.
+func (s *Uploads) Find(id string) (*Upload, error) {
+	if !rnd.IsUUID(id) {
+		return nil, ErrUploadNotFound
+	}
+
+	data, err := ioutil.ReadFile(s.infoName(id))
+
+	if os.IsNotExist(err) {
+		return nil, ErrUploadNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	u := &Upload{}
+
+	if err := json.Unmarshal(data, u); err != nil {
+		return nil, err
+	}
+
+	if u.Expires().Before(time.Now()) {
+		return nil, ErrUploadExpired
+	}
+
+	// The uploaded bytes are the source of truth in case a write was interrupted.
+	if info, err := os.Stat(s.dataName(id)); err != nil {
+		return nil, ErrUploadNotFound
+	} else {
+		u.Offset = info.Size()
+	}
+
+	return u, nil
+}
+
+// Write appends bytes at the given offset, the optional checksum is verified before
+// the bytes are kept. It returns the new offset.
+func (s *Uploads) Write(u *Upload, offset int64, r io.Reader, checksum hash.Hash, expected []byte) (int64, error) {
+	if offset != u.Offset {
+		return u.Offset, ErrUploadOffset
+	}
+
+	f, err := os.OpenFile(s.dataName(u.ID), os.O_WRONLY|os.O_APPEND, os.ModePerm)
+
+	if err != nil {
+		return u.Offset, err
+	}
+
+	var w io.Writer = f
+
+	if checksum != nil {
+		w = io.MultiWriter(f, checksum)
+	}
+
+	// Read one more byte than allowed to detect uploads that exceed the declared size.
+	n, copyErr := io.Copy(w, io.LimitReader(r, u.Size-u.Offset+1))
+
+	switch {
+	case n > u.Size-u.Offset:
+		copyErr = ErrUploadSize
+	case copyErr == nil && checksum != nil && !bytes.Equal(checksum.Sum(nil), expected):
+		copyErr = ErrUploadChecksum
+	}
+
+	// Discard bytes that are invalid, interrupted uploads keep what was received.
+	if copyErr == ErrUploadSize || copyErr == ErrUploadChecksum {
+		n = 0
+		if err := f.Truncate(u.Offset); err != nil {
+			log.Errorf("upload: %s", err)
+		}
+	}
+
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	u.Offset += n
+	u.UpdatedAt = time.Now().UTC()
+
+	if err := s.save(u); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	return u.Offset, copyErr
+}
+
+// Finish verifies a complete upload and moves the file to the import folder without replacing
+// existing files. It returns the file name in the import folder.
+func (s *Uploads) Finish(u *Upload) (string, error) {
+	if !u.Complete() {
+		return "", ErrUploadIncomplete
+	}
+
+	dataName := s.dataName(u.ID)
+
+	if u.Hash != "" && fs.Hash(dataName) != u.Hash {
+		if err := s.Delete(u.ID); err != nil {
+			log.Errorf("upload: %s", err)
+		}
+
+		return "", ErrUploadChecksum
+	}
+
+	// Each user has a separate folder, so that only completed uploads are imported from it.
+	dir := filepath.Join(s.importPath, u.Owner, u.Path)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	fileName, err := uniqueFileName(dir, u.FileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	// The temp folder may be on a different device, so the file is copied if it can't be renamed.
+	if err := os.Rename(dataName, fileName); err != nil {
+		f, err := os.Open(dataName)
+
+		if err != nil {
+			os.Remove(fileName)
+			return "", err
+		}
+
+		err = fs.WriteReader(fileName, f, true)
+		f.Close()
+
+		if err != nil {
+			os.Remove(fileName)
+			return "", err
+		}
+	}
+
+	return fileName, s.Delete(u.ID)
+}
+
+// uniqueFileName creates an empty file in dir that doesn't overwrite existing files and returns its name,
+// for example "IMG_1234_1.jpg" if another upload "IMG_1234.jpg" is waiting to be imported.
+func uniqueFileName(dir, baseName string) (string, error) {
+	ext := filepath.Ext(baseName)
+	prefix := strings.TrimSuffix(baseName, ext)
+	fileName := filepath.Join(dir, baseName)
+
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, os.ModePerm)
+
+		if err == nil {
+			return fileName, f.Close()
+		} else if !os.IsExist(err) {
+			return "", err
+		}
+
+		fileName = filepath.Join(dir, fmt.Sprintf("%s_%d%s", prefix, i, ext))
+	}
+}
+
+// Delete removes an upload.
+func (s *Uploads) Delete(id string) error {
+	if !rnd.IsUUID(id) {
+		return ErrUploadNotFound
+	}
+
+	if err := os.Remove(s.dataName(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Remove(s.infoName(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Cleanup removes expired uploads and returns the number of removed uploads.
+func (s *Uploads) Cleanup() (removed int, err error) {
+	files, err := ioutil.ReadDir(s.tempPath)
+
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	expired := time.Now().Add(-1 * UploadExpires)
+
+	for _, info := range files {
+		name := info.Name()
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+
+		if info.IsDir() || !rnd.IsUUID(id) || info.ModTime().After(expired) {
+			continue
+		}
+
+		// The upload is only expired if neither the information nor the bytes changed recently.
+		if _, err := s.Find(id); err == nil {
+			continue
+		} else if data, err := os.Stat(s.dataName(id)); err == nil && data.ModTime().After(expired) {
+			continue
+		}
+
+		if err := s.Delete(id); err != nil {
+			return removed, err
+		}
+
+		if filepath.Ext(name) == ".json" {
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// NewUploadChecksum returns a hash for verifying uploaded bytes, only SHA1 is supported.
+func NewUploadChecksum(algorithm string) (hash.Hash, bool) {
+	switch strings.ToLower(algorithm) {
+	case "sha1":
+		return sha1.New(), true
+	}
+
+	return nil, false
+}
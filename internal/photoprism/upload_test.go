@@ -0,0 +1,285 @@
+package photoprism
+
+import (
+	"crypto/sha1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+// uploadsTest returns an upload store in a temporary directory, which must be removed by the caller.
+func uploadsTest(t *testing.T) *Uploads {
+	dir, err := ioutil.TempDir("", "uploads")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUploads(config.TestConfig())
+	s.tempPath = filepath.Join(dir, "temp")
+	s.importPath = filepath.Join(dir, "import")
+
+	return s
+}
+
+func TestUploads_Create(t *testing.T) {
+	s := uploadsTest(t)
+	defer os.RemoveAll(filepath.Dir(s.tempPath))
+
+	t.Run("success", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "../2020/./trip", "../IMG_1234.jpg", 5, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2020/trip", u.Path)
+		assert.Equal(t, "IMG_1234.jpg", u.FileName)
+		assert.Equal(t, int64(0), u.Offset)
+		assert.False(t, u.Complete())
+		assert.FileExists(t, s.dataName(u.ID))
+		assert.FileExists(t, s.infoName(u.ID))
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := s.Create("uqxetse3cy5eo9z2", "", ".hidden", 5, "", nil)
+		assert.Equal(t, ErrUploadInvalidName, err)
+
+		_, err = s.Create("uqxetse3cy5eo9z2", "", "", 5, "", nil)
+		assert.Equal(t, ErrUploadInvalidName, err)
+	})
+
+	t.Run("size unsupported", func(t *testing.T) {
+		_, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", -1, "", nil)
+		assert.Equal(t, ErrUploadSizeUnsupported, err)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		limit := s.sizeLimit
+		s.sizeLimit = 10
+		defer func() { s.sizeLimit = limit }()
+
+		_, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", 11, "", nil)
+		assert.Equal(t, ErrUploadLimit, err)
+	})
+
+	t.Run("free space", func(t *testing.T) {
+		free, err := fs.FreeSpace(os.TempDir())
+
+		if err != nil {
+			t.Skip(err)
+		}
+
+		limit := s.sizeLimit
+		s.sizeLimit = -1
+		defer func() { s.sizeLimit = limit }()
+
+		_, err = s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", int64(free)+1, "", nil)
+		assert.Equal(t, ErrUploadSpace, err)
+	})
+}
+
+func TestUploads_Write(t *testing.T) {
+	s := uploadsTest(t)
+	defer os.RemoveAll(filepath.Dir(s.tempPath))
+
+	t.Run("resume", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", 10, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		offset, err := s.Write(u, 0, strings.NewReader("hello"), nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), offset)
+
+		found, err := s.Find(u.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(5), found.Offset)
+
+		offset, err = s.Write(found, 0, strings.NewReader("world"), nil, nil)
+
+		assert.Equal(t, ErrUploadOffset, err)
+		assert.Equal(t, int64(5), offset)
+
+		offset, err = s.Write(found, 5, strings.NewReader("world"), nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), offset)
+		assert.True(t, found.Complete())
+	})
+
+	t.Run("size exceeded", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", 4, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		offset, err := s.Write(u, 0, strings.NewReader("hello"), nil, nil)
+
+		assert.Equal(t, ErrUploadSize, err)
+		assert.Equal(t, int64(0), offset)
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", 5, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := sha1.Sum([]byte("hello"))
+		checksum, ok := NewUploadChecksum("SHA1")
+
+		assert.True(t, ok)
+
+		offset, err := s.Write(u, 0, strings.NewReader("hallo"), checksum, expected[:])
+
+		assert.Equal(t, ErrUploadChecksum, err)
+		assert.Equal(t, int64(0), offset)
+
+		checksum, _ = NewUploadChecksum("sha1")
+		offset, err = s.Write(u, 0, strings.NewReader("hello"), checksum, expected[:])
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), offset)
+	})
+
+	t.Run("unsupported checksum", func(t *testing.T) {
+		_, ok := NewUploadChecksum("md5")
+		assert.False(t, ok)
+	})
+}
+
+func TestUploads_Finish(t *testing.T) {
+	s := uploadsTest(t)
+	defer os.RemoveAll(filepath.Dir(s.tempPath))
+
+	t.Run("success", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "trip", "IMG_1234.jpg", 5, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.Finish(u); err != ErrUploadIncomplete {
+			t.Fatalf("expected incomplete upload, got %v", err)
+		}
+
+		if _, err := s.Write(u, 0, strings.NewReader("hello"), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName, err := s.Finish(u)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(s.importPath, "uqxetse3cy5eo9z2", "trip", "IMG_1234.jpg"), fileName)
+		assert.Equal(t, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", fs.Hash(fileName))
+
+		_, err = s.Find(u.ID)
+		assert.Equal(t, ErrUploadNotFound, err)
+	})
+
+	t.Run("same name", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "trip", "IMG_1234.jpg", 5, "", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.Write(u, 0, strings.NewReader("world"), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName, err := s.Finish(u)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(s.importPath, "uqxetse3cy5eo9z2", "trip", "IMG_1234_1.jpg"), fileName)
+		assert.Equal(t, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", fs.Hash(filepath.Join(s.importPath, "uqxetse3cy5eo9z2", "trip", "IMG_1234.jpg")))
+		assert.Equal(t, "7c211433f02071597741e6ff5a8ea34789abbf43", fs.Hash(fileName))
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		u, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_5678.jpg", 5, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.Write(u, 0, strings.NewReader("hallo"), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = s.Finish(u)
+
+		assert.Equal(t, ErrUploadChecksum, err)
+		assert.NoFileExists(t, filepath.Join(s.importPath, "uqxetse3cy5eo9z2", "IMG_5678.jpg"))
+
+		_, err = s.Find(u.ID)
+		assert.Equal(t, ErrUploadNotFound, err)
+	})
+}
+
+func TestUploads_Cleanup(t *testing.T) {
+	s := uploadsTest(t)
+	defer os.RemoveAll(filepath.Dir(s.tempPath))
+
+	active, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_1234.jpg", 5, "", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := s.Create("uqxetse3cy5eo9z2", "", "IMG_5678.jpg", 5, "", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired.UpdatedAt = time.Now().Add(-2 * UploadExpires)
+
+	if err := s.save(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-2 * UploadExpires)
+
+	for _, fileName := range []string{s.infoName(expired.ID), s.dataName(expired.ID)} {
+		if err := os.Chtimes(fileName, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = s.Find(expired.ID)
+	assert.Equal(t, ErrUploadExpired, err)
+
+	removed, err := s.Cleanup()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.NoFileExists(t, s.infoName(expired.ID))
+	assert.NoFileExists(t, s.dataName(expired.ID))
+
+	_, err = s.Find(active.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,105 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// VideoQueueSize is the maximum number of pending transcoding jobs.
+const VideoQueueSize = 100
+
+// VideoJob represents a video file to be transcoded to the given video type.
+type VideoJob struct {
+	File     *MediaFile
+	TypeName string
+}
+
+// Key returns a string identifying the job.
+func (j VideoJob) Key() string {
+	return j.TypeName + ":" + j.File.FileName()
+}
+
+// VideoQueue transcodes videos in the background so that requests don't block until transcoding is done.
+type VideoQueue struct {
+	convert *Convert
+	jobs    chan VideoJob
+	pending map[string]bool
+	mutex   sync.Mutex
+	once    sync.Once
+}
+
+// NewVideoQueue returns a new video transcoding queue.
+func NewVideoQueue(convert *Convert) *VideoQueue {
+	return &VideoQueue{
+		convert: convert,
+		jobs:    make(chan VideoJob, VideoQueueSize),
+		pending: make(map[string]bool),
+	}
+}
+
+// Add adds a video to the queue unless it is already pending, returns false if the queue is full.
+func (q *VideoQueue) Add(mf *MediaFile, typeName string) bool {
+	q.once.Do(func() {
+		go q.worker()
+	})
+
+	job := VideoJob{File: mf, TypeName: typeName}
+	key := job.Key()
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.pending[key] {
+		return true
+	}
+
+	select {
+	case q.jobs <- job:
+		q.pending[key] = true
+		return true
+	default:
+		log.Warnf("convert: video queue is full, skipped %s", txt.Quote(mf.BaseName()))
+		return false
+	}
+}
+
+// Pending tests if the video is waiting to be transcoded or being transcoded.
+func (q *VideoQueue) Pending(mf *MediaFile, typeName string) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.pending[VideoJob{File: mf, TypeName: typeName}.Key()]
+}
+
+// worker transcodes queued videos one after another.
+func (q *VideoQueue) worker() {
+	for job := range q.jobs {
+		q.transcode(job)
+
+		q.mutex.Lock()
+		delete(q.pending, job.Key())
+		q.mutex.Unlock()
+	}
+}
+
+// transcode converts a video file to the job's video type.
+func (q *VideoQueue) transcode(job VideoJob) {
+	var err error
+
+	switch t := video.Types[job.TypeName]; {
+	case t.Streaming:
+		_, err = q.convert.ToHls(job.File)
+	case t.Sprite:
+		_, err = q.convert.ToSprite(job.File)
+	default:
+		_, err = q.convert.ToAvc1(job.File)
+	}
+
+	if err != nil {
+		log.Errorf("convert: failed transcoding %s (%s)", txt.Quote(job.File.BaseName()), err)
+	} else {
+		log.Infof("convert: transcoded %s", txt.Quote(job.File.BaseName()))
+	}
+}
@@ -0,0 +1,21 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoJob_Key(t *testing.T) {
+	mf := &MediaFile{fileName: "/foo/bar.avi"}
+
+	assert.Equal(t, "hls:/foo/bar.avi", VideoJob{File: mf, TypeName: "hls"}.Key())
+}
+
+func TestVideoQueue_Pending(t *testing.T) {
+	q := NewVideoQueue(NewConvert(config.TestConfig()))
+	mf := &MediaFile{fileName: "/foo/bar.avi"}
+
+	assert.False(t, q.Pending(mf, "hls"))
+}
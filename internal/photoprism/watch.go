@@ -0,0 +1,413 @@
+package photoprism
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karrick/godirwalk"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Watch indexes new and changed originals as soon as they are reported by the file system.
+type Watch struct {
+	conf     *config.Config
+	index    *Index
+	notifier *fs.Notifier
+	ignore   *fs.IgnoreList
+	pending  map[string]fs.NotifyOp
+	mutex    sync.Mutex
+	stop     chan bool
+}
+
+// NewWatch returns a new originals watcher.
+func NewWatch(conf *config.Config, index *Index) *Watch {
+	return &Watch{
+		conf:    conf,
+		index:   index,
+		pending: make(map[string]fs.NotifyOp),
+	}
+}
+
+// originalsPath returns the originals path.
+func (w *Watch) originalsPath() string {
+	return w.conf.OriginalsPath()
+}
+
+// Start watches the originals folder, or folders with the watch flag set, in the background.
+func (w *Watch) Start() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.notifier != nil {
+		return errors.New("watch: already running")
+	}
+
+	notifier, err := fs.NewNotifier()
+
+	if err != nil {
+		return err
+	}
+
+	w.notifier = notifier
+	w.stop = make(chan bool, 1)
+	w.ignore = fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+	dirs, err := w.dirs()
+
+	if err != nil {
+		log.Errorf("watch: %s", err)
+	}
+
+	for _, dir := range dirs {
+		w.addDir(dir, false)
+	}
+
+	log.Infof("watch: watching %d folders for changes", notifier.Count())
+
+	event.Publish("watch.started", event.Data{
+		"folders": notifier.Count(),
+	})
+
+	go w.run(notifier, w.stop)
+
+	return nil
+}
+
+// Stop stops watching originals.
+func (w *Watch) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.notifier == nil {
+		return
+	}
+
+	w.stop <- true
+	w.notifier = nil
+}
+
+// internal tests if the file name is inside a folder written by PhotoPrism itself, e.g. the import folder.
+func (w *Watch) internal(fileName string) bool {
+	dirs := []string{w.conf.ImportPath(), w.conf.TempPath()}
+
+	if w.conf.SidecarPathIsAbs() {
+		dirs = append(dirs, w.conf.SidecarPath())
+	}
+
+	for _, dir := range dirs {
+		if dir == "" || dir == w.originalsPath() {
+			continue
+		}
+
+		if fileName == dir || strings.HasPrefix(fileName, dir+string(os.PathSeparator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unchanged tests if the file was already indexed with the same size and modification time,
+// e.g. because it was just imported or converted.
+func (w *Watch) unchanged(fileName string) bool {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return false
+	}
+
+	file, err := query.FileByName(entity.RootOriginals, fs.RelName(fileName, w.originalsPath()))
+
+	if err != nil {
+		return false
+	}
+
+	return !file.Missing() && !file.Changed(info.Size(), info.ModTime().Round(time.Second))
+}
+
+// dirs returns the folders to watch recursively.
+func (w *Watch) dirs() (result []string, err error) {
+	if !w.conf.WatchFlagged() {
+		return []string{w.originalsPath()}, nil
+	}
+
+	folders, err := query.WatchedFolders(entity.RootOriginals)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, folder := range folders {
+		result = append(result, filepath.Join(w.originalsPath(), folder.Path))
+	}
+
+	return result, nil
+}
+
+// addDir recursively watches a directory, new files are queued for indexing if requested.
+// Expects the mutex to be locked.
+func (w *Watch) addDir(dir string, queueFiles bool) {
+	originalsPath := w.originalsPath()
+
+	err := godirwalk.Walk(dir, &godirwalk.Options{
+		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+			log.Errorf("watch: %s", err)
+			return godirwalk.SkipNode
+		},
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			if w.ignore.Ignore(fileName) || w.internal(fileName) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !info.IsDir() {
+				if queueFiles {
+					w.pending[fileName] |= fs.NotifyCreate
+				}
+
+				return nil
+			}
+
+			if err := w.ignore.Dir(fileName); err != nil {
+				log.Debugf("watch: %s", err)
+			}
+
+			if err := w.notifier.Add(fileName); err != nil {
+				log.Errorf("watch: %s in %s", err, txt.Quote(fs.RelName(fileName, originalsPath)))
+				return filepath.SkipDir
+			}
+
+			if queueFiles {
+				folder := entity.NewFolder(entity.RootOriginals, fs.RelName(fileName, originalsPath), fs.BirthTime(fileName))
+				entity.FirstOrCreateFolder(&folder)
+			}
+
+			return nil
+		},
+		Unsorted:            true,
+		FollowSymbolicLinks: false,
+	})
+
+	if err != nil {
+		log.Errorf("watch: %s", err)
+	}
+}
+
+// run handles change notifications and indexes pending files once no further changes were reported.
+func (w *Watch) run(notifier *fs.Notifier, stop chan bool) {
+	delay := w.conf.WatchDelay()
+	timer := time.NewTimer(delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			log.Info("watch: shutting down")
+			timer.Stop()
+
+			if err := notifier.Close(); err != nil {
+				log.Errorf("watch: %s", err)
+			}
+
+			return
+		case err := <-notifier.Errors():
+			log.Errorf("watch: %s", err)
+		case e, ok := <-notifier.Events():
+			if !ok {
+				return
+			}
+
+			w.handle(e)
+			timer.Reset(delay)
+		case <-timer.C:
+			if !w.flush() {
+				// Try again later, e.g. if indexing is already running.
+				timer.Reset(delay)
+			}
+		}
+	}
+}
+
+// handle adds a change notification to the pending queue.
+func (w *Watch) handle(e fs.NotifyEvent) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.notifier == nil || w.ignore.Ignore(e.Name) || w.internal(e.Name) {
+		return
+	}
+
+	if e.Dir {
+		if e.Has(fs.NotifyCreate) {
+			w.addDir(e.Name, true)
+			return
+		}
+
+		if err := w.notifier.Remove(e.Name); err != nil {
+			log.Debugf("watch: %s", err)
+		}
+	}
+
+	w.pending[e.Name] |= e.Op
+}
+
+// flush indexes pending files and flags removed files as missing.
+// Returns false if indexing is busy and pending files must be processed later.
+func (w *Watch) flush() bool {
+	w.mutex.Lock()
+
+	if len(w.pending) == 0 {
+		w.mutex.Unlock()
+		return true
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		w.mutex.Unlock()
+		log.Debugf("watch: %s, will try again", err)
+		return false
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	pending := w.pending
+	w.pending = make(map[string]fs.NotifyOp)
+
+	w.mutex.Unlock()
+
+	var changed, removed []string
+
+	for fileName := range pending {
+		if w.unchanged(fileName) {
+			// Skip files that were already indexed, e.g. by an import.
+			continue
+		} else if fs.FileExists(fileName) {
+			changed = append(changed, fileName)
+		} else if !fs.PathExists(fileName) {
+			removed = append(removed, fileName)
+		}
+	}
+
+	missing := w.missing(removed)
+	indexed := w.indexFiles(changed)
+
+	if indexed > 0 || missing > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	}
+
+	event.Publish("watch.completed", event.Data{
+		"indexed": indexed,
+		"missing": missing,
+	})
+
+	return true
+}
+
+// missing flags files as missing that were removed or renamed and returns their number.
+func (w *Watch) missing(fileNames []string) (count int) {
+	originalsPath := w.originalsPath()
+
+	for _, fileName := range fileNames {
+		relName := fs.RelName(fileName, originalsPath)
+		files, err := query.FilesByName(entity.RootOriginals, relName)
+
+		if err != nil {
+			log.Errorf("watch: %s", err)
+			continue
+		}
+
+		for _, file := range files {
+			if err := file.Purge(); err != nil {
+				log.Errorf("watch: %s", err)
+				continue
+			}
+
+			w.index.files.Remove(file.FileName, file.FileRoot)
+
+			log.Infof("watch: flagged file %s as missing", txt.Quote(file.FileName))
+
+			event.Publish("watch.missing", event.Data{
+				"fileName": file.FileName,
+			})
+
+			count++
+		}
+	}
+
+	return count
+}
+
+// indexFiles indexes new or changed media files and their related files, and returns their number.
+func (w *Watch) indexFiles(fileNames []string) (count int) {
+	originalsPath := w.originalsPath()
+	settings := w.conf.Settings()
+	done := make(fs.Done)
+
+	opt := IndexOptions{
+		Path:    "/",
+		Rescan:  true,
+		Convert: settings.Index.Convert && w.conf.SidecarWritable(),
+		Stack:   settings.Index.Stacks,
+	}
+
+	for _, fileName := range fileNames {
+		if done[fileName].Processed() || !fs.IsMedia(fileName) {
+			continue
+		}
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			log.Errorf("watch: %s", err)
+			continue
+		}
+
+		if mf.FileSize() == 0 {
+			continue
+		}
+
+		related, err := mf.RelatedFiles(settings.StackSequences())
+
+		if err != nil {
+			log.Warnf("watch: %s", err)
+			continue
+		}
+
+		for _, f := range related.Files {
+			done[f.FileName()] = fs.Processed
+		}
+
+		if related.Main == nil {
+			continue
+		}
+
+		result := IndexRelated(related, w.index, opt)
+
+		if result.Err != nil {
+			log.Errorf("watch: %s in %s", result.Err, txt.Quote(fs.RelName(fileName, originalsPath)))
+		} else {
+			count += len(related.Files)
+		}
+
+		event.Publish("watch.indexed", event.Data{
+			"fileName": fs.RelName(related.Main.FileName(), originalsPath),
+			"status":   string(result.Status),
+		})
+	}
+
+	return count
+}
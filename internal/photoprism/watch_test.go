@@ -0,0 +1,125 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitPending waits until the watcher has queued at least one change.
+func waitPending(t *testing.T, w *Watch) {
+	timeout := time.Now().Add(10 * time.Second)
+
+	for time.Now().Before(timeout) {
+		w.mutex.Lock()
+		n := len(w.pending)
+		w.mutex.Unlock()
+
+		if n > 0 {
+			return
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatal("no changes reported")
+}
+
+func TestWatch_Start(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("watching originals is only supported on linux")
+	}
+
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fd, convert, NewFiles(), NewPhotos())
+	w := NewWatch(conf, ind)
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.Stop()
+
+	assert.Error(t, w.Start())
+	assert.LessOrEqual(t, 1, w.notifier.Count())
+
+	s := event.Subscribe("watch.completed")
+	defer event.Unsubscribe(s)
+
+	dir := filepath.Join(conf.OriginalsPath(), "watch-test")
+	fileName := filepath.Join(dir, "elephants.jpg")
+
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mf.Copy(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	waitPending(t, w)
+
+	// Wait for further events and index new files without waiting for the delay.
+	time.Sleep(time.Second)
+	assert.True(t, w.flush())
+
+	msg := <-s.Receiver
+	assert.Equal(t, 1, msg.Fields["indexed"])
+
+	// Files that were already indexed, e.g. by an import, are skipped.
+	assert.True(t, w.unchanged(fileName))
+
+	w.mutex.Lock()
+	w.pending[fileName] |= fs.NotifyWrite
+	w.mutex.Unlock()
+
+	assert.True(t, w.flush())
+
+	msg = <-s.Receiver
+	assert.Equal(t, 0, msg.Fields["indexed"])
+
+	if err := os.Remove(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	waitPending(t, w)
+	assert.True(t, w.flush())
+
+	msg = <-s.Receiver
+	assert.Equal(t, 1, msg.Fields["missing"])
+}
+
+func TestWatch_internal(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewWatch(conf, nil)
+
+	assert.True(t, w.internal(conf.ImportPath()))
+	assert.True(t, w.internal(filepath.Join(conf.ImportPath(), "upload", "IMG_1234.jpg")))
+	assert.True(t, w.internal(filepath.Join(conf.TempPath(), "IMG_1234.jpg")))
+	assert.False(t, w.internal(filepath.Join(conf.OriginalsPath(), "IMG_1234.jpg")))
+	assert.False(t, w.internal(conf.ImportPath()+"-2020"))
+}
@@ -66,7 +66,7 @@ func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 			return file, err
 		} else if len(photos) > 0 {
 			for _, photo := range photos {
-				if err := Db().Where("photo_uid = ? AND file_primary = 1", photo.PhotoUID).First(&file).Error; err != nil {
+				if err := Db().Where("photo_uid = ? AND file_primary = TRUE", photo.PhotoUID).First(&file).Error; err != nil {
 					return file, err
 				} else {
 					return file, nil
@@ -77,10 +77,10 @@ func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 		return file, fmt.Errorf("found no cover for moment")
 	}
 
-	if err := Db().Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
+	if err := Db().Where("files.file_primary = TRUE AND files.file_missing = FALSE AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
 		Joins("JOIN albums ON albums.album_uid = ?", albumUID).
-		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = files.photo_uid AND pa.hidden = 0").
-		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = files.photo_uid AND pa.hidden = FALSE").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = FALSE AND photos.deleted_at IS NULL").
 		Order("photos.photo_quality DESC, photos.taken_at DESC").
 		First(&file).Error; err != nil {
 		return file, err
@@ -101,6 +101,59 @@ func AlbumPhotos(a entity.Album, count int) (results PhotoResults, err error) {
 	return results, err
 }
 
+// AlbumPhotoUIDs returns the UIDs of all photos in an album, including smart albums with more photos
+// than a single search can return. Results are restricted to the library of the user unless the UID is empty.
+func AlbumPhotoUIDs(a entity.Album, userUID string) (uids []string, err error) {
+	return albumPhotoUIDs(a, userUID, 1000)
+}
+
+// albumPhotoUIDs pages through the photos of an album and returns their UIDs.
+func albumPhotoUIDs(a entity.Album, userUID string, batchSize int) (uids []string, err error) {
+	found := make(map[string]bool)
+
+	for offset := 0; ; offset += batchSize {
+		results, _, err := PhotoSearch(form.PhotoSearch{
+			Album:   a.AlbumUID,
+			Filter:  a.AlbumFilter,
+			Count:   batchSize,
+			Offset:  offset,
+			UserUID: userUID,
+		})
+
+		if err != nil {
+			return uids, err
+		}
+
+		for _, uid := range results.UIDs() {
+			if !found[uid] {
+				found[uid] = true
+				uids = append(uids, uid)
+			}
+		}
+
+		if len(results) < batchSize {
+			return uids, nil
+		}
+	}
+}
+
+// ValidateAlbumFilter returns an error if the filter of a smart album is empty or invalid.
+func ValidateAlbumFilter(filter string) error {
+	if strings.TrimSpace(filter) == "" {
+		return form.NewQueryError(0, "", "empty filter")
+	}
+
+	f := form.PhotoSearch{Filter: filter}
+
+	if err := f.ParseQueryString(); err != nil {
+		return err
+	}
+
+	_, _, err := ExprWhere(f.Expr)
+
+	return err
+}
+
 // AlbumSearch searches albums based on their name.
 func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 	if err := f.ParseQueryString(); err != nil {
@@ -111,11 +164,14 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 
 	s := UnscopedDb().Table("albums").
 		Select("albums.*, cp.photo_count,	cl.link_count").
-		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
+		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = FALSE GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
 		Joins("LEFT JOIN (SELECT share_uid, count(share_uid) AS link_count FROM links GROUP BY share_uid) AS cl ON cl.share_uid = albums.album_uid").
 		Where("albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL)").
 		Where("albums.deleted_at IS NULL")
 
+	// Restrict results to the albums of the user.
+	s = UserAlbums(s, f.UserUID)
+
 	if f.ID != "" {
 		s = s.Where("albums.album_uid IN (?)", strings.Split(f.ID, ","))
 
@@ -144,7 +200,7 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("albums.album_favorite = 1")
+		s = s.Where("albums.album_favorite = TRUE")
 	}
 
 	if (f.Year > 0 && f.Year <= txt.YearMax) || f.Year == entity.YearUnknown {
@@ -190,6 +246,13 @@ func UpdateAlbumDates() error {
 			 GROUP BY photo_path) AS p ON albums.album_path = p.photo_path
 		SET albums.album_year = YEAR(taken_max), albums.album_month = MONTH(taken_max), albums.album_day = DAY(taken_max)
 		WHERE albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
+	case Postgres:
+		return UnscopedDb().Exec(`UPDATE albums
+		SET album_year = EXTRACT(YEAR FROM p.taken_max), album_month = EXTRACT(MONTH FROM p.taken_max), album_day = EXTRACT(DAY FROM p.taken_max)
+		FROM (SELECT photo_path, MAX(taken_at_local) AS taken_max
+			 FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
+			 GROUP BY photo_path) AS p
+		WHERE albums.album_path = p.photo_path AND albums.album_type = 'folder' AND p.taken_max IS NOT NULL`).Error
 	default:
 		return nil
 	}
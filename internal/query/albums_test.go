@@ -75,6 +75,90 @@ func TestAlbumPhotos(t *testing.T) {
 	})
 }
 
+func TestAlbumPhotoUIDs(t *testing.T) {
+	album := entity.NewSmartAlbum("All Images", "type:image")
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := AlbumPhotos(*album, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string
+	found := make(map[string]bool)
+
+	for _, uid := range results.UIDs() {
+		if !found[uid] {
+			found[uid] = true
+			expected = append(expected, uid)
+		}
+	}
+
+	if len(expected) < 3 {
+		t.Fatalf("at least 3 photos expected: %d", len(expected))
+	}
+
+	t.Run("all", func(t *testing.T) {
+		uids, err := AlbumPhotoUIDs(*album, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, expected, uids)
+	})
+	t.Run("paged", func(t *testing.T) {
+		uids, err := albumPhotoUIDs(*album, "", 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, expected, uids)
+	})
+}
+
+func TestSmartAlbumPhotos(t *testing.T) {
+	album := entity.NewSmartAlbum("Mexico 2014", "year:2014 country:mx OR f:4.9..5.1")
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := AlbumPhotos(*album, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uids := results.UIDs()
+
+	assert.Contains(t, uids, "pt9jtdre2lvl0y11")
+	assert.Contains(t, uids, "pt9jtdre2lvl0yh7")
+
+	// The saved filter is used even if the client sends a different one.
+	photos, _, err := PhotoSearch(form.PhotoSearch{Album: album.AlbumUID, Filter: "year:1990", Count: 100})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uids, photos.UIDs())
+}
+
+func TestValidateAlbumFilter(t *testing.T) {
+	assert.NoError(t, ValidateAlbumFilter("label:cat"))
+	assert.NoError(t, ValidateAlbumFilter("label:cat OR -country:de (year:2019 | year:2020)"))
+	assert.Error(t, ValidateAlbumFilter(""))
+	assert.Error(t, ValidateAlbumFilter("foo:bar"))
+	assert.IsType(t, &form.QueryError{}, ValidateAlbumFilter("year:2019 | foo:bar"))
+	assert.IsType(t, &form.QueryError{}, ValidateAlbumFilter("(year:2019"))
+}
+
 func TestAlbumSearch(t *testing.T) {
 	t.Run("search with string", func(t *testing.T) {
 		query := form.NewAlbumSearch("chr")
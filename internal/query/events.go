@@ -0,0 +1,236 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// EventPhoto contains the time and location of a photo used for event detection.
+type EventPhoto struct {
+	PhotoUID     string
+	TakenAt      time.Time
+	CellID       string
+	PlaceID      string
+	PlaceCity    string
+	PlaceState   string
+	PlaceCountry string
+}
+
+// Event represents photos taken close to each other in time and space, e.g. on a trip or at a party.
+type Event struct {
+	Start      time.Time
+	End        time.Time
+	City       string
+	State      string
+	Country    string
+	PhotoCount int
+	PhotoUIDs  []string
+}
+
+// Events represents a list of events.
+type Events []Event
+
+// Days returns the number of calendar days covered by the event.
+func (m Event) Days() int {
+	start := time.Date(m.Start.Year(), m.Start.Month(), m.Start.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(m.End.Year(), m.End.Month(), m.End.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// Weekend returns true if the event lasts up to three days including a Saturday or Sunday.
+func (m Event) Weekend() bool {
+	days := m.Days()
+
+	if days < 2 || days > 3 {
+		return false
+	}
+
+	for i := 0; i < days; i++ {
+		switch m.Start.AddDate(0, 0, i).Weekday() {
+		case time.Saturday, time.Sunday:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Place returns the name of the place where the event took place.
+func (m Event) Place() string {
+	switch {
+	case m.City != "":
+		return m.City
+	case m.State != "":
+		return m.State
+	default:
+		return maps.CountryName(m.Country)
+	}
+}
+
+// Dates returns the event dates in a readable format like "12–14 May 2019".
+func (m Event) Dates() string {
+	start, end := m.Start, m.End
+
+	switch {
+	case m.Days() == 1:
+		return start.Format("2 January 2006")
+	case start.Year() != end.Year():
+		return fmt.Sprintf("%s – %s", start.Format("2 January 2006"), end.Format("2 January 2006"))
+	case start.Month() != end.Month():
+		return fmt.Sprintf("%s – %s", start.Format("2 January"), end.Format("2 January 2006"))
+	default:
+		return fmt.Sprintf("%d–%s", start.Day(), end.Format("2 January 2006"))
+	}
+}
+
+// Title returns an english title for the event, e.g. "Weekend in Lisbon, 12–14 May 2019".
+func (m Event) Title() string {
+	switch {
+	case m.Days() == 1:
+		return fmt.Sprintf("%s, %s", m.Place(), m.Dates())
+	case m.Weekend():
+		return fmt.Sprintf("Weekend in %s, %s", m.Place(), m.Dates())
+	default:
+		return fmt.Sprintf("Trip to %s, %s", m.Place(), m.Dates())
+	}
+}
+
+// Slug returns an identifier string for the event based on its first photo, e.g. "event-pt9jtdre2lvl0y13".
+func (m Event) Slug() string {
+	if len(m.PhotoUIDs) == 0 {
+		return ""
+	}
+
+	return slug.Make(fmt.Sprintf("event %s", m.PhotoUIDs[0]))
+}
+
+// EventAlbum returns the event album that contains most of the photos, including deleted albums,
+// and the number of photos it contains. Albums are matched by their photos, so that they are found
+// again if photos taken before, during or after the event are added later.
+func EventAlbum(photoUIDs []string) (result entity.Album, count int, err error) {
+	var row struct {
+		AlbumUID string
+		Count    int
+	}
+
+	if len(photoUIDs) == 0 {
+		return result, 0, fmt.Errorf("no photos")
+	}
+
+	if err := UnscopedDb().Table("photos_albums").
+		Select("photos_albums.album_uid, COUNT(*) AS count").
+		Joins("JOIN albums ON albums.album_uid = photos_albums.album_uid").
+		Where("albums.album_type = ? AND photos_albums.photo_uid IN (?)", entity.AlbumEvent, photoUIDs).
+		Group("photos_albums.album_uid").
+		Order("count DESC, photos_albums.album_uid").
+		Limit(1).Scan(&row).Error; err != nil {
+		return result, 0, err
+	}
+
+	if err := UnscopedDb().Where("album_uid = ?", row.AlbumUID).First(&result).Error; err != nil {
+		return result, 0, err
+	}
+
+	return result, row.Count, nil
+}
+
+// EventPhotos returns public photos with a known date in chronological order.
+func EventPhotos() (results []EventPhoto, err error) {
+	err = UnscopedDb().Table("photos").
+		Select("photos.photo_uid, photos.taken_at, photos.cell_id, photos.place_id, places.place_city, places.place_state, places.place_country").
+		Joins("JOIN places ON places.id = photos.place_id").
+		Where("photos.deleted_at IS NULL AND photos.photo_private = FALSE AND photos.photo_quality >= 0 AND photos.taken_src <> ''").
+		Order("photos.taken_at, photos.id").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// ClusterEvents groups photos into events: consecutive photos belong to the same event if they were taken
+// within the time gap and distance. Events with less than minPhotos photos or without known place are skipped.
+func ClusterEvents(photos []EventPhoto, gap time.Duration, distance float64, minPhotos int) (results Events) {
+	var cluster []EventPhoto
+	var last EventPhoto
+
+	flush := func() {
+		if len(cluster) >= minPhotos {
+			if event, ok := newEvent(cluster); ok {
+				results = append(results, event)
+			}
+		}
+
+		cluster = cluster[:0]
+	}
+
+	for _, p := range photos {
+		if len(cluster) > 0 {
+			split := p.TakenAt.Sub(cluster[len(cluster)-1].TakenAt) > gap
+
+			// Compare with the last photo that has a location.
+			if !split && last.PhotoUID != "" {
+				if d := s2.Distance(last.CellID, p.CellID); d > distance {
+					split = true
+				}
+			}
+
+			if split {
+				flush()
+				last = EventPhoto{}
+			}
+		}
+
+		cluster = append(cluster, p)
+
+		if p.CellID != "" && p.CellID != "zz" {
+			last = p
+		}
+	}
+
+	flush()
+
+	return results
+}
+
+// newEvent returns an event for the photos with the most common place, if any.
+func newEvent(photos []EventPhoto) (result Event, ok bool) {
+	counts := make(map[string]int)
+	var best EventPhoto
+
+	for _, p := range photos {
+		if p.PlaceID == "" || p.PlaceID == "zz" {
+			continue
+		}
+
+		counts[p.PlaceID]++
+
+		if counts[p.PlaceID] > counts[best.PlaceID] {
+			best = p
+		}
+	}
+
+	if best.PlaceID == "" {
+		return result, false
+	}
+
+	result = Event{
+		Start:      photos[0].TakenAt,
+		End:        photos[len(photos)-1].TakenAt,
+		City:       best.PlaceCity,
+		State:      best.PlaceState,
+		Country:    best.PlaceCountry,
+		PhotoCount: len(photos),
+		PhotoUIDs:  make([]string, len(photos)),
+	}
+
+	for i, p := range photos {
+		result.PhotoUIDs[i] = p.PhotoUID
+	}
+
+	return result, true
+}
@@ -0,0 +1,150 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/s2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Title(t *testing.T) {
+	t.Run("weekend", func(t *testing.T) {
+		event := Event{
+			Start:     time.Date(2019, 5, 10, 18, 0, 0, 0, time.UTC),
+			End:       time.Date(2019, 5, 12, 20, 0, 0, 0, time.UTC),
+			City:      "Lisbon",
+			PhotoUIDs: []string{"pt9jtdre2lvl0y13", "pt9jtdre2lvl0y14"},
+		}
+
+		assert.Equal(t, 3, event.Days())
+		assert.True(t, event.Weekend())
+		assert.Equal(t, "Weekend in Lisbon, 10–12 May 2019", event.Title())
+		assert.Equal(t, "event-pt9jtdre2lvl0y13", event.Slug())
+	})
+	t.Run("trip", func(t *testing.T) {
+		event := Event{
+			Start:   time.Date(2019, 5, 28, 8, 0, 0, 0, time.UTC),
+			End:     time.Date(2019, 6, 4, 20, 0, 0, 0, time.UTC),
+			Country: "pt",
+		}
+
+		assert.False(t, event.Weekend())
+		assert.Equal(t, "Trip to Portugal, 28 May – 4 June 2019", event.Title())
+	})
+	t.Run("day", func(t *testing.T) {
+		event := Event{
+			Start: time.Date(2019, 12, 31, 18, 0, 0, 0, time.UTC),
+			End:   time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC),
+			State: "Baden-Württemberg",
+		}
+
+		assert.Equal(t, "Baden-Württemberg, 31 December 2019", event.Title())
+	})
+	t.Run("new year", func(t *testing.T) {
+		event := Event{
+			Start: time.Date(2019, 12, 27, 18, 0, 0, 0, time.UTC),
+			End:   time.Date(2020, 1, 2, 23, 0, 0, 0, time.UTC),
+			City:  "Berlin",
+		}
+
+		assert.Equal(t, "Trip to Berlin, 27 December 2019 – 2 January 2020", event.Title())
+	})
+}
+
+func TestClusterEvents(t *testing.T) {
+	lisbon := s2.Token(38.7223, -9.1393)
+	sintra := s2.Token(38.8029, -9.3817)
+	berlin := s2.Token(52.5200, 13.4050)
+
+	start := time.Date(2019, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	photo := func(hours int, cell, place, city string) EventPhoto {
+		return EventPhoto{PhotoUID: cell + place, TakenAt: start.Add(time.Duration(hours) * time.Hour), CellID: cell, PlaceID: place, PlaceCity: city, PlaceCountry: "pt"}
+	}
+
+	photos := []EventPhoto{
+		photo(0, lisbon, "lis", "Lisbon"),
+		photo(2, lisbon, "lis", "Lisbon"),
+		photo(16, "zz", "zz", "Unknown"),
+		photo(20, sintra, "sin", "Sintra"),
+		photo(30, lisbon, "lis", "Lisbon"),
+		// Less than 18 hours later, but too far away.
+		photo(36, berlin, "ber", "Berlin"),
+		photo(37, berlin, "ber", "Berlin"),
+		// Three weeks later.
+		photo(500, berlin, "ber", "Berlin"),
+		photo(501, "zz", "zz", "Unknown"),
+	}
+
+	t.Run("default", func(t *testing.T) {
+		events := ClusterEvents(photos, 18*time.Hour, 150, 2)
+
+		assert.Len(t, events, 3)
+		assert.Equal(t, "Weekend in Lisbon, 10–12 May 2019", events[0].Title())
+		assert.Equal(t, 5, events[0].PhotoCount)
+		assert.Equal(t, []string{lisbon + "lis", lisbon + "lis", "zzzz", sintra + "sin", lisbon + "lis"}, events[0].PhotoUIDs)
+		assert.Equal(t, "Berlin, 12 May 2019", events[1].Title())
+		assert.Equal(t, 2, events[1].PhotoCount)
+		assert.Equal(t, 2, events[2].PhotoCount)
+	})
+	t.Run("min photos", func(t *testing.T) {
+		events := ClusterEvents(photos, 18*time.Hour, 150, 3)
+
+		assert.Len(t, events, 1)
+	})
+	t.Run("small gap", func(t *testing.T) {
+		events := ClusterEvents(photos, 3*time.Hour, 150, 2)
+
+		assert.Len(t, events, 3)
+		assert.Equal(t, "Lisbon, 10 May 2019", events[0].Title())
+	})
+}
+
+func TestEventPhotos(t *testing.T) {
+	results, err := EventPhotos()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, results)
+
+	for i := 1; i < len(results); i++ {
+		assert.False(t, results[i].TakenAt.Before(results[i-1].TakenAt))
+	}
+}
+
+func TestEventAlbum(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		a := entity.NewEventAlbum("Event Album Test", "event-album-test")
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(a)
+
+		if err := entity.NewPhotoAlbum("pt9jtdre2lvl0y11", a.AlbumUID).Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, count, err := EventAlbum([]string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0y12"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, a.AlbumUID, result.AlbumUID)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := EventAlbum([]string{"pt9jtdre2lvl0y12"})
+		assert.Error(t, err)
+	})
+	t.Run("no photos", func(t *testing.T) {
+		_, _, err := EventAlbum(nil)
+		assert.Error(t, err)
+	})
+}
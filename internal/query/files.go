@@ -16,7 +16,7 @@ func FilesByPath(limit, offset int, rootName, pathName string) (files entity.Fil
 	err = Db().
 		Table("files").Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
-		Where("files.file_missing = 0").
+		Where("files.file_missing = FALSE").
 		Where("files.file_root = ? AND photos.photo_path = ?", rootName, pathName).
 		Order("files.file_name").
 		Limit(limit).Offset(offset).
@@ -34,7 +34,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	stmt := Db()
 
 	if !includeMissing {
-		stmt = stmt.Where("file_missing = 0")
+		stmt = stmt.Where("file_missing = FALSE")
 	}
 
 	if pathName != "" {
@@ -48,7 +48,20 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 
 // FilesByUID
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
-	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+	if err := Db().Where("(photo_uid IN (?) AND file_primary = TRUE) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+		return files, err
+	}
+
+	return files, nil
+}
+
+// FilesByID returns files by ID, including deleted files.
+func FilesByID(ids []uint) (files entity.Files, err error) {
+	if len(ids) == 0 {
+		return files, nil
+	}
+
+	if err := UnscopedDb().Where("id IN (?)", ids).Find(&files).Error; err != nil {
 		return files, err
 	}
 
@@ -57,7 +70,7 @@ func FilesByUID(u []string, limit int, offset int) (files entity.Files, err erro
 
 // FileByPhotoUID
 func FileByPhotoUID(u string) (file entity.File, err error) {
-	if err := Db().Where("photo_uid = ? AND file_primary = 1", u).Preload("Photo").First(&file).Error; err != nil {
+	if err := Db().Where("photo_uid = ? AND file_primary = TRUE", u).Preload("Photo").First(&file).Error; err != nil {
 		return file, err
 	}
 
@@ -66,7 +79,7 @@ func FileByPhotoUID(u string) (file entity.File, err error) {
 
 // VideoByPhotoUID
 func VideoByPhotoUID(u string) (file entity.File, err error) {
-	if err := Db().Where("photo_uid = ? AND file_video = 1", u).Preload("Photo").First(&file).Error; err != nil {
+	if err := Db().Where("photo_uid = ? AND file_video = TRUE", u).Preload("Photo").First(&file).Error; err != nil {
 		return file, err
 	}
 
@@ -130,7 +143,7 @@ func IndexedFiles() (result FileMap, err error) {
 	// Query indexed files.
 	var files []File
 
-	if err := UnscopedDb().Raw("SELECT file_root, file_name, mod_time FROM files WHERE file_missing = 0 AND deleted_at IS NULL").Scan(&files).Error; err != nil {
+	if err := UnscopedDb().Raw("SELECT file_root, file_name, mod_time FROM files WHERE file_missing = FALSE AND deleted_at IS NULL").Scan(&files).Error; err != nil {
 		return result, err
 	}
 
@@ -141,12 +154,53 @@ func IndexedFiles() (result FileMap, err error) {
 	return result, err
 }
 
+// FileHashes returns the hashes of all files in the index, including deleted and missing files.
+func FileHashes() (result map[string]bool, err error) {
+	var hashes []string
+
+	if err := UnscopedDb().Model(entity.File{}).Where("file_hash <> ''").Pluck("DISTINCT file_hash", &hashes).Error; err != nil {
+		return result, err
+	}
+
+	result = make(map[string]bool, len(hashes))
+
+	for _, hash := range hashes {
+		result[hash] = true
+	}
+
+	return result, nil
+}
+
 // CleanDuplicates removes all files from the duplicates table that don't exist in the files table.
 func CleanDuplicates() error {
-	if err := UnscopedDb().Delete(entity.Duplicate{}, "file_hash IN (SELECT d.file_hash FROM duplicates d LEFT JOIN files f ON d.file_hash = f.file_hash AND f.file_missing = 0 AND f.deleted_at IS NULL WHERE f.file_hash IS NULL)").Error; err == nil {
+	if err := UnscopedDb().Delete(entity.Duplicate{}, "file_hash IN (SELECT d.file_hash FROM duplicates d LEFT JOIN files f ON d.file_hash = f.file_hash AND f.file_missing = FALSE AND f.deleted_at IS NULL WHERE f.file_hash IS NULL)").Error; err == nil {
 		return nil
 	}
 
 	// MySQL fallback, see https://github.com/photoprism/photoprism/issues/599
-	return UnscopedDb().Delete(entity.Duplicate{}, "file_hash IN (SELECT file_hash FROM (SELECT d.file_hash FROM duplicates d LEFT JOIN files f ON d.file_hash = f.file_hash AND f.file_missing = 0 AND f.deleted_at IS NULL WHERE f.file_hash IS NULL) AS tmp)").Error
+	return UnscopedDb().Delete(entity.Duplicate{}, "file_hash IN (SELECT file_hash FROM (SELECT d.file_hash FROM duplicates d LEFT JOIN files f ON d.file_hash = f.file_hash AND f.file_missing = FALSE AND f.deleted_at IS NULL WHERE f.file_hash IS NULL) AS tmp)").Error
+}
+
+// FileByName finds a file by its root and name relative to the root.
+func FileByName(rootName, fileName string) (file entity.File, err error) {
+	if err := Db().Where("file_root = ? AND file_name = ?", rootName, fileName).First(&file).Error; err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
+// FilesByName returns not-missing files with the given name or inside a folder with that name.
+func FilesByName(rootName, name string) (files entity.Files, err error) {
+	if strings.HasPrefix(name, "/") {
+		name = name[1:]
+	}
+
+	err = Db().
+		Where("file_missing = FALSE AND file_root = ?", rootName).
+		Where("file_name = ? OR file_name LIKE ? ESCAPE '!'", name, LikeEscape(name)+"/%").
+		Order("file_name").
+		Find(&files).Error
+
+	return files, err
 }
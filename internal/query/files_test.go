@@ -87,6 +87,28 @@ func TestFilesByUID(t *testing.T) {
 	})
 }
 
+func TestFilesByID(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		files, err := FilesByID([]uint{entity.FileFixturesExampleJPG.ID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(files))
+		assert.Equal(t, "exampleFileName.jpg", files[0].FileName)
+	})
+	t.Run("empty list", func(t *testing.T) {
+		files, err := FilesByID(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(files))
+	})
+}
+
 func TestFileByPhotoUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByPhotoUID("pt9jtdre2lvl0y11")
@@ -205,6 +227,17 @@ func TestIndexedFiles(t *testing.T) {
 	t.Logf("INDEXED FILES: %#v", result)
 }
 
+func TestFileHashes(t *testing.T) {
+	result, err := FileHashes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result[entity.FileFixturesExampleXMP.FileHash])
+	assert.False(t, result["xxx"])
+}
+
 func TestCleanDuplicates(t *testing.T) {
 	fileName := "hd89e5yhb8p9h.jpg"
 
@@ -234,3 +267,41 @@ func TestCleanDuplicates(t *testing.T) {
 		t.Fatalf("duplicate should be removed: %+v", dp)
 	}
 }
+
+func TestFileByName(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		file, err := FileByName(entity.RootOriginals, "reunion.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "acad9168fa6acc5c5c2965ddf6ec465ca42fd818", file.FileHash)
+	})
+	t.Run("no files found", func(t *testing.T) {
+		_, err := FileByName(entity.RootOriginals, "reunion-123.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFilesByName(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		files, err := FilesByName(entity.RootOriginals, "/reunion.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+	})
+	t.Run("wildcards", func(t *testing.T) {
+		files, err := FilesByName(entity.RootOriginals, "%")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, files)
+	})
+}
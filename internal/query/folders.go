@@ -38,7 +38,7 @@ func AlbumFolders(threshold int) (folders entity.Folders, err error) {
 		Select("folders.path, folders.root, folders.folder_uid, folders.folder_title, folders.folder_country, folders.folder_year, folders.folder_month, COUNT(photos.id) AS photo_count").
 		Joins("JOIN photos ON photos.photo_path = folders.path AND photos.deleted_at IS NULL AND photos.photo_quality >= 3").
 		Group("folders.path, folders.root, folders.folder_uid, folders.folder_title, folders.folder_country, folders.folder_year, folders.folder_month").
-		Having("photo_count >= ?", threshold)
+		Having("COUNT(photos.id) >= ?", threshold)
 
 	if err := db.Scan(&folders).Error; err != nil {
 		return folders, err
@@ -58,7 +58,30 @@ func UpdateFolderDates() error {
 			GROUP BY photo_path) AS p ON folders.path = p.photo_path
 		SET folders.folder_year = YEAR(taken_max), folders.folder_month = MONTH(taken_max), folders.folder_day = DAY(taken_max)
 		WHERE p.taken_max IS NOT NULL`).Error
+	case Postgres:
+		return UnscopedDb().Exec(`UPDATE folders
+		SET folder_year = EXTRACT(YEAR FROM p.taken_max), folder_month = EXTRACT(MONTH FROM p.taken_max), folder_day = EXTRACT(DAY FROM p.taken_max)
+		FROM (SELECT photo_path, MAX(taken_at_local) AS taken_max
+			FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
+			GROUP BY photo_path) AS p
+		WHERE folders.path = p.photo_path AND p.taken_max IS NOT NULL`).Error
 	default:
 		return nil
 	}
 }
+
+// WatchedFolders returns folders with the watch flag set.
+func WatchedFolders(rootName string) (folders entity.Folders, err error) {
+	err = Db().Where("root = ? AND folder_watch = TRUE", rootName).Order("path").Find(&folders).Error
+
+	return folders, err
+}
+
+// FolderByUID returns a folder based on the folder UID.
+func FolderByUID(folderUID string) (folder entity.Folder, err error) {
+	if err := Db().Where("folder_uid = ?", folderUID).First(&folder).Error; err != nil {
+		return folder, err
+	}
+
+	return folder, nil
+}
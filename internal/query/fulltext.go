@@ -0,0 +1,189 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TextTerm represents a single word, prefix or phrase in a full-text search query.
+type TextTerm struct {
+	Words   []string // Stemmed words, or the lowercase prefix.
+	Offsets []int    // Word positions relative to the first word.
+	Prefix  bool
+}
+
+// Phrase returns true if the term consists of multiple words.
+func (t TextTerm) Phrase() bool {
+	return len(t.Words) > 1
+}
+
+// TextQuery represents a parsed full-text search query, all terms must match.
+type TextQuery []TextTerm
+
+// ParseTextQuery parses a full-text search query: words in double quotes are matched as phrase,
+// words ending with an asterisk as prefix, all other words are stemmed like indexed terms.
+func ParseTextQuery(q string) (result TextQuery) {
+	quoted := false
+
+	for i, part := range strings.Split(q, `"`) {
+		if i > 0 {
+			quoted = !quoted
+		}
+
+		if quoted {
+			if t := newTextTerm(part); len(t.Words) > 0 {
+				result = append(result, t)
+			}
+
+			continue
+		}
+
+		for _, w := range strings.Fields(part) {
+			if strings.HasSuffix(w, "*") {
+				prefix := strings.ToLower(strings.Join(txt.TermsRegexp.FindAllString(w, -1), ""))
+
+				if len(prefix) >= 2 {
+					result = append(result, TextTerm{Words: []string{prefix}, Offsets: []int{0}, Prefix: true})
+				}
+			} else if t := newTextTerm(w); len(t.Words) > 0 {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
+// newTextTerm returns a term containing the stemmed words in s.
+func newTextTerm(s string) (result TextTerm) {
+	terms := txt.Terms(s)
+
+	for _, t := range terms {
+		result.Words = append(result.Words, t.Word)
+		result.Offsets = append(result.Offsets, t.Pos-terms[0].Pos)
+	}
+
+	return result
+}
+
+// Empty returns true if the query contains no terms.
+func (q TextQuery) Empty() bool {
+	return len(q) == 0
+}
+
+// HasPhrase returns true if the query contains a phrase.
+func (q TextQuery) HasPhrase() bool {
+	for _, t := range q {
+		if t.Phrase() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Relevance returns a subquery that selects the ids of photos matching all terms along with their relevance score.
+func (q TextQuery) Relevance() (sql string, values []interface{}) {
+	if q.Empty() {
+		return "", values
+	}
+
+	var total int
+
+	if err := UnscopedDb().Raw("SELECT COUNT(DISTINCT photo_id) FROM photos_terms").Row().Scan(&total); err != nil {
+		log.Errorf("search: %s (count indexed photos)", err)
+	}
+
+	weight := termWeightSql()
+
+	var tables []string
+
+	for i, t := range q {
+		var termSql string
+		var termValues []interface{}
+
+		var joins []string
+
+		for j := 1; j < len(t.Words); j++ {
+			joins = append(joins, fmt.Sprintf("JOIN photos_terms t%d ON t%d.photo_id = t0.photo_id AND t%d.term_field = t0.term_field AND t%d.term_pos = t0.term_pos + %d AND t%d.term = ?", j, j, j, j, t.Offsets[j], j))
+			termValues = append(termValues, t.Words[j])
+		}
+
+		if t.Prefix {
+			termSql = "t0.term LIKE ?"
+			termValues = append(termValues, t.Words[0]+"%")
+		} else {
+			termSql = "t0.term = ?"
+			termValues = append(termValues, t.Words[0])
+		}
+
+		// BM25 style score with term frequency saturation.
+		idf := t.idf(total)
+
+		tables = append(tables, fmt.Sprintf("(SELECT t0.photo_id, %f * SUM(%s) * 2.2 / (SUM(%s) + 1.2) AS score FROM photos_terms t0 %s WHERE %s GROUP BY t0.photo_id) m%d",
+			idf, weight, weight, strings.Join(joins, " "), termSql, i))
+
+		values = append(values, termValues...)
+	}
+
+	scores := make([]string, len(tables))
+
+	for i := range tables {
+		scores[i] = fmt.Sprintf("m%d.score", i)
+	}
+
+	sql = fmt.Sprintf("SELECT m0.photo_id, %s AS relevance FROM %s", strings.Join(scores, " + "), tables[0])
+
+	for i := 1; i < len(tables); i++ {
+		sql += fmt.Sprintf(" JOIN %s ON m%d.photo_id = m0.photo_id", tables[i], i)
+	}
+
+	return sql, values
+}
+
+// idf returns the inverse document frequency of the term.
+func (t TextTerm) idf(total int) float64 {
+	var count int
+
+	stmt := UnscopedDb().Table("photos_terms").Select("COUNT(DISTINCT photo_id)")
+
+	if t.Prefix {
+		stmt = stmt.Where("term LIKE ?", t.Words[0]+"%")
+	} else {
+		stmt = stmt.Where("term IN (?)", t.Words)
+	}
+
+	if err := stmt.Row().Scan(&count); err != nil {
+		log.Errorf("search: %s (count term frequency)", err)
+	}
+
+	return math.Log(1 + (float64(total-count)+0.5)/(float64(count)+0.5))
+}
+
+// termWeightSql returns a SQL expression for the relevance weight of the matching field.
+func termWeightSql() string {
+	var fields []int
+
+	for f := range entity.TermWeights {
+		fields = append(fields, int(f))
+	}
+
+	sort.Ints(fields)
+
+	var b strings.Builder
+
+	b.WriteString("CASE t0.term_field")
+
+	for _, f := range fields {
+		fmt.Fprintf(&b, " WHEN %d THEN %d", f, entity.TermWeights[uint8(f)])
+	}
+
+	b.WriteString(" ELSE 1 END")
+
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTextQuery(t *testing.T) {
+	t.Run("words", func(t *testing.T) {
+		q := ParseTextQuery("Beaches at sunset")
+
+		assert.Len(t, q, 2)
+		assert.Equal(t, []string{"beach"}, q[0].Words)
+		assert.Equal(t, []string{"sunset"}, q[1].Words)
+		assert.False(t, q[0].Phrase())
+		assert.False(t, q.HasPhrase())
+	})
+
+	t.Run("phrase", func(t *testing.T) {
+		q := ParseTextQuery(`"house of cards" lake`)
+
+		assert.Len(t, q, 2)
+		assert.Equal(t, []string{"hous", "card"}, q[0].Words)
+		assert.Equal(t, []int{0, 2}, q[0].Offsets)
+		assert.True(t, q[0].Phrase())
+		assert.True(t, q.HasPhrase())
+		assert.Equal(t, []string{"lake"}, q[1].Words)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		q := ParseTextQuery("Neckar*")
+
+		assert.Len(t, q, 1)
+		assert.Equal(t, []string{"neckar"}, q[0].Words)
+		assert.True(t, q[0].Prefix)
+	})
+
+	t.Run("stopwords", func(t *testing.T) {
+		q := ParseTextQuery("the and")
+
+		assert.True(t, q.Empty())
+	})
+}
+
+func TestTextQuery_Relevance(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		sql, values := TextQuery{}.Relevance()
+
+		assert.Equal(t, "", sql)
+		assert.Empty(t, values)
+	})
+
+	t.Run("phrase", func(t *testing.T) {
+		sql, values := ParseTextQuery(`"black beach" sun*`).Relevance()
+
+		assert.Contains(t, sql, "t1.term_pos = t0.term_pos + 1 AND t1.term = ?")
+		assert.Contains(t, sql, "t0.term LIKE ?")
+		assert.Equal(t, []interface{}{"beach", "black", "sun%"}, values)
+	})
+}
+
+func TestPhotoSearch_FullText(t *testing.T) {
+	for _, name := range []string{"Photo04", "19800101_000002_D640C559"} {
+		m := entity.PhotoFixtures.Get(name)
+
+		if m.PhotoTitle == "" {
+			m.PhotoTitle = "Sunset at Lake Tahoe"
+		}
+
+		if err := m.IndexTerms(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("phrase", func(t *testing.T) {
+		f := form.PhotoSearch{Query: `"lake tahoe"`, Count: 10, Order: entity.SortOrderRelevance}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+
+	t.Run("phrase order", func(t *testing.T) {
+		f := form.PhotoSearch{Query: `"tahoe lake"`, Count: 10, Order: entity.SortOrderRelevance}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh7", p.PhotoUID)
+		}
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		f := form.PhotoSearch{Query: "neckarbr*", Count: 10, Order: entity.SortOrderRelevance}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+		assert.Equal(t, "pt9jtdre2lvl0y11", photos[0].PhotoUID)
+	})
+
+	t.Run("stemming", func(t *testing.T) {
+		f := form.PhotoSearch{Query: "sunsets", Count: 10, Order: entity.SortOrderRelevance}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+}
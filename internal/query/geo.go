@@ -18,25 +18,47 @@ import (
 func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	start := time.Now()
 
-	if err := f.ParseQueryString(); err != nil {
+	s, err := geoSearch(f)
+
+	if err != nil {
 		return results, err
 	}
 
 	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("geo: search %s", form.Serialize(f, true))))
 
-	s := UnscopedDb()
+	s = s.Select(`photos.id, photos.photo_uid, photos.photo_type, photos.photo_lat, photos.photo_lng, 
+		photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
+		files.file_height`).
+		Order("taken_at, photos.photo_uid")
+
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	log.Infof("geo: found %d photos for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
+
+// geoSearch returns a query for photos with coordinates that match the form values.
+func geoSearch(f form.GeoSearch) (s *gorm.DB, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return s, err
+	}
+
+	s = UnscopedDb()
 
 	// s.LogMode(true)
 
 	s = s.Table("photos").
-		Select(`photos.id, photos.photo_uid, photos.photo_type, photos.photo_lat, photos.photo_lng, 
-		photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
-		files.file_height`).
 		Joins(`JOIN files ON files.photo_id = photos.id AND 
-		files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
+		files.file_missing = FALSE AND files.file_primary AND files.deleted_at IS NULL`).
 		Where("photos.deleted_at IS NULL").
 		Where("photos.photo_lat <> 0")
 
+	// Restrict results to the library of the user.
+	s = UserPhotos(s, f.UserUID)
+
 	f.Query = txt.Clip(f.Query, txt.ClipKeyword)
 
 	if f.Query != "" {
@@ -46,7 +68,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		var labelIds []uint
 
 		if len(f.Query) < 2 {
-			return results, fmt.Errorf("query too short")
+			return s, fmt.Errorf("query too short")
 		}
 
 		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
@@ -78,7 +100,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Album != "" {
-		s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+		s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album)
 	}
 
 	if f.Camera > 0 {
@@ -102,7 +124,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.Country != "" {
@@ -147,9 +169,9 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		s = s.Where("photos.deleted_at IS NULL")
 
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = TRUE")
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = FALSE")
 		}
 
 		if f.Review {
@@ -160,7 +182,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.S2 != "" {
@@ -184,6 +206,19 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		}
 	}
 
+	// Filter by bounding box, which may cross the antimeridian.
+	if minLng, minLat, maxLng, maxLat, ok, err := f.BoundingBox(); err != nil {
+		return s, err
+	} else if ok {
+		s = s.Where("photos.photo_lat BETWEEN ? AND ?", minLat, maxLat)
+
+		if minLng <= maxLng {
+			s = s.Where("photos.photo_lng BETWEEN ? AND ?", minLng, maxLng)
+		} else {
+			s = s.Where("photos.photo_lng >= ? OR photos.photo_lng <= ?", minLng, maxLng)
+		}
+	}
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
@@ -192,13 +227,5 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
-	s = s.Order("taken_at, photos.photo_uid")
-
-	if result := s.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	log.Infof("geo: found %d photos for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
-
-	return results, nil
+	return s, nil
 }
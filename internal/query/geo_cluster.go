@@ -0,0 +1,165 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// GeoCluster represents a group of photos in the same S2 cell for displaying it on a map.
+type GeoCluster struct {
+	Token      string    `json:"Token"`
+	Lat        float64   `json:"Lat"`
+	Lng        float64   `json:"Lng"`
+	Count      int       `json:"Count"`
+	PhotoUID   string    `json:"UID"`
+	PhotoType  string    `json:"Type,omitempty"`
+	PhotoTitle string    `json:"Title"`
+	FileHash   string    `json:"Hash"`
+	FileWidth  int       `json:"Width"`
+	FileHeight int       `json:"Height"`
+	TakenAt    time.Time `json:"TakenAt"`
+	cover      string
+	latSum     float64
+	lngSum     float64
+}
+
+type GeoClusters []GeoCluster
+
+// geoCell represents the aggregated photos with the same cell id prefix.
+type geoCell struct {
+	Prefix string
+	Count  int
+	LatSum float64
+	LngSum float64
+	Cover  string
+}
+
+// geoCoverKey returns an SQL expression for sorting photos in a cluster, so that the most recent favorite
+// or, if there is none, the most recent photo has the largest key. The photo uid is the last key segment.
+func geoCoverKey() string {
+	switch DbDialect() {
+	case MySQL:
+		return "CONCAT(photos.photo_favorite, '|', photos.taken_at, '|', photos.photo_uid)"
+	case Postgres:
+		return "CONCAT(CAST(photos.photo_favorite AS INT), '|', photos.taken_at, '|', photos.photo_uid)"
+	default:
+		return "photos.photo_favorite || '|' || photos.taken_at || '|' || photos.photo_uid"
+	}
+}
+
+// GeoClusterSearch searches photos like Geo and groups them by S2 cell at a level matching the zoom level.
+// Photos are counted in the database grouped by cell id prefix, so only one row per cell is loaded.
+// Cluster positions are the average of their photos, the most recent favorite or, if there is none,
+// the most recent photo is used as preview.
+func GeoClusterSearch(f form.GeoSearch) (results GeoClusters, err error) {
+	start := time.Now()
+	level := s2.ZoomLevel(f.Zoom)
+	prefixLen := len(s2.TokenPrefix) + s2.TokenLength(level)
+
+	s, err := geoSearch(f)
+
+	if err != nil {
+		return results, err
+	}
+
+	var cells []geoCell
+
+	// Photos without a known cell can't be clustered.
+	s = s.Select(fmt.Sprintf(`SUBSTR(photos.cell_id, 1, %d) AS prefix, COUNT(*) AS count,
+		SUM(photos.photo_lat) AS lat_sum, SUM(photos.photo_lng) AS lng_sum, MAX(%s) AS cover`, prefixLen, geoCoverKey())).
+		Where("photos.cell_id LIKE ?", s2.TokenPrefix+"%").
+		Group("prefix")
+
+	if err := s.Scan(&cells).Error; err != nil {
+		return results, err
+	}
+
+	// Cell id prefixes may be more detailed than the cluster level, so merge them by parent cell.
+	index := make(map[string]int)
+
+	for _, cell := range cells {
+		token := s2.ParentToken(cell.Prefix, level)
+
+		if token == "" {
+			continue
+		}
+
+		i, ok := index[token]
+
+		if !ok {
+			i = len(results)
+			index[token] = i
+			results = append(results, GeoCluster{Token: token})
+		}
+
+		c := &results[i]
+
+		c.Count += cell.Count
+		c.latSum += cell.LatSum
+		c.lngSum += cell.LngSum
+
+		if cell.Cover > c.cover {
+			c.cover = cell.Cover
+		}
+	}
+
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	uids := make([]string, len(results))
+
+	for i := range results {
+		results[i].Lat = results[i].latSum / float64(results[i].Count)
+		results[i].Lng = results[i].lngSum / float64(results[i].Count)
+
+		if n := strings.LastIndex(results[i].cover, "|"); n >= 0 {
+			results[i].PhotoUID = results[i].cover[n+1:]
+		}
+
+		uids[i] = results[i].PhotoUID
+	}
+
+	// Load preview photos.
+	var covers GeoResults
+
+	if err := UnscopedDb().Table("photos").
+		Select(`photos.photo_uid, photos.photo_type, photos.photo_title, photos.taken_at,
+		files.file_hash, files.file_width, files.file_height`).
+		Joins(`JOIN files ON files.photo_id = photos.id AND
+		files.file_missing = FALSE AND files.file_primary AND files.deleted_at IS NULL`).
+		Where("photos.photo_uid IN (?)", uids).
+		Scan(&covers).Error; err != nil {
+		return results, err
+	}
+
+	coverIndex := make(map[string]GeoResult, len(covers))
+
+	for _, p := range covers {
+		coverIndex[p.PhotoUID] = p
+	}
+
+	for i := range results {
+		if p, ok := coverIndex[results[i].PhotoUID]; ok {
+			results[i].PhotoType = p.PhotoType
+			results[i].PhotoTitle = p.PhotoTitle
+			results[i].FileHash = p.FileHash
+			results[i].FileWidth = p.FileWidth
+			results[i].FileHeight = p.FileHeight
+			results[i].TakenAt = p.TakenAt
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Count > results[j].Count
+	})
+
+	log.Debugf("geo: found %d clusters for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+
+	return results, nil
+}
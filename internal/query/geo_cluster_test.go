@@ -0,0 +1,80 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoClusterSearch(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		photos, err := Geo(form.GeoSearch{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		known := 0
+
+		for _, p := range photos {
+			if m, err := PhotoByUID(p.PhotoUID); err == nil && m.CellID != entity.UnknownLocation.ID {
+				known++
+			}
+		}
+
+		clusters, err := GeoClusterSearch(form.GeoSearch{Zoom: 2})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count := 0
+
+		for _, c := range clusters {
+			count += c.Count
+		}
+
+		assert.Equal(t, known, count)
+		assert.LessOrEqual(t, len(clusters), known)
+
+		for _, c := range clusters {
+			assert.NotEmpty(t, c.PhotoUID)
+			assert.NotEmpty(t, c.FileHash)
+		}
+	})
+	t.Run("street", func(t *testing.T) {
+		world, err := GeoClusterSearch(form.GeoSearch{Zoom: 0})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		street, err := GeoClusterSearch(form.GeoSearch{Zoom: 20})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(world), len(street))
+	})
+	t.Run("bbox", func(t *testing.T) {
+		clusters, err := GeoClusterSearch(form.GeoSearch{BBox: "50,-25,60,-15", Zoom: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, clusters)
+
+		for _, c := range clusters {
+			assert.InDelta(t, -21.34, c.Lat, 0.01)
+		}
+	})
+	t.Run("invalid bbox", func(t *testing.T) {
+		_, err := GeoClusterSearch(form.GeoSearch{BBox: "foo"})
+
+		assert.Error(t, err)
+	})
+}
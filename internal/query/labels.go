@@ -30,6 +30,15 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 		Where("labels.photo_count > 0").
 		Group("labels.id")
 
+	// Restrict results to labels of photos in the library of the user.
+	if f.UserUID != "" {
+		inLibrary := UnscopedDb().Table("photos_labels").Select("photos_labels.label_id").
+			Joins("JOIN photos ON photos.id = photos_labels.photo_id AND photos.deleted_at IS NULL").
+			Where("photos_labels.uncertainty < 100")
+
+		s = s.Where("labels.id IN ?", UserPhotos(inLibrary, f.UserUID).SubQuery())
+	}
+
 	if f.ID != "" {
 		s = s.Where("labels.label_uid = ?", f.ID)
 
@@ -68,11 +77,11 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("labels.label_favorite = 1")
+		s = s.Where("labels.label_favorite = TRUE")
 	}
 
 	if !f.All {
-		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = 1")
+		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = TRUE")
 	}
 
 	switch f.Order {
@@ -9,6 +9,16 @@ import (
 )
 
 func TestLabels(t *testing.T) {
+	t.Run("user library", func(t *testing.T) {
+		f := form.LabelSearch{Count: 1005, UserUID: "xxx"}
+		result, err := Labels(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
 	t.Run("search with query", func(t *testing.T) {
 		query := form.NewLabelSearch("Query:C Count:1005 Order:slug")
 		result, err := Labels(query)
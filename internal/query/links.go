@@ -0,0 +1,124 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ShareSearch restricts a photo search to the public photos shared by a link, which can be an album,
+// label, folder, single photo or a selection of photos.
+func ShareSearch(f *form.PhotoSearch, shareUID string) error {
+	f.Album = ""
+	f.Shared = true
+	f.Public = true
+	f.Private = false
+	f.Hidden = false
+	f.Archived = false
+	f.Review = false
+
+	switch {
+	case rnd.IsPPID(shareUID, 'a'):
+		f.Album = shareUID
+	case rnd.IsPPID(shareUID, 'l'):
+		label, err := LabelByUID(shareUID)
+
+		if err != nil {
+			return err
+		}
+
+		f.Label = label.LabelSlug
+	case rnd.IsPPID(shareUID, 'd'):
+		folder, err := FolderByUID(shareUID)
+
+		if err != nil {
+			return err
+		} else if folder.Root != entity.RootOriginals {
+			return fmt.Errorf("folder %s is not shared", shareUID)
+		}
+
+		f.Path = folder.Path + "/"
+	case rnd.IsPPID(shareUID, 'p'):
+		uids := publicPhotoUIDs([]string{shareUID})
+
+		if len(uids) == 0 {
+			return fmt.Errorf("photo %s not found", shareUID)
+		}
+
+		f.ID = shareUID
+	default:
+		link := entity.FindLink(shareUID)
+
+		if link == nil || !link.Selection() {
+			return fmt.Errorf("share %s not found", shareUID)
+		}
+
+		// Search by ID ignores other filters, so private photos must be excluded here.
+		uids := publicPhotoUIDs(link.PhotoUIDs())
+
+		if len(uids) == 0 {
+			return fmt.Errorf("share %s is empty", shareUID)
+		}
+
+		f.ID = strings.Join(uids, ",")
+	}
+
+	return nil
+}
+
+// publicPhotoUIDs returns the UIDs of public photos that have not been deleted.
+func publicPhotoUIDs(photoUIDs []string) (result []string) {
+	if len(photoUIDs) == 0 {
+		return result
+	}
+
+	if err := Db().Model(&entity.Photo{}).Where("photo_uid IN (?) AND photo_private = FALSE", photoUIDs).Pluck("photo_uid", &result).Error; err != nil {
+		log.Errorf("share: %s", err)
+	}
+
+	return result
+}
+
+// SharedPhoto tests if a public photo is shared by a link.
+func SharedPhoto(link entity.Link, photoUID string) bool {
+	var photo entity.Photo
+
+	if err := Db().Where("photo_uid = ? AND photo_private = FALSE", photoUID).First(&photo).Error; err != nil {
+		return false
+	}
+
+	var count int
+
+	switch shareUID := link.ShareUID; {
+	case link.Selection():
+		Db().Model(&entity.LinkPhoto{}).Where("link_uid = ? AND photo_uid = ?", link.LinkUID, photoUID).Count(&count)
+	case rnd.IsPPID(shareUID, 'p'):
+		return shareUID == photoUID
+	case rnd.IsPPID(shareUID, 'a'):
+		album, err := AlbumByUID(shareUID)
+
+		if err != nil {
+			return false
+		} else if album.IsSmart() {
+			_, count, err := PhotoSearch(form.PhotoSearch{Album: album.AlbumUID, ID: photoUID, Public: true, Count: 1})
+
+			return err == nil && count > 0
+		}
+
+		Db().Model(&entity.PhotoAlbum{}).Where("album_uid = ? AND photo_uid = ? AND hidden = FALSE", shareUID, photoUID).Count(&count)
+	case rnd.IsPPID(shareUID, 'l'):
+		Db().Table("photos_labels").
+			Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+			Where("labels.label_uid = ? AND photos_labels.photo_id = ? AND photos_labels.uncertainty < 100", shareUID, photo.ID).
+			Count(&count)
+	case rnd.IsPPID(shareUID, 'd'):
+		folder, err := FolderByUID(shareUID)
+
+		return err == nil && folder.Root == entity.RootOriginals && folder.Path == photo.PhotoPath
+	}
+
+	return count > 0
+}
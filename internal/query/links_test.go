@@ -0,0 +1,164 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+// selectionLinkTest creates a link that shares the given photos.
+func selectionLinkTest(t *testing.T, photoUIDs ...string) entity.Link {
+	link := entity.NewLink("", false, false)
+	link.ShareUID = link.LinkUID
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := link.SetPhotos(photoUIDs); err != nil {
+		t.Fatal(err)
+	}
+
+	return link
+}
+
+func TestShareSearch(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		f := form.PhotoSearch{Album: "at9lxuqxpogaaba9", Private: true, Archived: true}
+
+		assert.NoError(t, ShareSearch(&f, "at9lxuqxpogaaba9"))
+		assert.Equal(t, "at9lxuqxpogaaba9", f.Album)
+		assert.True(t, f.Public)
+		assert.False(t, f.Private)
+		assert.False(t, f.Archived)
+	})
+	t.Run("smart album", func(t *testing.T) {
+		album := entity.NewSmartAlbum("Private", "private:true archived:true")
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		f := form.PhotoSearch{Count: 100}
+
+		assert.NoError(t, ShareSearch(&f, album.AlbumUID))
+
+		results, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.False(t, r.PhotoPrivate)
+			assert.True(t, r.DeletedAt.IsZero())
+		}
+
+		assert.NotContains(t, results.UIDs(), "pt9jtdre2lvl0y12")
+	})
+	t.Run("label", func(t *testing.T) {
+		f := form.PhotoSearch{Album: "lt9k3pw1wowuy3c3"}
+
+		assert.NoError(t, ShareSearch(&f, "lt9k3pw1wowuy3c3"))
+		assert.Equal(t, "", f.Album)
+		assert.Equal(t, "flower", f.Label)
+	})
+	t.Run("folder", func(t *testing.T) {
+		folder := entity.NewFolder(entity.RootOriginals, "2790/02", time.Time{})
+		folder = *entity.FirstOrCreateFolder(&folder)
+
+		f := form.PhotoSearch{}
+
+		assert.NoError(t, ShareSearch(&f, folder.FolderUID))
+		assert.Equal(t, "2790/02/", f.Path)
+	})
+	t.Run("photo", func(t *testing.T) {
+		f := form.PhotoSearch{}
+
+		assert.NoError(t, ShareSearch(&f, "pt9jtdre2lvl0yh7"))
+		assert.Equal(t, "pt9jtdre2lvl0yh7", f.ID)
+	})
+	t.Run("private photo", func(t *testing.T) {
+		f := form.PhotoSearch{}
+
+		assert.Error(t, ShareSearch(&f, "pt9jtdre2lvl0y12"))
+	})
+	t.Run("selection", func(t *testing.T) {
+		link := selectionLinkTest(t, "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y12")
+		defer link.Delete()
+
+		f := form.PhotoSearch{}
+
+		assert.NoError(t, ShareSearch(&f, link.ShareUID))
+		assert.Equal(t, "pt9jtdre2lvl0yh7", f.ID)
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.Equal(t, "pt9jtdre2lvl0yh7", p.PhotoUID)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		f := form.PhotoSearch{}
+
+		assert.Error(t, ShareSearch(&f, "st9lxuqxpogaaba7"))
+		assert.Error(t, ShareSearch(&f, "dt9lxuqxpogaaxxx"))
+	})
+}
+
+func TestSharedPhoto(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		link := entity.NewLink("at9lxuqxpogaaba9", false, false)
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh8"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("smart album", func(t *testing.T) {
+		album := entity.NewSmartAlbum("Shared April 1990", "path:\"1990/04\"")
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		link := entity.NewLink(album.AlbumUID, false, false)
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh0"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("label", func(t *testing.T) {
+		link := entity.NewLink("lt9k3pw1wowuy3c3", false, false)
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh9"))
+	})
+	t.Run("folder", func(t *testing.T) {
+		folder := entity.NewFolder(entity.RootOriginals, "2790/02", time.Time{})
+		link := entity.NewLink(entity.FirstOrCreateFolder(&folder).FolderUID, false, false)
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh9"))
+	})
+	t.Run("photo", func(t *testing.T) {
+		link := entity.NewLink("pt9jtdre2lvl0yh7", false, false)
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh8"))
+	})
+	t.Run("selection", func(t *testing.T) {
+		link := selectionLinkTest(t, "pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y12")
+		defer link.Delete()
+
+		assert.True(t, SharedPhoto(link, "pt9jtdre2lvl0yh7"))
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0yh8"))
+
+		// Private photos are never shared.
+		assert.False(t, SharedPhoto(link, "pt9jtdre2lvl0y12"))
+	})
+}
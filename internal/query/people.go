@@ -0,0 +1,73 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+// People searches people based on their name.
+func People(f form.PersonSearch) (results entity.People, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("people: search %s", form.Serialize(f, true))))
+
+	s := UserPeople(Db().Where("face_count > 0"), f.UserUID)
+
+	if f.ID != "" {
+		s = s.Where("person_uid IN (?)", strings.Split(f.ID, ","))
+	}
+
+	if f.Slug != "" {
+		s = s.Where("person_slug = ?", slug.Make(f.Slug))
+	}
+
+	if f.Query != "" {
+		s = s.Where("LOWER(person_name) LIKE ?", "%"+strings.ToLower(f.Query)+"%")
+	}
+
+	if f.Name != "" {
+		s = s.Where("LOWER(person_name) LIKE ?", strings.ReplaceAll(strings.ToLower(f.Name), "*", "%"))
+	}
+
+	if f.Named {
+		s = s.Where("person_name <> ''")
+	}
+
+	switch f.Order {
+	case "name":
+		s = s.Order("person_name = '', person_slug ASC, face_count DESC")
+	case "added":
+		s = s.Order("id DESC")
+	default:
+		s = s.Order("person_name = '', face_count DESC, person_slug ASC")
+	}
+
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if err := s.Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// PersonByUID returns a person based on the UID.
+func PersonByUID(personUID string) (person entity.Person, err error) {
+	if err := Db().Where("person_uid = ?", personUID).First(&person).Error; err != nil {
+		return person, err
+	}
+
+	return person, nil
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeople(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+		assert.Equal(t, "Jane Doe", results[0].PersonName)
+	})
+	t.Run("named", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Named: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.PersonName)
+		}
+	})
+	t.Run("query", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Query: "jane", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "rt9lxuqxkkvd1000", results[0].PersonUID)
+	})
+}
+
+func TestPersonByUID(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		result, err := PersonByUID("rt9lxuqxkkvd1000")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jane-doe", result.PersonSlug)
+	})
+	t.Run("not existing", func(t *testing.T) {
+		_, err := PersonByUID("rt9lxuqxkkvd9999")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,337 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ExprKind specifies how the values of a search expression term are matched.
+type ExprKind int
+
+const (
+	ExprList     ExprKind = iota // Comma-separated list of lowercase values.
+	ExprCond                     // SQL condition, each placeholder is bound to the comma-separated values.
+	ExprSlug                     // SQL condition, each placeholder is bound to the slugs of the comma-separated values.
+	ExprLike                     // Pattern with "*" as wildcard.
+	ExprInt                      // Integer, may be compared or used in ranges.
+	ExprFloat                    // Decimal number, may be compared or used in ranges.
+	ExprDate                     // Year, month or day like "2019-06", may be compared or used in ranges.
+	ExprBool                     // Flag like "true" or "no".
+	ExprDuration                 // Duration like "30s" or "2m", plain numbers are seconds.
+	ExprCodec                    // Comma-separated list of codec names like "hevc", including aliases.
+)
+
+// ExprField maps a search filter to a column or SQL condition.
+type ExprField struct {
+	Column string
+	Kind   ExprKind
+}
+
+// exprVideoFile matches conditions for columns of video files with the table alias "vf".
+const exprVideoFile = "photos.id IN (SELECT vf.photo_id FROM files vf WHERE vf.file_video = TRUE AND vf.deleted_at IS NULL AND (%s))"
+
+// floatTolerance compensates for the limited precision of FLOAT columns.
+const floatTolerance = 0.01
+
+// ExprFields contains the filters supported in boolean search expressions.
+var ExprFields = map[string]ExprField{
+	"label": {"photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON l.id = pl.label_id " +
+		"LEFT JOIN categories c ON c.label_id = l.id LEFT JOIN labels cl ON cl.id = c.category_id " +
+		"WHERE pl.uncertainty < 100 AND (l.label_slug IN (?) OR l.custom_slug IN (?) OR cl.label_slug IN (?) OR cl.custom_slug IN (?)))", ExprSlug},
+	"person":   {"photos.id IN (SELECT fc.photo_id FROM faces fc JOIN people p ON p.person_uid = fc.person_uid WHERE p.person_uid IN (?) OR p.person_slug IN (?))", ExprSlug},
+	"album":    {"photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?))", ExprCond},
+	"category": {"photos.cell_id IN (SELECT cells.id FROM cells WHERE cells.cell_category IN (?))", ExprCond},
+	"state":    {"places.place_state IN (?)", ExprCond},
+	"country":  {"photos.photo_country", ExprList},
+	"type":     {"photos.photo_type", ExprList},
+	"color":    {"files.file_main_color", ExprList},
+	"hash":     {"files.file_hash", ExprList},
+	"path":     {"photos.photo_path", ExprLike},
+	"folder":   {"photos.photo_path", ExprLike},
+	"name":     {"photos.photo_name", ExprLike},
+	"filename": {"files.file_name", ExprLike},
+	"original": {"photos.original_name", ExprLike},
+	"title":    {"LOWER(photos.photo_title)", ExprLike},
+	"year":     {"photos.photo_year", ExprInt},
+	"month":    {"photos.photo_month", ExprInt},
+	"day":      {"photos.photo_day", ExprInt},
+	"quality":  {"photos.photo_quality", ExprInt},
+	"iso":      {"photos.photo_iso", ExprInt},
+	"mm":       {"photos.photo_focal_length", ExprInt},
+	"camera":   {"photos.camera_id", ExprInt},
+	"lens":     {"photos.lens_id", ExprInt},
+	"f":        {"photos.photo_f_number", ExprFloat},
+	"taken":    {"photos.taken_at", ExprDate},
+	"favorite": {"photos.photo_favorite", ExprBool},
+	"private":  {"photos.photo_private", ExprBool},
+	"scan":     {"photos.photo_scan", ExprBool},
+	"panorama": {"photos.photo_panorama", ExprBool},
+	"portrait": {"files.file_portrait", ExprBool},
+	"duration": {"vf.file_duration", ExprDuration},
+	"codec":    {"vf.file_codec", ExprCodec},
+}
+
+// ExprWhere returns the SQL condition and values matching a boolean search expression.
+func ExprWhere(x *form.Expr) (where string, values []interface{}, err error) {
+	if x == nil {
+		return "", values, nil
+	}
+
+	switch x.Op {
+	case form.ExprAnd, form.ExprOr:
+		conditions := make([]string, len(x.Args))
+
+		for i, arg := range x.Args {
+			w, v, err := ExprWhere(arg)
+
+			if err != nil {
+				return "", values, err
+			}
+
+			conditions[i] = "(" + w + ")"
+			values = append(values, v...)
+		}
+
+		return strings.Join(conditions, " "+strings.ToUpper(string(x.Op))+" "), values, nil
+	case form.ExprNot:
+		if len(x.Args) != 1 {
+			return "", values, form.NewQueryError(x.Pos, "", "missing operand")
+		}
+
+		w, v, err := ExprWhere(x.Args[0])
+
+		if err != nil {
+			return "", values, err
+		}
+
+		return "NOT (" + w + ")", v, nil
+	case form.ExprTerm:
+		if x.Key == "" {
+			return exprText(x)
+		}
+
+		return exprTerm(x)
+	}
+
+	return "", values, form.NewQueryError(x.Pos, string(x.Op), "unknown operator")
+}
+
+// exprText returns the SQL condition matching a full-text term.
+func exprText(x *form.Expr) (where string, values []interface{}, err error) {
+	textQuery := ParseTextQuery(x.Value)
+
+	var conditions []string
+
+	if relevance, v := textQuery.Relevance(); relevance != "" {
+		conditions = append(conditions, fmt.Sprintf("photos.id IN (SELECT fts.photo_id FROM (%s) fts)", relevance))
+		values = append(values, v...)
+	}
+
+	// Phrases can only be found in the full-text index.
+	if !textQuery.HasPhrase() {
+		if likeAny := LikeAny("k.keyword", x.Value); likeAny != "" {
+			conditions = append(conditions, fmt.Sprintf("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (%s))", likeAny))
+		}
+	}
+
+	// Ignore stop words.
+	if len(conditions) == 0 {
+		return "1 = 1", values, nil
+	}
+
+	return strings.Join(conditions, " OR "), values, nil
+}
+
+// ExprTermWhere returns the SQL condition matching a single filter like "duration" and its value.
+func ExprTermWhere(key, value string) (where string, values []interface{}, err error) {
+	return ExprWhere(&form.Expr{Op: form.ExprTerm, Key: key, Cmp: form.CmpEqual, Value: value})
+}
+
+// exprTerm returns the SQL condition matching a filter term.
+func exprTerm(x *form.Expr) (where string, values []interface{}, err error) {
+	field, ok := ExprFields[x.Key]
+
+	if !ok {
+		return "", values, form.NewQueryError(x.Pos, x.Key, "unknown filter")
+	}
+
+	where, values, err = exprField(x, field)
+
+	if err != nil || !strings.HasPrefix(field.Column, "vf.") {
+		return where, values, err
+	}
+
+	return fmt.Sprintf(exprVideoFile, where), values, nil
+}
+
+// exprField returns the SQL condition matching a filter term for the field.
+func exprField(x *form.Expr, field ExprField) (where string, values []interface{}, err error) {
+	switch field.Kind {
+	case ExprInt, ExprFloat, ExprDate, ExprDuration:
+		return exprCompare(x, field)
+	}
+
+	if x.Cmp != form.CmpEqual {
+		return "", values, form.NewQueryError(x.Pos, x.String(), "%s can't be compared", x.Key)
+	}
+
+	switch field.Kind {
+	case ExprList:
+		return field.Column + " IN (?)", []interface{}{strings.Split(strings.ToLower(x.Value), ",")}, nil
+	case ExprCodec:
+		var codecs []string
+
+		for _, name := range strings.Split(x.Value, ",") {
+			codecs = append(codecs, video.CodecNames(name)...)
+		}
+
+		return field.Column + " IN (?)", []interface{}{codecs}, nil
+	case ExprCond, ExprSlug:
+		list := strings.Split(x.Value, ",")
+
+		if field.Kind == ExprSlug {
+			for i, s := range list {
+				list[i] = slug.Make(s)
+			}
+		}
+
+		for i := strings.Count(field.Column, "?"); i > 0; i-- {
+			values = append(values, list)
+		}
+
+		return field.Column, values, nil
+	case ExprLike:
+		p := x.Value
+
+		if field.Column == "photos.photo_path" {
+			p = strings.Trim(p, "/")
+		} else if strings.HasPrefix(field.Column, "LOWER(") {
+			p = strings.ToLower(p)
+		}
+
+		return field.Column + " LIKE ?", []interface{}{strings.ReplaceAll(p, "*", "%")}, nil
+	case ExprBool:
+		if txt.Bool(x.Value) {
+			return field.Column + " = TRUE", values, nil
+		}
+
+		return field.Column + " = FALSE", values, nil
+	}
+
+	return "", values, form.NewQueryError(x.Pos, x.Key, "unsupported filter")
+}
+
+// exprCompare returns the SQL condition comparing a number or date column.
+func exprCompare(x *form.Expr, field ExprField) (where string, values []interface{}, err error) {
+	// Lower and upper bound of the first and the last value.
+	var min, max [2]interface{}
+
+	for i, s := range []string{x.Value, x.Max} {
+		if i > 0 && x.Cmp != form.CmpRange {
+			break
+		}
+
+		switch field.Kind {
+		case ExprInt:
+			n, err := strconv.Atoi(s)
+
+			if err != nil {
+				return "", values, form.NewQueryError(x.Pos, s, "%s must be a number", x.Key)
+			}
+
+			min[i], max[i] = n, n
+		case ExprFloat:
+			n, err := strconv.ParseFloat(s, 64)
+
+			if err != nil {
+				return "", values, form.NewQueryError(x.Pos, s, "%s must be a number", x.Key)
+			}
+
+			min[i], max[i] = n-floatTolerance, n+floatTolerance
+		case ExprDate:
+			start, end, err := exprDate(s)
+
+			if err != nil {
+				return "", values, form.NewQueryError(x.Pos, s, "%s must be a date like 2019-06", x.Key)
+			}
+
+			// Dates match if they are in the period, upper bounds are exclusive.
+			min[i], max[i] = start.Format("2006-01-02"), end.Format("2006-01-02")
+		case ExprDuration:
+			d, err := exprDuration(s)
+
+			if err != nil {
+				return "", values, form.NewQueryError(x.Pos, s, "%s must be a duration like 30s", x.Key)
+			}
+
+			// Durations are compared with a precision of one second.
+			min[i], max[i] = int64(d), int64(d+time.Second-1)
+		}
+	}
+
+	upper := " <= ?"
+
+	if field.Kind == ExprDate {
+		upper = " < ?"
+	}
+
+	col := field.Column
+
+	switch x.Cmp {
+	case form.CmpEqual:
+		if field.Kind == ExprInt {
+			return col + " = ?", []interface{}{min[0]}, nil
+		}
+
+		return col + " >= ? AND " + col + upper, []interface{}{min[0], max[0]}, nil
+	case form.CmpGreater:
+		if field.Kind == ExprDate {
+			return col + " >= ?", []interface{}{max[0]}, nil
+		}
+
+		return col + " > ?", []interface{}{max[0]}, nil
+	case form.CmpGreaterEqual:
+		return col + " >= ?", []interface{}{min[0]}, nil
+	case form.CmpLess:
+		return col + " < ?", []interface{}{min[0]}, nil
+	case form.CmpLessEqual:
+		return col + upper, []interface{}{max[0]}, nil
+	case form.CmpRange:
+		return col + " >= ? AND " + col + upper, []interface{}{min[0], max[1]}, nil
+	}
+
+	return "", values, form.NewQueryError(x.Pos, x.Cmp, "unknown comparison")
+}
+
+// exprDate returns the start and the exclusive end of the year, month or day in s.
+func exprDate(s string) (start, end time.Time, err error) {
+	switch len(s) {
+	case 4:
+		start, err = time.Parse("2006", s)
+		end = start.AddDate(1, 0, 0)
+	case 7:
+		start, err = time.Parse("2006-01", s)
+		end = start.AddDate(0, 1, 0)
+	default:
+		start, err = time.Parse("2006-01-02", s)
+		end = start.AddDate(0, 0, 1)
+	}
+
+	return start, end, err
+}
+
+// exprDuration parses a duration like "90s", "1m30s" or "90" (seconds).
+func exprDuration(s string) (time.Duration, error) {
+	if sec, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(sec * float64(time.Second)), nil
+	}
+
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,158 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExprWhere(t *testing.T) {
+	where := func(q string) (string, []interface{}, error) {
+		expr, err := form.ParseExpr(q)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return ExprWhere(expr)
+	}
+
+	t.Run("comparison", func(t *testing.T) {
+		w, v, err := where("iso:>1600")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos.photo_iso > ?", w)
+		assert.Equal(t, []interface{}{1600}, v)
+	})
+	t.Run("date range", func(t *testing.T) {
+		w, v, err := where("taken:2019-01..2019-06")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos.taken_at >= ? AND photos.taken_at < ?", w)
+		assert.Equal(t, []interface{}{"2019-01-01", "2019-07-01"}, v)
+	})
+	t.Run("boolean", func(t *testing.T) {
+		w, v, err := where("country:de OR -favorite:true year:2019..2020")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "(photos.photo_country IN (?)) OR ((NOT (photos.photo_favorite = TRUE)) AND (photos.photo_year >= ? AND photos.photo_year <= ?))", w)
+		assert.Equal(t, []interface{}{[]string{"de"}, 2019, 2020}, v)
+	})
+	t.Run("label", func(t *testing.T) {
+		w, v, err := where("-label:Cat,dogs")
+
+		assert.NoError(t, err)
+		assert.Contains(t, w, "NOT (photos.id IN (SELECT pl.photo_id FROM photos_labels pl")
+		assert.Len(t, v, 4)
+		assert.Equal(t, []string{"cat", "dogs"}, v[0])
+	})
+	t.Run("duration", func(t *testing.T) {
+		w, v, err := where("duration:>30s")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos.id IN (SELECT vf.photo_id FROM files vf WHERE vf.file_video = TRUE AND vf.deleted_at IS NULL AND (vf.file_duration > ?))", w)
+		assert.Equal(t, []interface{}{int64(31*time.Second - 1)}, v)
+
+		_, v, err = where("duration:90..2m")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{int64(90 * time.Second), int64(121*time.Second - 1)}, v)
+
+		_, _, err = where("duration:>long")
+
+		assert.IsType(t, &form.QueryError{}, err)
+	})
+	t.Run("codec", func(t *testing.T) {
+		w, v, err := where("codec:hevc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos.id IN (SELECT vf.photo_id FROM files vf WHERE vf.file_video = TRUE AND vf.deleted_at IS NULL AND (vf.file_codec IN (?)))", w)
+		assert.Equal(t, []interface{}{[]string{"hvc1", "hev1", "hevc"}}, v)
+	})
+	t.Run("unknown filter", func(t *testing.T) {
+		_, _, err := where("year:2019 | foo:bar")
+
+		assert.IsType(t, &form.QueryError{}, err)
+		assert.Equal(t, "unknown filter at position 12: foo", err.Error())
+	})
+	t.Run("invalid number", func(t *testing.T) {
+		_, _, err := where("f:1.4..x")
+
+		assert.IsType(t, &form.QueryError{}, err)
+		assert.Equal(t, "f must be a number at position 0: x", err.Error())
+	})
+	t.Run("not comparable", func(t *testing.T) {
+		_, _, err := where("label:>cat")
+
+		assert.IsType(t, &form.QueryError{}, err)
+	})
+}
+
+func TestPhotoSearch_Expr(t *testing.T) {
+	uids := func(q string) (result []string) {
+		photos, _, err := PhotoSearch(form.PhotoSearch{Query: q, Count: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			result = append(result, p.PhotoUID)
+		}
+
+		return result
+	}
+
+	t.Run("or", func(t *testing.T) {
+		result := uids("year:2014 | f:4.9..5.1")
+
+		assert.Contains(t, result, "pt9jtdre2lvl0yh7")
+		assert.Contains(t, result, "pt9jtdre2lvl0y11")
+	})
+	t.Run("date", func(t *testing.T) {
+		assert.Contains(t, uids("taken:2014-07..2014-07"), "pt9jtdre2lvl0y11")
+		assert.Contains(t, uids("taken:<=2014-07-17"), "pt9jtdre2lvl0y11")
+		assert.NotContains(t, uids("taken:>2014-07-17"), "pt9jtdre2lvl0y11")
+		assert.NotContains(t, uids("taken:<2014"), "pt9jtdre2lvl0y11")
+	})
+	t.Run("negation", func(t *testing.T) {
+		assert.Contains(t, uids("year:2014 (country:mx OR country:de)"), "pt9jtdre2lvl0y11")
+		assert.NotContains(t, uids("year:2014 -country:mx"), "pt9jtdre2lvl0y11")
+	})
+	t.Run("text", func(t *testing.T) {
+		if err := entity.PhotoFixtures.Pointer("Photo04").IndexTerms(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, uids("neckar* | year:1990"), "pt9jtdre2lvl0y11")
+		assert.NotContains(t, uids("-neckar* year:2014"), "pt9jtdre2lvl0y11")
+	})
+	t.Run("filter", func(t *testing.T) {
+		photos, _, err := PhotoSearch(form.PhotoSearch{Filter: "f:>=5 OR iso:>1600", Count: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for _, p := range photos {
+			assert.True(t, p.PhotoFNumber >= 4.99 || p.PhotoIso > 1600)
+		}
+	})
+	t.Run("video", func(t *testing.T) {
+		assert.Contains(t, uids("duration:>30s"), "pt9jtdre2lvl0yh0")
+		assert.NotContains(t, uids("duration:>1m"), "pt9jtdre2lvl0yh0")
+		assert.Contains(t, uids("duration:45"), "pt9jtdre2lvl0yh0")
+		assert.Contains(t, uids("codec:hevc"), "pt9jtdre2lvl0yh0")
+		assert.NotContains(t, uids("codec:avc"), "pt9jtdre2lvl0yh0")
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		_, _, err := PhotoSearch(form.PhotoSearch{Query: "(label:cat", Count: 10})
+
+		assert.IsType(t, &form.QueryError{}, err)
+	})
+}
@@ -15,6 +15,13 @@ import (
 func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error) {
 	start := time.Now()
 
+	// Smart albums always use their saved filter.
+	if f.Album != "" {
+		if a, err := AlbumByUID(f.Album); err == nil && a.IsSmart() {
+			f.Filter = a.AlbumFilter
+		}
+	}
+
 	if err := f.ParseQueryString(); err != nil {
 		return results, 0, err
 	}
@@ -34,13 +41,13 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		cameras.camera_make, cameras.camera_model,
 		lenses.lens_make, lenses.lens_model,
 		places.place_label, places.place_city, places.place_state, places.place_country`).
-		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = FALSE AND files.deleted_at IS NULL").
 		Joins("JOIN cameras ON photos.camera_id = cameras.id").
 		Joins("JOIN lenses ON photos.lens_id = lenses.id").
 		Joins("JOIN places ON photos.place_id = places.id")
 
 	if !f.Hidden {
-		s = s.Where("files.file_type = 'jpg' OR files.file_video = 1")
+		s = s.Where("files.file_type = 'jpg' OR files.file_video = TRUE")
 
 		if f.Error {
 			s = s.Where("files.file_error <> ''")
@@ -49,13 +56,16 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		}
 	}
 
+	// Restrict results to the library of the user.
+	s = UserPhotos(s, f.UserUID)
+
 	// Return primary files only.
 	if f.Primary {
-		s = s.Where("files.file_primary = 1")
+		s = s.Where("files.file_primary = TRUE")
 	}
 
-	// Shortcut for known photo ids.
-	if f.ID != "" {
+	// Shortcut for known photo ids, unless they must also match an album.
+	if f.ID != "" && f.Album == "" {
 		s = s.Where("photos.photo_uid IN (?)", strings.Split(f.ID, ","))
 		s = s.Order("files.file_primary DESC")
 
@@ -76,6 +86,7 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	var categories []entity.Category
 	var labels []entity.Label
 	var labelIds []uint
+	var textSearch bool
 
 	if f.Label != "" {
 		if err := Db().Where(AnySlug("label_slug", f.Label, ",")).Or(AnySlug("custom_slug", f.Label, ",")).Find(&labels).Error; len(labels) == 0 || err != nil {
@@ -95,8 +106,25 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 			}
 
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = photos.id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
-				Group("photos.id, files.id")
+				Group("photos.id, files.id, cameras.id, lenses.id, places.id")
+		}
+	}
+
+	// Filter by people whose faces were found in the photos.
+	if f.Person != "" {
+		var people entity.People
+		var personUIDs []string
+
+		if err := Db().Where("person_uid IN (?)", strings.Split(f.Person, ",")).Or(AnySlug("person_slug", f.Person, ",")).Find(&people).Error; len(people) == 0 || err != nil {
+			log.Errorf("search: people %s not found", txt.Quote(f.Person))
+			return results, 0, fmt.Errorf("%s not found", txt.Quote(f.Person))
+		}
+
+		for _, p := range people {
+			personUIDs = append(personUIDs, p.PersonUID)
 		}
+
+		s = s.Where("photos.id IN (SELECT fc.photo_id FROM faces fc WHERE fc.person_uid IN (?))", personUIDs)
 	}
 
 	// Filter by location.
@@ -111,11 +139,26 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 			return results, 0, fmt.Errorf("query too short")
 		}
 
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		// Full-text search in titles, descriptions, notes, album captions and file names.
+		textQuery := ParseTextQuery(f.Query)
+		textMatch := ""
+
+		if relevance, values := textQuery.Relevance(); relevance != "" {
+			s = s.Joins("LEFT JOIN (?) fts ON fts.photo_id = photos.id", gorm.Expr(relevance, values...))
+			textMatch = "fts.photo_id IS NOT NULL OR "
+			textSearch = true
+		}
+
+		if textQuery.HasPhrase() {
+			// Phrases can only be found in the full-text index.
+			s = s.Where("fts.photo_id IS NOT NULL")
+		} else if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Infof("search: label %s not found, using fuzzy search", txt.Quote(f.Query))
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
-				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
+				s = s.Where(textMatch+"photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
+			} else if textSearch {
+				s = s.Where("fts.photo_id IS NOT NULL")
 			}
 		} else {
 			for _, l := range labels {
@@ -131,14 +174,25 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 			}
 
 			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
-				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
+				s = s.Where(textMatch+"photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
 					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", gorm.Expr(likeAny), labelIds)
 			} else {
-				s = s.Where("photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
+				s = s.Where(textMatch+"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
 			}
 		}
 	}
 
+	// Filter by boolean search expression.
+	if f.Expr != nil {
+		where, values, err := ExprWhere(f.Expr)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		s = s.Where(where, values...)
+	}
+
 	// Filter by status.
 	if f.Hidden {
 		s = s.Where("photos.photo_quality = -1")
@@ -149,9 +203,9 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.deleted_at IS NULL")
 
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = TRUE")
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = FALSE")
 		}
 
 		if f.Review {
@@ -187,15 +241,15 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.Scan {
-		s = s.Where("photos.photo_scan = 1")
+		s = s.Where("photos.photo_scan = TRUE")
 	}
 
 	if f.Panorama {
-		s = s.Where("photos.photo_panorama = 1")
+		s = s.Where("photos.photo_panorama = TRUE")
 	}
 
 	if f.Country != "" {
@@ -252,12 +306,33 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("LOWER(photos.photo_title) LIKE ?", strings.ReplaceAll(strings.ToLower(f.Title), "*", "%"))
 	}
 
+	// Filter by video duration and codec.
+	if f.Duration != "" {
+		where, values, err := ExprTermWhere("duration", f.Duration)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		s = s.Where(where, values...)
+	}
+
+	if f.Codec != "" {
+		where, values, err := ExprTermWhere("codec", f.Codec)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		s = s.Where(where, values...)
+	}
+
 	if f.Hash != "" {
 		s = s.Where("files.file_hash IN (?)", strings.Split(strings.ToLower(f.Hash), ","))
 	}
 
 	if f.Portrait {
-		s = s.Where("files.file_portrait = 1")
+		s = s.Where("files.file_portrait = TRUE")
 	}
 
 	if f.Mono {
@@ -312,13 +387,17 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if f.Album != "" {
+		if f.ID != "" {
+			s = s.Where("photos.photo_uid IN (?)", strings.Split(f.ID, ","))
+		}
+
 		if f.Filter != "" {
-			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
+			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = TRUE AND pa.album_uid = ?)", f.Album)
 		} else {
-			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album)
 		}
 	} else if f.Unsorted && f.Filter == "" {
-		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 0)")
+		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE)")
 	}
 
 	// Set sort order for results.
@@ -326,8 +405,12 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	case entity.SortOrderEdited:
 		s = s.Where("edited_at IS NOT NULL").Order("edited_at DESC, photos.photo_uid, files.file_primary DESC")
 	case entity.SortOrderRelevance:
-		if f.Label != "" {
-			s = s.Order("photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC")
+		if textSearch && f.Label != "" {
+			s = s.Order("COALESCE(MAX(fts.relevance), 0) DESC, photo_quality DESC, MIN(photos_labels.uncertainty) ASC, taken_at DESC, files.file_primary DESC")
+		} else if textSearch {
+			s = s.Order("COALESCE(fts.relevance, 0) DESC, photo_quality DESC, taken_at DESC, files.file_primary DESC")
+		} else if f.Label != "" {
+			s = s.Order("photo_quality DESC, MIN(photos_labels.uncertainty) ASC, taken_at DESC, files.file_primary DESC")
 		} else {
 			s = s.Order("photo_quality DESC, taken_at DESC, files.file_primary DESC")
 		}
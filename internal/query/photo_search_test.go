@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -91,6 +92,32 @@ func TestPhotoSearch(t *testing.T) {
 
 		assert.LessOrEqual(t, 2, len(photos))
 	})
+	t.Run("person query jane-doe", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "person:jane-doe"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0y11", photos[0].PhotoUID)
+	})
+	t.Run("invalid person query", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "person:xxx"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+		assert.Empty(t, photos)
+	})
 	t.Run("invalid label query", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "label:xxx"
@@ -638,3 +665,55 @@ func TestPhotoSearch(t *testing.T) {
 		assert.IsType(t, PhotoResults{}, photos)
 	})
 }
+
+func TestPhotoSearch_UserUID(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		f := form.PhotoSearch{Count: 5000, UserUID: entity.Admin.UserUID}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 5, len(photos))
+	})
+	t.Run("library", func(t *testing.T) {
+		f := form.PhotoSearch{Count: 5000, UserUID: entity.UserFixtures.Get("alice").UserUID}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.True(t, strings.HasPrefix(p.PhotoPath, "2790/"))
+		}
+	})
+	t.Run("shared album", func(t *testing.T) {
+		f := form.PhotoSearch{Count: 5000, UserUID: entity.UserFixtures.Get("bob").UserUID}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		f := form.PhotoSearch{Count: 5000, UserUID: "ut9lxuqxkkvdxxxx"}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+}
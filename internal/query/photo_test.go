@@ -78,3 +78,12 @@ func TestPhotosCheck(t *testing.T) {
 	}
 	assert.IsType(t, entity.Photos{}, result)
 }
+
+func TestPhotosWithDetails(t *testing.T) {
+	result, err := PhotosWithDetails(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.IsType(t, entity.Photos{}, result)
+	assert.Len(t, result, 10)
+}
@@ -47,8 +47,9 @@ import (
 var log = event.Log
 
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+	Postgres = "postgres"
 )
 
 // Max result limit for queries.
@@ -91,6 +92,11 @@ func DbDialect() string {
 	return Db().Dialect().GetName()
 }
 
+// LikeEscape escapes wildcards in a LIKE pattern, requires "ESCAPE '!'" as it works with all dialects.
+func LikeEscape(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
 // LikeAny returns a where condition that matches any keyword in search.
 func LikeAny(col, search string) (where string) {
 	var wheres []string
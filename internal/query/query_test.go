@@ -25,6 +25,13 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+func TestLikeEscape(t *testing.T) {
+	assert.Equal(t, "IMG!_1234", LikeEscape("IMG_1234"))
+	assert.Equal(t, "100!%", LikeEscape("100%"))
+	assert.Equal(t, "Hello!!", LikeEscape("Hello!"))
+	assert.Equal(t, "2020/06", LikeEscape("2020/06"))
+}
+
 func TestLikeAny(t *testing.T) {
 	t.Run("table spoon usa img json", func(t *testing.T) {
 		where := LikeAny("k.keyword", "table spoon usa img json")
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// SimilarDistance is the default maximum Hamming distance of perceptual hashes for near-duplicates.
+const SimilarDistance = 6
+
+// SimilarPhoto represents a photo and the perceptual hash of its primary file.
+type SimilarPhoto struct {
+	PhotoID         uint
+	PhotoUID        string
+	PhotoQuality    int
+	PhotoResolution int
+	FileUID         string
+	FileName        string
+	FileSize        int64
+	FilePhash       string
+	Distance        int `gorm:"-"`
+}
+
+// Hash returns the parsed perceptual hash.
+func (m SimilarPhoto) Hash() (phash.Hash, error) {
+	return phash.Parse(m.FilePhash)
+}
+
+// SimilarResults contains photos with similar perceptual hashes.
+type SimilarResults []SimilarPhoto
+
+// UIDs returns the photo UIDs.
+func (m SimilarResults) UIDs() []string {
+	result := make([]string, len(m))
+
+	for i, p := range m {
+		result[i] = p.PhotoUID
+	}
+
+	return result
+}
+
+// PhotoHashes returns the perceptual hashes of primary files for all photos that are not archived.
+func PhotoHashes() (results SimilarResults, err error) {
+	err = Db().Table("photos").
+		Select("photos.id AS photo_id, photos.photo_uid, photos.photo_quality, photos.photo_resolution, files.file_uid, files.file_name, files.file_size, files.file_phash").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = TRUE AND files.file_missing = FALSE AND files.deleted_at IS NULL").
+		Where("photos.deleted_at IS NULL AND files.file_phash <> ''").
+		Order("photos.id").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// SimilarPhotos returns photos that look similar to the given photo, based on the Hamming distance
+// of the perceptual hashes of their primary files. Results are sorted by distance.
+func SimilarPhotos(photoUID string, distance int) (results SimilarResults, err error) {
+	file, err := FileByPhotoUID(photoUID)
+
+	if err != nil {
+		return results, err
+	}
+
+	// Photos without perceptual hash can't be compared.
+	if file.FilePhash == "" {
+		return results, nil
+	}
+
+	h, err := phash.Parse(file.FilePhash)
+
+	if err != nil {
+		return results, err
+	}
+
+	all, err := PhotoHashes()
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, p := range all {
+		if p.PhotoUID == photoUID {
+			continue
+		}
+
+		other, err := p.Hash()
+
+		if err != nil {
+			continue
+		}
+
+		if p.Distance = h.Distance(other); p.Distance <= distance {
+			results = append(results, p)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Distance == results[j].Distance {
+			return results[i].PhotoQuality > results[j].PhotoQuality
+		}
+
+		return results[i].Distance < results[j].Distance
+	})
+
+	return results, nil
+}
+
+// NearDuplicates returns groups of photos with similar perceptual hashes. Each group is sorted by
+// quality so that the first photo is the one that should be kept.
+func NearDuplicates(distance int) (groups []SimilarResults, err error) {
+	photos, err := PhotoHashes()
+
+	if err != nil {
+		return groups, err
+	}
+
+	// Photos with higher quality become group references first.
+	sort.SliceStable(photos, func(i, j int) bool {
+		return photos[i].better(photos[j])
+	})
+
+	tree := phash.Tree{}
+	hashes := make(map[uint]phash.Hash, len(photos))
+	index := make(map[uint]int, len(photos))
+
+	for i, p := range photos {
+		h, err := p.Hash()
+
+		if err != nil {
+			continue
+		}
+
+		hashes[p.PhotoID] = h
+		index[p.PhotoID] = i
+		tree.Add(h, p.PhotoID)
+	}
+
+	grouped := make(map[uint]bool, len(photos))
+
+	for _, p := range photos {
+		h, ok := hashes[p.PhotoID]
+
+		if !ok || grouped[p.PhotoID] {
+			continue
+		}
+
+		group := SimilarResults{p}
+
+		for _, id := range tree.Search(h, distance) {
+			if id == p.PhotoID || grouped[id] {
+				continue
+			}
+
+			m := photos[index[id]]
+			m.Distance = h.Distance(hashes[id])
+			group = append(group, m)
+		}
+
+		if len(group) < 2 {
+			continue
+		}
+
+		sort.SliceStable(group[1:], func(i, j int) bool {
+			return group[i+1].better(group[j+1])
+		})
+
+		for _, m := range group {
+			grouped[m.PhotoID] = true
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// better returns true if the photo should be preferred over the other one.
+func (m SimilarPhoto) better(other SimilarPhoto) bool {
+	switch {
+	case m.PhotoQuality != other.PhotoQuality:
+		return m.PhotoQuality > other.PhotoQuality
+	case m.PhotoResolution != other.PhotoResolution:
+		return m.PhotoResolution > other.PhotoResolution
+	case m.FileSize != other.FileSize:
+		return m.FileSize > other.FileSize
+	default:
+		return m.PhotoID < other.PhotoID
+	}
+}
+
+// FilesWithoutPhash returns primary JPEG originals without perceptual hash.
+func FilesWithoutPhash(limit, offset int) (files entity.Files, err error) {
+	err = Db().
+		Where("file_primary = TRUE AND file_missing = FALSE AND file_type = ? AND file_root = ?", "jpg", entity.RootOriginals).
+		Where("file_phash = '' OR file_phash IS NULL").
+		Order("id").Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoHashes(t *testing.T) {
+	results, err := PhotoHashes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 4)
+
+	for _, r := range results {
+		assert.Len(t, r.FilePhash, 16)
+	}
+}
+
+func TestSimilarPhotos(t *testing.T) {
+	t.Run("bridge", func(t *testing.T) {
+		results, err := SimilarPhotos("pt9jtdre2lvl0y11", SimilarDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh0", "pt9jtdre2lvl0yh9"}, results.UIDs())
+		assert.Equal(t, 2, results[0].Distance)
+		assert.Equal(t, 2, results[1].Distance)
+	})
+	t.Run("distance zero", func(t *testing.T) {
+		results, err := SimilarPhotos("pt9jtdre2lvl0y11", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("no hash", func(t *testing.T) {
+		results, err := SimilarPhotos("pt9jtdre2lvl0y25", SimilarDistance)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := SimilarPhotos("xxx", SimilarDistance)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNearDuplicates(t *testing.T) {
+	groups, err := NearDuplicates(SimilarDistance)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, groups, 1)
+	assert.Len(t, groups[0], 3)
+	assert.Equal(t, "pt9jtdre2lvl0yh0", groups[0][0].PhotoUID)
+}
+
+func TestFilesWithoutPhash(t *testing.T) {
+	files, err := FilesWithoutPhash(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		assert.Empty(t, f.FilePhash)
+		assert.True(t, f.FilePrimary)
+	}
+}
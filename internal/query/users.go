@@ -0,0 +1,175 @@
+package query
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Users returns all registered users.
+func Users() (results entity.Users, err error) {
+	err = Db().Where("user_name <> ''").Order("user_name").Find(&results).Error
+
+	return results, err
+}
+
+// UserByUID returns a registered user by UID.
+func UserByUID(userUID string) (user entity.User, err error) {
+	err = Db().Where("user_uid = ? AND user_name <> ''", userUID).Preload("Address").First(&user).Error
+
+	return user, err
+}
+
+// userAlbums returns a subquery for the albums a user created or that were shared with them.
+func userAlbums(user *entity.User) *gorm.SqlExpr {
+	return UnscopedDb().Table("albums").Select("albums.album_uid").
+		Where("albums.deleted_at IS NULL").
+		Where("albums.created_by = ? OR albums.album_uid IN (SELECT us.share_uid FROM users_shares us WHERE us.user_uid = ?)", user.UserUID, user.UserUID).
+		SubQuery()
+}
+
+// UserPhotos restricts photo search results to the library of a user and albums shared with them.
+// Admins and empty user UIDs are not restricted.
+func UserPhotos(s *gorm.DB, userUID string) *gorm.DB {
+	if userUID == "" {
+		return s
+	}
+
+	user := entity.FindUserByUID(userUID)
+
+	if user == nil {
+		return s.Where("1 = 0")
+	}
+
+	if user.Admin() {
+		return s
+	}
+
+	inAlbums := UnscopedDb().Table("photos_albums").Select("photos_albums.photo_uid").
+		Where("photos_albums.hidden = FALSE AND photos_albums.album_uid IN ?", userAlbums(user)).
+		SubQuery()
+
+	if user.StoragePath == "" {
+		return s.Where("photos.photo_uid IN ?", inAlbums)
+	}
+
+	return s.Where("photos.photo_path = ? OR photos.photo_path LIKE ? ESCAPE '!' OR photos.photo_uid IN ?",
+		user.StoragePath, LikeEscape(user.StoragePath)+"/%", inAlbums)
+}
+
+// UserAlbums restricts album search results to albums a user created, albums shared with them
+// and folders in their library. Admins and empty user UIDs are not restricted.
+func UserAlbums(s *gorm.DB, userUID string) *gorm.DB {
+	if userUID == "" {
+		return s
+	}
+
+	user := entity.FindUserByUID(userUID)
+
+	if user == nil {
+		return s.Where("1 = 0")
+	}
+
+	if user.Admin() {
+		return s
+	}
+
+	if user.StoragePath == "" {
+		return s.Where("albums.album_uid IN ?", userAlbums(user))
+	}
+
+	return s.Where("albums.album_uid IN ? OR albums.album_type = ? AND (albums.album_path = ? OR albums.album_path LIKE ? ESCAPE '!')",
+		userAlbums(user), entity.AlbumFolder, user.StoragePath, LikeEscape(user.StoragePath)+"/%")
+}
+
+// UserPeople restricts people search results to people whose faces were found in the library of a user
+// and albums shared with them. Admins and empty user UIDs are not restricted.
+func UserPeople(s *gorm.DB, userUID string) *gorm.DB {
+	if userUID == "" {
+		return s
+	}
+
+	photos := UserPhotos(UnscopedDb().Table("photos").Select("photos.photo_uid"), userUID).
+		Where("photos.deleted_at IS NULL").
+		SubQuery()
+
+	return s.Where("people.person_uid IN (SELECT faces.person_uid FROM faces WHERE faces.photo_uid IN ?)", photos)
+}
+
+// UserHasPhotos returns true if all photos are in the library of a user or in albums shared with them.
+// Admins and empty user UIDs are not restricted.
+func UserHasPhotos(userUID string, photoUIDs []string) bool {
+	if userUID == "" || len(photoUIDs) == 0 {
+		return true
+	}
+
+	count := 0
+	s := UserPhotos(UnscopedDb().Table("photos"), userUID).
+		Where("photos.photo_uid IN (?)", photoUIDs).
+		Select("COUNT(DISTINCT photos.photo_uid)")
+
+	if err := s.Row().Scan(&count); err != nil {
+		log.Errorf("user: %s", err)
+		return false
+	}
+
+	return count == distinct(photoUIDs)
+}
+
+// UserHasAlbums returns true if the user created all albums or they were shared with them.
+// Admins and empty user UIDs are not restricted.
+func UserHasAlbums(userUID string, albumUIDs []string) bool {
+	if userUID == "" || len(albumUIDs) == 0 {
+		return true
+	}
+
+	count := 0
+	s := UserAlbums(UnscopedDb().Table("albums"), userUID).
+		Where("albums.album_uid IN (?)", albumUIDs).
+		Select("COUNT(DISTINCT albums.album_uid)")
+
+	if err := s.Row().Scan(&count); err != nil {
+		log.Errorf("user: %s", err)
+		return false
+	}
+
+	return count == distinct(albumUIDs)
+}
+
+// UserHasPeople returns true if the faces of all people were found in the library of a user or in albums
+// shared with them. Admins and empty user UIDs are not restricted.
+func UserHasPeople(userUID string, personUIDs []string) bool {
+	if userUID == "" || len(personUIDs) == 0 {
+		return true
+	}
+
+	count := 0
+	s := UserPeople(UnscopedDb().Table("people"), userUID).
+		Where("people.person_uid IN (?)", personUIDs).
+		Select("COUNT(DISTINCT people.person_uid)")
+
+	if err := s.Row().Scan(&count); err != nil {
+		log.Errorf("user: %s", err)
+		return false
+	}
+
+	return count == distinct(personUIDs)
+}
+
+// distinct returns the number of distinct values.
+func distinct(values []string) int {
+	seen := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		seen[v] = true
+	}
+
+	return len(seen)
+}
+
+// AlbumUsers returns the registered users an album is shared with.
+func AlbumUsers(albumUID string) (results entity.Users, err error) {
+	err = Db().Where("user_name <> '' AND user_uid IN (SELECT user_uid FROM users_shares WHERE share_uid = ?)", albumUID).
+		Order("user_name").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,155 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsers(t *testing.T) {
+	users, err := Users()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 3, len(users))
+
+	for _, u := range users {
+		assert.NotEmpty(t, u.UserName)
+	}
+}
+
+func TestUserByUID(t *testing.T) {
+	t.Run("alice", func(t *testing.T) {
+		user, err := UserByUID("ut9lxuqxkkvd1000")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "alice", user.UserName)
+	})
+	t.Run("guest", func(t *testing.T) {
+		_, err := UserByUID(entity.Guest.UserUID)
+		assert.Error(t, err)
+	})
+}
+
+func TestUserAlbums(t *testing.T) {
+	t.Run("shared album", func(t *testing.T) {
+		f := form.AlbumSearch{Count: 100, UserUID: entity.UserFixtures.Get("bob").UserUID}
+
+		albums, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, albums, 1)
+		assert.Equal(t, "at9lxuqxpogaaba8", albums[0].AlbumUID)
+	})
+	t.Run("admin", func(t *testing.T) {
+		f := form.AlbumSearch{Count: 100, UserUID: entity.Admin.UserUID}
+
+		albums, err := AlbumSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 3, len(albums))
+	})
+}
+
+func TestUserHasPhotos(t *testing.T) {
+	bob := entity.UserFixtures.Get("bob").UserUID
+
+	assert.True(t, UserHasPhotos(bob, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh7"}))
+	assert.False(t, UserHasPhotos(bob, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}))
+	assert.False(t, UserHasPhotos("xxx", []string{"pt9jtdre2lvl0yh7"}))
+	assert.True(t, UserHasPhotos("", []string{"pt9jtdre2lvl0yh8"}))
+	assert.True(t, UserHasPhotos(entity.Admin.UserUID, []string{"pt9jtdre2lvl0yh8"}))
+}
+
+func TestUserPhotos(t *testing.T) {
+	user := &entity.User{UserName: "wildcard", RoleFamily: true, StoragePath: "199_"}
+
+	if err := user.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer user.Delete()
+
+	// Wildcards in storage paths must not match folders of other users, e.g. "1990/04".
+	assert.False(t, UserHasPhotos(user.UserUID, []string{"pt9jtdre2lvl0yh0"}))
+
+	user.StoragePath = "1990"
+
+	if err := user.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, UserHasPhotos(user.UserUID, []string{"pt9jtdre2lvl0yh0"}))
+}
+
+func TestUserPeople(t *testing.T) {
+	alice := entity.UserFixtures.Get("alice")
+
+	t.Run("admin", func(t *testing.T) {
+		assert.True(t, UserHasPeople("", []string{"rt9lxuqxkkvd1000", "rt9lxuqxkkvd1001"}))
+	})
+	t.Run("not in library", func(t *testing.T) {
+		results, err := People(form.PersonSearch{UserUID: alice.UserUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+		assert.False(t, UserHasPeople(alice.UserUID, []string{"rt9lxuqxkkvd1000"}))
+	})
+	t.Run("in library", func(t *testing.T) {
+		m := entity.Face{PhotoUID: "pt9jtdre2lvl0yh7", PersonUID: "rt9lxuqxkkvd1000"}
+
+		if err := entity.Db().Create(&m).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Delete(&m)
+
+		results, err := People(form.PersonSearch{UserUID: alice.UserUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "rt9lxuqxkkvd1000", results[0].PersonUID)
+		}
+
+		assert.True(t, UserHasPeople(alice.UserUID, []string{"rt9lxuqxkkvd1000"}))
+		assert.False(t, UserHasPeople(alice.UserUID, []string{"rt9lxuqxkkvd1000", "rt9lxuqxkkvd1001"}))
+	})
+}
+
+func TestUserHasAlbums(t *testing.T) {
+	bob := entity.UserFixtures.Get("bob").UserUID
+
+	assert.True(t, UserHasAlbums(bob, []string{"at9lxuqxpogaaba8"}))
+	assert.False(t, UserHasAlbums(bob, []string{"at9lxuqxpogaaba8", "at9lxuqxpogaaba9"}))
+	assert.True(t, UserHasAlbums("", []string{"at9lxuqxpogaaba9"}))
+}
+
+func TestAlbumUsers(t *testing.T) {
+	users, err := AlbumUsers("at9lxuqxpogaaba8")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, users, 1)
+	assert.Equal(t, "bob", users[0].UserName)
+}
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Webhooks returns all webhooks.
+func Webhooks() (result entity.Webhooks, err error) {
+	if err := Db().Order("id").Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// WebhookByID finds a webhook by primary key.
+func WebhookByID(id uint) (result entity.Webhook, err error) {
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// WebhookDeliveries returns the delivery log of a webhook, newest first.
+func WebhookDeliveries(webhookID uint, limit, offset int) (result entity.WebhookDeliveries, err error) {
+	if limit <= 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+
+	err = Db().Where("webhook_id = ?", webhookID).Order("id DESC").Limit(limit).Offset(offset).Find(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	m, err := entity.CreateWebhook(form.Webhook{HookURL: "https://example.com/hook", HookTopics: "photos.*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	t.Run("all", func(t *testing.T) {
+		r, err := Webhooks()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+	})
+
+	t.Run("by id", func(t *testing.T) {
+		r, err := WebhookByID(m.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.*", r.HookTopics)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := WebhookByID(999999)
+		assert.Error(t, err)
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		for i := 1; i <= 2; i++ {
+			d := entity.WebhookDelivery{WebhookID: m.ID, DeliveryUID: "a", EventName: "photos.created", Attempt: i, StatusCode: 500}
+
+			if err := d.Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r, err := WebhookDeliveries(m.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 2)
+		assert.Equal(t, 2, r[0].Attempt)
+	})
+}
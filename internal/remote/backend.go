@@ -0,0 +1,61 @@
+package remote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/remote/local"
+	"github.com/photoprism/photoprism/internal/remote/s3"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const SyncTimeout = webdav.SyncTimeout
+const AsyncTimeout = webdav.AsyncTimeout
+
+// ErrUnsupported is returned for services that can't be used for syncing and sharing.
+var ErrUnsupported = errors.New("service type not supported")
+
+// Backend is implemented by all services that can be used for syncing and sharing files.
+type Backend interface {
+	Files(dir string) (fs.FileInfos, error)
+	Directories(root string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	Stat(name string) (fs.FileInfo, error)
+	Download(from, to string, force bool) error
+	Upload(from, to string) error
+	CreateDir(dir string) error
+	Delete(name string) error
+	Close() error
+}
+
+// HostKeyBackend is implemented by backends that identify servers by a host key, which must be pinned on first use.
+type HostKeyBackend interface {
+	HostKey() string
+}
+
+// Supported tests if a service type has a backend.
+func Supported(serviceType string) bool {
+	switch serviceType {
+	case ServiceWebDAV, ServiceS3, ServiceSFTP, ServiceLocal:
+		return true
+	}
+
+	return false
+}
+
+// NewBackend returns a connected backend for the service type, the host key fingerprint is only used by SFTP.
+func NewBackend(serviceType, rawUrl, user, pass, hostKey string) (Backend, error) {
+	switch serviceType {
+	case ServiceWebDAV:
+		return webdav.New(rawUrl, user, pass), nil
+	case ServiceS3:
+		return s3.New(rawUrl, user, pass)
+	case ServiceSFTP:
+		return sftp.New(rawUrl, user, pass, hostKey)
+	case ServiceLocal:
+		return local.New(rawUrl)
+	}
+
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,48 @@
+package remote
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/remote/local"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(ServiceWebDAV))
+	assert.True(t, Supported(ServiceS3))
+	assert.True(t, Supported(ServiceSFTP))
+	assert.True(t, Supported(ServiceLocal))
+	assert.False(t, Supported(ServiceFacebook))
+	assert.False(t, Supported(""))
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		local.Roots = []string{os.TempDir()}
+		defer func() { local.Roots = nil }()
+
+		b, err := NewBackend(ServiceLocal, os.TempDir(), "", "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer b.Close()
+
+		_, err = b.Directories("/", false, SyncTimeout)
+
+		assert.NoError(t, err)
+	})
+	t.Run("s3", func(t *testing.T) {
+		b, err := NewBackend(ServiceS3, "http://minio.local:9000/photos", "key", "secret", "")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, b)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewBackend(ServiceFacebook, "https://www.facebook.com/", "", "", "")
+
+		assert.Equal(t, ErrUnsupported, err)
+	})
+}
@@ -3,8 +3,10 @@ package remote
 import (
 	"errors"
 	"net/url"
+	"path/filepath"
 	"strings"
 
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -46,6 +48,35 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.User = url.UserPassword(result.AccUser, result.AccPass)
 	}
 
+	// Local and network file systems
+	if u.Scheme == "file" || u.Scheme == "" && u.Host == "" && filepath.IsAbs(u.Path) {
+		if !fs.PathExists(u.Path) {
+			return result, errors.New("folder not found")
+		}
+
+		result.AccName = strings.Title(filepath.Base(u.Path))
+		result.AccType = ServiceLocal
+		result.AccURL = filepath.Clean(u.Path)
+
+		return result, nil
+	}
+
+	// SSH File Transfer Protocol
+	if u.Scheme == "sftp" || u.Scheme == "ssh" {
+		if !SshOk(u.Host) {
+			return result, errors.New("could not connect")
+		}
+
+		u.Scheme = "sftp"
+		u.User = nil
+
+		result.AccName = serviceName(u.Hostname())
+		result.AccType = ServiceSFTP
+		result.AccURL = u.String()
+
+		return result, nil
+	}
+
 	// Set default scheme
 	if u.Scheme == "" {
 		u.Scheme = "https"
@@ -59,12 +90,7 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		if serviceUrl := h.Discover(u.String(), result.AccUser); serviceUrl != nil {
 			serviceUrl.User = nil
 
-			if w := txt.Keywords(serviceUrl.Host); len(w) > 0 {
-				result.AccName = strings.Title(w[0])
-			} else {
-				result.AccName = serviceUrl.Host
-			}
-
+			result.AccName = serviceName(serviceUrl.Host)
 			result.AccType = h.ServiceType
 			result.AccURL = serviceUrl.String()
 
@@ -74,3 +100,12 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 
 	return result, errors.New("could not connect")
 }
+
+// serviceName returns a display name based on the host name.
+func serviceName(host string) string {
+	if w := txt.Keywords(host); len(w) > 0 {
+		return strings.Title(w[0])
+	}
+
+	return host
+}
@@ -1,6 +1,11 @@
 package remote
 
 import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,4 +74,74 @@ func TestDiscover(t *testing.T) {
 		assert.Equal(t, "", r.AccUser)
 		assert.Equal(t, "", r.AccPass)
 	})
+	t.Run("local", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		r, err := Discover(dir, "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "local", r.AccType)
+		assert.Equal(t, dir, r.AccURL)
+
+		_, err = Discover("file:///this/folder/does/not/exist", "", "")
+
+		assert.Error(t, err)
+	})
+	t.Run("sftp", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer l.Close()
+
+		go func() {
+			conn, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			conn.Write([]byte("SSH-2.0-OpenSSH_8.2\r\n"))
+			conn.Close()
+		}()
+
+		r, err := Discover("ssh://admin:photoprism@"+l.Addr().String()+"/photos", "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sftp", r.AccType)
+		assert.Equal(t, "sftp://"+l.Addr().String()+"/photos", r.AccURL)
+		assert.Equal(t, "admin", r.AccUser)
+		assert.Equal(t, "photoprism", r.AccPass)
+	})
+	t.Run("s3", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Amz-Request-Id", "16342D5F6C2D1F3B")
+			w.WriteHeader(http.StatusForbidden)
+		}))
+
+		defer srv.Close()
+
+		r, err := Discover(srv.URL+"/photos", "key", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "s3", r.AccType)
+		assert.Equal(t, srv.URL+"/photos", r.AccURL)
+	})
 }
@@ -10,20 +10,22 @@ type Heuristic struct {
 	Domains     []string
 	Paths       []string
 	Method      string
+	Header      string
 }
 
 var Heuristics = []Heuristic{
-	{ServiceFacebook, []string{"facebook.com", "www.facebook.com"}, []string{}, "GET"},
-	{ServiceTwitter, []string{"twitter.com"}, []string{}, "GET"},
-	{ServiceFlickr, []string{"flickr.com", "www.flickr.com"}, []string{}, "GET"},
-	{ServiceInstagram, []string{"instagram.com", "www.instagram.com"}, []string{}, "GET"},
-	{ServiceEyeEm, []string{"eyeem.com", "www.eyeem.com"}, []string{}, "GET"},
-	{ServiceTelegram, []string{"web.telegram.org", "www.telegram.org", "telegram.org"}, []string{}, "GET"},
-	{ServiceWhatsApp, []string{"web.whatsapp.com", "www.whatsapp.com", "whatsapp.com"}, []string{}, "GET"},
-	{ServiceOneDrive, []string{"onedrive.live.com"}, []string{}, "GET"},
-	{ServiceGDrive, []string{"drive.google.com"}, []string{}, "GET"},
-	{ServiceGPhotos, []string{"photos.google.com"}, []string{}, "GET"},
-	{ServiceWebDAV, []string{}, []string{"/", "/webdav", "/remote.php/dav/files/{user}", "/remote.php/webdav", "/dav/files/{user}", "/servlet/webdav.infostore/"}, "PROPFIND"},
+	{ServiceFacebook, []string{"facebook.com", "www.facebook.com"}, []string{}, "GET", ""},
+	{ServiceTwitter, []string{"twitter.com"}, []string{}, "GET", ""},
+	{ServiceFlickr, []string{"flickr.com", "www.flickr.com"}, []string{}, "GET", ""},
+	{ServiceInstagram, []string{"instagram.com", "www.instagram.com"}, []string{}, "GET", ""},
+	{ServiceEyeEm, []string{"eyeem.com", "www.eyeem.com"}, []string{}, "GET", ""},
+	{ServiceTelegram, []string{"web.telegram.org", "www.telegram.org", "telegram.org"}, []string{}, "GET", ""},
+	{ServiceWhatsApp, []string{"web.whatsapp.com", "www.whatsapp.com", "whatsapp.com"}, []string{}, "GET", ""},
+	{ServiceOneDrive, []string{"onedrive.live.com"}, []string{}, "GET", ""},
+	{ServiceGDrive, []string{"drive.google.com"}, []string{}, "GET", ""},
+	{ServiceGPhotos, []string{"photos.google.com"}, []string{}, "GET", ""},
+	{ServiceS3, []string{}, []string{}, "HEAD", "X-Amz-Request-Id"},
+	{ServiceWebDAV, []string{}, []string{"/", "/webdav", "/remote.php/dav/files/{user}", "/remote.php/webdav", "/dav/files/{user}", "/servlet/webdav.infostore/"}, "PROPFIND", ""},
 }
 
 func (h Heuristic) MatchDomain(match string) bool {
@@ -47,6 +49,15 @@ func (h Heuristic) Discover(rawUrl, user string) *url.URL {
 		return nil
 	}
 
+	// Services identified by a response header are only probed with the given URL.
+	if h.Header != "" {
+		if HttpHeader(h.Method, u.String(), h.Header) {
+			return u
+		}
+
+		return nil
+	}
+
 	if HttpOk(h.Method, u.String()) {
 		return u
 	}
@@ -0,0 +1,229 @@
+/*
+
+Package local implements syncing and sharing with local or network file systems like NFS.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+// Roots contains the server folders that can be used by local accounts, see the local-paths config option.
+var Roots []string
+
+type Client struct {
+	root string
+}
+
+// New creates a new client for a local path, which may also be a file:// URL.
+func New(rawUrl string) (Client, error) {
+	root := rawUrl
+
+	if u, err := url.Parse(rawUrl); err == nil && u.Scheme == "file" {
+		root = u.Path
+	}
+
+	if root == "" || !filepath.IsAbs(root) {
+		return Client{}, fmt.Errorf("local: %s is not an absolute path", rawUrl)
+	}
+
+	root = filepath.Clean(root)
+
+	if !allowed(root) {
+		return Client{}, fmt.Errorf("local: %s is not in an allowed folder", rawUrl)
+	}
+
+	return Client{root: root}, nil
+}
+
+// allowed tests if the path is inside one of the configured roots, symlinks are resolved if possible.
+func allowed(dir string) bool {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	for _, root := range Roots {
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolved
+		}
+
+		if rel, err := filepath.Rel(filepath.Clean(root), dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// abs returns the absolute local file name, names can't refer to files outside the root.
+func (c Client) abs(name string) string {
+	return filepath.Join(c.root, filepath.FromSlash(path.Clean("/"+name)))
+}
+
+// Files returns all files in path as string slice.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	files, err := ioutil.ReadDir(c.abs(dir))
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		result = append(result, fs.NewFileInfo(file, dir))
+	}
+
+	return result, nil
+}
+
+// Directories returns all sub directories in path as string slice.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("local: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	files, err := ioutil.ReadDir(c.abs(root))
+
+	if err != nil {
+		return result, err
+	}
+
+	if root == "/" {
+		root = ""
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		info := fs.NewFileInfo(file, root)
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Stat returns information about a single file or directory.
+func (c Client) Stat(name string) (result fs.FileInfo, err error) {
+	info, err := os.Stat(c.abs(name))
+
+	if err != nil {
+		return result, err
+	}
+
+	return fs.NewFileInfo(info, path.Dir(name)), nil
+}
+
+// Download copies a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	f, err := os.Open(c.abs(from))
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	if err := fs.WriteReader(to, f, force); err != nil {
+		return fmt.Errorf("local: %s", err)
+	}
+
+	return nil
+}
+
+// CreateDir recursively creates directories if they don't exist.
+func (c Client) CreateDir(dir string) error {
+	return os.MkdirAll(c.abs(dir), os.ModePerm)
+}
+
+// Upload copies a single file to the remote path.
+func (c Client) Upload(from, to string) error {
+	f, err := os.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return fs.WriteReader(c.abs(to), f, true)
+}
+
+// Delete deletes a single file or directory.
+func (c Client) Delete(name string) error {
+	fileName := c.abs(name)
+
+	if fileName == c.root {
+		return fmt.Errorf("local: can't delete root folder")
+	}
+
+	return os.RemoveAll(fileName)
+}
+
+// Close releases resources, there is no persistent connection to close.
+func (c Client) Close() error {
+	return nil
+}
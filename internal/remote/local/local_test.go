@@ -0,0 +1,121 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testClient(t *testing.T) (Client, string) {
+	dir, err := ioutil.TempDir("", "local-remote")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Roots = []string{dir}
+
+	c, err := New("file://" + dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, dir
+}
+
+func TestNew(t *testing.T) {
+	Roots = []string{"/mnt"}
+
+	c, err := New("/mnt/photos/")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/photos", c.root)
+
+	_, err = New("photos")
+
+	assert.Error(t, err)
+
+	for _, dir := range []string{"/", "/etc", "/mnt/../etc", "/mntx"} {
+		_, err = New(dir)
+
+		assert.Error(t, err, dir)
+	}
+
+	Roots = nil
+
+	_, err = New("/mnt/photos/")
+
+	assert.Error(t, err)
+}
+
+func TestClient_Abs(t *testing.T) {
+	c := Client{root: "/mnt/photos"}
+
+	assert.Equal(t, "/mnt/photos/a/b.jpg", c.abs("/a/b.jpg"))
+	assert.Equal(t, "/mnt/photos/etc/passwd", c.abs("../../etc/passwd"))
+	assert.Equal(t, "/mnt/photos", c.abs(""))
+}
+
+func TestClient(t *testing.T) {
+	c, dir := testClient(t)
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "..", filepath.Base(dir)+".src.txt")
+
+	if err := ioutil.WriteFile(src, []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(src)
+
+	t.Run("upload", func(t *testing.T) {
+		assert.NoError(t, c.CreateDir("/Photos/2020"))
+		assert.NoError(t, c.Upload(src, "/Photos/2020/test.txt"))
+		assert.FileExists(t, filepath.Join(dir, "Photos", "2020", "test.txt"))
+	})
+	t.Run("list", func(t *testing.T) {
+		files, err := c.Files("/Photos/2020")
+
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "/Photos/2020/test.txt", files[0].Abs)
+		assert.Equal(t, int64(3), files[0].Size)
+
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/Photos", "/Photos/2020"}, dirs.Abs())
+	})
+	t.Run("stat", func(t *testing.T) {
+		info, err := c.Stat("/Photos/2020/test.txt")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test.txt", info.Name)
+		assert.False(t, info.Dir)
+
+		_, err = c.Stat("/Photos/missing.txt")
+
+		assert.Error(t, err)
+	})
+	t.Run("download", func(t *testing.T) {
+		dest := filepath.Join(dir, "download", "test.txt")
+
+		assert.NoError(t, c.Download("/Photos/2020/test.txt", dest, false))
+		assert.Error(t, c.Download("/Photos/2020/test.txt", dest, false))
+		assert.FileExists(t, dest)
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, c.Delete("/Photos"))
+		assert.Error(t, c.Delete("/"))
+
+		_, err := c.Stat("/Photos")
+
+		assert.Error(t, err)
+	})
+}
@@ -35,7 +35,10 @@ https://docs.photoprism.org/developer-guide/
 package remote
 
 import (
+	"bufio"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -43,6 +46,9 @@ var client = &http.Client{Timeout: 30 * time.Second} // TODO: Change timeout if
 
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceS3        = "s3"
+	ServiceSFTP      = "sftp"
+	ServiceLocal     = "local"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
@@ -55,6 +61,52 @@ const (
 	ServiceOneDrive  = "onedrive"
 )
 
+// SshOk tests if the host runs a SSH server, the default port is 22.
+func SshOk(host string) bool {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+
+	conn, err := net.DialTimeout("tcp", host, client.Timeout)
+
+	if err != nil {
+		return false
+	}
+
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(client.Timeout)); err != nil {
+		return false
+	}
+
+	banner, err := bufio.NewReader(conn).ReadString('\n')
+
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(banner, "SSH-")
+}
+
+// HttpHeader tests if the response contains a header, independent of the status code.
+func HttpHeader(method, rawUrl, header string) bool {
+	req, err := http.NewRequest(method, rawUrl, nil)
+
+	if err != nil {
+		return false
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return false
+	}
+
+	resp.Body.Close()
+
+	return resp.Header.Get(header) != ""
+}
+
 func HttpOk(method, rawUrl string) bool {
 	req, err := http.NewRequest(method, rawUrl, nil)
 
@@ -0,0 +1,371 @@
+/*
+
+Package s3 implements syncing and sharing with S3-compatible object storage like MinIO.
+
+Account URLs contain the endpoint and bucket name, followed by an optional key prefix,
+e.g. "https://minio.example.com:9000/photos/shared?region=eu-west-1". The access key is
+stored as user name and the secret key as password.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+// DefaultRegion is used if the account URL doesn't specify a region.
+const DefaultRegion = "us-east-1"
+
+type Client struct {
+	bucket string
+	prefix string
+	region string
+	client *minio.Client
+}
+
+// New creates a new S3 client, see package documentation for the URL format.
+func New(rawUrl, accessKey, secretKey string) (Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return Client{}, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return Client{}, fmt.Errorf("s3: invalid endpoint %s", rawUrl)
+	}
+
+	segments := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+
+	if segments[0] == "" {
+		return Client{}, fmt.Errorf("s3: bucket name missing in %s", rawUrl)
+	}
+
+	c := Client{
+		bucket: segments[0],
+		region: u.Query().Get("region"),
+	}
+
+	if len(segments) > 1 {
+		c.prefix = strings.Trim(segments[1], "/")
+	}
+
+	if c.region == "" {
+		c.region = DefaultRegion
+	}
+
+	c.client, err = minio.New(u.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       u.Scheme == "https",
+		Region:       c.region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+
+	if err != nil {
+		return Client{}, fmt.Errorf("s3: %s", err)
+	}
+
+	return c, nil
+}
+
+// key returns the object key for a remote file name.
+func (c Client) key(name string) string {
+	return strings.TrimPrefix(path.Join(c.prefix, path.Clean("/"+name)), "/")
+}
+
+// dirKey returns the key prefix of objects in a remote directory.
+func (c Client) dirKey(dir string) string {
+	if k := c.key(dir); k != "" {
+		return k + "/"
+	}
+
+	return ""
+}
+
+// name returns the remote file name for an object key.
+func (c Client) name(key string) string {
+	if c.prefix != "" {
+		key = strings.TrimPrefix(key, c.prefix+"/")
+	}
+
+	return "/" + strings.Trim(key, "/")
+}
+
+// error returns a readable error, missing objects are reported as os.ErrNotExist.
+func (c Client) error(op, key string, err error) error {
+	resp := minio.ToErrorResponse(err)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey":
+		return &os.PathError{Op: op, Path: c.name(key), Err: os.ErrNotExist}
+	case resp.Code != "" && resp.Message != "":
+		return fmt.Errorf("s3: %s (%s)", resp.Message, resp.Code)
+	}
+
+	return fmt.Errorf("s3: %s", err)
+}
+
+// list returns all objects and, if not recursive, common prefixes with the key prefix.
+// Common prefixes are returned as objects with a trailing slash.
+func (c Client) list(prefix string, recursive bool) (result []minio.ObjectInfo, err error) {
+	opt := minio.ListObjectsOptions{Prefix: prefix, Recursive: recursive}
+
+	for obj := range c.client.ListObjects(context.Background(), c.bucket, opt) {
+		if obj.Err != nil {
+			return result, c.error("list", prefix, obj.Err)
+		}
+
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+// exists returns true if there is at least one object with the key prefix.
+func (c Client) exists(prefix string) (bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opt := minio.ListObjectsOptions{Prefix: prefix, Recursive: true, MaxKeys: 1}
+
+	for obj := range c.client.ListObjects(ctx, c.bucket, opt) {
+		if obj.Err != nil {
+			return false, c.error("list", prefix, obj.Err)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Files returns all files in path as string slice.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	objects, err := c.list(c.dirKey(dir), false)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, obj := range objects {
+		// Skip common prefixes and directory markers.
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+
+		result = append(result, fs.FileInfo{
+			Name: path.Base(obj.Key),
+			Abs:  c.name(obj.Key),
+			Size: obj.Size,
+			Date: obj.LastModified,
+			ETag: strings.Trim(obj.ETag, `"`),
+		})
+	}
+
+	return result, nil
+}
+
+// Directories returns all sub directories in path as string slice.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("s3: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	prefix := c.dirKey(root)
+	objects, err := c.list(prefix, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, obj := range objects {
+		if !strings.HasSuffix(obj.Key, "/") || obj.Key == prefix {
+			continue
+		}
+
+		info := fs.FileInfo{
+			Name: path.Base(obj.Key),
+			Abs:  c.name(obj.Key),
+			Dir:  true,
+		}
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Stat returns information about a single file or directory.
+func (c Client) Stat(name string) (result fs.FileInfo, err error) {
+	key := c.key(name)
+
+	obj, err := c.client.StatObject(context.Background(), c.bucket, key, minio.StatObjectOptions{})
+
+	if err == nil {
+		result.Name = path.Base(key)
+		result.Abs = c.name(key)
+		result.Size = obj.Size
+		result.Date = obj.LastModified
+		result.ETag = strings.Trim(obj.ETag, `"`)
+
+		return result, nil
+	} else if err = c.error("stat", key, err); !os.IsNotExist(err) {
+		return result, err
+	}
+
+	// Directories exist implicitly if there are objects with their prefix.
+	if found, listErr := c.exists(c.dirKey(name)); listErr != nil {
+		return result, listErr
+	} else if !found {
+		return result, err
+	}
+
+	return fs.FileInfo{Name: path.Base(key), Abs: c.name(key), Dir: true}, nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("s3: download skipped, %s already exists", to)
+	}
+
+	key := c.key(from)
+
+	obj, err := c.client.GetObject(context.Background(), c.bucket, key, minio.GetObjectOptions{})
+
+	if err != nil {
+		return c.error("get", key, err)
+	}
+
+	defer obj.Close()
+
+	// Objects are fetched lazily, so errors like a missing object are only returned on first access.
+	if _, err := obj.Stat(); err != nil {
+		return c.error("get", key, err)
+	}
+
+	if err := fs.WriteReader(to, obj, force); err != nil {
+		return fmt.Errorf("s3: %s", err)
+	}
+
+	return nil
+}
+
+// CreateDir creates an empty directory marker, since object storage has no real directories.
+func (c Client) CreateDir(dir string) error {
+	key := c.dirKey(dir)
+
+	if key == "" {
+		return nil
+	}
+
+	if _, err := c.client.PutObject(context.Background(), c.bucket, key, strings.NewReader(""), 0, minio.PutObjectOptions{}); err != nil {
+		return c.error("put", key, err)
+	}
+
+	return nil
+}
+
+// Upload uploads a single file to the remote server.
+func (c Client) Upload(from, to string) error {
+	key := c.key(to)
+
+	if _, err := c.client.FPutObject(context.Background(), c.bucket, key, from, minio.PutObjectOptions{}); err != nil {
+		return c.error("put", key, err)
+	}
+
+	return nil
+}
+
+// Delete deletes a single file or all objects in a directory.
+func (c Client) Delete(name string) error {
+	key := c.key(name)
+
+	if key == c.prefix {
+		return fmt.Errorf("s3: can't delete root folder")
+	}
+
+	objects, err := c.list(key+"/", true)
+
+	if err != nil {
+		return err
+	}
+
+	keys := []string{key}
+
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+
+	for _, k := range keys {
+		if err := c.client.RemoveObject(context.Background(), c.bucket, k, minio.RemoveObjectOptions{}); err != nil {
+			return c.error("delete", k, err)
+		}
+	}
+
+	return nil
+}
+
+// Close releases resources, there is no persistent connection to close.
+func (c Client) Close() error {
+	return nil
+}
@@ -0,0 +1,279 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// listObject represents an object in a ListObjectsV2 response.
+type listObject struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	Size         int64     `xml:"Size"`
+	ETag         string    `xml:"ETag"`
+}
+
+// listResult represents a ListObjectsV2 response.
+type listResult struct {
+	XMLName        xml.Name     `xml:"ListBucketResult"`
+	Contents       []listObject `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated bool `xml:"IsTruncated"`
+}
+
+// testServer returns a minimal S3-compatible server that keeps objects in memory.
+func testServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>")
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+			var result listResult
+
+			prefix := r.URL.Query().Get("prefix")
+			seen := make(map[string]bool)
+			keys := make([]string, 0, len(objects))
+
+			for k := range objects {
+				keys = append(keys, k)
+			}
+
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				if !strings.HasPrefix(k, prefix) {
+					continue
+				}
+
+				rest := strings.TrimPrefix(k, prefix)
+
+				// Keys containing the delimiter are grouped by common prefix.
+				if i := strings.Index(rest, "/"); r.URL.Query().Get("delimiter") == "/" && i >= 0 {
+					p := prefix + rest[:i+1]
+
+					if !seen[p] {
+						seen[p] = true
+						result.CommonPrefixes = append(result.CommonPrefixes, struct {
+							Prefix string `xml:"Prefix"`
+						}{p})
+					}
+
+					continue
+				}
+
+				result.Contents = append(result.Contents, listObject{
+					Key:          k,
+					LastModified: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+					Size:         int64(len(objects[k])),
+					ETag:         fmt.Sprintf(`"%x"`, md5.Sum(objects[k])),
+				})
+			}
+
+			data, _ := xml.Marshal(result)
+			w.Write(data)
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			data, ok := objects[key]
+
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+			w.Header().Set("Last-Modified", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+			w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(data)))
+
+			if r.Method == http.MethodGet {
+				w.Write(data)
+			}
+		case r.Method == http.MethodPut:
+			data, _ := ioutil.ReadAll(r.Body)
+
+			if r.Header.Get("X-Amz-Content-Sha256") == "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+				data = decodeChunks(data)
+			}
+
+			objects[key] = data
+			w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(data)))
+		case r.Method == http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+// decodeChunks returns the payload of a streaming upload with signed chunks.
+func decodeChunks(data []byte) (result []byte) {
+	for len(data) > 0 {
+		i := bytes.Index(data, []byte("\r\n"))
+
+		if i < 0 {
+			break
+		}
+
+		size, err := strconv.ParseInt(strings.SplitN(string(data[:i]), ";", 2)[0], 16, 64)
+
+		if err != nil || size == 0 || i+2+int(size) > len(data) {
+			break
+		}
+
+		result = append(result, data[i+2:i+2+int(size)]...)
+		data = bytes.TrimPrefix(data[i+2+int(size):], []byte("\r\n"))
+	}
+
+	return result
+}
+
+func TestNew(t *testing.T) {
+	t.Run("prefix", func(t *testing.T) {
+		c, err := New("https://minio.local:9000/bucket/shared/photos/?region=eu-west-1", "minio", "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "bucket", c.bucket)
+		assert.Equal(t, "shared/photos", c.prefix)
+		assert.Equal(t, "eu-west-1", c.region)
+		assert.Equal(t, "shared/photos/2020/a.jpg", c.key("/2020/a.jpg"))
+		assert.Equal(t, "shared/photos/a.jpg", c.key("../a.jpg"))
+		assert.Equal(t, "/2020/a.jpg", c.name("shared/photos/2020/a.jpg"))
+	})
+	t.Run("bucket", func(t *testing.T) {
+		c, err := New("http://minio.local:9000/bucket", "minio", "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultRegion, c.region)
+		assert.Equal(t, "", c.dirKey("/"))
+		assert.Equal(t, "2020/", c.dirKey("/2020"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := New("http://minio.local:9000/", "minio", "secret")
+
+		assert.Error(t, err)
+
+		_, err = New("ftp://minio.local/bucket", "minio", "secret")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClient(t *testing.T) {
+	srv := testServer(t)
+
+	defer srv.Close()
+
+	c, err := New(srv.URL+"/bucket/shared", "minio", "secret")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "s3-remote")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	src := filepath.Join(tmpDir, "src.txt")
+
+	if err := ioutil.WriteFile(src, []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		assert.NoError(t, c.CreateDir("/Photos/2020"))
+		assert.NoError(t, c.Upload(src, "/Photos/2020/a b.txt"))
+		assert.NoError(t, c.Upload(src, "/Photos/c.txt"))
+	})
+	t.Run("files", func(t *testing.T) {
+		files, err := c.Files("/Photos")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/Photos/c.txt"}, files.Abs())
+		assert.Equal(t, int64(3), files[0].Size)
+		assert.Len(t, files[0].ETag, 32)
+
+		files, err = c.Files("/Photos/2020")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/Photos/2020/a b.txt"}, files.Abs())
+	})
+	t.Run("directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/Photos", "/Photos/2020"}, dirs.Abs())
+	})
+	t.Run("stat", func(t *testing.T) {
+		info, err := c.Stat("/Photos/c.txt")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), info.Size)
+		assert.False(t, info.Dir)
+		assert.Len(t, info.ETag, 32)
+
+		info, err = c.Stat("/Photos")
+
+		assert.NoError(t, err)
+		assert.True(t, info.Dir)
+
+		_, err = c.Stat("/missing")
+
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("download", func(t *testing.T) {
+		dest := filepath.Join(tmpDir, "download", "a.txt")
+
+		assert.NoError(t, c.Download("/Photos/2020/a b.txt", dest, false))
+		assert.Error(t, c.Download("/Photos/2020/a b.txt", dest, false))
+
+		data, _ := ioutil.ReadFile(dest)
+
+		assert.Equal(t, "foo", string(data))
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, c.Delete("/Photos"))
+		assert.Error(t, c.Delete("/"))
+
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Empty(t, dirs)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		c, _ := New(srv.URL+"/bucket", "foo", "bar")
+
+		_, err := c.Files("/")
+
+		assert.EqualError(t, err, "s3: Access Denied (AccessDenied)")
+	})
+}
@@ -0,0 +1,355 @@
+/*
+
+Package sftp implements syncing and sharing with servers supporting the SSH File Transfer Protocol.
+
+Account URLs contain the host name, an optional port and the root path,
+e.g. "sftp://photos.example.com:2222/home/photos". A relative or empty path refers
+to the home directory of the user. The SHA256 fingerprint of the server's host key
+must match if one is given, so that it can be pinned on first use, see Client.HostKey.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+var log = event.Log
+
+// DefaultPort is used if the account URL doesn't specify a port.
+const DefaultPort = "22"
+
+type Client struct {
+	root    string
+	hostKey string
+	sftp    *sftp.Client
+	closer  io.Closer
+}
+
+// New connects to a SFTP server, see package documentation for the URL format.
+func New(rawUrl, user, pass, hostKey string) (Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return Client{}, err
+	}
+
+	if u.Scheme != "sftp" && u.Scheme != "ssh" || u.Hostname() == "" {
+		return Client{}, fmt.Errorf("sftp: invalid server url %s", rawUrl)
+	}
+
+	if user == "" && u.User != nil {
+		user = u.User.Username()
+	}
+
+	if pass == "" && u.User != nil {
+		pass, _ = u.User.Password()
+	}
+
+	port := u.Port()
+
+	if port == "" {
+		port = DefaultPort
+	}
+
+	var found string
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.Password(pass)},
+		HostKeyCallback: checkHostKey(hostKey, &found),
+		Timeout:         30 * time.Second,
+	}
+
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(u.Hostname(), port), config)
+
+	if err != nil {
+		return Client{}, fmt.Errorf("sftp: %s", err)
+	}
+
+	sftpClient, err := sftp.NewClient(sshClient)
+
+	if err != nil {
+		sshClient.Close()
+		return Client{}, fmt.Errorf("sftp: %s", err)
+	}
+
+	root := u.Path
+
+	// Paths starting with "/~" are relative to the home directory.
+	if strings.HasPrefix(root, "/~") {
+		root = strings.TrimLeft(strings.TrimPrefix(root, "/~"), "/")
+	}
+
+	c := newClient(root, sftpClient)
+
+	c.closer = sshClient
+	c.hostKey = found
+
+	return c, nil
+}
+
+// newClient creates a new client on an established SFTP session.
+func newClient(root string, sftpClient *sftp.Client) Client {
+	return Client{root: path.Clean(root), sftp: sftpClient}
+}
+
+// checkHostKey returns a callback that stores the host key fingerprint and verifies it if one is given.
+func checkHostKey(fingerprint string, found *string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		*found = ssh.FingerprintSHA256(key)
+
+		if fingerprint == "" {
+			log.Infof("sftp: pinning host key fingerprint %s of %s", *found, hostname)
+			return nil
+		}
+
+		if *found != fingerprint {
+			return fmt.Errorf("host key fingerprint %s doesn't match", *found)
+		}
+
+		return nil
+	}
+}
+
+// HostKey returns the SHA256 fingerprint of the server's host key.
+func (c Client) HostKey() string {
+	return c.hostKey
+}
+
+// abs returns the remote file name, names can't refer to files outside the root.
+func (c Client) abs(name string) string {
+	return path.Join(c.root, path.Clean("/"+name))
+}
+
+// Close closes the connection.
+func (c Client) Close() error {
+	if c.sftp != nil {
+		c.sftp.Close()
+	}
+
+	if c.closer == nil {
+		return nil
+	}
+
+	return c.closer.Close()
+}
+
+// Files returns all files in path as string slice.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	files, err := c.sftp.ReadDir(c.abs(dir))
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		result = append(result, fs.NewFileInfo(file, dir))
+	}
+
+	return result, nil
+}
+
+// Directories returns all sub directories in path as string slice.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("sftp: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	files, err := c.sftp.ReadDir(c.abs(root))
+
+	if err != nil {
+		return result, err
+	}
+
+	if root == "/" {
+		root = ""
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		info := fs.NewFileInfo(file, root)
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Stat returns information about a single file or directory.
+func (c Client) Stat(name string) (result fs.FileInfo, err error) {
+	info, err := c.sftp.Stat(c.abs(name))
+
+	if err != nil {
+		return result, err
+	}
+
+	return fs.NewFileInfo(info, path.Dir(name)), nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", to)
+	}
+
+	f, err := c.sftp.Open(c.abs(from))
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	if err := fs.WriteReader(to, f, force); err != nil {
+		return fmt.Errorf("sftp: %s", err)
+	}
+
+	return nil
+}
+
+// CreateDir recursively creates directories if they don't exist.
+func (c Client) CreateDir(dir string) error {
+	dir = path.Clean("/" + dir)
+
+	if dir == "/" {
+		return nil
+	}
+
+	if info, err := c.Stat(dir); err == nil {
+		if !info.Dir {
+			return fmt.Errorf("sftp: %s is not a directory", dir)
+		}
+
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := c.CreateDir(path.Dir(dir)); err != nil {
+		return err
+	}
+
+	return c.sftp.Mkdir(c.abs(dir))
+}
+
+// Upload uploads a single file to the remote server.
+func (c Client) Upload(from, to string) error {
+	f, err := os.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	remote, err := c.sftp.Create(c.abs(to))
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := remote.ReadFrom(f); err != nil {
+		remote.Close()
+		return err
+	}
+
+	return remote.Close()
+}
+
+// Delete deletes a single file or directory including its contents.
+func (c Client) Delete(name string) error {
+	name = path.Clean("/" + name)
+
+	if name == "/" {
+		return fmt.Errorf("sftp: can't delete root folder")
+	}
+
+	info, err := c.Stat(name)
+
+	if err != nil {
+		return err
+	}
+
+	if !info.Dir {
+		return c.sftp.Remove(c.abs(name))
+	}
+
+	files, err := c.sftp.ReadDir(c.abs(name))
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := c.Delete(path.Join(name, file.Name())); err != nil {
+			return err
+		}
+	}
+
+	return c.sftp.RemoveDirectory(c.abs(name))
+}
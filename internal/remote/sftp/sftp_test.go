@@ -0,0 +1,193 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testClient returns a client connected to an in-process SFTP server with the given root directory.
+func testClient(t *testing.T, root string) Client {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	server, err := sftp.NewServer(pipe{serverReader, serverWriter})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Like a real SSH channel, the server closes the connection once the client disconnects.
+	go func() {
+		server.Serve()
+		server.Close()
+	}()
+
+	sftpClient, err := sftp.NewClientPipe(clientReader, clientWriter)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newClient(root, sftpClient)
+}
+
+// pipe combines the server ends of two pipes into a single connection.
+type pipe struct {
+	*io.PipeReader
+	*io.PipeWriter
+}
+
+func (p pipe) Close() error {
+	p.PipeReader.Close()
+	return p.PipeWriter.Close()
+}
+
+func TestNew(t *testing.T) {
+	t.Run("invalid scheme", func(t *testing.T) {
+		_, err := New("http://example.com/photos", "admin", "photoprism", "")
+
+		assert.Error(t, err)
+	})
+	t.Run("missing host", func(t *testing.T) {
+		_, err := New("sftp:///photos", "admin", "photoprism", "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckHostKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	t.Run("pin", func(t *testing.T) {
+		var found string
+
+		assert.NoError(t, checkHostKey("", &found)("example.com:22", nil, key))
+		assert.Equal(t, fingerprint, found)
+	})
+	t.Run("match", func(t *testing.T) {
+		var found string
+
+		assert.NoError(t, checkHostKey(fingerprint, &found)("example.com:22", nil, key))
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		var found string
+
+		assert.Error(t, checkHostKey("SHA256:xxx", &found)("example.com:22", nil, key))
+	})
+}
+
+func TestClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sftp")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "Photos", "2020"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "Photos", "cat.jpg"), []byte("meow"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testClient(t, dir)
+
+	defer c.Close()
+
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("Photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+		assert.Equal(t, "cat.jpg", files[0].Name)
+		assert.Equal(t, "/Photos/cat.jpg", files[0].Abs)
+		assert.Equal(t, int64(4), files[0].Size)
+	})
+	t.Run("Directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Photos/2020"}, dirs.Abs())
+	})
+	t.Run("Stat", func(t *testing.T) {
+		info, err := c.Stat("/Photos/cat.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/Photos/cat.jpg", info.Abs)
+		assert.False(t, info.Dir)
+
+		_, err = c.Stat("/Photos/dog.jpg")
+
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Download", func(t *testing.T) {
+		fileName := filepath.Join(dir, "download", "cat.jpg")
+
+		if err := c.Download("/Photos/cat.jpg", fileName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(fileName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "meow", string(data))
+
+		assert.Error(t, c.Download("/Photos/cat.jpg", fileName, false))
+	})
+	t.Run("CreateDir", func(t *testing.T) {
+		assert.NoError(t, c.CreateDir("/Shared/2021/January"))
+		assert.NoError(t, c.CreateDir("/Shared/2021"))
+		assert.DirExists(t, filepath.Join(dir, "Shared", "2021", "January"))
+	})
+	t.Run("Upload", func(t *testing.T) {
+		if err := c.Upload(filepath.Join(dir, "Photos", "cat.jpg"), "/Shared/2021/cat.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "Shared", "2021", "cat.jpg"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "meow", string(data))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.Error(t, c.Delete("/"))
+		assert.NoError(t, c.Delete("/Shared"))
+
+		_, err := os.Stat(filepath.Join(dir, "Shared"))
+
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -33,7 +33,6 @@ package webdav
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
 	"time"
@@ -148,25 +147,30 @@ func (c Client) Download(from, to string, force bool) error {
 		return fmt.Errorf("webdav: download skipped, %s already exists", to)
 	}
 
-	dir := path.Dir(to)
-	dirInfo, err := os.Stat(dir)
+	r, err := c.client.ReadStream(from)
 
 	if err != nil {
-		// Create directory
-		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-			return fmt.Errorf("webdav: can't create %s (%s)", dir, err)
-		}
-	} else if !dirInfo.IsDir() {
-		return fmt.Errorf("webdav: %s is not a folder", dir)
+		return err
+	}
+
+	defer r.Close()
+
+	if err := fs.WriteReader(to, r, force); err != nil {
+		return fmt.Errorf("webdav: %s", err)
 	}
 
-	bytes, err := c.client.Read(from)
+	return nil
+}
+
+// Stat returns information about a single file or directory.
+func (c Client) Stat(name string) (result fs.FileInfo, err error) {
+	info, err := c.client.Stat(name)
 
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	return ioutil.WriteFile(to, bytes, 0644)
+	return fs.NewFileInfo(info, path.Dir(name)), nil
 }
 
 // DownloadDir downloads all files from a remote to a local directory.
@@ -235,3 +239,8 @@ func (c Client) Upload(from, to string) error {
 func (c Client) Delete(path string) error {
 	return c.client.Remove(path)
 }
+
+// Close releases resources, there is no persistent connection to close.
+func (c Client) Close() error {
+	return nil
+}
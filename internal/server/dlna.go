@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/dlna"
+)
+
+// startDLNA starts the read-only DLNA media server on a separate port that only accepts requests
+// from the local network, so that neither the preview token nor the library is exposed publicly.
+func startDLNA(ctx context.Context, conf *config.Config) {
+	router := gin.New()
+	router.Use(Logger(), Recovery(), dlna.LocalNetwork())
+
+	dlna.NewServer(conf).Register(router.Group(dlna.BasePath))
+
+	// Media content referenced by the content directory.
+	v1 := router.Group("/api/v1")
+	{
+		api.GetThumb(v1)
+		api.GetVideo(v1)
+		api.AlbumThumb(v1)
+		api.LabelThumb(v1)
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", conf.HttpServerHost(), conf.DLNAPort()),
+		Handler: router,
+	}
+
+	go func() {
+		log.Infof("starting dlna server at %s", server.Addr)
+
+		if err := server.ListenAndServe(); err != nil {
+			if err == http.ErrServerClosed {
+				log.Info("dlna server shutdown complete")
+			} else {
+				log.Errorf("dlna server closed unexpect: %s", err)
+			}
+		}
+	}()
+
+	go dlna.Advertise(ctx, conf)
+
+	<-ctx.Done()
+
+	if err := server.Close(); err != nil {
+		log.Errorf("dlna server shutdown failed: %v", err)
+	}
+}
@@ -30,15 +30,22 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteSession(v1)
 
 		api.GetThumb(v1)
+		api.GetThumbsStatus(v1)
 		api.GetDownload(v1)
 		api.GetVideo(v1)
+		api.GetVideoStream(v1)
 		api.CreateZip(v1)
 		api.DownloadZip(v1)
 
 		api.GetGeo(v1)
+		api.GetGeoClusters(v1)
+		api.GetGeoTile(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
 		api.UpdatePhoto(v1)
+		api.GetPhotoEdit(v1)
+		api.UpdatePhotoEdit(v1)
+		api.GetSimilarPhotos(v1)
 		api.GetPhotos(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -67,10 +74,20 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DislikeLabel(v1)
 		api.LabelThumb(v1)
 
+		api.GetPeople(v1)
+		api.GetPerson(v1)
+		api.UpdatePerson(v1)
+
 		api.GetFoldersOriginals(v1)
 		api.GetFoldersImport(v1)
 
 		api.Upload(v1)
+		api.ShareUpload(v1)
+		api.UploadOptions(v1)
+		api.CreateUpload(v1)
+		api.GetUploadOffset(v1)
+		api.ResumeUpload(v1)
+		api.DeleteUpload(v1)
 		api.StartImport(v1)
 		api.CancelImport(v1)
 		api.StartIndexing(v1)
@@ -93,23 +110,44 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.CreateAlbumLink(v1)
 		api.UpdateAlbumLink(v1)
 		api.DeleteAlbumLink(v1)
+		api.GetAlbumUsers(v1)
+		api.ShareAlbumWithUser(v1)
+		api.UnshareAlbumWithUser(v1)
 		api.LikeAlbum(v1)
 		api.DislikeAlbum(v1)
 		api.AlbumThumb(v1)
 		api.CloneAlbums(v1)
+		api.FreezeAlbum(v1)
 		api.AddPhotosToAlbum(v1)
 		api.RemovePhotosFromAlbum(v1)
 
+		api.CreateShareLink(v1)
+		api.UpdateShareLink(v1)
+		api.DeleteShareLink(v1)
+
 		api.GetAccounts(v1)
 		api.GetAccount(v1)
 		api.GetAccountFolders(v1)
+		api.GetAccountSyncPlan(v1)
 		api.ShareWithAccount(v1)
 		api.CreateAccount(v1)
 		api.DeleteAccount(v1)
 		api.UpdateAccount(v1)
 
+		api.GetWebhooks(v1)
+		api.GetWebhook(v1)
+		api.GetWebhookDeliveries(v1)
+		api.CreateWebhook(v1)
+		api.UpdateWebhook(v1)
+		api.DeleteWebhook(v1)
+
 		api.GetSettings(v1)
 		api.SaveSettings(v1)
+		api.GetUsers(v1)
+		api.GetUser(v1)
+		api.CreateUser(v1)
+		api.UpdateUser(v1)
+		api.DeleteUser(v1)
 		api.ChangePassword(v1)
 		api.GetErrors(v1)
 		api.SendFeedback(v1)
@@ -51,6 +51,10 @@ func Start(ctx context.Context, conf *config.Config) {
 		}
 	}()
 
+	if conf.DLNA() {
+		go startDLNA(ctx, conf)
+	}
+
 	<-ctx.Done()
 	log.Info("shutting down web server")
 	err := server.Close()
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceExportMeta sync.Once
+
+func initExportMeta() {
+	services.Export = photoprism.NewExportMeta(Config())
+}
+
+func ExportMeta() *photoprism.ExportMeta {
+	onceExportMeta.Do(initExportMeta)
+
+	return services.Export
+}
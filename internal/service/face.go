@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+var onceFaceDetector sync.Once
+
+func initFaceDetector() {
+	services.Faces = face.New(conf.FaceModelPath(), conf.TensorFlowOff() || !conf.DetectFaces())
+}
+
+func FaceDetector() *face.Detector {
+	onceFaceDetector.Do(initFaceDetector)
+
+	return services.Faces
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -15,19 +16,25 @@ var log = event.Log
 var conf *config.Config
 
 var services struct {
-	Cache    *bigcache.BigCache
-	Classify *classify.TensorFlow
-	Convert  *photoprism.Convert
-	Files    *photoprism.Files
-	Photos   *photoprism.Photos
-	Import   *photoprism.Import
-	Index    *photoprism.Index
-	Moments  *photoprism.Moments
-	Purge    *photoprism.Purge
-	Nsfw     *nsfw.Detector
-	Query    *query.Query
-	Resample *photoprism.Resample
-	Session  *session.Session
+	Cache      *bigcache.BigCache
+	Classify   *classify.TensorFlow
+	Convert    *photoprism.Convert
+	Duplicates *photoprism.Duplicates
+	Export     *photoprism.ExportMeta
+	Files      *photoprism.Files
+	Photos     *photoprism.Photos
+	Import     *photoprism.Import
+	Index      *photoprism.Index
+	Moments    *photoprism.Moments
+	Purge      *photoprism.Purge
+	Nsfw       *nsfw.Detector
+	Faces      *face.Detector
+	Query      *query.Query
+	Resample   *photoprism.Resample
+	Session    *session.Session
+	Thumbs     *photoprism.ThumbCache
+	Watch      *photoprism.Watch
+	VideoQueue *photoprism.VideoQueue
 }
 
 func SetConfig(c *config.Config) {
@@ -69,3 +69,11 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestThumbs(t *testing.T) {
+	assert.IsType(t, &photoprism.ThumbCache{}, Thumbs())
+}
+
+func TestVideoQueue(t *testing.T) {
+	assert.IsType(t, &photoprism.VideoQueue{}, VideoQueue())
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceThumbs sync.Once
+
+func initThumbs() {
+	services.Thumbs = photoprism.NewThumbCache(Config())
+}
+
+func Thumbs() *photoprism.ThumbCache {
+	onceThumbs.Do(initThumbs)
+
+	return services.Thumbs
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceVideoQueue sync.Once
+
+func initVideoQueue() {
+	services.VideoQueue = photoprism.NewVideoQueue(Convert())
+}
+
+func VideoQueue() *photoprism.VideoQueue {
+	onceVideoQueue.Do(initVideoQueue)
+
+	return services.VideoQueue
+}
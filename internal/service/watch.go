@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceWatch sync.Once
+
+func initWatch() {
+	services.Watch = photoprism.NewWatch(Config(), Index())
+}
+
+func Watch() *photoprism.Watch {
+	onceWatch.Do(initWatch)
+
+	return services.Watch
+}
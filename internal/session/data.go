@@ -9,9 +9,21 @@ import (
 type Saved struct {
 	User       string   `json:"user"`
 	Tokens     []string `json:"tokens"`
+	Shares     UIDs     `json:"shares"`
 	Expiration int64    `json:"expiration"`
 }
 
+// HasShare returns true if the share UID was saved with the session.
+func (s Saved) HasShare(uid string) bool {
+	for _, share := range s.Shares {
+		if share == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
 type UIDs []string
 
 func (list UIDs) String() string {
@@ -25,7 +37,7 @@ type Data struct {
 }
 
 func (s Data) Saved() Saved {
-	return Saved{User: s.User.UserUID, Tokens: s.Tokens}
+	return Saved{User: s.User.UserUID, Tokens: s.Tokens, Shares: s.Shares}
 }
 
 func (s Data) Invalid() bool {
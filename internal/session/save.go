@@ -48,7 +48,12 @@ func New(expiration time.Duration, cachePath string) *Session {
 
 					if len(links) > 0 {
 						for _, link := range links {
-							shared = append(shared, link.LinkUID)
+							// Password protected shares must have been unlocked before.
+							if link.HasPassword && !saved.HasShare(link.ShareUID) {
+								continue
+							}
+
+							shared = append(shared, link.ShareUID)
 						}
 
 						tokens = append(tokens, token)
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -24,6 +25,10 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 		switch option {
 		case ResamplePng:
 			format = fs.TypePng
+		case ResampleWebp:
+			format = FormatWebp
+		case ResampleAvif:
+			format = FormatAvif
 		case ResampleNearestNeighbor:
 			filter = imaging.NearestNeighbor
 		case ResampleDefault:
@@ -38,6 +43,8 @@ func ResampleOptions(opts ...ResampleOption) (method ResampleOption, filter imag
 			method = ResampleFit
 		case ResampleResize:
 			method = ResampleResize
+		case ResampleSprite:
+			method = ResampleSprite
 		}
 	}
 
@@ -101,6 +108,9 @@ func Filename(hash string, thumbPath string, width, height int, opts ...Resample
 	return filename, nil
 }
 
+// UsedInterval is the minimum time between updates of the modification time of used on-demand thumbnails.
+var UsedInterval = time.Hour
+
 func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
 	if len(hash) < 4 {
 		return "", fmt.Errorf("resample: file hash is empty or too short (%s)", txt.Quote(hash))
@@ -117,7 +127,13 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 		return "", err
 	}
 
-	if fs.FileExists(fileName) {
+	if info, err := os.Stat(fileName); err == nil && !info.IsDir() {
+		// Remember when on-demand thumbnails were last used, so that the cache can evict unused files first.
+		if (width > Size || height > Size) && time.Since(info.ModTime()) > UsedInterval {
+			now := time.Now()
+			_ = os.Chtimes(fileName, now, now)
+		}
+
 		return fileName, nil
 	}
 
@@ -125,6 +141,11 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 }
 
 func FromFile(imageFilename, hash, thumbPath string, width, height int, opts ...ResampleOption) (fileName string, err error) {
+	return FromEditedFile(imageFilename, hash, thumbPath, Edit{}, width, height, opts...)
+}
+
+// FromEditedFile returns the thumbnail file name, and creates it from the edited image file if needed.
+func FromEditedFile(imageFilename, hash, thumbPath string, edit Edit, width, height int, opts ...ResampleOption) (fileName string, err error) {
 	if fileName, err := FromCache(imageFilename, hash, thumbPath, width, height, opts...); err == nil {
 		return fileName, err
 	} else if err != ErrThumbNotCached {
@@ -145,6 +166,8 @@ func FromFile(imageFilename, hash, thumbPath string, width, height int, opts ...
 		return "", err
 	}
 
+	img = edit.Apply(img)
+
 	if _, err := Create(img, fileName, width, height, opts...); err != nil {
 		return "", err
 	}
@@ -163,6 +186,29 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 
 	result = Resample(img, width, height, opts...)
 
+	if result == nil {
+		return img, fmt.Errorf("resample: can't create %s from a single image", txt.Quote(filepath.Base(fileName)))
+	}
+
+	switch filepath.Ext(fileName) {
+	case "." + string(FormatWebp):
+		err = Encode(result, fileName, FormatWebp)
+	case "." + string(FormatAvif):
+		err = Encode(result, fileName, FormatAvif)
+	default:
+		err = save(result, fileName, width, height)
+	}
+
+	if err != nil {
+		log.Errorf("resample: failed to save %s (%s)", txt.Quote(filepath.Base(fileName)), err)
+		return result, err
+	}
+
+	return result, nil
+}
+
+// save saves a JPEG or PNG thumbnail, small JPEG thumbnails use a lower quality.
+func save(img image.Image, fileName string, width, height int) error {
 	var saveOption imaging.EncodeOption
 
 	if filepath.Ext(fileName) == "."+string(fs.TypePng) {
@@ -173,12 +219,5 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 		saveOption = imaging.JPEGQuality(JpegQuality)
 	}
 
-	err = imaging.Save(result, fileName, saveOption)
-
-	if err != nil {
-		log.Errorf("resample: failed to save %s", txt.Quote(filepath.Base(fileName)))
-		return result, err
-	}
-
-	return result, nil
+	return imaging.Save(img, fileName, saveOption)
 }
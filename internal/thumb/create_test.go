@@ -1,8 +1,10 @@
 package thumb
 
 import (
+	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -142,6 +144,10 @@ func TestPostfix(t *testing.T) {
 	result := Postfix(tile50.Width, tile50.Height, tile50.Options...)
 
 	assert.Equal(t, "50x50_center.jpg", result)
+
+	result = Postfix(tile50.Width, tile50.Height, WithFormat(tile50.Options, FormatWebp)...)
+
+	assert.Equal(t, "50x50_center.webp", result)
 }
 
 func TestFilename(t *testing.T) {
@@ -257,6 +263,38 @@ func TestFromFile(t *testing.T) {
 	})
 }
 
+func TestFromEditedFile(t *testing.T) {
+	fit720 := Types["fit_720"]
+	src := "testdata/example.jpg"
+	dir, err := ioutil.TempDir("", "thumbs")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	original, err := imaging.Open(src)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName, err := FromEditedFile(src, "123456789098765432", dir, Edit{Rotate: 90}, fit720.Width, fit720.Height, fit720.Options...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := imaging.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, original.Bounds().Dx() > original.Bounds().Dy(), img.Bounds().Dy() > img.Bounds().Dx())
+}
+
 func TestFromCache(t *testing.T) {
 	t.Run("missing thumb", func(t *testing.T) {
 		tile50 := Types["tile_50"]
@@ -284,6 +322,44 @@ func TestFromCache(t *testing.T) {
 		assert.Equal(t, "", fileName)
 		assert.Error(t, err)
 	})
+	t.Run("on-demand thumb used", func(t *testing.T) {
+		fit4096 := Types["fit_4096"]
+		src := "testdata/example.jpg"
+		dir, err := ioutil.TempDir("", "thumbs")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		cached, err := Filename("193456789098765432", dir, fit4096.Width, fit4096.Height, fit4096.Options...)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(cached, []byte("jpeg"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		lastUsed := time.Now().Add(-2 * UsedInterval)
+
+		if err := os.Chtimes(cached, lastUsed, lastUsed); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName, err := FromCache(src, "193456789098765432", dir, fit4096.Width, fit4096.Height, fit4096.Options...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cached, fileName)
+
+		if info, err := os.Stat(cached); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.True(t, info.ModTime().After(lastUsed.Add(UsedInterval)))
+		}
+	})
 	t.Run("invalid hash", func(t *testing.T) {
 		tile50 := Types["tile_50"]
 		src := "testdata/example.jpg"
@@ -0,0 +1,134 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// Edit represents non-destructive adjustments that are applied to the original image before resampling.
+type Edit struct {
+	CropX       float64 // Left edge of the crop rectangle relative to the image width (0-1)
+	CropY       float64 // Top edge of the crop rectangle relative to the image height (0-1)
+	CropW       float64 // Width of the crop rectangle relative to the image width (0-1), 0 for none
+	CropH       float64 // Height of the crop rectangle relative to the image height (0-1), 0 for none
+	Rotate      int     // Clockwise rotation in 90 degree steps
+	Angle       float64 // Clockwise fine rotation in degrees (-45 to 45)
+	Exposure    float64 // Exposure correction in stops (-5 to 5)
+	Contrast    float64 // Contrast adjustment in percent (-100 to 100)
+	Saturation  float64 // Saturation adjustment in percent (-100 to 100)
+	Temperature float64 // White balance from cool to warm (-100 to 100)
+	Tint        float64 // White balance from green to magenta (-100 to 100)
+}
+
+// IsZero returns true if the edit does not change the image.
+func (e Edit) IsZero() bool {
+	return e == Edit{} || e == Edit{CropW: 1, CropH: 1}
+}
+
+// Cropped returns true if the edit contains a crop rectangle.
+func (e Edit) Cropped() bool {
+	return e.CropW > 0 && e.CropH > 0 && (e.CropX > 0 || e.CropY > 0 || e.CropW < 1 || e.CropH < 1)
+}
+
+// Apply returns the edited image: it is rotated first, then cropped and finally adjusted.
+func (e Edit) Apply(img image.Image) image.Image {
+	if img == nil || e.IsZero() {
+		return img
+	}
+
+	switch ((e.Rotate % 360) + 360) % 360 {
+	case 90:
+		img = imaging.Rotate270(img)
+	case 180:
+		img = imaging.Rotate180(img)
+	case 270:
+		img = imaging.Rotate90(img)
+	}
+
+	if e.Angle != 0 {
+		img = rotateFine(img, e.Angle)
+	}
+
+	if e.Cropped() {
+		b := img.Bounds()
+		w, h := float64(b.Dx()), float64(b.Dy())
+
+		rect := image.Rect(
+			int(math.Round(e.CropX*w)),
+			int(math.Round(e.CropY*h)),
+			int(math.Round((e.CropX+e.CropW)*w)),
+			int(math.Round((e.CropY+e.CropH)*h)),
+		).Add(b.Min).Intersect(b)
+
+		if !rect.Empty() {
+			img = imaging.Crop(img, rect)
+		}
+	}
+
+	if e.Exposure != 0 || e.Temperature != 0 || e.Tint != 0 {
+		img = adjustColors(img, e.Exposure, e.Temperature, e.Tint)
+	}
+
+	if e.Contrast != 0 {
+		img = imaging.AdjustContrast(img, e.Contrast)
+	}
+
+	if e.Saturation != 0 {
+		img = imaging.AdjustSaturation(img, e.Saturation)
+	}
+
+	return img
+}
+
+// rotateFine rotates an image clockwise by the angle in degrees and crops it to the largest
+// rectangle with the original aspect ratio, so that no empty corners are visible.
+func rotateFine(img image.Image, angle float64) image.Image {
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+
+	rad := math.Abs(angle) * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	scale := math.Min(w/(w*cos+h*sin), h/(w*sin+h*cos))
+
+	rotated := imaging.Rotate(img, -angle, color.Black)
+
+	cw, ch := int(math.Floor(w*scale)), int(math.Floor(h*scale))
+
+	if cw < 1 || ch < 1 {
+		return rotated
+	}
+
+	return imaging.CropCenter(rotated, cw, ch)
+}
+
+// adjustColors changes the exposure in stops and the white balance of an image.
+func adjustColors(img image.Image, exposure, temperature, tint float64) image.Image {
+	gain := math.Pow(2, exposure)
+
+	r := gain * (1 + temperature/500)
+	g := gain * (1 - tint/500)
+	b := gain * (1 - temperature/500)
+
+	return imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+		return color.NRGBA{
+			R: clampUint8(float64(c.R) * r),
+			G: clampUint8(float64(c.G) * g),
+			B: clampUint8(float64(c.B) * b),
+			A: c.A,
+		}
+	})
+}
+
+// clampUint8 rounds and limits a color value to the range 0-255.
+func clampUint8(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	} else if v >= 255 {
+		return 255
+	}
+
+	return uint8(v + 0.5)
+}
@@ -0,0 +1,84 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdit_IsZero(t *testing.T) {
+	assert.True(t, Edit{}.IsZero())
+	assert.True(t, Edit{CropW: 1, CropH: 1}.IsZero())
+	assert.False(t, Edit{Rotate: 90}.IsZero())
+	assert.False(t, Edit{CropX: 0.1, CropW: 0.5, CropH: 0.5}.IsZero())
+}
+
+func TestEdit_Apply(t *testing.T) {
+	img := imaging.New(400, 200, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, img, Edit{}.Apply(img))
+	})
+	t.Run("rotate", func(t *testing.T) {
+		result := Edit{Rotate: 90}.Apply(img)
+
+		assert.Equal(t, image.Rect(0, 0, 200, 400), result.Bounds())
+
+		result = Edit{Rotate: -180}.Apply(img)
+
+		assert.Equal(t, image.Rect(0, 0, 400, 200), result.Bounds())
+	})
+	t.Run("rotate clockwise", func(t *testing.T) {
+		src := imaging.New(2, 1, color.NRGBA{A: 255})
+		src.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+		result := imaging.Clone(Edit{Rotate: 90}.Apply(src))
+
+		assert.Equal(t, color.NRGBA{R: 255, A: 255}, result.NRGBAAt(0, 0))
+		assert.Equal(t, color.NRGBA{A: 255}, result.NRGBAAt(0, 1))
+	})
+	t.Run("fine rotation", func(t *testing.T) {
+		result := imaging.Clone(Edit{Angle: 5}.Apply(img))
+
+		assert.InDelta(t, 2.0, float64(result.Bounds().Dx())/float64(result.Bounds().Dy()), 0.02)
+		assert.Less(t, result.Bounds().Dx(), 400)
+
+		// No empty corners are visible.
+		assert.Equal(t, color.NRGBA{R: 100, G: 100, B: 100, A: 255}, result.NRGBAAt(0, 0))
+	})
+	t.Run("crop", func(t *testing.T) {
+		result := Edit{CropX: 0.25, CropY: 0.5, CropW: 0.5, CropH: 0.5}.Apply(img)
+
+		assert.Equal(t, image.Rect(0, 0, 200, 100), result.Bounds())
+	})
+	t.Run("crop exceeds bounds", func(t *testing.T) {
+		result := Edit{CropX: 0.5, CropY: 0.5, CropW: 1, CropH: 1}.Apply(img)
+
+		assert.Equal(t, image.Rect(0, 0, 200, 100), result.Bounds())
+	})
+	t.Run("exposure", func(t *testing.T) {
+		result := imaging.Clone(Edit{Exposure: 1}.Apply(img))
+
+		assert.Equal(t, color.NRGBA{R: 200, G: 200, B: 200, A: 255}, result.NRGBAAt(10, 10))
+	})
+	t.Run("white balance", func(t *testing.T) {
+		c := imaging.Clone(Edit{Temperature: 50}.Apply(img)).NRGBAAt(10, 10)
+
+		assert.Greater(t, c.R, c.G)
+		assert.Less(t, c.B, c.G)
+
+		c = imaging.Clone(Edit{Tint: 50}.Apply(img)).NRGBAAt(10, 10)
+
+		assert.Less(t, c.G, c.R)
+	})
+	t.Run("saturation", func(t *testing.T) {
+		src := imaging.New(10, 10, color.NRGBA{R: 200, G: 100, B: 100, A: 255})
+		c := imaging.Clone(Edit{Saturation: -100}.Apply(src)).NRGBAAt(5, 5)
+
+		assert.Equal(t, c.R, c.G)
+		assert.Equal(t, c.G, c.B)
+	})
+}
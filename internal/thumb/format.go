@@ -0,0 +1,144 @@
+package thumb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Thumbnail file formats.
+const (
+	FormatJpeg             = fs.TypeJpeg
+	FormatPng              = fs.TypePng
+	FormatWebp fs.FileType = "webp"
+	FormatAvif fs.FileType = "avif"
+)
+
+// External encoder executables, empty if not installed.
+var (
+	CwebpBin   = ""
+	AvifencBin = ""
+)
+
+// FormatMimeTypes maps thumbnail file formats to mime types.
+var FormatMimeTypes = map[fs.FileType]string{
+	FormatJpeg: "image/jpeg",
+	FormatPng:  "image/png",
+	FormatWebp: "image/webp",
+	FormatAvif: "image/avif",
+}
+
+// ParseFormat returns the thumbnail file format for a name like "webp".
+func ParseFormat(s string) (fs.FileType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "jpg", "jpeg":
+		return FormatJpeg, nil
+	case "webp":
+		return FormatWebp, nil
+	case "avif":
+		return FormatAvif, nil
+	default:
+		return "", fmt.Errorf("resample: unknown format %s", s)
+	}
+}
+
+// FormatSupported returns true if thumbnails can be created in the file format.
+func FormatSupported(format fs.FileType) bool {
+	switch format {
+	case FormatJpeg, FormatPng:
+		return true
+	case FormatWebp:
+		return CwebpBin != ""
+	case FormatAvif:
+		return AvifencBin != ""
+	default:
+		return false
+	}
+}
+
+// WithFormat returns the resample options with the output format replaced, PNG thumbnails are not changed.
+func WithFormat(opts []ResampleOption, format fs.FileType) (result []ResampleOption) {
+	result = make([]ResampleOption, 0, len(opts)+1)
+
+	for _, option := range opts {
+		switch option {
+		case ResamplePng:
+			return opts
+		case ResampleWebp, ResampleAvif:
+			continue
+		default:
+			result = append(result, option)
+		}
+	}
+
+	switch format {
+	case FormatWebp:
+		result = append(result, ResampleWebp)
+	case FormatAvif:
+		result = append(result, ResampleAvif)
+	}
+
+	return result
+}
+
+// EncodeCommand returns the command for encoding a PNG image as WebP or AVIF file.
+func EncodeCommand(pngName, fileName string, format fs.FileType) (*exec.Cmd, error) {
+	switch format {
+	case FormatWebp:
+		if CwebpBin == "" {
+			return nil, errors.New("resample: cwebp not found")
+		}
+
+		return exec.Command(CwebpBin, "-quiet", "-q", strconv.Itoa(WebpQuality), "-metadata", "none", pngName, "-o", fileName), nil
+	case FormatAvif:
+		if AvifencBin == "" {
+			return nil, errors.New("resample: avifenc not found")
+		}
+
+		return exec.Command(AvifencBin, "-q", strconv.Itoa(AvifQuality), "-s", "6", pngName, fileName), nil
+	default:
+		return nil, fmt.Errorf("resample: can't encode %s", format)
+	}
+}
+
+// Encode saves an image as WebP or AVIF file using an external encoder.
+func Encode(img image.Image, fileName string, format fs.FileType) error {
+	pngName := fileName + ".png"
+
+	cmd, err := EncodeCommand(pngName, fileName, format)
+
+	if err != nil {
+		return err
+	}
+
+	// Lossless intermediate file.
+	if err := imaging.Save(img, pngName); err != nil {
+		return err
+	}
+
+	defer os.Remove(pngName)
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return errors.New(stderr.String())
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
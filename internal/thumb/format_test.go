@@ -0,0 +1,81 @@
+package thumb
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	for name, expected := range map[string]string{"jpeg": "jpg", "JPG": "jpg", "webp": "webp", " avif": "avif"} {
+		format, err := ParseFormat(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(format))
+	}
+
+	_, err := ParseFormat("gif")
+
+	assert.Error(t, err)
+}
+
+func TestFormatSupported(t *testing.T) {
+	cwebp, avifenc := CwebpBin, AvifencBin
+
+	defer func() {
+		CwebpBin, AvifencBin = cwebp, avifenc
+	}()
+
+	CwebpBin, AvifencBin = "/usr/bin/cwebp", ""
+
+	assert.True(t, FormatSupported(FormatJpeg))
+	assert.True(t, FormatSupported(FormatWebp))
+	assert.False(t, FormatSupported(FormatAvif))
+	assert.False(t, FormatSupported("gif"))
+}
+
+func TestWithFormat(t *testing.T) {
+	t.Run("webp", func(t *testing.T) {
+		opts := WithFormat(Types["fit_720"].Options, FormatWebp)
+
+		assert.Equal(t, []ResampleOption{ResampleFit, ResampleDefault, ResampleWebp}, opts)
+		assert.Equal(t, []ResampleOption{ResampleFit, ResampleDefault, ResampleAvif}, WithFormat(opts, FormatAvif))
+		assert.Equal(t, []ResampleOption{ResampleFit, ResampleDefault}, WithFormat(opts, FormatJpeg))
+	})
+	t.Run("png", func(t *testing.T) {
+		opts := Types["colors"].Options
+
+		assert.Equal(t, opts, WithFormat(opts, FormatWebp))
+	})
+}
+
+func TestEncodeCommand(t *testing.T) {
+	cwebp, avifenc := CwebpBin, AvifencBin
+
+	defer func() {
+		CwebpBin, AvifencBin = cwebp, avifenc
+	}()
+
+	CwebpBin, AvifencBin = "/usr/bin/cwebp", "/usr/bin/avifenc"
+
+	cmd, err := EncodeCommand("in.png", "out.webp", FormatWebp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/usr/bin/cwebp", "-quiet", "-q", "80", "-metadata", "none", "in.png", "-o", "out.webp"}, cmd.Args)
+
+	cmd, err = EncodeCommand("in.png", "out.avif", FormatAvif)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/usr/bin/avifenc", "-q", "60", "-s", "6", "in.png", "out.avif"}, cmd.Args)
+
+	_, err = EncodeCommand("in.png", "out.gif", "gif")
+
+	assert.Error(t, err)
+
+	AvifencBin = ""
+
+	err = Encode(image.NewRGBA(image.Rect(0, 0, 8, 8)), "testdata/out.avif", FormatAvif)
+
+	assert.EqualError(t, err, "resample: avifenc not found")
+}
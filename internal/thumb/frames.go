@@ -0,0 +1,123 @@
+package thumb
+
+import (
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+// BlackLevel is the mean luminance (0-1) below which a video frame is considered black.
+const BlackLevel = 0.08
+
+// FrameStats returns the mean luminance (0-1) and the sharpness (variance of the Laplacian) of a video frame.
+func FrameStats(img image.Image) (brightness, sharpness float64) {
+	gray := imaging.Grayscale(imaging.Resize(img, 320, 0, imaging.Box))
+
+	w, h := gray.Bounds().Dx(), gray.Bounds().Dy()
+
+	if w == 0 || h == 0 {
+		return 0, 0
+	}
+
+	lum := func(x, y int) float64 {
+		return float64(gray.Pix[y*gray.Stride+x*4])
+	}
+
+	var sum, lapSum, lapSqSum float64
+	var n int
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sum += lum(x, y)
+
+			if x == 0 || y == 0 || x == w-1 || y == h-1 {
+				continue
+			}
+
+			l := 4*lum(x, y) - lum(x-1, y) - lum(x+1, y) - lum(x, y-1) - lum(x, y+1)
+			lapSum += l
+			lapSqSum += l * l
+			n++
+		}
+	}
+
+	brightness = sum / float64(w*h) / 255
+
+	if n > 0 {
+		mean := lapSum / float64(n)
+		sharpness = lapSqSum/float64(n) - mean*mean
+	}
+
+	return brightness, sharpness
+}
+
+// BestFrame returns the file name of the sharpest video frame that isn't black.
+func BestFrame(fileNames []string) (result string, err error) {
+	var best, brightest float64
+	var fallback string
+
+	for _, fileName := range fileNames {
+		img, err := imaging.Open(fileName)
+
+		if err != nil {
+			log.Debugf("resample: %s", err)
+			continue
+		}
+
+		brightness, sharpness := FrameStats(img)
+
+		if brightness < BlackLevel {
+			if fallback == "" || brightness > brightest {
+				fallback, brightest = fileName, brightness
+			}
+
+			continue
+		}
+
+		if result == "" || sharpness > best {
+			result, best = fileName, sharpness
+		}
+	}
+
+	if result == "" {
+		result = fallback
+	}
+
+	if result == "" {
+		return "", errors.New("resample: no video frame found")
+	}
+
+	return result, nil
+}
+
+// Sprite creates a sprite sheet with the frames arranged in a grid with the given number of columns.
+func Sprite(fileNames []string, fileName string, width, height, cols int) error {
+	if len(fileNames) == 0 {
+		return errors.New("resample: no sprite frames")
+	}
+
+	if cols > len(fileNames) {
+		cols = len(fileNames)
+	}
+
+	rows := (len(fileNames) + cols - 1) / cols
+
+	sprite := imaging.New(cols*width, rows*height, color.Black)
+
+	for i, frameName := range fileNames {
+		img, err := imaging.Open(frameName)
+
+		if err != nil {
+			return err
+		}
+
+		frame := imaging.Fit(img, width, height, Filter.Imaging())
+		pos := image.Pt((i%cols)*width+(width-frame.Bounds().Dx())/2, (i/cols)*height+(height-frame.Bounds().Dy())/2)
+
+		sprite = imaging.Paste(sprite, frame, pos)
+	}
+
+	return imaging.Save(sprite, fileName, imaging.JPEGQuality(JpegQualitySmall))
+}
@@ -0,0 +1,117 @@
+package thumb
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func frameImages(t *testing.T) (black, flat, sharp string) {
+	black, flat, sharp = "testdata/frame_black.jpg", "testdata/frame_flat.jpg", "testdata/frame_sharp.jpg"
+
+	checkers := imaging.New(320, 180, color.White)
+
+	for y := 0; y < 180; y++ {
+		for x := 0; x < 320; x++ {
+			if (x/8+y/8)%2 == 0 {
+				checkers.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	images := map[string]image.Image{
+		black: imaging.New(320, 180, color.NRGBA{R: 5, G: 5, B: 5, A: 255}),
+		flat:  imaging.New(320, 180, color.NRGBA{R: 128, G: 128, B: 128, A: 255}),
+		sharp: checkers,
+	}
+
+	for fileName, img := range images {
+		if err := imaging.Save(img, fileName); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return black, flat, sharp
+}
+
+func TestFrameStats(t *testing.T) {
+	brightness, sharpness := FrameStats(imaging.New(100, 100, color.White))
+
+	assert.InEpsilon(t, 1.0, brightness, 0.01)
+	assert.Equal(t, 0.0, sharpness)
+
+	brightness, sharpness = FrameStats(imaging.New(100, 100, color.Black))
+
+	assert.Equal(t, 0.0, brightness)
+	assert.Equal(t, 0.0, sharpness)
+}
+
+func TestBestFrame(t *testing.T) {
+	black, flat, sharp := frameImages(t)
+
+	defer func() {
+		_ = os.Remove(black)
+		_ = os.Remove(flat)
+		_ = os.Remove(sharp)
+	}()
+
+	t.Run("sharpest", func(t *testing.T) {
+		result, err := BestFrame([]string{black, flat, sharp})
+
+		assert.NoError(t, err)
+		assert.Equal(t, sharp, result)
+	})
+	t.Run("skip black", func(t *testing.T) {
+		result, err := BestFrame([]string{black, flat})
+
+		assert.NoError(t, err)
+		assert.Equal(t, flat, result)
+	})
+	t.Run("only black", func(t *testing.T) {
+		result, err := BestFrame([]string{black, "testdata/missing.jpg"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, black, result)
+	})
+	t.Run("none", func(t *testing.T) {
+		_, err := BestFrame([]string{"testdata/missing.jpg"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSprite(t *testing.T) {
+	black, flat, sharp := frameImages(t)
+	fileName := "testdata/sprite.jpg"
+
+	defer func() {
+		_ = os.Remove(black)
+		_ = os.Remove(flat)
+		_ = os.Remove(sharp)
+		_ = os.Remove(fileName)
+	}()
+
+	if err := Sprite([]string{black, flat, sharp}, fileName, 160, 90, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := imaging.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 320, img.Bounds().Dx())
+	assert.Equal(t, 180, img.Bounds().Dy())
+
+	assert.Error(t, Sprite(nil, fileName, 160, 90, 2))
+}
+
+func TestType_Sprite(t *testing.T) {
+	assert.True(t, Types["sprite"].Sprite())
+	assert.False(t, Types["fit_720"].Sprite())
+}
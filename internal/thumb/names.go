@@ -0,0 +1,43 @@
+package thumb
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// TypeOther is the type name of cache files that don't match a known thumbnail type.
+const TypeOther = "other"
+
+// postfixTypes maps file name postfixes without extension, like "720x720_fit", to thumbnail type names.
+var postfixTypes = func() map[string]string {
+	result := make(map[string]string, len(Types))
+
+	for name, t := range Types {
+		method, _, _ := ResampleOptions(t.Options...)
+		result[fmt.Sprintf("%dx%d_%s", t.Width, t.Height, ResampleMethods[method])] = name
+	}
+
+	return result
+}()
+
+// ParseName returns the file hash and thumbnail type name of a file in the thumbnail cache.
+// The type name is TypeOther if the postfix is unknown, and the hash is empty if the name is invalid.
+func ParseName(fileName string) (hash, typeName string) {
+	base := filepath.Base(fileName)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+
+	i := strings.Index(base, "_")
+
+	if i < 4 {
+		return "", TypeOther
+	}
+
+	hash = base[:i]
+
+	if name, ok := postfixTypes[base[i+1:]]; ok {
+		return hash, name
+	}
+
+	return hash, TypeOther
+}
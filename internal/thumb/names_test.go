@@ -0,0 +1,45 @@
+package thumb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseName(t *testing.T) {
+	t.Run("fit_720", func(t *testing.T) {
+		hash, name := ParseName("/thumbs/1/2/3/123456789098765432_720x720_fit.jpg")
+
+		assert.Equal(t, "123456789098765432", hash)
+		assert.Equal(t, "fit_720", name)
+	})
+	t.Run("webp", func(t *testing.T) {
+		hash, name := ParseName("123456789098765432_500x500_center.webp")
+
+		assert.Equal(t, "123456789098765432", hash)
+		assert.Equal(t, "tile_500", name)
+	})
+	t.Run("sprite track", func(t *testing.T) {
+		hash, name := ParseName("123456789098765432_160x90_sprite.vtt")
+
+		assert.Equal(t, "123456789098765432", hash)
+		assert.Equal(t, "sprite", name)
+	})
+	t.Run("colors", func(t *testing.T) {
+		_, name := ParseName("123456789098765432_3x3_resize.png")
+
+		assert.Equal(t, "colors", name)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		hash, name := ParseName("123456789098765432_99x99_fit.jpg")
+
+		assert.Equal(t, "123456789098765432", hash)
+		assert.Equal(t, TypeOther, name)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		hash, name := ParseName("foo.jpg")
+
+		assert.Equal(t, "", hash)
+		assert.Equal(t, TypeOther, name)
+	})
+}
@@ -8,6 +8,8 @@ var (
 	Filter           = ResampleLanczos
 	JpegQuality      = 95
 	JpegQualitySmall = 80
+	WebpQuality      = 80
+	AvifQuality      = 60
 )
 
 func MaxSize() int {
@@ -55,6 +57,9 @@ const (
 	ResampleNearestNeighbor
 	ResampleDefault
 	ResamplePng
+	ResampleSprite
+	ResampleWebp
+	ResampleAvif
 )
 
 type ResampleOption int
@@ -65,6 +70,7 @@ var ResampleMethods = map[ResampleOption]string{
 	ResampleFillBottomRight: "right",
 	ResampleFit:             "fit",
 	ResampleResize:          "resize",
+	ResampleSprite:          "sprite",
 }
 
 type Type struct {
@@ -94,6 +100,7 @@ var Types = TypeMap{
 	"fit_3840":  {"Ultra HD", "", 3840, 2400, false, []ResampleOption{ResampleFit, ResampleDefault}}, // Deprecated in favor of fit_4096
 	"fit_4096":  {"Ultra HD, Retina 4K", "", 4096, 4096, true, []ResampleOption{ResampleFit, ResampleDefault}},
 	"fit_7680":  {"8K Ultra HD 2, Retina 6K", "", 7680, 4320, true, []ResampleOption{ResampleFit, ResampleDefault}},
+	"sprite":    {"Video Scrubbing", "", 160, 90, false, []ResampleOption{ResampleSprite}}, // Sprite sheet with video frames
 }
 
 var DefaultTypes = []string{
@@ -126,6 +133,17 @@ func Find(limit int) (name string, result Type) {
 	return "", Type{}
 }
 
+// Sprite returns true if the type is a sprite sheet with video frames of the given size.
+func (t Type) Sprite() bool {
+	for _, option := range t.Options {
+		if option == ResampleSprite {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Returns true if thumbnail type exceeds the cached thumbnails size.
 func (t Type) ExceedsSize() bool {
 	return t.Width > Size || t.Height > Size
@@ -0,0 +1,26 @@
+package video
+
+import "strings"
+
+// CodecAliases maps common codec names to the identifiers found in video metadata.
+var CodecAliases = map[string][]string{
+	"avc":  {"avc1", "avc3", "h264"},
+	"h264": {"avc1", "avc3", "h264"},
+	"avc1": {"avc1", "avc3", "h264"},
+	"hevc": {"hvc1", "hev1", "hevc"},
+	"h265": {"hvc1", "hev1", "hevc"},
+	"hvc1": {"hvc1", "hev1", "hevc"},
+	"vp9":  {"vp09", "vp9"},
+	"av1":  {"av01", "av1"},
+}
+
+// CodecNames returns the codec identifiers matching a codec name.
+func CodecNames(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if names, ok := CodecAliases[name]; ok {
+		return names
+	}
+
+	return []string{name}
+}
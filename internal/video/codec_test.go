@@ -0,0 +1,13 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecNames(t *testing.T) {
+	assert.Equal(t, []string{"hvc1", "hev1", "hevc"}, CodecNames("HEVC"))
+	assert.Equal(t, []string{"avc1", "avc3", "h264"}, CodecNames("h264"))
+	assert.Equal(t, []string{"mjpeg"}, CodecNames("mjpeg"))
+}
@@ -0,0 +1,135 @@
+package video
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// gpmfState contains the stream properties needed to decode GPS samples.
+type gpmfState struct {
+	scale []float64
+	fix   bool
+}
+
+// ParseGPMF returns the GPS track contained in GoPro metadata (GPMF), one position per payload.
+// See https://github.com/gopro/gpmf-parser
+func ParseGPMF(data []byte) (track meta.Track, err error) {
+	if err := parseGPMF(data, &gpmfState{fix: true}, &track); err != nil {
+		return track, err
+	}
+
+	return track, nil
+}
+
+// parseGPMF decodes the KLV (key, length, value) structures in data recursively.
+func parseGPMF(data []byte, state *gpmfState, track *meta.Track) error {
+	for len(data) >= 8 {
+		key := string(data[0:4])
+		valueType := data[4]
+		size := int(data[5])
+		repeat := int(binary.BigEndian.Uint16(data[6:8]))
+		length := size * repeat
+		padded := (length + 3) &^ 3
+
+		if 8+padded > len(data) {
+			return errors.New("gpmf: unexpected end of data")
+		}
+
+		value := data[8 : 8+length]
+		data = data[8+padded:]
+
+		switch {
+		case valueType == 0:
+			// Nested structure, e.g. DEVC or STRM; each stream has its own scale.
+			if err := parseGPMF(value, &gpmfState{fix: true}, track); err != nil {
+				return err
+			}
+		case key == "SCAL":
+			state.scale = gpmfNumbers(valueType, size, value)
+		case key == "GPSF":
+			if n := gpmfNumbers(valueType, size, value); len(n) > 0 {
+				state.fix = n[0] >= 2
+			}
+		case key == "GPS5":
+			if !state.fix || len(state.scale) == 0 || size != 20 || valueType != 'l' {
+				continue
+			}
+
+			raw := gpmfNumbers(valueType, 4, value[0:20])
+			p := meta.TrackPoint{}
+
+			for i, v := range raw[0:3] {
+				s := state.scale[0]
+
+				if len(state.scale) > i {
+					s = state.scale[i]
+				}
+
+				if s == 0 {
+					s = 1
+				}
+
+				switch i {
+				case 0:
+					p.Lat = v / s
+				case 1:
+					p.Lng = v / s
+				case 2:
+					p.Altitude = math.Round(v / s)
+				}
+			}
+
+			if p.Valid() {
+				*track = append(*track, p)
+			}
+		}
+	}
+
+	return nil
+}
+
+// gpmfNumbers returns the numeric values of a GPMF sample.
+func gpmfNumbers(valueType byte, size int, value []byte) (result []float64) {
+	var n int
+
+	switch valueType {
+	case 'l', 'L', 'f':
+		n = 4
+	case 's', 'S':
+		n = 2
+	case 'b', 'B':
+		n = 1
+	default:
+		return result
+	}
+
+	if size%n != 0 {
+		return result
+	}
+
+	for i := 0; i+n <= len(value); i += n {
+		b := value[i : i+n]
+
+		switch valueType {
+		case 'l':
+			result = append(result, float64(int32(binary.BigEndian.Uint32(b))))
+		case 'L':
+			result = append(result, float64(binary.BigEndian.Uint32(b)))
+		case 'f':
+			result = append(result, float64(math.Float32frombits(binary.BigEndian.Uint32(b))))
+		case 's':
+			result = append(result, float64(int16(binary.BigEndian.Uint16(b))))
+		case 'S':
+			result = append(result, float64(binary.BigEndian.Uint16(b)))
+		case 'b':
+			result = append(result, float64(int8(b[0])))
+		case 'B':
+			result = append(result, float64(b[0]))
+		}
+	}
+
+	return result
+}
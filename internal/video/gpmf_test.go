@@ -0,0 +1,95 @@
+package video
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+// gpmfKLV encodes a GPMF key, length, value structure.
+func gpmfKLV(key string, valueType byte, size int, value []byte) []byte {
+	header := make([]byte, 8)
+	copy(header, key)
+	header[4] = valueType
+	header[5] = byte(size)
+
+	if size > 0 {
+		binary.BigEndian.PutUint16(header[6:], uint16(len(value)/size))
+	}
+
+	for len(value)%4 != 0 {
+		value = append(value, 0)
+	}
+
+	return append(header, value...)
+}
+
+func gpmfInt32(values ...int32) []byte {
+	b := make([]byte, 4*len(values))
+
+	for i, v := range values {
+		binary.BigEndian.PutUint32(b[i*4:], uint32(v))
+	}
+
+	return b
+}
+
+func TestParseGPMF(t *testing.T) {
+	var strm []byte
+
+	strm = append(strm, gpmfKLV("STNM", 'c', 1, []byte("GPS (Lat., Long., Alt., 2D speed, 3D speed)"))...)
+	strm = append(strm, gpmfKLV("GPSF", 'L', 4, gpmfInt32(3))...)
+	strm = append(strm, gpmfKLV("SCAL", 'l', 4, gpmfInt32(10000000, 10000000, 1000, 1000, 100))...)
+	strm = append(strm, gpmfKLV("GPS5", 'l', 20, gpmfInt32(
+		473857130, 86213400, 490312, 1200, 130,
+		473857200, 86213500, 490400, 1200, 130))...)
+
+	devc := gpmfKLV("DEVC", 0, 1, gpmfKLV("STRM", 0, 1, strm))
+
+	// Second payload without GPS lock.
+	var noFix []byte
+
+	noFix = append(noFix, gpmfKLV("GPSF", 'L', 4, gpmfInt32(0))...)
+	noFix = append(noFix, gpmfKLV("SCAL", 'l', 4, gpmfInt32(10000000, 10000000, 1000, 1000, 100))...)
+	noFix = append(noFix, gpmfKLV("GPS5", 'l', 20, gpmfInt32(1, 1, 1, 1, 1))...)
+
+	devc = append(devc, gpmfKLV("DEVC", 0, 1, gpmfKLV("STRM", 0, 1, noFix))...)
+
+	track, err := ParseGPMF(devc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, meta.Track{{Lat: 47.385713, Lng: 8.62134, Altitude: 490}}, track)
+
+	_, err = ParseGPMF(devc[:20])
+
+	assert.Error(t, err)
+}
+
+func TestParseSRT(t *testing.T) {
+	data := `1
+00:00:00,000 --> 00:00:00,033
+[latitude: 47.385713] [longitude: 8.621340] [rel_alt: 1.200 abs_alt: 490.312]
+
+2
+00:00:00,033 --> 00:00:00,066
+[latitude : 47.385801] [longtitude : 8.621412] [rel_alt: 1.500 abs_alt: 490.612]
+
+3
+00:00:00,066 --> 00:00:00,099
+GPS(8.621500,47.385900,19) BAROMETER:1.9
+
+4
+00:00:00,099 --> 00:00:00,132
+GPS(0.0,0.0,0) BAROMETER:1.9
+`
+
+	track := ParseSRT(data)
+
+	assert.Equal(t, meta.Track{
+		{Lat: 47.385713, Lng: 8.62134, Altitude: 490.312},
+		{Lat: 47.385801, Lng: 8.621412, Altitude: 490.612},
+		{Lat: 47.3859, Lng: 8.6215, Altitude: 19},
+	}, track)
+}
@@ -0,0 +1,111 @@
+package video
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	HlsPlaylist = "index.m3u8"
+	HlsSegment  = "segment_%03d.ts"
+	HlsTime     = 6
+	HlsMimeType = "application/vnd.apple.mpegurl"
+	TsMimeType  = "video/mp2t"
+)
+
+var hlsFileRegexp = regexp.MustCompile(`^(index\.m3u8|segment_[0-9]{3,6}\.ts)$`)
+
+// Rendition represents a video quality level for adaptive streaming.
+type Rendition struct {
+	Name         string
+	Height       int
+	Bitrate      int // Video bitrate in kbit/s.
+	AudioBitrate int // Audio bitrate in kbit/s.
+}
+
+// Renditions represents a list of renditions sorted by quality.
+type Renditions []Rendition
+
+// HlsRenditions contains the renditions created for HTTP live streaming.
+var HlsRenditions = Renditions{
+	{Name: "360p", Height: 360, Bitrate: 800, AudioBitrate: 96},
+	{Name: "720p", Height: 720, Bitrate: 2800, AudioBitrate: 128},
+	{Name: "1080p", Height: 1080, Bitrate: 5000, AudioBitrate: 128},
+}
+
+// Bandwidth returns the peak bandwidth in bit/s as announced in the master playlist.
+func (r Rendition) Bandwidth() int {
+	return (r.MaxRate() + r.AudioBitrate) * 1000
+}
+
+// MaxRate returns the maximum video bitrate in kbit/s.
+func (r Rendition) MaxRate() int {
+	return r.Bitrate * 107 / 100
+}
+
+// BufSize returns the rate control buffer size in kbit.
+func (r Rendition) BufSize() int {
+	return r.Bitrate * 3 / 2
+}
+
+// Size returns the rendition dimensions for the given source dimensions, 0 if unknown.
+func (r Rendition) Size(width, height int) (w, h int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	h = r.Height
+
+	if height < h {
+		h = height
+	}
+
+	// Codecs require even dimensions.
+	w = int(float64(width)*float64(h)/float64(height)+0.5) &^ 1
+
+	return w, h
+}
+
+// Get returns the rendition with the given name.
+func (l Renditions) Get(name string) (Rendition, bool) {
+	for _, r := range l {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return Rendition{}, false
+}
+
+// For returns the renditions that don't upscale a video with the given height.
+func (l Renditions) For(height int) (result Renditions) {
+	for i, r := range l {
+		if height <= 0 || i == 0 || r.Height <= height {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// MasterPlaylist returns an HLS master playlist referencing the renditions.
+func (l Renditions) MasterPlaylist(width, height int) string {
+	s := "#EXTM3U\n#EXT-X-VERSION:3\n"
+
+	for _, r := range l {
+		s += fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d", r.Bandwidth())
+
+		if w, h := r.Size(width, height); w > 0 {
+			s += fmt.Sprintf(",RESOLUTION=%dx%d", w, h)
+		}
+
+		s += fmt.Sprintf("\n%s/%s\n", r.Name, HlsPlaylist)
+	}
+
+	return s
+}
+
+// HlsFile tests if the file name is a valid rendition playlist or segment name.
+func HlsFile(name string) bool {
+	return hlsFileRegexp.MatchString(name)
+}
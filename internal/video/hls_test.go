@@ -0,0 +1,49 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRendition_Size(t *testing.T) {
+	r, ok := HlsRenditions.Get("720p")
+
+	assert.True(t, ok)
+
+	w, h := r.Size(1920, 1080)
+	assert.Equal(t, 1280, w)
+	assert.Equal(t, 720, h)
+
+	w, h = r.Size(1080, 1920)
+	assert.Equal(t, 404, w)
+	assert.Equal(t, 720, h)
+
+	w, h = r.Size(0, 0)
+	assert.Equal(t, 0, w)
+	assert.Equal(t, 0, h)
+}
+
+func TestRenditions_For(t *testing.T) {
+	assert.Len(t, HlsRenditions.For(0), 3)
+	assert.Len(t, HlsRenditions.For(1080), 3)
+	assert.Len(t, HlsRenditions.For(720), 2)
+	assert.Len(t, HlsRenditions.For(240), 1)
+}
+
+func TestRenditions_MasterPlaylist(t *testing.T) {
+	s := HlsRenditions.For(720).MasterPlaylist(1280, 720)
+
+	expected := "#EXTM3U\n#EXT-X-VERSION:3\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=952000,RESOLUTION=640x360\n360p/index.m3u8\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=3124000,RESOLUTION=1280x720\n720p/index.m3u8\n"
+
+	assert.Equal(t, expected, s)
+}
+
+func TestHlsFile(t *testing.T) {
+	assert.True(t, HlsFile("index.m3u8"))
+	assert.True(t, HlsFile("segment_012.ts"))
+	assert.False(t, HlsFile("../index.m3u8"))
+	assert.False(t, HlsFile("segment_.ts"))
+}
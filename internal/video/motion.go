@@ -0,0 +1,43 @@
+package video
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// MotionBrands lists the MP4 file type brands found in videos embedded by motion photo cameras.
+var MotionBrands = []string{"mp41", "mp42", "isom", "iso2", "iso4", "iso5", "iso6", "avc1", "qt  ", "MSNV"}
+
+var ftypBox = []byte("ftyp")
+
+// MotionOffset returns the offset of an MP4 video appended to a still image, as written by
+// Samsung and Google cameras for motion photos, or -1 if the image does not contain a video.
+func MotionOffset(data []byte) int {
+	// Skip the image header, which may contain a file type box itself.
+	for pos := 8; pos < len(data)-12; {
+		i := bytes.Index(data[pos:], ftypBox)
+
+		if i < 0 {
+			break
+		}
+
+		box := pos + i - 4
+		pos += i + len(ftypBox)
+
+		size := int(binary.BigEndian.Uint32(data[box : box+4]))
+
+		if size < 16 || size > 256 || box+size > len(data) {
+			continue
+		}
+
+		brand := string(data[box+8 : box+12])
+
+		for _, b := range MotionBrands {
+			if brand == b {
+				return box
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,29 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMotionOffset(t *testing.T) {
+	jpeg := []byte("\xff\xd8\xff\xe1\x00\x10Exif\x00\x00ftyp data\xff\xd9")
+	mp4 := []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00isommp42\x00\x00\x00\x08free")
+
+	t.Run("motion photo", func(t *testing.T) {
+		data := append(append([]byte{}, jpeg...), mp4...)
+
+		assert.Equal(t, len(jpeg), MotionOffset(data))
+	})
+	t.Run("still image", func(t *testing.T) {
+		assert.Equal(t, -1, MotionOffset(jpeg))
+	})
+	t.Run("unknown brand", func(t *testing.T) {
+		data := append(append([]byte{}, jpeg...), []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic")...)
+
+		assert.Equal(t, -1, MotionOffset(data))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, -1, MotionOffset(nil))
+	})
+}
@@ -0,0 +1,239 @@
+package video
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Probe represents video file information as reported by "ffprobe -of json -show_format -show_streams".
+type Probe struct {
+	Streams []ProbeStream `json:"streams"`
+	Format  ProbeFormat   `json:"format"`
+}
+
+// ProbeFormat represents the container format information reported by ffprobe.
+type ProbeFormat struct {
+	FormatName string            `json:"format_name"`
+	Duration   string            `json:"duration"`
+	BitRate    string            `json:"bit_rate"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// ProbeStream represents a video, audio, subtitle or data stream reported by ffprobe.
+type ProbeStream struct {
+	Index        int               `json:"index"`
+	CodecName    string            `json:"codec_name"`
+	CodecType    string            `json:"codec_type"`
+	CodecTag     string            `json:"codec_tag_string"`
+	Width        int               `json:"width"`
+	Height       int               `json:"height"`
+	AvgFrameRate string            `json:"avg_frame_rate"`
+	Duration     string            `json:"duration"`
+	BitRate      string            `json:"bit_rate"`
+	Channels     int               `json:"channels"`
+	SampleRate   string            `json:"sample_rate"`
+	Tags         map[string]string `json:"tags"`
+	SideDataList []ProbeSideData   `json:"side_data_list"`
+	Disposition  map[string]int    `json:"disposition"`
+}
+
+// ProbeSideData represents stream side data like the display matrix rotation.
+type ProbeSideData struct {
+	Rotation int `json:"rotation"`
+}
+
+// ContentIdentifierTag is the QuickTime metadata key that links the video of an Apple live photo to its image.
+const ContentIdentifierTag = "com.apple.quicktime.content.identifier"
+
+var codecTagRegexp = regexp.MustCompile(`^[a-z0-9]{4}$`)
+
+// ProbeCommand returns the command for probing a video file.
+func ProbeCommand(ffprobeBin, fileName string) *exec.Cmd {
+	return exec.Command(ffprobeBin, "-v", "quiet", "-of", "json", "-show_format", "-show_streams", fileName)
+}
+
+// ProbeFile runs ffprobe and returns the video file information.
+func ProbeFile(ffprobeBin, fileName string) (result Probe, err error) {
+	if ffprobeBin == "" {
+		return result, errors.New("ffprobe not found")
+	}
+
+	cmd := ProbeCommand(ffprobeBin, fileName)
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return result, errors.New(stderr.String())
+		} else {
+			return result, err
+		}
+	}
+
+	return ParseProbe(out.Bytes())
+}
+
+// ParseProbe parses ffprobe JSON output.
+func ParseProbe(data []byte) (result Probe, err error) {
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("can't parse ffprobe output (%s)", err)
+	}
+
+	if len(result.Streams) == 0 {
+		return result, errors.New("no streams found")
+	}
+
+	return result, nil
+}
+
+// VideoStream returns the first video stream that isn't an attached picture.
+func (p Probe) VideoStream() (ProbeStream, bool) {
+	for _, s := range p.Streams {
+		if s.CodecType == "video" && s.Disposition["attached_pic"] == 0 {
+			return s, true
+		}
+	}
+
+	return ProbeStream{}, false
+}
+
+// Duration returns the video duration.
+func (p Probe) Duration() time.Duration {
+	d := parseSeconds(p.Format.Duration)
+
+	if d == 0 {
+		if s, ok := p.VideoStream(); ok {
+			d = parseSeconds(s.Duration)
+		}
+	}
+
+	return d
+}
+
+// Codec returns the video codec, preferring four character codes like "avc1" or "hvc1" as used by exiftool.
+func (s ProbeStream) Codec() string {
+	if tag := strings.ToLower(s.CodecTag); codecTagRegexp.MatchString(tag) {
+		return tag
+	}
+
+	return strings.ToLower(s.CodecName)
+}
+
+// FrameRate returns the average frames per second.
+func (s ProbeStream) FrameRate() float64 {
+	n := strings.SplitN(s.AvgFrameRate, "/", 2)
+
+	num, err := strconv.ParseFloat(n[0], 64)
+
+	if err != nil {
+		return 0
+	}
+
+	if len(n) == 2 {
+		den, err := strconv.ParseFloat(n[1], 64)
+
+		if err != nil || den == 0 {
+			return 0
+		}
+
+		num = num / den
+	}
+
+	return math.Round(num*100) / 100
+}
+
+// Rotation returns the clockwise display rotation in degrees.
+func (s ProbeStream) Rotation() int {
+	if r, err := strconv.Atoi(s.Tags["rotate"]); err == nil {
+		return (r%360 + 360) % 360
+	}
+
+	for _, d := range s.SideDataList {
+		if d.Rotation != 0 {
+			// Display matrix rotation is counter-clockwise.
+			return (-d.Rotation%360 + 360) % 360
+		}
+	}
+
+	return 0
+}
+
+// Meta adds the video information to metadata, values from other sources are only replaced if missing or less precise.
+func (p Probe) Meta(data *meta.Data) {
+	if d := p.Duration(); d > 0 {
+		data.Duration = d
+	}
+
+	if n, err := strconv.Atoi(p.Format.BitRate); err == nil && n > 0 {
+		data.Bitrate = n
+	}
+
+	if id := rnd.SanitizeUUID(p.Format.Tags[ContentIdentifierTag]); id != "" && data.ContentID == "" {
+		data.ContentID = id
+	}
+
+	if s, ok := p.VideoStream(); ok {
+		if data.Codec == "" {
+			data.Codec = s.Codec()
+		}
+
+		if data.Width == 0 || data.Height == 0 {
+			data.Width, data.Height = s.Width, s.Height
+		}
+
+		if fps := s.FrameRate(); fps > 0 {
+			data.FrameRate = fps
+		}
+
+		if data.Rotation == 0 {
+			data.Rotation = s.Rotation()
+		}
+
+		if data.Bitrate == 0 {
+			data.Bitrate, _ = strconv.Atoi(s.BitRate)
+		}
+	}
+
+	data.Audio = nil
+
+	for _, s := range p.Streams {
+		if s.CodecType != "audio" {
+			continue
+		}
+
+		sampleRate, _ := strconv.Atoi(s.SampleRate)
+
+		data.Audio = append(data.Audio, meta.AudioStream{
+			Codec:      strings.ToLower(s.CodecName),
+			Channels:   s.Channels,
+			SampleRate: sampleRate,
+			Language:   s.Tags["language"],
+		})
+	}
+}
+
+// parseSeconds returns the duration of a decimal number of seconds.
+func parseSeconds(s string) time.Duration {
+	sec, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || sec <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Round(sec*1000)) * time.Millisecond
+}
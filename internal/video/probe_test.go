@@ -0,0 +1,95 @@
+package video
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProbe(t *testing.T, fileName string) Probe {
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ParseProbe(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestParseProbe(t *testing.T) {
+	t.Run("gopro.json", func(t *testing.T) {
+		p := testProbe(t, "testdata/gopro.json")
+
+		assert.Len(t, p.Streams, 4)
+		assert.Equal(t, 41041*time.Millisecond, p.Duration())
+
+		s, ok := p.TrackStream()
+
+		assert.True(t, ok)
+		assert.Equal(t, 3, s.Index)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseProbe([]byte("{"))
+		assert.Error(t, err)
+
+		_, err = ParseProbe([]byte("{}"))
+		assert.Error(t, err)
+	})
+}
+
+func TestProbe_Meta(t *testing.T) {
+	t.Run("gopro.json", func(t *testing.T) {
+		data := meta.NewData()
+		data.Duration = 41 * time.Second
+
+		testProbe(t, "testdata/gopro.json").Meta(&data)
+
+		assert.Equal(t, 41041*time.Millisecond, data.Duration)
+		assert.Equal(t, "avc1", data.Codec)
+		assert.Equal(t, 61507557, data.Bitrate)
+		assert.Equal(t, 119.88, data.FrameRate)
+		assert.Equal(t, 1920, data.Width)
+		assert.Equal(t, 1080, data.Height)
+		assert.Equal(t, 0, data.Rotation)
+		assert.Equal(t, []meta.AudioStream{{Codec: "aac", Channels: 2, SampleRate: 48000, Language: "eng"}}, data.Audio)
+	})
+	t.Run("iphone-hevc.json", func(t *testing.T) {
+		data := meta.NewData()
+
+		testProbe(t, "testdata/iphone-hevc.json").Meta(&data)
+
+		assert.Equal(t, 4*time.Second, data.Duration)
+		assert.Equal(t, "hvc1", data.Codec)
+		assert.Equal(t, 30.0, data.FrameRate)
+		assert.Equal(t, 90, data.Rotation)
+		assert.Len(t, data.Audio, 1)
+		assert.Equal(t, "4aa0a7d5-2f3b-4b0e-8d0a-2e6f3c4c5b11", data.ContentID)
+
+		_, ok := testProbe(t, "testdata/iphone-hevc.json").TrackStream()
+		assert.False(t, ok)
+	})
+}
+
+func TestProbeStream_Codec(t *testing.T) {
+	assert.Equal(t, "hevc", ProbeStream{CodecName: "hevc", CodecTag: "[0][0][0][0]"}.Codec())
+	assert.Equal(t, "avc1", ProbeStream{CodecName: "h264", CodecTag: "avc1"}.Codec())
+}
+
+func TestTrackCommand(t *testing.T) {
+	cmd := TrackCommand("/usr/bin/ffmpeg", "GOPR0533.MP4", ProbeStream{Index: 3, CodecType: "data", CodecTag: "gpmd"})
+
+	assert.Equal(t, []string{"/usr/bin/ffmpeg", "-v", "quiet", "-i", "GOPR0533.MP4", "-map", "0:3", "-codec", "copy", "-f", "rawvideo", "-"}, cmd.Args)
+
+	cmd = TrackCommand("/usr/bin/ffmpeg", "DJI_0001.MP4", ProbeStream{Index: 2, CodecType: "subtitle"})
+
+	assert.Equal(t, []string{"/usr/bin/ffmpeg", "-v", "quiet", "-i", "DJI_0001.MP4", "-map", "0:2", "-f", "srt", "-"}, cmd.Args)
+}
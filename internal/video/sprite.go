@@ -0,0 +1,54 @@
+package video
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	VttMimeType    = "text/vtt; charset=utf-8"
+	PosterInterval = 500 * time.Millisecond // Time between poster frame candidates.
+	PosterDuration = 10 * time.Second       // Poster frames are selected from the beginning of a video.
+	SpriteFrames   = 100                    // Maximum number of frames in a sprite sheet.
+	SpriteCols     = 10                     // Number of frames in a sprite sheet row.
+	SpriteInterval = 2 * time.Second        // Time between sprite frames if the video duration is unknown.
+)
+
+// SpriteFrameInterval returns the time between sprite frames so that the whole video is covered.
+func SpriteFrameInterval(duration time.Duration) time.Duration {
+	if duration <= 0 {
+		return SpriteInterval
+	}
+
+	interval := (duration/SpriteFrames + time.Second - 1).Truncate(time.Second)
+
+	if interval < time.Second {
+		return time.Second
+	}
+
+	return interval
+}
+
+// VttTimestamp returns the duration formatted as WebVTT timestamp.
+func VttTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// WebVTT returns a WebVTT thumbnail track with a cue for each frame in the sprite sheet.
+func WebVTT(spriteName string, frames, cols, width, height int, interval time.Duration) string {
+	var b strings.Builder
+
+	b.WriteString("WEBVTT\n")
+
+	for i := 0; i < frames; i++ {
+		start := time.Duration(i) * interval
+		x, y := (i%cols)*width, (i/cols)*height
+
+		fmt.Fprintf(&b, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n", VttTimestamp(start), VttTimestamp(start+interval), spriteName, x, y, width, height)
+	}
+
+	return b.String()
+}
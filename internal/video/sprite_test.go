@@ -0,0 +1,28 @@
+package video
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpriteFrameInterval(t *testing.T) {
+	assert.Equal(t, SpriteInterval, SpriteFrameInterval(0))
+	assert.Equal(t, time.Second, SpriteFrameInterval(30*time.Second))
+	assert.Equal(t, 4*time.Second, SpriteFrameInterval(301*time.Second))
+}
+
+func TestVttTimestamp(t *testing.T) {
+	assert.Equal(t, "00:00:00.000", VttTimestamp(0))
+	assert.Equal(t, "01:02:03.500", VttTimestamp(time.Hour+2*time.Minute+3500*time.Millisecond))
+}
+
+func TestWebVTT(t *testing.T) {
+	expected := "WEBVTT\n" +
+		"\n00:00:00.000 --> 00:00:02.000\nsprite.jpg#xywh=0,0,160,90\n" +
+		"\n00:00:02.000 --> 00:00:04.000\nsprite.jpg#xywh=160,0,160,90\n" +
+		"\n00:00:04.000 --> 00:00:06.000\nsprite.jpg#xywh=0,90,160,90\n"
+
+	assert.Equal(t, expected, WebVTT("sprite.jpg", 3, 2, 160, 90, 2*time.Second))
+}
@@ -0,0 +1,45 @@
+package video
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+var (
+	srtGpsRegexp = regexp.MustCompile(`GPS\s*\(\s*(-?[0-9.]+)\s*,\s*(-?[0-9.]+)\s*,\s*(-?[0-9.]+)`)
+	srtLatRegexp = regexp.MustCompile(`latitude\s*:\s*(-?[0-9.]+)`)
+	srtLngRegexp = regexp.MustCompile(`longt?itude\s*:\s*(-?[0-9.]+)`)
+	srtAltRegexp = regexp.MustCompile(`abs_alt\s*:\s*(-?[0-9.]+)`)
+)
+
+// ParseSRT returns the GPS track contained in the subtitles of DJI drone videos.
+func ParseSRT(s string) (track meta.Track) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	for _, block := range strings.Split(s, "\n\n") {
+		var p meta.TrackPoint
+
+		if m := srtGpsRegexp.FindStringSubmatch(block); m != nil {
+			// Older models use the format "GPS(longitude,latitude,altitude)".
+			p.Lng, _ = strconv.ParseFloat(m[1], 64)
+			p.Lat, _ = strconv.ParseFloat(m[2], 64)
+			p.Altitude, _ = strconv.ParseFloat(m[3], 64)
+		} else if lat, lng := srtLatRegexp.FindStringSubmatch(block), srtLngRegexp.FindStringSubmatch(block); lat != nil && lng != nil {
+			p.Lat, _ = strconv.ParseFloat(lat[1], 64)
+			p.Lng, _ = strconv.ParseFloat(lng[1], 64)
+
+			if alt := srtAltRegexp.FindStringSubmatch(block); alt != nil {
+				p.Altitude, _ = strconv.ParseFloat(alt[1], 64)
+			}
+		}
+
+		if p.Valid() {
+			track = append(track, p)
+		}
+	}
+
+	return track
+}
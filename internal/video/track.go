@@ -0,0 +1,71 @@
+package video
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// TrackStream returns the stream that may contain a GPS track, e.g. GoPro metadata or DJI subtitles.
+func (p Probe) TrackStream() (ProbeStream, bool) {
+	for _, s := range p.Streams {
+		if s.CodecTag == "gpmd" {
+			return s, true
+		}
+	}
+
+	for _, s := range p.Streams {
+		if s.CodecType == "subtitle" {
+			return s, true
+		}
+	}
+
+	return ProbeStream{}, false
+}
+
+// TrackCommand returns the command for extracting a metadata or subtitle stream.
+func TrackCommand(ffmpegBin, fileName string, s ProbeStream) *exec.Cmd {
+	stream := "0:" + strconv.Itoa(s.Index)
+
+	if s.CodecType == "subtitle" {
+		return exec.Command(ffmpegBin, "-v", "quiet", "-i", fileName, "-map", stream, "-f", "srt", "-")
+	}
+
+	return exec.Command(ffmpegBin, "-v", "quiet", "-i", fileName, "-map", stream, "-codec", "copy", "-f", "rawvideo", "-")
+}
+
+// ReadTrack returns the GPS track embedded in a video file, if any.
+func ReadTrack(ffmpegBin, fileName string, p Probe) (track meta.Track, err error) {
+	s, ok := p.TrackStream()
+
+	if !ok {
+		return track, nil
+	} else if ffmpegBin == "" {
+		return track, errors.New("ffmpeg not found")
+	}
+
+	cmd := TrackCommand(ffmpegBin, fileName, s)
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return track, errors.New(stderr.String())
+		} else {
+			return track, err
+		}
+	}
+
+	if s.CodecType == "subtitle" {
+		return ParseSRT(out.String()), nil
+	}
+
+	return ParseGPMF(out.Bytes())
+}
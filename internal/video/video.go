@@ -36,22 +36,45 @@ import (
 )
 
 type Type struct {
-	Format fs.FileType
-	Width  int
-	Height int
-	Public bool
+	Format    fs.FileType
+	Width     int
+	Height    int
+	Public    bool
+	Streaming bool
+	Sprite    bool
 }
 
 type TypeMap map[string]Type
 
 var TypeMP4 = Type{
-	Format: fs.TypeMp4,
-	Width:  0,
-	Height: 0,
+	Format:    fs.TypeMp4,
+	Width:     0,
+	Height:    0,
+	Public:    true,
+	Streaming: false,
+}
+
+// TypeHLS represents adaptive HTTP live streaming with multiple renditions.
+var TypeHLS = Type{
+	Format:    fs.TypeMp4,
+	Width:     0,
+	Height:    0,
+	Public:    true,
+	Streaming: true,
+}
+
+// TypeVTT represents a WebVTT thumbnail track referencing a sprite sheet with video frames.
+var TypeVTT = Type{
+	Format: fs.TypeJpeg,
+	Width:  160,
+	Height: 90,
 	Public: true,
+	Sprite: true,
 }
 
 var Types = TypeMap{
 	"":    TypeMP4,
 	"mp4": TypeMP4,
+	"hls": TypeHLS,
+	"vtt": TypeVTT,
 }
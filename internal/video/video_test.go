@@ -10,4 +10,8 @@ func TestTypes(t *testing.T) {
 	if val := Types["mp4"]; val != TypeMP4 {
 		t.Fatal("mp4 type should be TypeMP4")
 	}
+
+	if val := Types["hls"]; val != TypeHLS {
+		t.Fatal("hls type should be TypeHLS")
+	}
 }
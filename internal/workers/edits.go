@@ -0,0 +1,76 @@
+package workers
+
+import (
+	"fmt"
+
+	"github.com/leandro-lugaresi/hub"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// EditTopic is the event published after a photo edit was saved, its data contains the photo "uid".
+const EditTopic = "edits.saved"
+
+// Edits represents a worker that renders the thumbnails of edited photos.
+type Edits struct {
+	conf *config.Config
+}
+
+// NewEdits returns a new edits worker.
+func NewEdits(conf *config.Config) *Edits {
+	return &Edits{conf: conf}
+}
+
+// logError logs an error message if err is not nil.
+func (worker *Edits) logError(err error) {
+	if err != nil {
+		log.Errorf("edit: %s", err.Error())
+	}
+}
+
+// Run renders the thumbnails of photos edited in the events received by the subscription,
+// one photo at a time, until the subscription is closed.
+func (worker *Edits) Run(s hub.Subscription) {
+	for msg := range s.Receiver {
+		if uid, ok := msg.Fields["uid"].(string); ok {
+			worker.logError(worker.Render(uid))
+		}
+	}
+}
+
+// Render replaces the cached thumbnails and downloads of a photo, so that they show its current edit.
+func (worker *Edits) Render(photoUID string) error {
+	m, err := query.PhotoByUID(photoUID)
+
+	if err != nil {
+		return fmt.Errorf("photo %s not found", txt.Quote(photoUID))
+	}
+
+	thumbs := photoprism.NewThumbCache(worker.conf)
+
+	for _, file := range m.AllFiles() {
+		if file.Missing() || file.NoJPEG() {
+			continue
+		}
+
+		if _, err := thumbs.Remove(file.FileHash); err != nil {
+			worker.logError(err)
+		}
+
+		worker.logError(photoprism.RemoveEditedFiles(file.FileHash))
+
+		fileName := photoprism.FileName(file.FileRoot, file.FileName)
+
+		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
+			worker.logError(fmt.Errorf("%s in %s", err, txt.Quote(file.FileName)))
+		} else if err := mf.ResampleDefault(worker.conf.ThumbPath(), false); err != nil {
+			worker.logError(fmt.Errorf("%s in %s", err, txt.Quote(file.FileName)))
+		}
+	}
+
+	log.Infof("edit: rendered thumbnails of %s", txt.Quote(photoUID))
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdits_Render(t *testing.T) {
+	worker := NewEdits(config.TestConfig())
+
+	t.Run("photo", func(t *testing.T) {
+		assert.NoError(t, worker.Render("pt9jtdre2lvl0y13"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Error(t, worker.Render("xxx"))
+	})
+}
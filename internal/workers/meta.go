@@ -46,6 +46,13 @@ func (worker *Meta) Start() (err error) {
 
 	log.Debugf("metadata: starting routine check")
 
+	// Add photos that were indexed before full-text search was available.
+	if count, err := entity.IndexAllPhotoTerms(false); err != nil {
+		log.Errorf("metadata: %s (index photo terms)", err)
+	} else if count > 0 {
+		log.Infof("metadata: updated full-text index of %d photos", count)
+	}
+
 	done := make(map[string]bool)
 
 	limit := 50
@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
 
@@ -61,7 +60,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -77,7 +76,15 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Backend()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
+		defer client.Close()
+
 		existingDirs := make(map[string]string)
 
 		for _, file := range files {
@@ -99,12 +106,12 @@ func (worker *Share) Start() (err error) {
 			if a.ShareSize != "" {
 				thumbType, ok := thumb.Types[a.ShareSize]
 
-				if !ok {
+				if !ok || thumbType.Sprite() {
 					log.Errorf("share: invalid size %s", a.ShareSize)
 					continue
 				}
 
-				srcFileName, err = thumb.FromFile(srcFileName, file.File.FileHash, worker.conf.ThumbPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+				srcFileName, err = thumb.FromEditedFile(srcFileName, file.File.FileHash, worker.conf.ThumbPath(), photoprism.FileEdit(file.File.FileHash), thumbType.Width, thumbType.Height, thumbType.Options...)
 
 				if err != nil {
 					worker.logError(err)
@@ -141,7 +148,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -157,7 +164,14 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Backend()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
+		defer client.Close()
 
 		for _, file := range files {
 			if mutex.ShareWorker.Canceled() {
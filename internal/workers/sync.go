@@ -40,8 +40,8 @@ func (worker *Sync) logWarn(err error) {
 	}
 }
 
-// Start starts the sync worker.
-func (worker *Sync) Start() (err error) {
+// Start syncs the given accounts or, if none are given, all accounts with sync enabled.
+func (worker *Sync) Start(accounts ...entity.Account) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("sync: %s (panic)\nstack: %s", r, debug.Stack())
@@ -55,14 +55,16 @@ func (worker *Sync) Start() (err error) {
 
 	defer mutex.SyncWorker.Stop()
 
-	f := form.AccountSearch{
-		Sync: true,
-	}
+	if len(accounts) == 0 {
+		f := form.AccountSearch{
+			Sync: true,
+		}
 
-	accounts, err := query.AccountSearch(f)
+		accounts, err = query.AccountSearch(f)
+	}
 
 	for _, a := range accounts {
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
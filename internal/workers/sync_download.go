@@ -3,13 +3,13 @@ package workers
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -48,18 +48,55 @@ func (worker *Sync) relatedDownloads(a entity.Account) (result Downloads, err er
 	return result, nil
 }
 
+// linkDownloads records the indexed files of downloads, so that changes on either side
+// can be detected by comparing with the downloaded version.
+func (worker *Sync) linkDownloads(downloads []entity.FileSync) {
+	for _, s := range downloads {
+		if s.LocalHash == "" {
+			continue
+		}
+
+		file, err := query.FileByHash(s.LocalHash)
+
+		if err != nil {
+			log.Debugf("sync: %s was not indexed", s.RemoteName)
+			continue
+		}
+
+		worker.logError(s.Update("FileID", file.ID))
+	}
+}
+
 // Downloads remote files in batches and imports / indexes them
 func (worker *Sync) download(a entity.Account) (complete bool, err error) {
+	var wg sync.WaitGroup
+	var downloads []entity.FileSync
+
+	wg.Add(2)
+
 	// Set up index worker
 	indexJobs := make(chan photoprism.IndexJob)
 
-	go photoprism.IndexWorker(indexJobs)
-	defer close(indexJobs)
+	go func() {
+		defer wg.Done()
+		photoprism.IndexWorker(indexJobs)
+	}()
 
 	// Set up import worker
 	importJobs := make(chan photoprism.ImportJob)
-	go photoprism.ImportWorker(importJobs)
-	defer close(importJobs)
+
+	go func() {
+		defer wg.Done()
+		photoprism.ImportWorker(importJobs)
+	}()
+
+	// Downloaded files are linked once all of them were imported or indexed.
+	defer func() {
+		close(indexJobs)
+		close(importJobs)
+		wg.Wait()
+		worker.linkDownloads(downloads)
+	}()
 
 	relatedFiles, err := worker.relatedDownloads(a)
 
@@ -76,7 +113,13 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Backend()
+
+	if err != nil {
+		return false, err
+	}
+
+	defer client.Close()
 
 	var baseDir string
 
@@ -112,6 +155,7 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 				} else {
 					log.Infof("sync: downloaded %s from %s", file.RemoteName, a.AccName)
 					file.Status = entity.FileSyncDownloaded
+					file.LocalHash = fs.Hash(localName)
 				}
 
 				if mutex.SyncWorker.Canceled() {
@@ -123,6 +167,10 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 				worker.logError(err)
 			} else {
 				files[i] = file
+
+				if file.Status == entity.FileSyncDownloaded {
+					downloads = append(downloads, file)
+				}
 			}
 		}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,36 @@ func TestSync_relatedDownloads(t *testing.T) {
 		assert.IsType(t, Downloads{}, result)
 	}
 }
+
+func TestSync_linkDownloads(t *testing.T) {
+	worker := NewSync(config.TestConfig())
+
+	file, err := query.FileByHash("acad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := entity.NewFileSync(1000001, "/link/reunion.jpg")
+	s.Status = entity.FileSyncDownloaded
+	s.LocalHash = file.FileHash
+
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Delete()
+
+	worker.linkDownloads([]entity.FileSync{*s})
+
+	result, err := query.FileSyncs(1000001, entity.FileSyncDownloaded, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, file.ID, result[0].FileID)
+		assert.Equal(t, file.FileHash, result[0].LocalHash)
+	}
+}
@@ -0,0 +1,235 @@
+package workers
+
+import (
+	"path"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Sync actions, see SyncAction.
+const (
+	SyncDownload     = "download"
+	SyncUpload       = "upload"
+	SyncUpdateLocal  = "update-local"
+	SyncUpdateRemote = "update-remote"
+	SyncDeleteLocal  = "delete-local"
+	SyncDeleteRemote = "delete-remote"
+	SyncConflict     = "conflict"
+	SyncForget       = "forget"
+)
+
+// SyncAction represents a single change that is performed when syncing an account.
+type SyncAction struct {
+	Action     string `json:"Action"`
+	RemoteName string `json:"RemoteName"`
+	FileName   string `json:"FileName,omitempty"`
+	FileID     uint   `json:"FileID,omitempty"`
+	Reason     string `json:"Reason"`
+}
+
+// SyncPlan represents all changes that are performed when syncing an account.
+type SyncPlan []SyncAction
+
+// Count returns the number of planned actions by type.
+func (p SyncPlan) Count() map[string]int {
+	result := make(map[string]int)
+
+	for _, a := range p {
+		result[a.Action]++
+	}
+
+	return result
+}
+
+// syncMedia tests if a remote file should be downloaded.
+func syncMedia(a entity.Account, fileName string) bool {
+	switch fs.GetMediaType(fileName) {
+	case fs.MediaImage, fs.MediaSidecar:
+		return true
+	case fs.MediaRaw, fs.MediaVideo:
+		return a.SyncRaw
+	}
+
+	return false
+}
+
+// planSync compares remote files, previously synced files and local files to find changes.
+func planSync(a entity.Account, remoteFiles fs.FileInfos, syncs []entity.FileSync, files map[uint]entity.File, uploads entity.Files) (result SyncPlan) {
+	remoteByName := make(map[string]fs.FileInfo, len(remoteFiles))
+	known := make(map[string]bool, len(syncs))
+
+	for _, info := range remoteFiles {
+		remoteByName[info.Abs] = info
+	}
+
+	for _, s := range syncs {
+		known[s.RemoteName] = true
+
+		if s.Status == entity.FileSyncNew {
+			if _, ok := remoteByName[s.RemoteName]; ok && a.SyncDownload {
+				result = append(result, SyncAction{Action: SyncDownload, RemoteName: s.RemoteName, Reason: "new remote file"})
+			}
+
+			continue
+		}
+
+		// Only files that exist on both sides after syncing are compared.
+		if !a.TwoWay() || s.FileID == 0 || s.Status != entity.FileSyncDownloaded && s.Status != entity.FileSyncUploaded {
+			continue
+		}
+
+		info, remoteExists := remoteByName[s.RemoteName]
+		file, localExists := files[s.FileID]
+		localExists = localExists && !file.Missing()
+
+		action := SyncAction{RemoteName: s.RemoteName, FileID: s.FileID, FileName: file.FileName}
+
+		switch {
+		case !remoteExists && !localExists:
+			action.Action, action.Reason = SyncForget, "deleted on both sides"
+		case !remoteExists && s.LocalChanged(file):
+			action.Action, action.Reason = SyncUpdateRemote, "changed locally, deleted remotely"
+		case !remoteExists && a.SyncDelete:
+			action.Action, action.Reason = SyncDeleteLocal, "deleted remotely"
+		case !remoteExists:
+			action.Action, action.Reason = SyncUpdateRemote, "deleted remotely, restoring"
+		case !localExists && s.RemoteChanged(info):
+			action.Action, action.Reason = SyncDownload, "changed remotely, deleted locally"
+		case !localExists && a.SyncDelete:
+			action.Action, action.Reason = SyncDeleteRemote, "deleted locally"
+		case !localExists:
+			action.Action, action.Reason = SyncDownload, "deleted locally, restoring"
+		case s.LocalChanged(file) && s.RemoteChanged(info):
+			action.Action, action.Reason = SyncConflict, "changed on both sides"
+		case s.LocalChanged(file):
+			action.Action, action.Reason = SyncUpdateRemote, "changed locally"
+		case s.RemoteChanged(info):
+			action.Action, action.Reason = SyncUpdateLocal, "changed remotely"
+		default:
+			continue
+		}
+
+		result = append(result, action)
+	}
+
+	if a.SyncDownload {
+		for _, info := range remoteFiles {
+			if !known[info.Abs] && syncMedia(a, info.Name) {
+				result = append(result, SyncAction{Action: SyncDownload, RemoteName: info.Abs, Reason: "new remote file"})
+			}
+		}
+	}
+
+	if a.SyncUpload {
+		for _, file := range uploads {
+			result = append(result, SyncAction{
+				Action:     SyncUpload,
+				RemoteName: path.Join(a.SyncPath, file.FileName),
+				FileName:   file.FileName,
+				FileID:     file.ID,
+				Reason:     "new local file",
+			})
+		}
+	}
+
+	return result
+}
+
+// remoteFiles returns all files in the sync path of an account.
+func (worker *Sync) remoteFiles(a entity.Account, client remote.Backend) (result fs.FileInfos, err error) {
+	subDirs, err := client.Directories(a.SyncPath, true, remote.AsyncTimeout)
+
+	if err != nil {
+		return result, err
+	}
+
+	dirs := append(subDirs.Abs(), a.SyncPath)
+
+	for _, dir := range dirs {
+		if mutex.SyncWorker.Canceled() {
+			return result, nil
+		}
+
+		files, err := client.Files(dir)
+
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, files...)
+	}
+
+	return result, nil
+}
+
+// localFiles returns the indexed files that were previously synced, including deleted files.
+func localFiles(syncs []entity.FileSync) (map[uint]entity.File, error) {
+	var ids []uint
+
+	for _, s := range syncs {
+		if s.FileID > 0 {
+			ids = append(ids, s.FileID)
+		}
+	}
+
+	result := make(map[uint]entity.File, len(ids))
+
+	files, err := query.FilesByID(ids)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		result[file.ID] = file
+	}
+
+	return result, nil
+}
+
+// Plan returns the changes the next sync of an account would perform without changing anything.
+func (worker *Sync) Plan(a entity.Account) (result SyncPlan, err error) {
+	if !remote.Supported(a.AccType) {
+		return result, remote.ErrUnsupported
+	}
+
+	client, err := a.Backend()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer client.Close()
+
+	remoteFiles, err := worker.remoteFiles(a, client)
+
+	if err != nil {
+		return result, err
+	}
+
+	syncs, err := query.FileSyncs(a.ID, "", 0)
+
+	if err != nil {
+		return result, err
+	}
+
+	files, err := localFiles(syncs)
+
+	if err != nil {
+		return result, err
+	}
+
+	var uploads entity.Files
+
+	if a.SyncUpload {
+		if uploads, err = query.AccountUploads(a, 0); err != nil {
+			return result, err
+		}
+	}
+
+	return planSync(a, remoteFiles, syncs, files, uploads), nil
+}
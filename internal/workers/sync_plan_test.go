@@ -0,0 +1,128 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSync(t *testing.T) {
+	date := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	account := entity.Account{ID: 1, SyncPath: "/", SyncDownload: true, SyncUpload: true}
+
+	synced := func(name string, fileID uint) entity.FileSync {
+		return entity.FileSync{RemoteName: name, AccountID: 1, FileID: fileID, Status: entity.FileSyncDownloaded, RemoteDate: date, RemoteSize: 100, LocalHash: "abc"}
+	}
+
+	remoteFile := func(name string, size int64) fs.FileInfo {
+		return fs.FileInfo{Name: name[1:], Abs: name, Size: size, Date: date}
+	}
+
+	plan := func(a entity.Account, remoteFiles fs.FileInfos, syncs []entity.FileSync, files map[uint]entity.File) map[string]string {
+		result := make(map[string]string)
+
+		for _, action := range planSync(a, remoteFiles, syncs, files, nil) {
+			result[action.RemoteName] = action.Action
+		}
+
+		return result
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		result := plan(account, fs.FileInfos{remoteFile("/a.jpg", 100)}, []entity.FileSync{synced("/a.jpg", 1)}, map[uint]entity.File{1: {ID: 1, FileHash: "abc"}})
+
+		assert.Empty(t, result)
+	})
+	t.Run("changed", func(t *testing.T) {
+		remoteFiles := fs.FileInfos{remoteFile("/local.jpg", 100), remoteFile("/remote.jpg", 200), remoteFile("/both.jpg", 200)}
+		syncs := []entity.FileSync{synced("/local.jpg", 1), synced("/remote.jpg", 2), synced("/both.jpg", 3)}
+		files := map[uint]entity.File{1: {ID: 1, FileHash: "def"}, 2: {ID: 2, FileHash: "abc"}, 3: {ID: 3, FileHash: "def"}}
+
+		result := plan(account, remoteFiles, syncs, files)
+
+		assert.Equal(t, SyncUpdateRemote, result["/local.jpg"])
+		assert.Equal(t, SyncUpdateLocal, result["/remote.jpg"])
+		assert.Equal(t, SyncConflict, result["/both.jpg"])
+	})
+	t.Run("deleted", func(t *testing.T) {
+		deleted := time.Now()
+		remoteFiles := fs.FileInfos{remoteFile("/local.jpg", 100)}
+		syncs := []entity.FileSync{synced("/local.jpg", 1), synced("/remote.jpg", 2), synced("/both.jpg", 3)}
+		files := map[uint]entity.File{1: {ID: 1, FileHash: "abc", DeletedAt: &deleted}, 2: {ID: 2, FileHash: "abc"}}
+
+		result := plan(account, remoteFiles, syncs, files)
+
+		assert.Equal(t, SyncDownload, result["/local.jpg"])
+		assert.Equal(t, SyncUpdateRemote, result["/remote.jpg"])
+		assert.Equal(t, SyncForget, result["/both.jpg"])
+
+		deletions := account
+		deletions.SyncDelete = true
+
+		result = plan(deletions, remoteFiles, syncs, files)
+
+		assert.Equal(t, SyncDeleteRemote, result["/local.jpg"])
+		assert.Equal(t, SyncDeleteLocal, result["/remote.jpg"])
+		assert.Equal(t, SyncForget, result["/both.jpg"])
+	})
+	t.Run("one way", func(t *testing.T) {
+		downloads := account
+		downloads.SyncUpload = false
+
+		result := plan(downloads, fs.FileInfos{remoteFile("/a.jpg", 200)}, []entity.FileSync{synced("/a.jpg", 1)}, map[uint]entity.File{1: {ID: 1, FileHash: "def"}})
+
+		assert.Empty(t, result)
+	})
+	t.Run("new files", func(t *testing.T) {
+		remoteFiles := fs.FileInfos{remoteFile("/new.jpg", 100), remoteFile("/setup.exe", 10), remoteFile("/queued.jpg", 100)}
+		syncs := []entity.FileSync{{RemoteName: "/queued.jpg", AccountID: 1, Status: entity.FileSyncNew}}
+		uploads := entity.Files{{ID: 5, FileName: "2020/10/upload.jpg"}}
+
+		result := planSync(account, remoteFiles, syncs, nil, uploads)
+
+		assert.Equal(t, SyncPlan{
+			{Action: SyncDownload, RemoteName: "/queued.jpg", Reason: "new remote file"},
+			{Action: SyncDownload, RemoteName: "/new.jpg", Reason: "new remote file"},
+			{Action: SyncUpload, RemoteName: "/2020/10/upload.jpg", FileName: "2020/10/upload.jpg", FileID: 5, Reason: "new local file"},
+		}, result)
+		assert.Equal(t, map[string]int{SyncDownload: 2, SyncUpload: 1}, result.Count())
+	})
+}
+
+func TestConflictName(t *testing.T) {
+	date := time.Date(2020, 11, 2, 15, 30, 12, 0, time.UTC)
+
+	assert.Equal(t, "/photos/IMG_1234-conflict-20201102-153012.jpg", conflictName("/photos/IMG_1234.jpg", date))
+	assert.Equal(t, "/photos/README-conflict-20201102-153012", conflictName("/photos/README", date))
+}
+
+func TestBaseline(t *testing.T) {
+	date := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	remoteByName := map[string]fs.FileInfo{"/a.jpg": {Abs: "/a.jpg", Size: 100, Date: date, ETag: "xyz"}}
+	files := map[uint]entity.File{1: {ID: 1, FileHash: "abc"}}
+
+	t.Run("untracked", func(t *testing.T) {
+		s := entity.FileSync{RemoteName: "/a.jpg", FileID: 1, Status: entity.FileSyncUploaded}
+		values := baseline(&s, remoteByName, files)
+
+		assert.Equal(t, "abc", s.LocalHash)
+		assert.Equal(t, "xyz", s.RemoteETag)
+		assert.Equal(t, int64(100), s.RemoteSize)
+		assert.Len(t, values, 4)
+	})
+	t.Run("tracked", func(t *testing.T) {
+		s := entity.FileSync{RemoteName: "/a.jpg", FileID: 1, Status: entity.FileSyncDownloaded, LocalHash: "def", RemoteDate: date, RemoteSize: 100, RemoteETag: "uvw"}
+		values := baseline(&s, remoteByName, files)
+
+		assert.Empty(t, values)
+		assert.Equal(t, "def", s.LocalHash)
+	})
+	t.Run("new", func(t *testing.T) {
+		s := entity.FileSync{RemoteName: "/a.jpg", Status: entity.FileSyncNew}
+
+		assert.Empty(t, baseline(&s, remoteByName, files))
+	})
+}
@@ -0,0 +1,200 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// conflictName returns the file name for keeping a conflicting version, e.g. "IMG_1234-conflict-20201102-153012.jpg".
+func conflictName(fileName string, t time.Time) string {
+	ext := filepath.Ext(fileName)
+
+	return fmt.Sprintf("%s-conflict-%s%s", strings.TrimSuffix(fileName, ext), t.Format("20060102-150405"), ext)
+}
+
+// baseline records the versions of files that were synced before their state was tracked.
+func baseline(s *entity.FileSync, remoteByName map[string]fs.FileInfo, files map[uint]entity.File) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	if s.FileID == 0 || s.Status != entity.FileSyncDownloaded && s.Status != entity.FileSyncUploaded {
+		return values
+	}
+
+	if file, ok := files[s.FileID]; ok && !file.Missing() && s.LocalHash == "" {
+		s.LocalHash = file.FileHash
+		values["LocalHash"] = s.LocalHash
+	}
+
+	info, ok := remoteByName[s.RemoteName]
+
+	if !ok {
+		return values
+	}
+
+	if s.RemoteDate.IsZero() {
+		s.RemoteDate, s.RemoteSize, s.RemoteETag = info.Date, info.Size, info.ETag
+		values["RemoteDate"], values["RemoteSize"], values["RemoteETag"] = info.Date, info.Size, info.ETag
+	} else if s.RemoteETag == "" && info.ETag != "" && !s.RemoteChanged(info) {
+		s.RemoteETag = info.ETag
+		values["RemoteETag"] = info.ETag
+	}
+
+	return values
+}
+
+// synced updates the versions of a file after syncing.
+func synced(s entity.FileSync, localHash string, info fs.FileInfo) error {
+	return s.Updates(map[string]interface{}{
+		"LocalHash":  localHash,
+		"RemoteDate": info.Date,
+		"RemoteSize": info.Size,
+		"RemoteETag": info.ETag,
+		"Error":      "",
+		"Errors":     0,
+	})
+}
+
+// reconcile applies changes to previously synced files in both directions,
+// new files are handled by the download and upload phases.
+func (worker *Sync) reconcile(a entity.Account, client remote.Backend, remoteFiles fs.FileInfos) error {
+	syncs, err := query.FileSyncs(a.ID, "", 0)
+
+	if err != nil {
+		return err
+	}
+
+	files, err := localFiles(syncs)
+
+	if err != nil {
+		return err
+	}
+
+	remoteByName := make(map[string]fs.FileInfo, len(remoteFiles))
+
+	for _, info := range remoteFiles {
+		remoteByName[info.Abs] = info
+	}
+
+	syncByName := make(map[string]entity.FileSync, len(syncs))
+
+	for i := range syncs {
+		if values := baseline(&syncs[i], remoteByName, files); len(values) > 0 {
+			worker.logError(syncs[i].Updates(values))
+		}
+
+		syncByName[syncs[i].RemoteName] = syncs[i]
+	}
+
+	for _, action := range planSync(a, remoteFiles, syncs, files, nil) {
+		if mutex.SyncWorker.Canceled() {
+			return nil
+		}
+
+		s, ok := syncByName[action.RemoteName]
+
+		if !ok || s.Status == entity.FileSyncNew {
+			continue
+		}
+
+		file := files[s.FileID]
+		info := remoteByName[s.RemoteName]
+		localName := photoprism.FileName(file.FileRoot, file.FileName)
+
+		switch action.Action {
+		case SyncDownload:
+			// Downloaded again and imported by the download phase.
+			err = s.Updates(map[string]interface{}{"Status": entity.FileSyncNew, "FileID": 0, "LocalHash": ""})
+		case SyncUpdateLocal:
+			err = worker.updateLocal(client, s, localName, info)
+		case SyncUpdateRemote:
+			err = worker.updateRemote(client, s, localName, file.FileHash)
+		case SyncConflict:
+			err = worker.conflict(client, s, localName, file.FileHash)
+		case SyncDeleteLocal:
+			if err = os.Remove(localName); err == nil || os.IsNotExist(err) {
+				if err = file.Purge(); err == nil {
+					err = s.Delete()
+				}
+			}
+		case SyncDeleteRemote:
+			if err = client.Delete(s.RemoteName); err == nil {
+				err = s.Delete()
+			}
+		case SyncForget:
+			err = s.Delete()
+		}
+
+		if err != nil {
+			worker.logError(fmt.Errorf("%s %s failed, %s", action.Action, txt.Quote(s.RemoteName), err))
+			worker.logError(s.Updates(map[string]interface{}{"Error": err.Error(), "Errors": s.Errors + 1}))
+		} else {
+			log.Infof("sync: %s %s (%s)", action.Action, txt.Quote(s.RemoteName), action.Reason)
+		}
+	}
+
+	return nil
+}
+
+// updateLocal replaces a local file with the remote version.
+func (worker *Sync) updateLocal(client remote.Backend, s entity.FileSync, localName string, info fs.FileInfo) error {
+	if err := client.Download(s.RemoteName, localName, true); err != nil {
+		return err
+	}
+
+	if res := service.Index().SingleFile(localName); res.Failed() {
+		return res.Err
+	}
+
+	return synced(s, fs.Hash(localName), info)
+}
+
+// updateRemote replaces a remote file with the local version.
+func (worker *Sync) updateRemote(client remote.Backend, s entity.FileSync, localName, localHash string) error {
+	if err := client.CreateDir(path.Dir(s.RemoteName)); err != nil {
+		return err
+	}
+
+	if err := client.Upload(localName, s.RemoteName); err != nil {
+		return err
+	}
+
+	info, err := client.Stat(s.RemoteName)
+
+	if err != nil {
+		return err
+	}
+
+	return synced(s, localHash, info)
+}
+
+// conflict keeps both versions of a file that was changed on both sides: the remote
+// version is stored next to the local file with a suffix and the local version replaces
+// the remote file. The copy is uploaded with the next upload phase.
+func (worker *Sync) conflict(client remote.Backend, s entity.FileSync, localName, localHash string) error {
+	copyName := conflictName(localName, time.Now())
+
+	if err := client.Download(s.RemoteName, copyName, false); err != nil {
+		return err
+	}
+
+	if res := service.Index().SingleFile(copyName); res.Failed() {
+		return res.Err
+	}
+
+	log.Warnf("sync: conflict, remote version of %s saved as %s", txt.Quote(s.RemoteName), txt.Quote(filepath.Base(copyName)))
+
+	return worker.updateRemote(client, s, localName, localHash)
+}
@@ -4,79 +4,84 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.Supported(a.AccType) {
 		return false, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
-
-	subDirs, err := client.Directories(a.SyncPath, true, webdav.AsyncTimeout)
+	client, err := a.Backend()
 
 	if err != nil {
-		log.Error(err)
 		return false, err
 	}
 
-	dirs := append(subDirs.Abs(), a.SyncPath)
+	defer client.Close()
 
-	for _, dir := range dirs {
-		if mutex.SyncWorker.Canceled() {
-			return false, nil
-		}
+	remoteFiles, err := worker.remoteFiles(a, client)
+
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
 
-		files, err := client.Files(dir)
+	if mutex.SyncWorker.Canceled() {
+		return false, nil
+	}
 
-		if err != nil {
+	// Apply changes to previously synced files first
+	if a.TwoWay() {
+		if err := worker.reconcile(a, client, remoteFiles); err != nil {
 			log.Error(err)
 			return false, err
 		}
+	}
 
-		for _, file := range files {
-			if mutex.SyncWorker.Canceled() {
-				return false, nil
-			}
+	for _, file := range remoteFiles {
+		if mutex.SyncWorker.Canceled() {
+			return false, nil
+		}
 
-			f := entity.NewFileSync(a.ID, file.Abs)
+		f := entity.NewFileSync(a.ID, file.Abs)
 
-			f.Status = entity.FileSyncIgnore
-			f.RemoteDate = file.Date
-			f.RemoteSize = file.Size
+		f.Status = entity.FileSyncIgnore
+		f.RemoteDate = file.Date
+		f.RemoteSize = file.Size
+		f.RemoteETag = file.ETag
 
-			// Select supported types for download
-			mediaType := fs.GetMediaType(file.Name)
-			switch mediaType {
-			case fs.MediaImage, fs.MediaSidecar:
+		// Select supported types for download
+		mediaType := fs.GetMediaType(file.Name)
+		switch mediaType {
+		case fs.MediaImage, fs.MediaSidecar:
+			f.Status = entity.FileSyncNew
+		case fs.MediaRaw, fs.MediaVideo:
+			if a.SyncRaw {
 				f.Status = entity.FileSyncNew
-			case fs.MediaRaw, fs.MediaVideo:
-				if a.SyncRaw {
-					f.Status = entity.FileSyncNew
-				}
 			}
+		}
 
-			f = entity.FirstOrCreateFileSync(f)
+		f = entity.FirstOrCreateFileSync(f)
 
-			if f == nil {
-				log.Errorf("sync: file sync entity should not be nil - bug?")
-				continue
-			}
+		if f == nil {
+			log.Errorf("sync: file sync entity should not be nil - bug?")
+			continue
+		}
 
-			if f.Status == entity.FileSyncIgnore && a.SyncRaw && (mediaType == fs.MediaRaw || mediaType == fs.MediaVideo) {
-				worker.logError(f.Update("Status", entity.FileSyncNew))
-			}
+		if f.Status == entity.FileSyncIgnore && a.SyncRaw && (mediaType == fs.MediaRaw || mediaType == fs.MediaVideo) {
+			worker.logError(f.Update("Status", entity.FileSyncNew))
+		}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
-				worker.logError(f.Updates(map[string]interface{}{
-					"Status":     entity.FileSyncNew,
-					"RemoteDate": file.Date,
-					"RemoteSize": file.Size,
-				}))
-			}
+		// Changed files are downloaded again unless they are synced in both directions
+		if !a.TwoWay() && f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			worker.logError(f.Updates(map[string]interface{}{
+				"Status":     entity.FileSyncNew,
+				"RemoteDate": file.Date,
+				"RemoteSize": file.Size,
+				"RemoteETag": file.ETag,
+			}))
 		}
 	}
 
@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 )
 
 // Uploads local files to a remote account
@@ -32,7 +31,14 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		return true, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Backend()
+
+	if err != nil {
+		return false, err
+	}
+
+	defer client.Close()
+
 	existingDirs := make(map[string]string)
 
 	for _, file := range files {
@@ -63,6 +69,14 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		fileSync.RemoteDate = time.Now()
 		fileSync.RemoteSize = file.FileSize
 		fileSync.FileID = file.ID
+		fileSync.LocalHash = file.FileHash
+
+		// Remember the remote version to detect changes
+		if info, err := client.Stat(remoteName); err == nil {
+			fileSync.RemoteDate = info.Date
+			fileSync.RemoteSize = info.Size
+			fileSync.RemoteETag = info.ETag
+		}
 		fileSync.Error = ""
 		fileSync.Errors = 0
 
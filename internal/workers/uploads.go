@@ -0,0 +1,107 @@
+package workers
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// uploadImports maps the import folders of completed uploads that have not been imported yet to their owner.
+var uploadImports = make(map[string]string)
+var uploadImportsMutex = sync.Mutex{}
+var uploadImportsBusy = mutex.Busy{}
+
+// ImportUpload enqueues the import folder of a completed upload and starts importing it
+// unless another import is running, pending folders are retried by the upload worker.
+// The owner is the UID of the user who uploaded the files.
+func ImportUpload(conf *config.Config, dir, owner string) {
+	uploadImportsMutex.Lock()
+	uploadImports[dir] = owner
+	uploadImportsMutex.Unlock()
+
+	go ImportUploads(conf)
+}
+
+// pendingUploadImports returns the sorted import folders of completed uploads.
+func pendingUploadImports() (dirs []string) {
+	uploadImportsMutex.Lock()
+	defer uploadImportsMutex.Unlock()
+
+	for dir := range uploadImports {
+		dirs = append(dirs, dir)
+	}
+
+	sort.Strings(dirs)
+
+	return dirs
+}
+
+// uploadOwner returns the user who uploaded the files in an import folder, or nil if unknown.
+func uploadOwner(dir string) *entity.User {
+	uploadImportsMutex.Lock()
+	owner := uploadImports[dir]
+	uploadImportsMutex.Unlock()
+
+	if owner == "" {
+		return nil
+	}
+
+	return entity.FindUserByUID(owner)
+}
+
+// dequeueUploadImport removes an import folder from the queue.
+func dequeueUploadImport(dir string) {
+	uploadImportsMutex.Lock()
+	delete(uploadImports, dir)
+	uploadImportsMutex.Unlock()
+}
+
+// ImportUploads moves completed uploads from their import folders to originals.
+func ImportUploads(conf *config.Config) {
+	if err := uploadImportsBusy.Start(); err != nil {
+		return
+	}
+
+	defer uploadImportsBusy.Stop()
+
+	for dirs := pendingUploadImports(); len(dirs) > 0; dirs = pendingUploadImports() {
+		for _, dir := range dirs {
+			// Imports started by users or the indexer have priority, the next wakeup retries.
+			if mutex.MainWorker.Busy() {
+				return
+			}
+
+			if !fs.PathExists(dir) {
+				dequeueUploadImport(dir)
+				continue
+			}
+
+			log.Infof("upload: importing %s", txt.Quote(fs.RelName(dir, conf.ImportPath())))
+
+			done := service.Import().Start(photoprism.ImportOptionsUpload(dir, uploadOwner(dir)))
+
+			if fs.IsEmpty(dir) {
+				if err := os.Remove(dir); err != nil {
+					log.Warnf("upload: %s", err)
+				}
+			} else if len(done) == 0 {
+				// Nothing was imported if another worker started in the meantime.
+				return
+			}
+
+			dequeueUploadImport(dir)
+
+			event.Publish("import.completed", event.Data{"path": dir})
+			event.Publish("index.completed", event.Data{"path": dir})
+		}
+	}
+}
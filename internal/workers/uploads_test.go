@@ -0,0 +1,43 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportUploads(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("not found", func(t *testing.T) {
+		uploadImportsMutex.Lock()
+		uploadImports["/xxx/upload/uqxetse3cy5eo9z2"] = "ut9lxuqxkkvd1001"
+		uploadImportsMutex.Unlock()
+
+		assert.Equal(t, []string{"/xxx/upload/uqxetse3cy5eo9z2"}, pendingUploadImports())
+
+		ImportUploads(conf)
+
+		assert.Empty(t, pendingUploadImports())
+	})
+}
+
+func TestUploadOwner(t *testing.T) {
+	uploadImportsMutex.Lock()
+	uploadImports["/xxx/upload/alice"] = "ut9lxuqxkkvd1000"
+	uploadImports["/xxx/upload/admin"] = ""
+	uploadImportsMutex.Unlock()
+
+	defer dequeueUploadImport("/xxx/upload/alice")
+	defer dequeueUploadImport("/xxx/upload/admin")
+
+	if owner := uploadOwner("/xxx/upload/alice"); owner == nil {
+		t.Fatal("owner should not be nil")
+	} else {
+		assert.Equal(t, "alice", owner.UserName)
+	}
+
+	assert.Nil(t, uploadOwner("/xxx/upload/admin"))
+	assert.Nil(t, uploadOwner("/xxx/upload/unknown"))
+}
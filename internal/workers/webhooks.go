@@ -0,0 +1,256 @@
+package workers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// WebhookTopics are the library events that are posted to webhooks, see entity.Webhook.Matches.
+var WebhookTopics = []string{
+	"photos.*",
+	"albums.*",
+	"labels.*",
+	"people.*",
+	"import.completed",
+	"index.completed",
+	"index.failed",
+	"sync.*",
+}
+
+const (
+	WebhookRetries = 5
+	WebhookBackoff = 10 * time.Second
+	WebhookTimeout = 30 * time.Second
+	WebhookLogAge  = 30 * 24 * time.Hour
+	WebhookQueue   = 100
+)
+
+// WebhookPayload represents the JSON request body posted to webhooks.
+type WebhookPayload struct {
+	Event string     `json:"event"`
+	Time  time.Time  `json:"time"`
+	Data  event.Data `json:"data"`
+}
+
+// webhookJob represents an event waiting to be posted to a webhook.
+type webhookJob struct {
+	hook  entity.Webhook
+	event string
+	body  []byte
+}
+
+// Webhooks represents a worker that posts library events to webhooks.
+type Webhooks struct {
+	conf    *config.Config
+	client  *http.Client
+	retries int
+	backoff time.Duration
+	mutex   sync.Mutex
+	queues  map[uint]chan webhookJob
+}
+
+// NewWebhooks returns a new webhooks worker.
+func NewWebhooks(conf *config.Config) *Webhooks {
+	return &Webhooks{
+		conf:    conf,
+		client:  &http.Client{Timeout: WebhookTimeout},
+		retries: WebhookRetries,
+		backoff: WebhookBackoff,
+		queues:  make(map[uint]chan webhookJob),
+	}
+}
+
+// logError logs an error message if err is not nil.
+func (worker *Webhooks) logError(err error) {
+	if err != nil {
+		log.Errorf("webhook: %s", err.Error())
+	}
+}
+
+// Run posts events received by the subscription to matching webhooks until the subscription is closed.
+func (worker *Webhooks) Run(s hub.Subscription) {
+	purge := time.NewTicker(time.Hour)
+
+	defer purge.Stop()
+	defer worker.closeQueues()
+
+	worker.logError(entity.PurgeWebhookDeliveries(WebhookLogAge))
+
+	for {
+		select {
+		case <-purge.C:
+			worker.logError(entity.PurgeWebhookDeliveries(WebhookLogAge))
+		case msg, ok := <-s.Receiver:
+			if !ok {
+				return
+			}
+
+			worker.Dispatch(msg)
+		}
+	}
+}
+
+// queue returns the delivery queue of a webhook, its events are posted one at a time in the background.
+func (worker *Webhooks) queue(hookID uint) chan webhookJob {
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+
+	if q, ok := worker.queues[hookID]; ok {
+		return q
+	}
+
+	q := make(chan webhookJob, WebhookQueue)
+	worker.queues[hookID] = q
+
+	go func() {
+		for job := range q {
+			worker.logError(worker.Deliver(job.hook, job.event, job.body))
+		}
+	}()
+
+	return q
+}
+
+// closeQueues stops the delivery queues once the pending events were posted.
+func (worker *Webhooks) closeQueues() {
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+
+	for hookID, q := range worker.queues {
+		close(q)
+		delete(worker.queues, hookID)
+	}
+}
+
+// Dispatch adds an event to the queues of all matching webhooks, events are dropped
+// if a queue is full, e.g. because the webhook is not reachable.
+func (worker *Webhooks) Dispatch(msg event.Message) {
+	hooks, err := query.Webhooks()
+
+	if err != nil {
+		worker.logError(err)
+		return
+	}
+
+	var body []byte
+
+	for _, h := range hooks {
+		if !h.Matches(msg.Name) {
+			continue
+		}
+
+		if body == nil {
+			if body, err = json.Marshal(WebhookPayload{Event: msg.Name, Time: time.Now().UTC(), Data: msg.Fields}); err != nil {
+				worker.logError(fmt.Errorf("can't encode %s (%s)", msg.Name, err))
+				return
+			}
+		}
+
+		select {
+		case worker.queue(h.ID) <- webhookJob{hook: h, event: msg.Name, body: body}:
+		default:
+			worker.logError(fmt.Errorf("%s to %s dropped, too many pending events", msg.Name, txt.Quote(h.HookURL)))
+		}
+	}
+}
+
+// Deliver posts an event to a webhook and retries with exponential backoff if the request failed
+// or the server responded with a temporary error. Each attempt is added to the delivery log.
+func (worker *Webhooks) Deliver(h entity.Webhook, eventName string, body []byte) error {
+	deliveryUID := rnd.UUID()
+
+	for attempt := 1; ; attempt++ {
+		d := worker.post(h, eventName, deliveryUID, body)
+		d.Attempt = attempt
+
+		worker.logError(d.Create())
+
+		if d.Success() {
+			return h.Updates(map[string]interface{}{"HookError": "", "HookErrors": 0})
+		}
+
+		// Only connection errors and temporary server errors are retried.
+		temporary := d.StatusCode == 0 || d.StatusCode >= 500 || d.StatusCode == http.StatusTooManyRequests
+
+		if attempt >= worker.retries || !temporary {
+			worker.logError(h.Updates(map[string]interface{}{"HookError": txt.Clip(d.Error, 512), "HookErrors": gorm.Expr("hook_errors + 1")}))
+
+			return fmt.Errorf("%s to %s failed after %d attempts (%s)", eventName, txt.Quote(h.HookURL), attempt, d.Error)
+		}
+
+		time.Sleep(worker.backoff << uint(attempt-1))
+	}
+}
+
+// post sends a single signed request and returns the result for the delivery log.
+func (worker *Webhooks) post(h entity.Webhook, eventName, deliveryUID string, body []byte) (d entity.WebhookDelivery) {
+	d = entity.WebhookDelivery{WebhookID: h.ID, DeliveryUID: deliveryUID, EventName: eventName}
+
+	start := time.Now()
+
+	defer func() {
+		d.Duration = int(time.Since(start) / time.Millisecond)
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, h.HookURL, bytes.NewReader(body))
+
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", worker.conf.UserAgent())
+	req.Header.Set("X-PhotoPrism-Event", eventName)
+	req.Header.Set("X-PhotoPrism-Delivery", deliveryUID)
+
+	if h.HookSecret != "" {
+		req.Header.Set("X-PhotoPrism-Signature", WebhookSignature(h.HookSecret, body))
+	}
+
+	resp, err := worker.client.Do(req)
+
+	if err != nil {
+		d.Error = txt.Clip(err.Error(), 512)
+		return d
+	}
+
+	defer resp.Body.Close()
+
+	// Read part of the response so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	d.StatusCode = resp.StatusCode
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		d.Error = resp.Status
+	}
+
+	return d
+}
+
+// WebhookSignature returns the HMAC-SHA256 signature of a request body, e.g. "sha256=3f1e...".
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,227 @@
+package workers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookStub is a local HTTP server that records requests and responds with the given status codes.
+type webhookStub struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	codes    []int
+	requests []*http.Request
+	bodies   [][]byte
+	received chan bool
+}
+
+func newWebhookStub(codes ...int) *webhookStub {
+	stub := &webhookStub{codes: codes, received: make(chan bool, 10)}
+
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		stub.mutex.Lock()
+		code := http.StatusOK
+
+		if n := len(stub.requests); n < len(stub.codes) {
+			code = stub.codes[n]
+		}
+
+		stub.requests = append(stub.requests, r)
+		stub.bodies = append(stub.bodies, body)
+		stub.mutex.Unlock()
+
+		w.WriteHeader(code)
+		stub.received <- true
+	}))
+
+	return stub
+}
+
+func webhookTest(t *testing.T, url, topics string) entity.Webhook {
+	m, err := entity.CreateWebhook(form.Webhook{HookURL: url, HookSecret: "s3cr3t", HookTopics: topics, HookEnabled: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *m
+}
+
+func TestWebhookSignature(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", WebhookSignature("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestWebhooks_Deliver(t *testing.T) {
+	worker := NewWebhooks(config.TestConfig())
+	worker.backoff = time.Millisecond
+
+	t.Run("success", func(t *testing.T) {
+		stub := newWebhookStub(http.StatusNoContent)
+		defer stub.Close()
+
+		h := webhookTest(t, stub.URL, "*")
+		defer h.Delete()
+
+		body := []byte(`{"event":"albums.updated"}`)
+
+		assert.NoError(t, worker.Deliver(h, "albums.updated", body))
+
+		if assert.Len(t, stub.requests, 1) {
+			r := stub.requests[0]
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "albums.updated", r.Header.Get("X-PhotoPrism-Event"))
+			assert.NotEmpty(t, r.Header.Get("X-PhotoPrism-Delivery"))
+			assert.Equal(t, WebhookSignature("s3cr3t", body), r.Header.Get("X-PhotoPrism-Signature"))
+			assert.Equal(t, body, stub.bodies[0])
+		}
+
+		log, err := query.WebhookDeliveries(h.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, log, 1) {
+			assert.True(t, log[0].Success())
+			assert.Equal(t, http.StatusNoContent, log[0].StatusCode)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		stub := newWebhookStub(http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK)
+		defer stub.Close()
+
+		h := webhookTest(t, stub.URL, "*")
+		defer h.Delete()
+
+		assert.NoError(t, worker.Deliver(h, "index.failed", []byte(`{}`)))
+		assert.Len(t, stub.requests, 3)
+
+		log, err := query.WebhookDeliveries(h.ID, 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, log, 3) {
+			assert.Equal(t, 3, log[0].Attempt)
+			assert.True(t, log[0].Success())
+			assert.Equal(t, http.StatusInternalServerError, log[2].StatusCode)
+			assert.Equal(t, log[0].DeliveryUID, log[2].DeliveryUID)
+		}
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		stub := newWebhookStub(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		defer stub.Close()
+
+		h := webhookTest(t, stub.URL, "*")
+		defer h.Delete()
+
+		assert.Error(t, worker.Deliver(h, "index.failed", []byte(`{}`)))
+		assert.Len(t, stub.requests, WebhookRetries)
+
+		m, err := query.WebhookByID(h.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "502 Bad Gateway", m.HookError)
+		assert.Equal(t, 1, m.HookErrors)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		stub := newWebhookStub(http.StatusNotFound)
+		defer stub.Close()
+
+		h := webhookTest(t, stub.URL, "*")
+		defer h.Delete()
+
+		assert.Error(t, worker.Deliver(h, "index.failed", []byte(`{}`)))
+		assert.Len(t, stub.requests, 1)
+	})
+}
+
+func TestWebhooks_Run(t *testing.T) {
+	stub := newWebhookStub()
+	defer stub.Close()
+
+	matching := webhookTest(t, stub.URL, "albums.*")
+	defer matching.Delete()
+
+	other := webhookTest(t, stub.URL+"/other", "photos.created")
+	defer other.Delete()
+
+	worker := NewWebhooks(config.TestConfig())
+	worker.backoff = time.Millisecond
+
+	s := event.Subscribe(WebhookTopics...)
+	done := make(chan bool)
+
+	go func() {
+		worker.Run(s)
+		done <- true
+	}()
+
+	event.EntitiesUpdated("albums", []string{"at9lxuqxpogaaba7"})
+
+	select {
+	case <-stub.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+	}
+
+	event.Unsubscribe(s)
+	<-done
+
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+
+	if assert.Len(t, stub.requests, 1) {
+		assert.Equal(t, "/", stub.requests[0].URL.Path)
+
+		var payload WebhookPayload
+
+		if err := json.Unmarshal(stub.bodies[0], &payload); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "albums.updated", payload.Event)
+		assert.Equal(t, []interface{}{"at9lxuqxpogaaba7"}, payload.Data["entities"])
+	}
+}
+
+func TestWebhooks_Dispatch(t *testing.T) {
+	h := webhookTest(t, "http://localhost:1/hook", "albums.*")
+	defer h.Delete()
+
+	worker := NewWebhooks(config.TestConfig())
+
+	t.Run("queue full", func(t *testing.T) {
+		// Queue without a consumer that already contains a pending event.
+		q := make(chan webhookJob, 1)
+		q <- webhookJob{hook: h, event: "albums.created"}
+		worker.queues[h.ID] = q
+
+		worker.Dispatch(event.Message{Name: "albums.updated", Fields: event.Data{}})
+		worker.Dispatch(event.Message{Name: "photos.updated", Fields: event.Data{}})
+
+		assert.Len(t, q, 1)
+		assert.Equal(t, "albums.created", (<-q).event)
+	})
+}
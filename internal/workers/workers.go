@@ -6,6 +6,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 )
 
 var log = event.Log
@@ -14,6 +15,11 @@ var stop = make(chan bool, 1)
 // Start runs PhotoPrism background workers every wakeup interval.
 func Start(conf *config.Config) {
 	ticker := time.NewTicker(conf.WakeupInterval())
+	webhooks := event.Subscribe(WebhookTopics...)
+	edits := event.Subscribe(EditTopic)
+
+	go NewWebhooks(conf).Run(webhooks)
+	go NewEdits(conf).Run(edits)
 
 	go func() {
 		for {
@@ -21,14 +27,19 @@ func Start(conf *config.Config) {
 			case <-stop:
 				log.Info("shutting down workers")
 				ticker.Stop()
+				event.Unsubscribe(webhooks)
+				event.Unsubscribe(edits)
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.ThumbWorker.Cancel()
 				return
 			case <-ticker.C:
 				StartMeta(conf)
 				StartShare(conf)
 				StartSync(conf)
+				StartThumbs(conf)
+				StartUploads(conf)
 			}
 		}
 	}()
@@ -74,3 +85,30 @@ func StartSync(conf *config.Config) {
 		}()
 	}
 }
+
+// StartThumbs evicts on-demand thumbnails once if the thumbnail cache exceeds its quota.
+func StartThumbs(conf *config.Config) {
+	if conf.ThumbCacheQuota() > 0 && !mutex.ThumbWorker.Busy() {
+		go func() {
+			worker := photoprism.NewThumbCache(conf)
+			if result, err := worker.Evict(false); err != nil {
+				log.Warnf("thumbs: %s", err)
+			} else if result.Evicted > 0 {
+				log.Infof("thumbs: evicted %d on-demand thumbnails", result.Evicted)
+			}
+		}()
+	}
+}
+
+// StartUploads removes expired incomplete uploads and imports pending completed uploads once.
+func StartUploads(conf *config.Config) {
+	go func() {
+		if removed, err := photoprism.NewUploads(conf).Cleanup(); err != nil {
+			log.Warnf("upload: %s", err)
+		} else if removed > 0 {
+			log.Infof("upload: removed %d expired uploads", removed)
+		}
+
+		ImportUploads(conf)
+	}()
+}
@@ -13,6 +13,7 @@ type FileInfo struct {
 	Size int64     `json:"size"`
 	Date time.Time `json:"date"`
 	Dir  bool      `json:"dir"`
+	ETag string    `json:"etag,omitempty"`
 }
 
 func NewFileInfo(info os.FileInfo, dir string) FileInfo {
@@ -36,6 +37,11 @@ func NewFileInfo(info os.FileInfo, dir string) FileInfo {
 		Dir:  info.IsDir(),
 	}
 
+	// Some remote file systems like WebDAV provide entity tags to detect changes.
+	if e, ok := info.(interface{ ETag() string }); ok {
+		result.ETag = strings.Trim(e.ETag(), `"`)
+	}
+
 	return result
 }
 
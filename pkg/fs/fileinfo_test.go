@@ -23,6 +23,29 @@ func TestNewFileInfo(t *testing.T) {
 	assert.Equal(t, int64(10990), result.Size)
 	assert.IsType(t, time.Time{}, result.Date)
 	assert.Equal(t, false, result.Dir)
+	assert.Equal(t, "", result.ETag)
+}
+
+// etagInfo adds an entity tag to a file info like some remote file systems do.
+type etagInfo struct {
+	os.FileInfo
+}
+
+func (etagInfo) ETag() string {
+	return `"5d41402abc4b2a76"`
+}
+
+func TestNewFileInfo_ETag(t *testing.T) {
+	info, err := os.Stat("testdata/test.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewFileInfo(etagInfo{info}, "/")
+
+	assert.Equal(t, "/test.jpg", result.Abs)
+	assert.Equal(t, "5d41402abc4b2a76", result.ETag)
 }
 
 func TestNewFileInfos(t *testing.T) {
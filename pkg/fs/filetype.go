@@ -47,6 +47,7 @@ type TypeExtensions map[FileType][]string
 
 const (
 	YamlExt = ".yml"
+	XmpExt  = ".xmp"
 	JpegExt = ".jpg"
 	AvcExt  = ".mp4"
 	HevcExt = ".hevc"
@@ -0,0 +1,18 @@
+// +build linux
+
+package fs
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// FreeSpace returns the number of bytes available to unprivileged users on the file system containing dir.
+func FreeSpace(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
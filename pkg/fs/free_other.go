@@ -0,0 +1,10 @@
+// +build !linux
+
+package fs
+
+import "errors"
+
+// FreeSpace returns an error as the available space is only determined on Linux.
+func FreeSpace(dir string) (uint64, error) {
+	return 0, errors.New("free space is not available on this platform")
+}
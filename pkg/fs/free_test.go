@@ -0,0 +1,24 @@
+package fs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeSpace(t *testing.T) {
+	t.Run("temp", func(t *testing.T) {
+		free, err := FreeSpace(os.TempDir())
+
+		if err != nil {
+			t.Skip(err)
+		}
+
+		assert.Greater(t, free, uint64(0))
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := FreeSpace("/xxx/not-found")
+		assert.Error(t, err)
+	})
+}
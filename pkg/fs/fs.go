@@ -208,6 +208,37 @@ func Download(filepath string, url string) error {
 	return nil
 }
 
+// WriteReader writes the data from a reader to a file and creates missing parent directories,
+// existing files are only overwritten if force is true.
+func WriteReader(fileName string, r io.Reader, force bool) error {
+	if _, err := os.Stat(fileName); err == nil && !force {
+		return fmt.Errorf("%s already exists", fileName)
+	}
+
+	dir := filepath.Dir(fileName)
+
+	if info, err := os.Stat(dir); err != nil {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("can't create %s (%s)", dir, err)
+		}
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a folder", dir)
+	}
+
+	out, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
 // IsEmpty returns true if a directory is empty.
 func IsEmpty(path string) bool {
 	f, err := os.Open(path)
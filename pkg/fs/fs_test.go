@@ -1,8 +1,10 @@
 package fs
 
 import (
+	"io/ioutil"
 	"os"
 	"os/user"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +41,32 @@ func TestOverwrite(t *testing.T) {
 	assert.True(t, result)
 }
 
+func TestWriteReader(t *testing.T) {
+	tmpPath := "./testdata/_tmp_write"
+
+	defer os.RemoveAll(tmpPath)
+
+	fileName := tmpPath + "/sub/test.txt"
+
+	if err := WriteReader(fileName, strings.NewReader("foo"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "foo", string(data))
+	assert.Error(t, WriteReader(fileName, strings.NewReader("bar"), false))
+	assert.NoError(t, WriteReader(fileName, strings.NewReader("bar"), true))
+
+	data, _ = ioutil.ReadFile(fileName)
+
+	assert.Equal(t, "bar", string(data))
+}
+
 func TestExpandedFilename(t *testing.T) {
 	t.Run("test.jpg", func(t *testing.T) {
 		filename := Abs("./testdata/test.jpg")
@@ -0,0 +1,71 @@
+package fs
+
+import (
+	"errors"
+	"sync"
+)
+
+// NotifyOp describes the kind of change reported by a Notifier.
+type NotifyOp uint8
+
+const (
+	NotifyCreate NotifyOp = 1 << iota
+	NotifyWrite
+	NotifyRemove
+	NotifyRename
+)
+
+// ErrNotifyUnsupported is returned on platforms without file system notifications.
+var ErrNotifyUnsupported = errors.New("file system notifications are not supported on this platform")
+
+// NotifyEvent represents a change of a file or directory in a watched directory.
+type NotifyEvent struct {
+	Name string
+	Op   NotifyOp
+	Dir  bool
+}
+
+// Has tests if the event includes the given operation.
+func (e NotifyEvent) Has(op NotifyOp) bool {
+	return e.Op&op != 0
+}
+
+// Notifier reports changes in watched directories, e.g. using inotify on Linux.
+// Directories are not watched recursively, so sub directories must be added separately.
+type Notifier struct {
+	fd      int
+	watches map[int]string
+	paths   map[string]int
+	events  chan NotifyEvent
+	errors  chan error
+	done    chan bool
+	mutex   sync.Mutex
+}
+
+// Events returns the channel on which changes are reported.
+func (n *Notifier) Events() <-chan NotifyEvent {
+	return n.events
+}
+
+// Errors returns the channel on which read errors are reported.
+func (n *Notifier) Errors() <-chan error {
+	return n.errors
+}
+
+// Watching tests if a directory is being watched.
+func (n *Notifier) Watching(dir string) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	_, ok := n.paths[dir]
+
+	return ok
+}
+
+// Count returns the number of watched directories.
+func (n *Notifier) Count() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return len(n.paths)
+}
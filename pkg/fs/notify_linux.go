@@ -0,0 +1,174 @@
+// +build linux
+
+package fs
+
+import (
+	"bytes"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const notifyMask = unix.IN_CREATE | unix.IN_CLOSE_WRITE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+	unix.IN_DELETE | unix.IN_DELETE_SELF | unix.IN_ONLYDIR
+
+// NewNotifier returns a new inotify based Notifier.
+func NewNotifier() (*Notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		fd:      fd,
+		watches: make(map[int]string),
+		paths:   make(map[string]int),
+		events:  make(chan NotifyEvent, 256),
+		errors:  make(chan error, 1),
+		done:    make(chan bool),
+	}
+
+	go n.read()
+
+	return n, nil
+}
+
+// Add starts watching a directory.
+func (n *Notifier) Add(dir string) error {
+	dir = filepath.Clean(dir)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	wd, err := unix.InotifyAddWatch(n.fd, dir, notifyMask)
+
+	if err != nil {
+		return err
+	}
+
+	n.watches[wd] = dir
+	n.paths[dir] = wd
+
+	return nil
+}
+
+// Remove stops watching a directory.
+func (n *Notifier) Remove(dir string) error {
+	dir = filepath.Clean(dir)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	wd, ok := n.paths[dir]
+
+	if !ok {
+		return nil
+	}
+
+	delete(n.paths, dir)
+	delete(n.watches, wd)
+
+	if _, err := unix.InotifyRmWatch(n.fd, uint32(wd)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close stops watching all directories and closes the event channel.
+func (n *Notifier) Close() error {
+	close(n.done)
+
+	return nil
+}
+
+// read reads inotify events until the notifier is closed.
+func (n *Notifier) read() {
+	defer close(n.events)
+	defer unix.Close(n.fd)
+
+	var buf [unix.SizeofInotifyEvent * 4096]byte
+
+	fds := []unix.PollFd{{Fd: int32(n.fd), Events: unix.POLLIN}}
+
+	for {
+		select {
+		case <-n.done:
+			return
+		default:
+		}
+
+		if ready, err := unix.Poll(fds, 250); err != nil && err != unix.EINTR {
+			n.error(err)
+			return
+		} else if ready < 1 {
+			continue
+		}
+
+		size, err := unix.Read(n.fd, buf[:])
+
+		if err == unix.EAGAIN || err == unix.EINTR {
+			continue
+		} else if err != nil {
+			n.error(err)
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= size; {
+			raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(raw.Len)]
+			offset += unix.SizeofInotifyEvent + int(raw.Len)
+
+			n.handle(int(raw.Wd), raw.Mask, string(bytes.TrimRight(nameBytes, "\x00")))
+		}
+	}
+}
+
+// handle converts a raw inotify event and sends it to the event channel.
+func (n *Notifier) handle(wd int, mask uint32, name string) {
+	n.mutex.Lock()
+	dir, ok := n.watches[wd]
+
+	if ok && mask&(unix.IN_DELETE_SELF|unix.IN_IGNORED) != 0 {
+		delete(n.watches, wd)
+		delete(n.paths, dir)
+	}
+
+	n.mutex.Unlock()
+
+	if !ok || name == "" {
+		return
+	}
+
+	e := NotifyEvent{Name: filepath.Join(dir, name), Dir: mask&unix.IN_ISDIR != 0}
+
+	switch {
+	case mask&unix.IN_CREATE != 0:
+		e.Op = NotifyCreate
+	case mask&unix.IN_MOVED_TO != 0:
+		e.Op = NotifyCreate
+	case mask&unix.IN_CLOSE_WRITE != 0:
+		e.Op = NotifyWrite
+	case mask&unix.IN_MOVED_FROM != 0:
+		e.Op = NotifyRename
+	case mask&unix.IN_DELETE != 0:
+		e.Op = NotifyRemove
+	default:
+		return
+	}
+
+	select {
+	case n.events <- e:
+	case <-n.done:
+	}
+}
+
+// error reports an error without blocking.
+func (n *Notifier) error(err error) {
+	select {
+	case n.errors <- err:
+	default:
+	}
+}
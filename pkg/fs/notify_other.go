@@ -0,0 +1,23 @@
+// +build !linux
+
+package fs
+
+// NewNotifier returns ErrNotifyUnsupported as file system notifications are only implemented on Linux.
+func NewNotifier() (*Notifier, error) {
+	return nil, ErrNotifyUnsupported
+}
+
+// Add starts watching a directory.
+func (n *Notifier) Add(dir string) error {
+	return ErrNotifyUnsupported
+}
+
+// Remove stops watching a directory.
+func (n *Notifier) Remove(dir string) error {
+	return ErrNotifyUnsupported
+}
+
+// Close stops watching all directories.
+func (n *Notifier) Close() error {
+	return nil
+}
@@ -0,0 +1,81 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyEvent_Has(t *testing.T) {
+	e := NotifyEvent{Name: "foo.jpg", Op: NotifyCreate}
+
+	assert.True(t, e.Has(NotifyCreate))
+	assert.False(t, e.Has(NotifyRemove))
+}
+
+func TestNewNotifier(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("inotify is only available on linux")
+	}
+
+	dir, err := ioutil.TempDir("", "notify")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	n, err := NewNotifier()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer n.Close()
+
+	if err := n.Add(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, n.Watching(dir))
+	assert.Equal(t, 1, n.Count())
+
+	fileName := filepath.Join(dir, "test.jpg")
+
+	if err := ioutil.WriteFile(fileName, []byte("test"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	var ops NotifyOp
+
+	timeout := time.After(5 * time.Second)
+
+	for ops&NotifyRemove == 0 {
+		select {
+		case e := <-n.Events():
+			assert.Equal(t, fileName, e.Name)
+			ops |= e.Op
+		case <-timeout:
+			t.Fatal("timeout")
+		}
+	}
+
+	assert.True(t, ops&NotifyCreate != 0)
+	assert.True(t, ops&NotifyWrite != 0)
+
+	if err := n.Remove(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, n.Watching(dir))
+}
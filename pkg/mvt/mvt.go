@@ -0,0 +1,221 @@
+/*
+
+Package mvt encodes point features as Mapbox Vector Tiles.
+
+See https://github.com/mapbox/vector-tile-spec/tree/master/2.1
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package mvt
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MimeType is the content type of encoded tiles.
+const MimeType = "application/vnd.mapbox-vector-tile"
+
+// Version is the vector tile specification version.
+const Version = 2
+
+// DefaultExtent is the default number of integer coordinates per tile edge.
+const DefaultExtent = 4096
+
+// Protobuf field numbers, see vector_tile.proto.
+const (
+	tileLayers    = 3
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+	featureID     = 1
+	featureTags   = 2
+	featureType   = 3
+	featureGeom   = 4
+	valueString   = 1
+	valueDouble   = 3
+	valueSint     = 6
+	valueBool     = 7
+	geomTypePoint = 1
+	cmdMoveTo     = 1
+)
+
+// Feature represents a point with properties in tile coordinates.
+type Feature struct {
+	ID         uint64
+	X, Y       int
+	Properties map[string]interface{}
+}
+
+// Layer represents a named set of features.
+type Layer struct {
+	Name     string
+	Extent   uint32
+	Features []Feature
+}
+
+// Encode returns the protobuf encoded tile containing the layers.
+func Encode(layers ...Layer) ([]byte, error) {
+	var tile []byte
+
+	for _, l := range layers {
+		data, err := l.encode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		tile = appendBytes(tile, tileLayers, data)
+	}
+
+	return tile, nil
+}
+
+// encode returns the protobuf encoded layer.
+func (l Layer) encode() ([]byte, error) {
+	var keys []string
+	var values [][]byte
+
+	keyIndex := make(map[string]uint64)
+	valueIndex := make(map[string]uint64)
+
+	var buf []byte
+
+	buf = appendBytes(buf, layerName, []byte(l.Name))
+
+	for _, f := range l.Features {
+		var tags []uint64
+
+		names := make([]string, 0, len(f.Properties))
+
+		for name := range f.Properties {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			v, err := encodeValue(f.Properties[name])
+
+			if err != nil {
+				return nil, fmt.Errorf("mvt: property %s %s", name, err)
+			}
+
+			k, ok := keyIndex[name]
+
+			if !ok {
+				k = uint64(len(keys))
+				keyIndex[name] = k
+				keys = append(keys, name)
+			}
+
+			i, ok := valueIndex[string(v)]
+
+			if !ok {
+				i = uint64(len(values))
+				valueIndex[string(v)] = i
+				values = append(values, v)
+			}
+
+			tags = append(tags, k, i)
+		}
+
+		var feat []byte
+
+		if f.ID > 0 {
+			feat = appendVarintField(feat, featureID, f.ID)
+		}
+
+		if len(tags) > 0 {
+			feat = appendBytes(feat, featureTags, packed(tags...))
+		}
+
+		feat = appendVarintField(feat, featureType, geomTypePoint)
+		feat = appendBytes(feat, featureGeom, packed(command(cmdMoveTo, 1), zigzag(int64(f.X)), zigzag(int64(f.Y))))
+
+		buf = appendBytes(buf, layerFeatures, feat)
+	}
+
+	for _, k := range keys {
+		buf = appendBytes(buf, layerKeys, []byte(k))
+	}
+
+	for _, v := range values {
+		buf = appendBytes(buf, layerValues, v)
+	}
+
+	extent := l.Extent
+
+	if extent == 0 {
+		extent = DefaultExtent
+	}
+
+	buf = appendVarintField(buf, layerExtent, uint64(extent))
+	buf = appendVarintField(buf, layerVersion, Version)
+
+	return buf, nil
+}
+
+// encodeValue returns the protobuf encoded property value.
+func encodeValue(v interface{}) ([]byte, error) {
+	switch t := v.(type) {
+	case string:
+		return appendBytes(nil, valueString, []byte(t)), nil
+	case int:
+		return appendVarintField(nil, valueSint, zigzag(int64(t))), nil
+	case int64:
+		return appendVarintField(nil, valueSint, zigzag(t)), nil
+	case uint:
+		return appendVarintField(nil, valueSint, zigzag(int64(t))), nil
+	case float32:
+		return appendDouble(nil, valueDouble, float64(t)), nil
+	case float64:
+		return appendDouble(nil, valueDouble, t), nil
+	case bool:
+		if t {
+			return appendVarintField(nil, valueBool, 1), nil
+		}
+
+		return appendVarintField(nil, valueBool, 0), nil
+	default:
+		return nil, fmt.Errorf("has unsupported type %T", v)
+	}
+}
+
+// command returns a geometry command integer.
+func command(id, count uint64) uint64 {
+	return (id & 0x7) | (count << 3)
+}
+
+// zigzag returns the zigzag encoded value of a signed integer.
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
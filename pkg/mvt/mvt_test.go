@@ -0,0 +1,62 @@
+package mvt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("point", func(t *testing.T) {
+		result, err := Encode(Layer{Name: "a", Features: []Feature{{X: 1, Y: 1}}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []byte{
+			0x1a, 0x11, // Layer
+			0x0a, 0x01, 'a', // Name
+			0x12, 0x07, 0x18, 0x01, 0x22, 0x03, 0x09, 0x02, 0x02, // Feature with MoveTo(1, 1)
+			0x28, 0x80, 0x20, // Extent 4096
+			0x78, 0x02, // Version 2
+		}
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("properties", func(t *testing.T) {
+		result, err := Encode(Layer{Name: "a", Extent: 256, Features: []Feature{
+			{ID: 1, X: -1, Y: 0, Properties: map[string]interface{}{"n": 2, "s": "x"}},
+			{ID: 2, X: 0, Y: 0, Properties: map[string]interface{}{"n": 2}},
+		}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []byte{
+			0x1a, 0x37, // Layer
+			0x0a, 0x01, 'a', // Name
+			0x12, 0x0f, 0x08, 0x01, 0x12, 0x04, 0x00, 0x00, 0x01, 0x01, 0x18, 0x01, 0x22, 0x03, 0x09, 0x01, 0x00, // Feature 1
+			0x12, 0x0d, 0x08, 0x02, 0x12, 0x02, 0x00, 0x00, 0x18, 0x01, 0x22, 0x03, 0x09, 0x00, 0x00, // Feature 2
+			0x1a, 0x01, 'n', 0x1a, 0x01, 's', // Keys
+			0x22, 0x02, 0x30, 0x04, 0x22, 0x03, 0x0a, 0x01, 'x', // Values
+			0x28, 0x80, 0x02, // Extent 256
+			0x78, 0x02, // Version 2
+		}
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Encode(Layer{Name: "a", Features: []Feature{{Properties: map[string]interface{}{"x": []int{1}}}}})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestZigzag(t *testing.T) {
+	assert.Equal(t, uint64(0), zigzag(0))
+	assert.Equal(t, uint64(1), zigzag(-1))
+	assert.Equal(t, uint64(2), zigzag(1))
+	assert.Equal(t, uint64(3), zigzag(-2))
+}
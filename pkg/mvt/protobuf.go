@@ -0,0 +1,57 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protobuf wire types.
+const (
+	wireVarint = 0
+	wire64Bit  = 1
+	wireBytes  = 2
+)
+
+// appendVarint appends a base 128 varint.
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(buf, byte(v))
+}
+
+// appendKey appends a field key.
+func appendKey(buf []byte, field, wireType uint64) []byte {
+	return appendVarint(buf, field<<3|wireType)
+}
+
+// appendVarintField appends a varint field.
+func appendVarintField(buf []byte, field, v uint64) []byte {
+	return appendVarint(appendKey(buf, field, wireVarint), v)
+}
+
+// appendBytes appends a length delimited field.
+func appendBytes(buf []byte, field uint64, data []byte) []byte {
+	buf = appendVarint(appendKey(buf, field, wireBytes), uint64(len(data)))
+	return append(buf, data...)
+}
+
+// appendDouble appends a 64 bit floating point field.
+func appendDouble(buf []byte, field uint64, v float64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	return append(appendKey(buf, field, wire64Bit), b[:]...)
+}
+
+// packed returns packed repeated varints.
+func packed(values ...uint64) []byte {
+	var buf []byte
+
+	for _, v := range values {
+		buf = appendVarint(buf, v)
+	}
+
+	return buf
+}
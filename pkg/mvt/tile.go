@@ -0,0 +1,47 @@
+package mvt
+
+import (
+	"math"
+)
+
+// MaxZoom is the maximum supported zoom level.
+const MaxZoom = 24
+
+// ValidTile returns true if the tile coordinates exist at the zoom level.
+func ValidTile(z, x, y int) bool {
+	if z < 0 || z > MaxZoom {
+		return false
+	}
+
+	n := 1 << uint(z)
+
+	return x >= 0 && x < n && y >= 0 && y < n
+}
+
+// TileBounds returns the bounding box of a web mercator tile in degrees.
+func TileBounds(z, x, y int) (minLng, minLat, maxLng, maxLat float64) {
+	n := float64(int(1) << uint(z))
+
+	minLng = float64(x)/n*360 - 180
+	maxLng = float64(x+1)/n*360 - 180
+	maxLat = tileLat(float64(y), n)
+	minLat = tileLat(float64(y+1), n)
+
+	return minLng, minLat, maxLng, maxLat
+}
+
+// tileLat returns the latitude of a tile edge in degrees.
+func tileLat(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
+
+// Project returns the position of coordinates in tile space, with the origin at the top left corner.
+func Project(lat, lng float64, z, x, y int, extent uint32) (px, py int) {
+	n := float64(int(1) << uint(z))
+	rad := lat * math.Pi / 180
+
+	tx := (lng + 180) / 360 * n
+	ty := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+
+	return int(math.Round((tx - float64(x)) * float64(extent))), int(math.Round((ty - float64(y)) * float64(extent)))
+}
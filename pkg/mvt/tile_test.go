@@ -0,0 +1,39 @@
+package mvt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidTile(t *testing.T) {
+	assert.True(t, ValidTile(0, 0, 0))
+	assert.True(t, ValidTile(2, 3, 3))
+	assert.False(t, ValidTile(2, 4, 0))
+	assert.False(t, ValidTile(-1, 0, 0))
+	assert.False(t, ValidTile(MaxZoom+1, 0, 0))
+}
+
+func TestTileBounds(t *testing.T) {
+	minLng, minLat, maxLng, maxLat := TileBounds(1, 1, 0)
+
+	assert.Equal(t, 0.0, minLng)
+	assert.Equal(t, 0.0, minLat)
+	assert.Equal(t, 180.0, maxLng)
+	assert.InDelta(t, 85.0511, maxLat, 0.0001)
+}
+
+func TestProject(t *testing.T) {
+	t.Run("center", func(t *testing.T) {
+		x, y := Project(0, 0, 0, 0, 0, 4096)
+
+		assert.Equal(t, 2048, x)
+		assert.Equal(t, 2048, y)
+	})
+	t.Run("berlin", func(t *testing.T) {
+		x, y := Project(52.52, 13.405, 10, 550, 335, 4096)
+
+		assert.True(t, x >= 0 && x < 4096)
+		assert.True(t, y >= 0 && y < 4096)
+	})
+}
@@ -0,0 +1,95 @@
+/*
+
+Package phash provides perceptual image hashes for finding visually similar images.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// Hash represents a 64 bit perceptual image hash.
+type Hash uint64
+
+// Width and Height of the grayscale image used for calculating difference hashes.
+const (
+	Width  = 9
+	Height = 8
+)
+
+// DHash returns the difference hash of an image, which compares the brightness of adjacent pixels.
+func DHash(img image.Image) Hash {
+	gray := imaging.Grayscale(imaging.Resize(img, Width, Height, imaging.Box))
+
+	var h Hash
+	var i uint
+
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width-1; x++ {
+			if gray.Pix[gray.PixOffset(x, y)] < gray.Pix[gray.PixOffset(x+1, y)] {
+				h |= 1 << i
+			}
+
+			i++
+		}
+	}
+
+	return h
+}
+
+// Parse returns a hash from its hexadecimal string representation.
+func Parse(s string) (Hash, error) {
+	if s == "" {
+		return 0, fmt.Errorf("phash: empty string")
+	}
+
+	h, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %s", strconv.Quote(s))
+	}
+
+	return Hash(h), nil
+}
+
+// Hex returns the hash as hexadecimal string.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Distance returns the number of different bits (Hamming distance).
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
@@ -0,0 +1,62 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDHash(t *testing.T) {
+	cat, err := imaging.Open("../../assets/examples/cat_brown.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dog, err := imaging.Open("../../assets/examples/dog_created_1919.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := DHash(cat)
+
+	t.Run("resized", func(t *testing.T) {
+		small := DHash(imaging.Resize(cat, 200, 0, imaging.Lanczos))
+		assert.LessOrEqual(t, h.Distance(small), 4)
+	})
+
+	t.Run("recompressed", func(t *testing.T) {
+		blurred := DHash(imaging.Blur(cat, 0.8))
+		assert.LessOrEqual(t, h.Distance(blurred), 6)
+	})
+
+	t.Run("different", func(t *testing.T) {
+		assert.Greater(t, h.Distance(DHash(dog)), 12)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		h, err := Parse("00ff00ff00ff00ff")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0x00ff00ff00ff00ff), h)
+		assert.Equal(t, "00ff00ff00ff00ff", h.Hex())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.EqualError(t, err, "phash: invalid hash \"xyz\"")
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := Parse("")
+		assert.Error(t, err)
+	})
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(0xffffffffffffffff))
+}
@@ -0,0 +1,80 @@
+package phash
+
+// Tree represents a BK-tree for finding hashes within a given Hamming distance.
+type Tree struct {
+	root *node
+	size int
+}
+
+type node struct {
+	hash     Hash
+	ids      []uint
+	children map[int]*node
+}
+
+// Add adds a hash and the id it belongs to.
+func (t *Tree) Add(h Hash, id uint) {
+	t.size++
+
+	if t.root == nil {
+		t.root = &node{hash: h, ids: []uint{id}}
+		return
+	}
+
+	n := t.root
+
+	for {
+		d := n.hash.Distance(h)
+
+		if d == 0 {
+			n.ids = append(n.ids, id)
+			return
+		}
+
+		if n.children == nil {
+			n.children = make(map[int]*node)
+		}
+
+		child, ok := n.children[d]
+
+		if !ok {
+			n.children[d] = &node{hash: h, ids: []uint{id}}
+			return
+		}
+
+		n = child
+	}
+}
+
+// Search returns the ids of all hashes within the maximum distance.
+func (t *Tree) Search(h Hash, max int) (ids []uint) {
+	if t.root == nil {
+		return ids
+	}
+
+	stack := []*node{t.root}
+
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := n.hash.Distance(h)
+
+		if d <= max {
+			ids = append(ids, n.ids...)
+		}
+
+		for dist, child := range n.children {
+			if dist >= d-max && dist <= d+max {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	return ids
+}
+
+// Len returns the number of hashes in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
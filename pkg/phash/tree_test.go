@@ -0,0 +1,33 @@
+package phash
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	tree := Tree{}
+
+	assert.Empty(t, tree.Search(0, 10))
+
+	tree.Add(0x0, 1)
+	tree.Add(0x1, 2)
+	tree.Add(0x3, 3)
+	tree.Add(0xff, 4)
+	tree.Add(0x0, 5)
+	tree.Add(0xffff0000, 6)
+
+	assert.Equal(t, 6, tree.Len())
+
+	result := tree.Search(0x0, 1)
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	assert.Equal(t, []uint{1, 2, 5}, result)
+
+	result = tree.Search(0x7, 2)
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	assert.Equal(t, []uint{2, 3}, result)
+
+	assert.Equal(t, []uint{6}, tree.Search(0xffff0001, 1))
+}
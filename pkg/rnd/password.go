@@ -1,6 +1,22 @@
 package rnd
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
 // Password returns a random password with 8 characters as string.
 func Password() string {
 	return Token(8)
 }
+
+// Secret returns a random secret with 32 hexadecimal characters, e.g. for signing requests.
+func Secret() string {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -23,6 +23,13 @@ func TestRandomPassword(t *testing.T) {
 	assert.Equal(t, 8, len(pw))
 }
 
+func TestSecret(t *testing.T) {
+	secret := Secret()
+	assert.Len(t, secret, 32)
+	assert.True(t, IsHex(secret))
+	assert.NotEqual(t, secret, Secret())
+}
+
 func BenchmarkRandomPassword(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		Password()
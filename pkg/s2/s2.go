@@ -40,6 +40,9 @@ import (
 // Default cell level, see https://s2geometry.io/resources/s2cell_statistics.html.
 var DefaultLevel = 21
 
+// EarthRadius is the mean radius of the earth in km.
+const EarthRadius = 6371.01
+
 // Token returns the S2 cell token for coordinates using the default level.
 func Token(lat, lng float64) string {
 	return TokenLevel(lat, lng, DefaultLevel)
@@ -81,6 +84,18 @@ func LatLng(token string) (lat, lng float64) {
 	return l.Lat.Degrees(), l.Lng.Degrees()
 }
 
+// Distance returns the great circle distance between two S2 cell tokens in km, or -1 if a token is invalid.
+func Distance(tokenA, tokenB string) float64 {
+	a := gs2.CellIDFromToken(NormalizeToken(tokenA))
+	b := gs2.CellIDFromToken(NormalizeToken(tokenB))
+
+	if !a.IsValid() || !b.IsValid() {
+		return -1
+	}
+
+	return a.LatLng().Distance(b.LatLng()).Radians() * EarthRadius
+}
+
 // IsZero returns true if the coordinates are both empty.
 func IsZero(lat, lng float64) bool {
 	return lat == 0.0 && lng == 0.0
@@ -103,3 +118,46 @@ func Range(token string, levelUp int) (min, max string) {
 
 	return parent.Prev().ChildBeginAtLevel(lvl).ToToken(), parent.Next().ChildBeginAtLevel(lvl).ToToken()
 }
+
+// MaxClusterLevel is the most detailed cell level used for clustering map markers.
+const MaxClusterLevel = 21
+
+// ZoomLevel returns the cell level for clustering markers on a web map at the zoom level,
+// so that a cell covers about one eighth of a 256 pixel map tile.
+func ZoomLevel(zoom int) int {
+	level := zoom + 1
+
+	if level < 1 {
+		return 1
+	} else if level > MaxClusterLevel {
+		return MaxClusterLevel
+	}
+
+	return level
+}
+
+// TokenLength returns the number of token characters needed to identify the parent cell at a level.
+func TokenLength(level int) int {
+	return (2*level + 6) / 4
+}
+
+// ParentToken returns the token of the parent cell at a level, the token may be shortened to TokenLength(level).
+func ParentToken(token string, level int) string {
+	token = NormalizeToken(token)
+
+	if token == "" || len(token) > 16 || len(token) < TokenLength(level) {
+		return ""
+	}
+
+	c := gs2.CellIDFromToken(token)
+
+	if c == 0 {
+		return ""
+	}
+
+	if c = c.Parent(level); !c.IsValid() {
+		return ""
+	}
+
+	return c.ToToken()
+}
@@ -121,6 +121,27 @@ func TestLatLng(t *testing.T) {
 	})
 }
 
+func TestDistance(t *testing.T) {
+	t.Run("lisbon_porto", func(t *testing.T) {
+		lisbon := Token(38.7223, -9.1393)
+		porto := Token(41.1579, -8.6291)
+
+		assert.InDelta(t, 274, Distance(lisbon, porto), 1)
+		assert.InDelta(t, 274, Distance(Prefix(lisbon), porto), 1)
+	})
+
+	t.Run("same", func(t *testing.T) {
+		token := Token(48.56344833333333, 8.996878333333333)
+
+		assert.InDelta(t, 0, Distance(token, token), 0.001)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, float64(-1), Distance("zz", Token(38.7223, -9.1393)))
+		assert.Equal(t, float64(-1), Distance("", ""))
+	})
+}
+
 func TestIsZero(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		lat, lng := LatLng("4799e370ca54c8b9")
@@ -164,3 +185,32 @@ func TestRange(t *testing.T) {
 		assert.Equal(t, "", max)
 	})
 }
+
+func TestZoomLevel(t *testing.T) {
+	assert.Equal(t, 1, ZoomLevel(-1))
+	assert.Equal(t, 1, ZoomLevel(0))
+	assert.Equal(t, 11, ZoomLevel(10))
+	assert.Equal(t, MaxClusterLevel, ZoomLevel(22))
+}
+
+func TestTokenLength(t *testing.T) {
+	assert.Equal(t, 1, TokenLength(0))
+	assert.Equal(t, 3, TokenLength(4))
+	assert.Equal(t, 6, TokenLength(10))
+	assert.Equal(t, 12, TokenLength(21))
+}
+
+func TestParentToken(t *testing.T) {
+	t.Run("level 10", func(t *testing.T) {
+		token := ParentToken("s2:1ef744d1e28c", 10)
+
+		assert.Equal(t, TokenLevel(-29.28264, 31.44450, 10), token)
+		assert.Equal(t, token, ParentToken("1ef744", 10))
+	})
+	t.Run("too short", func(t *testing.T) {
+		assert.Equal(t, "", ParentToken("1ef", 10))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, "", ParentToken("zz", 1))
+	})
+}
@@ -0,0 +1,126 @@
+package txt
+
+import (
+	"strings"
+)
+
+// Stem returns the stem of a lowercase English word by removing plural and
+// verb suffixes, see steps 1 and 5a of the Porter stemming algorithm. Other languages and
+// words with less than 4 characters are returned unchanged.
+func Stem(w string) string {
+	if len(w) < 4 || !ASCII(w) {
+		return w
+	}
+
+	// Step 1a: plurals.
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ies"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"):
+	case strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+
+	// Step 1b: past tense and gerunds.
+	stripped := false
+
+	switch {
+	case strings.HasSuffix(w, "eed"):
+		if stemMeasure(w[:len(w)-3]) > 0 {
+			w = w[:len(w)-1]
+		}
+	case strings.HasSuffix(w, "ed") && stemHasVowel(w[:len(w)-2]):
+		w = w[:len(w)-2]
+		stripped = true
+	case strings.HasSuffix(w, "ing") && stemHasVowel(w[:len(w)-3]):
+		w = w[:len(w)-3]
+		stripped = true
+	}
+
+	if stripped {
+		switch {
+		case strings.HasSuffix(w, "at"), strings.HasSuffix(w, "bl"), strings.HasSuffix(w, "iz"):
+			w += "e"
+		case stemDoubleConsonant(w) && !strings.ContainsAny(w[len(w)-1:], "lsz"):
+			w = w[:len(w)-1]
+		case stemMeasure(w) == 1 && stemCvc(w):
+			w += "e"
+		}
+	}
+
+	// Step 1c: terminal y.
+	if strings.HasSuffix(w, "y") && stemHasVowel(w[:len(w)-1]) {
+		w = w[:len(w)-1] + "i"
+	}
+
+	// Step 5a: terminal e.
+	if strings.HasSuffix(w, "e") {
+		if m := stemMeasure(w[:len(w)-1]); m > 1 || m == 1 && !stemCvc(w[:len(w)-1]) {
+			w = w[:len(w)-1]
+		}
+	}
+
+	return w
+}
+
+// stemConsonant returns true if the character at position i is a consonant.
+func stemConsonant(w string, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !stemConsonant(w, i-1)
+	}
+
+	return true
+}
+
+// stemMeasure returns the number of vowel-consonant sequences in a word.
+func stemMeasure(w string) (m int) {
+	vowel := false
+
+	for i := range w {
+		if stemConsonant(w, i) {
+			if vowel {
+				m++
+			}
+
+			vowel = false
+		} else {
+			vowel = true
+		}
+	}
+
+	return m
+}
+
+// stemHasVowel returns true if the word contains a vowel.
+func stemHasVowel(w string) bool {
+	for i := range w {
+		if !stemConsonant(w, i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stemDoubleConsonant returns true if the word ends with a double consonant.
+func stemDoubleConsonant(w string) bool {
+	l := len(w)
+
+	return l > 1 && w[l-1] == w[l-2] && stemConsonant(w, l-1)
+}
+
+// stemCvc returns true if the word ends with consonant-vowel-consonant and the last one is not w, x or y.
+func stemCvc(w string) bool {
+	l := len(w)
+
+	if l < 3 || !stemConsonant(w, l-1) || stemConsonant(w, l-2) || !stemConsonant(w, l-3) {
+		return false
+	}
+
+	return !strings.ContainsAny(w[l-1:], "wxy")
+}
@@ -0,0 +1,35 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	words := map[string]string{
+		"caresses":   "caress",
+		"ponies":     "poni",
+		"cats":       "cat",
+		"agreed":     "agre",
+		"plastered":  "plaster",
+		"motoring":   "motor",
+		"sing":       "sing",
+		"hopping":    "hop",
+		"falling":    "fall",
+		"filing":     "file",
+		"happy":      "happi",
+		"sky":        "sky",
+		"bridges":    "bridg",
+		"bridge":     "bridg",
+		"conflated":  "conflat",
+		"1990s":      "1990s",
+		"österreich": "österreich",
+	}
+
+	for w, expected := range words {
+		t.Run(w, func(t *testing.T) {
+			assert.Equal(t, expected, Stem(w))
+		})
+	}
+}
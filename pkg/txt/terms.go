@@ -0,0 +1,34 @@
+package txt
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var TermsRegexp = regexp.MustCompile("[\\p{L}\\p{N}]+")
+
+// Term represents a stemmed full-text search term and its word position.
+type Term struct {
+	Word string
+	Pos  int
+}
+
+// Terms returns the full-text search terms in a string without stopwords, positions of skipped words are preserved.
+func Terms(s string) (results []Term) {
+	for i, w := range TermsRegexp.FindAllString(s, -1) {
+		w = strings.ToLower(w)
+
+		if utf8.RuneCountInString(w) < 2 {
+			continue
+		}
+
+		if _, ok := StopWords[w]; ok {
+			continue
+		}
+
+		results = append(results, Term{Word: Stem(Clip(w, ClipKeyword)), Pos: i})
+	}
+
+	return results
+}
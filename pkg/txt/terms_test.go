@@ -0,0 +1,25 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	t.Run("Golden Gate Bridge", func(t *testing.T) {
+		result := Terms("The Golden Gate Bridges at night")
+		assert.Equal(t, []Term{{"golden", 1}, {"gate", 2}, {"bridg", 3}, {"night", 5}}, result)
+	})
+	t.Run("filename", func(t *testing.T) {
+		result := Terms("Holiday_Beaches_1234.jpg")
+		assert.Equal(t, []Term{{"holidai", 0}, {"beach", 1}, {"1234", 2}}, result)
+	})
+	t.Run("Île de la Réunion", func(t *testing.T) {
+		result := Terms("Île de la Réunion")
+		assert.Equal(t, []Term{{"île", 0}, {"réunion", 3}}, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, Terms(""))
+	})
+}
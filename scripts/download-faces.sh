@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+
+TODAY=`date -u +%Y%m%d`
+
+MODEL_NAME="Faces"
+MODEL_URL="https://dl.photoprism.org/tensorflow/faces.zip?$TODAY"
+MODEL_PATH="assets/faces"
+MODEL_ZIP="/tmp/photoprism/faces.zip"
+MODEL_VERSION="$MODEL_PATH/version.txt"
+MODEL_BACKUP="storage/backup/faces-$TODAY"
+
+echo "Installing $MODEL_NAME models for TensorFlow..."
+
+# Create directories
+mkdir -p /tmp/photoprism
+mkdir -p storage/backup
+
+# Check for update
+if [[ -f ${MODEL_ZIP} ]] && [[ -f ${MODEL_VERSION} ]] && grep -q "`sha1sum ${MODEL_ZIP}`" ${MODEL_VERSION}; then
+  echo "Already up to date."
+  exit
+fi
+
+# Download models
+echo "Downloading latest models from $MODEL_URL..."
+wget ${MODEL_URL} -O ${MODEL_ZIP} || exit 1
+
+MODEL_HASH=`sha1sum ${MODEL_ZIP}`
+
+echo ${MODEL_HASH}
+
+# Create backup
+if [[ -e ${MODEL_PATH} ]]; then
+  echo "Creating backup of existing directory: $MODEL_BACKUP"
+  rm -rf ${MODEL_BACKUP}
+  mv ${MODEL_PATH} ${MODEL_BACKUP}
+fi
+
+# Unzip models, the archive contains the detector and facenet directories
+unzip ${MODEL_ZIP} -d assets
+echo "$MODEL_NAME $TODAY $MODEL_HASH" > ${MODEL_VERSION}
+
+echo "Latest $MODEL_NAME installed."